@@ -330,7 +330,7 @@ func runSingleAnalysis(ctx context.Context, cmd *cobra.Command, ep daemon.Daemon
 	for name := range files {
 		relPaths = append(relPaths, name)
 	}
-	outputPrefix := buildOutputPrefix(analysisType.Name, relPaths)
+	outputPrefix := analyze.OutputPrefix(analysisType.Name, relPaths)
 
 	// If prompt is too large, fall back to file paths only
 	if len(fullPrompt) > maxPromptSize {
@@ -405,7 +405,7 @@ func runPerFileAnalysis(ctx context.Context, cmd *cobra.Command, ep daemon.Daemo
 		}
 
 		// Build output prefix for this file
-		outputPrefix := buildOutputPrefix(analysisType.Name, []string{fileName})
+		outputPrefix := analyze.OutputPrefix(analysisType.Name, []string{fileName})
 
 		// If single file is too large, fall back to file path only
 		if len(fullPrompt) > maxPromptSize {
@@ -490,20 +490,6 @@ func runPerFileAnalysis(ctx context.Context, cmd *cobra.Command, ep daemon.Daemo
 	return nil
 }
 
-// buildOutputPrefix creates a prefix showing which files were analyzed.
-// This is prepended to the agent's output for reliable file identification.
-func buildOutputPrefix(analysisType string, filePaths []string) string {
-	sort.Strings(filePaths)
-	var sb strings.Builder
-	fmt.Fprintf(&sb, "## %s Analysis\n\n", analysisType)
-	sb.WriteString("**Files:**\n")
-	for _, path := range filePaths {
-		fmt.Fprintf(&sb, "- %s\n", path)
-	}
-	sb.WriteString("\n---\n\n")
-	return sb.String()
-}
-
 // enqueueAnalysisJob sends a job to the daemon
 func enqueueAnalysisJob(ctx context.Context, ep daemon.DaemonEndpoint, repoRoot, prompt, outputPrefix, label string, opts analyzeOptions) (*storage.ReviewJob, error) {
 	branch := gitrepo.CurrentBranch(ctx, repoRoot)
@@ -913,7 +899,7 @@ func expandAndReadFiles(workDir, repoRoot string, patterns []string) (map[string
 					return nil
 				}
 				// Only include source files
-				if isSourceFile(path) {
+				if analyze.IsSourceFile(path) {
 					relPath, err := filepath.Rel(repoRoot, path)
 					if err != nil {
 						relPath = path // Use absolute path as fallback
@@ -970,7 +956,7 @@ func expandAndReadFiles(workDir, repoRoot string, patterns []string) (map[string
 					if info.IsDir() {
 						return nil
 					}
-					if isSourceFile(path) {
+					if analyze.IsSourceFile(path) {
 						relPath, err := filepath.Rel(repoRoot, path)
 						if err != nil {
 							relPath = path // Use absolute path as fallback
@@ -1119,7 +1105,7 @@ func getBranchFiles(ctx context.Context, cmd *cobra.Command, repoRoot string, op
 	return files, nil
 }
 
-// isCodeFile returns true if the file is a code file (stricter than isSourceFile).
+// isCodeFile returns true if the file is a code file (stricter than analyze.IsSourceFile).
 // Excludes documentation, configuration, and markup files that are typically not
 // useful for code analysis on a branch.
 func isCodeFile(path string) bool {
@@ -1134,19 +1120,3 @@ func isCodeFile(path string) bool {
 	}
 	return codeExts[ext]
 }
-
-// isSourceFile returns true if the file looks like source code
-func isSourceFile(path string) bool {
-	ext := strings.ToLower(filepath.Ext(path))
-	sourceExts := map[string]bool{
-		".go": true, ".py": true, ".js": true, ".ts": true, ".tsx": true, ".jsx": true,
-		".rs": true, ".c": true, ".h": true, ".cpp": true, ".hpp": true, ".cc": true,
-		".java": true, ".kt": true, ".scala": true, ".rb": true, ".php": true,
-		".swift": true, ".m": true, ".cs": true, ".fs": true, ".vb": true,
-		".sh": true, ".bash": true, ".zsh": true, ".fish": true,
-		".sql": true, ".graphql": true, ".proto": true,
-		".yaml": true, ".yml": true, ".toml": true, ".json": true,
-		".md": true, ".txt": true, ".html": true, ".css": true, ".scss": true,
-	}
-	return sourceExts[ext]
-}
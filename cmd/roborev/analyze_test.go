@@ -158,36 +158,6 @@ func TestExpandAndReadFiles_RecursiveFromSubdir(t *testing.T) {
 	assertFilesExist(t, files, []string{"main.go", "sub/helper.go"})
 }
 
-func TestIsSourceFile(t *testing.T) {
-	tests := []struct {
-		path string
-		want bool
-	}{
-		{"main.go", true},
-		{"script.py", true},
-		{"app.js", true},
-		{"app.ts", true},
-		{"app.tsx", true},
-		{"lib.rs", true},
-		{"config.yaml", true},
-		{"config.yml", true},
-		{"data.json", true},
-		{"readme.md", true},
-		{"binary.exe", false},
-		{"image.png", false},
-		{"archive.tar.gz", false},
-		{".gitignore", false},
-		{"Makefile", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.path, func(t *testing.T) {
-			got := isSourceFile(tt.path)
-			assert.Equal(t, tt.want, got, "isSourceFile(%q)", tt.path)
-		})
-	}
-}
-
 func assertContains(t *testing.T, s, substr, msg string) {
 	t.Helper()
 	require.Contains(t, s, substr, "%s: expected string to contain %q\nDocument content:\n%s", msg, substr, s)
@@ -23,7 +23,9 @@ func backfillTokensCmd() *cobra.Command {
 		Short: "Backfill token usage for completed jobs",
 		Long: `Scan completed jobs missing token usage or cost data.
 
-Codex job logs are checked first for turn.completed usage events. When
+Stored job logs are reprocessed first: usage events from Codex, Claude
+Code, Gemini and Pi output are parsed, and usage without a cost is priced
+from the built-in price list and any [cost.pricing] overrides. When
 available, agentsview is also queried to recover cost estimates.
 
 This is best-effort: jobs whose session files have been deleted
@@ -34,6 +36,7 @@ will be skipped.`,
 				return fmt.Errorf("load config: %w", err)
 			}
 			fetchConfig := backfillCostFetchConfig(cfg)
+			pricing := cfg.Cost.ResolvedPricing()
 
 			db, err := storage.Open(storage.DefaultDBPath())
 			if err != nil {
@@ -58,7 +61,7 @@ will be skipped.`,
 			for _, job := range candidates {
 				total++
 
-				logUsage, logErr := tokens.ParseCodexUsageFile(
+				logUsage, logErr := tokens.ParseUsageFile(
 					daemon.JobLogPath(job.ID),
 				)
 				if logErr != nil {
@@ -66,6 +69,7 @@ will be skipped.`,
 						"job %d: parse job log: %v", job.ID, logErr,
 					)
 				}
+				pricing.EstimateCost(logUsage, backfill.UsageModel(logUsage, job))
 
 				var fetchedUsage *tokens.Usage
 				var fetchErr error
@@ -363,3 +363,42 @@ func writeCodexUsageLog(
 			fmt.Sprintf("%d", output)+`}}`+"\n",
 	), 0o600))
 }
+
+func TestBackfillTokensPricesJobLogUsageForJobModel(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("ROBOREV_DATA_DIR", dataDir)
+	t.Setenv("PATH", t.TempDir())
+
+	db, err := storage.Open(storage.DefaultDBPath())
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo, err := db.GetOrCreateRepo(filepath.Join(t.TempDir(), "repo"))
+	require.NoError(t, err)
+	commit, err := db.GetOrCreateCommit(repo.ID, "abc123", "Author", "Subject", time.Now())
+	require.NoError(t, err)
+	job, err := db.EnqueueJob(storage.EnqueueOpts{
+		RepoID:   repo.ID,
+		CommitID: commit.ID,
+		GitRef:   "abc123",
+		Agent:    "codex",
+		Model:    "gpt-5-codex",
+	})
+	require.NoError(t, err)
+	_, err = db.ClaimJob("worker-1")
+	require.NoError(t, err)
+	require.NoError(t, db.CompleteJob(job.ID, "codex", "prompt", "No issues found."))
+	writeCodexUsageLog(t, job.ID, "thread-priced", 1_000_000, 800_000, 100_000)
+
+	cmd := backfillTokensCmd()
+	cmd.SetArgs(nil)
+	require.NoError(t, cmd.Execute())
+
+	updated, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	usage := tokens.ParseJSON(updated.TokenUsage)
+	require.NotNil(t, usage)
+	assert.True(t, usage.HasCost)
+	// gpt-5 list price: 200k uncached, 800k cached and 100k output tokens.
+	assert.InDelta(t, 0.25+0.1+1.0, usage.CostUSD, 1e-9)
+}
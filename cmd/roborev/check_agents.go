@@ -2,16 +2,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/storage"
 )
 
 func checkAgentsCmd() *cobra.Command {
@@ -19,6 +25,7 @@ func checkAgentsCmd() *cobra.Command {
 		timeoutSecs int
 		agentFilter string
 		largePrompt bool
+		viaDaemon   bool
 	)
 
 	cmd := &cobra.Command{
@@ -29,12 +36,22 @@ func checkAgentsCmd() *cobra.Command {
 For each agent found on PATH, runs a short smoke-test prompt with a timeout
 to verify the agent is actually functional.
 
+With --daemon, the daemon instead probes each configured agent and model,
+records latency, auth status, rate-limit state, reasoning levels and
+version, and shows them in 'roborev status' and the TUI. Set
+agent_probe_interval (e.g. 6h) to have the daemon repeat this probe
+periodically; it is off by default since each pass spends agent quota.
+
 Examples:
   roborev check-agents                  # Check all agents
   roborev check-agents --agent codex    # Check only codex
   roborev check-agents --timeout 30     # 30 second timeout per agent
-  roborev check-agents --large-prompt   # Test with 33KB+ prompt (Windows limit check)`,
+  roborev check-agents --large-prompt   # Test with 33KB+ prompt (Windows limit check)
+  roborev check-agents --daemon         # Probe configured agents through the daemon`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if viaDaemon {
+				return probeAgentsViaDaemon(agentFilter)
+			}
 			names := agent.Available()
 			sort.Strings(names)
 
@@ -122,6 +139,85 @@ Examples:
 	cmd.Flags().StringVar(&agentFilter, "agent", "", "check only this agent")
 	cmd.Flags().BoolVar(&largePrompt, "large-prompt", false,
 		"use a 33KB+ prompt to test Windows command-line limits")
+	cmd.Flags().BoolVar(&viaDaemon, "daemon", false,
+		"probe configured agents through the daemon and record the results")
 
 	return cmd
 }
+
+// probeAgentsViaDaemon asks the daemon to probe its configured agents (or
+// only agentFilter) and prints the recorded results.
+func probeAgentsViaDaemon(agentFilter string) error {
+	if err := ensureDaemon(); err != nil {
+		return fmt.Errorf("daemon not running: %w", err)
+	}
+	ep := getDaemonEndpoint()
+	// Each agent may take up to two minutes to answer.
+	client := ep.HTTPClient(30 * time.Minute)
+	u := ep.BaseURL() + "/api/agents/probe"
+	if agentFilter != "" {
+		u += "?agent=" + url.QueryEscape(agentFilter)
+	}
+	resp, err := client.Post(u, "application/json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("probe agents: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var result struct {
+		Agents []storage.AgentHealth `json:"agents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	printAgentHealth(os.Stdout, result.Agents)
+	var failed int
+	for _, h := range result.Agents {
+		if !h.Healthy() {
+			failed++
+		}
+	}
+	fmt.Printf("\n%d healthy, %d unhealthy\n", len(result.Agents)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d agent(s) failed health check", failed)
+	}
+	return nil
+}
+
+// printAgentHealth writes one line per probed agent, followed by the error of
+// each unhealthy one.
+func printAgentHealth(w io.Writer, health []storage.AgentHealth) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, h := range health {
+		mark := "+"
+		if !h.Healthy() {
+			mark = "!"
+		}
+		name := h.Agent
+		if h.Model != "" {
+			name += "/" + h.Model
+		}
+		status := h.Status
+		if h.LimitKind != "" {
+			status += " (" + h.LimitKind
+			if h.LimitResetAt != nil {
+				status += ", until " + h.LimitResetAt.Local().Format("Jan 02 15:04")
+			}
+			status += ")"
+		}
+		latency := ""
+		if h.LatencyMs > 0 {
+			latency = (time.Duration(h.LatencyMs) * time.Millisecond).Round(100 * time.Millisecond).String()
+		}
+		fmt.Fprintf(tw, "  %s %s\t%s\t%s\t%s\t%s ago\n", mark, name, status, latency, h.Version,
+			time.Since(h.CheckedAt).Round(time.Minute))
+		if h.Error != "" && !h.Healthy() {
+			fmt.Fprintf(tw, "      %s\n", truncateString(strings.ReplaceAll(h.Error, "\n", " "), 100))
+		}
+	}
+	tw.Flush()
+}
@@ -15,6 +15,7 @@ import (
 
 	"go.kenn.io/roborev/internal/config"
 	ghpkg "go.kenn.io/roborev/internal/github"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/review"
 )
 
@@ -274,6 +275,12 @@ func runCIReview(ctx context.Context, opts ciReviewOpts) error {
 			prNumber = detected
 		}
 
+		redactor, err := redact.ForRepo(root, globalCfg)
+		if err != nil {
+			return fmt.Errorf("redaction: %w", err)
+		}
+		comment = redactor.String(comment)
+
 		upsert := config.ResolveCIUpsertComments(
 			repoCfg, globalCfg)
 		if err := postCIComment(
@@ -9,9 +9,6 @@ import (
 	"log"
 	"maps"
 	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -263,7 +260,7 @@ func enqueueConsolidation(ctx context.Context, cmd *cobra.Command, repoRoot stri
 		label = fmt.Sprintf("compact-all-%s", timestamp)
 	}
 
-	outputPrefix := buildCompactOutputPrefix(len(jobReviews), branchFilter, extractJobIDs(jobReviews))
+	outputPrefix := review.CompactOutputPrefix(branchFilter, extractJobIDs(jobReviews))
 
 	// Use resolved values so daemon enqueues with the correct agent/model
 	resolved := opts
@@ -463,74 +460,16 @@ func runCompact(cmd *cobra.Command, opts compactOptions) error {
 }
 
 func buildCompactPrompt(ctx context.Context, jobReviews []jobReview, branch, repoRoot, agentName string) string {
-	var sb strings.Builder
-
-	if reviewPrompt := strings.TrimSpace(prompt.GetSystemPrompt(agentName, "review")); reviewPrompt != "" {
-		sb.WriteString(reviewPrompt)
-		sb.WriteString("\n\n")
-	}
-
-	sb.WriteString("# Verification and Consolidation Request\n\n")
-	sb.WriteString("You are a code reviewer tasked with verifying and consolidating previous review findings.\n\n")
-
 	// Include project review guidelines so the compact agent
 	// respects the same rules as regular reviews.
 	globalCfg, _ := config.LoadGlobal()
 	guidelines := prompt.LoadGuidelinesWithConfig(ctx, repoRoot, globalCfg)
-	if guidelines != "" {
-		sb.WriteString("## Project Guidelines\n\n")
-		sb.WriteString(strings.TrimSpace(guidelines))
-		sb.WriteString("\n\n")
-	}
-
-	sb.WriteString(review.VerifyDedupePreamble())
-
-	sb.WriteString("## Open Review Findings\n\n")
-	reviewWord := "review"
-	if len(jobReviews) != 1 {
-		reviewWord = "reviews"
-	}
-	fmt.Fprintf(&sb, "Below are %d open %s", len(jobReviews), reviewWord)
-	if branch != "" {
-		fmt.Fprintf(&sb, " from branch %s", branch)
-	}
-	sb.WriteString(":\n\n")
 
+	reviews := make([]review.CompactReview, len(jobReviews))
 	for i, jr := range jobReviews {
-		fmt.Fprintf(&sb, "--- Review %d (Job %d", i+1, jr.jobID)
-		if jr.job.GitRef != "" {
-			fmt.Fprintf(&sb, " — %s", gitrepo.ShortSHA(jr.job.GitRef))
-		}
-		sb.WriteString(") ---\n")
-		sb.WriteString(jr.review.Output)
-		sb.WriteString("\n\n")
+		reviews[i] = review.CompactReview{JobID: jr.jobID, GitRef: jr.job.GitRef, Output: jr.review.Output}
 	}
-
-	sb.WriteString("## Expected Output\n\n")
-	sb.WriteString("Provide a consolidated review output containing only verified findings.\n")
-	sb.WriteString("Use the same general structure as regular reviews so verdict parsing and downstream fix flows continue to work.\n")
-	sb.WriteString("Do not include false positives or already-fixed issues as findings.\n")
-	sb.WriteString("If any verified findings remain, repeat each remaining finding in the final review output; do not output only counts, totals, or a summary.\n")
-	sb.WriteString("If NO findings remain after verification, use the no-issues form from the regular review format.\n")
-
-	return sb.String()
-}
-
-func buildCompactOutputPrefix(jobCount int, branch string, jobIDs []int64) string {
-	var sb strings.Builder
-	sb.WriteString("## Compact Analysis\n\n")
-	reviewWord := "review"
-	if jobCount != 1 {
-		reviewWord = "reviews"
-	}
-	fmt.Fprintf(&sb, "Verified and consolidated %d open %s", jobCount, reviewWord)
-	if branch != "" {
-		fmt.Fprintf(&sb, " from branch %s", branch)
-	}
-	sb.WriteString("\n\n")
-	fmt.Fprintf(&sb, "Original jobs: %s\n\n", formatJobIDs(jobIDs))
-	sb.WriteString("---\n\n")
-	return sb.String()
+	return review.BuildCompactPrompt(prompt.GetSystemPrompt(agentName, "review"), guidelines, branch, reviews)
 }
 
 func enqueueCompactJob(ctx context.Context, repoRoot, prompt, outputPrefix, label, branch string, opts compactOptions) (*storage.ReviewJob, error) {
@@ -632,31 +571,5 @@ func getCompactMetadataFilename(id int64) string {
 
 // writeCompactMetadata writes source job IDs to a metadata file for later processing
 func writeCompactMetadata(consolidatedJobID int64, sourceJobIDs []int64) error {
-	if len(sourceJobIDs) == 0 {
-		// Nothing to track - skip metadata file creation
-		return nil
-	}
-
-	metadata := struct {
-		SourceJobIDs []int64 `json:"source_job_ids"`
-	}{
-		SourceJobIDs: sourceJobIDs,
-	}
-
-	data, err := json.Marshal(metadata)
-	if err != nil {
-		return fmt.Errorf("marshal metadata: %w", err)
-	}
-
-	dataDir := config.DataDir()
-	if err := os.MkdirAll(dataDir, 0o755); err != nil {
-		return fmt.Errorf("create data directory: %w", err)
-	}
-
-	path := filepath.Join(dataDir, getCompactMetadataFilename(consolidatedJobID))
-	if err := os.WriteFile(path, data, 0o644); err != nil {
-		return fmt.Errorf("write metadata file: %w", err)
-	}
-
-	return nil
+	return daemon.WriteCompactMetadata(consolidatedJobID, sourceJobIDs)
 }
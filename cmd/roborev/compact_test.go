@@ -324,57 +324,6 @@ func TestBuildCompactPrompt(t *testing.T) {
 	}
 }
 
-func TestBuildCompactOutputPrefix(t *testing.T) {
-	tests := []struct {
-		name         string
-		jobCount     int
-		branch       string
-		jobIDs       []int64
-		wantContains []string
-	}{
-		{
-			name:     "with_branch",
-			jobCount: 3,
-			branch:   "main",
-			jobIDs:   []int64{123, 124, 125},
-			wantContains: []string{
-				"Verified and consolidated 3 open reviews from branch main",
-				"Original jobs: 123, 124, 125",
-			},
-		},
-		{
-			name:     "without_branch",
-			jobCount: 2,
-			branch:   "",
-			jobIDs:   []int64{100, 200},
-			wantContains: []string{
-				"Verified and consolidated 2 open reviews",
-				"Original jobs: 100, 200",
-			},
-		},
-		{
-			name:     "single_job",
-			jobCount: 1,
-			branch:   "feature",
-			jobIDs:   []int64{999},
-			wantContains: []string{
-				"Verified and consolidated 1 open review from branch feature",
-				"Original jobs: 999",
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := buildCompactOutputPrefix(tt.jobCount, tt.branch, tt.jobIDs)
-
-			for _, want := range tt.wantContains {
-				assert.Contains(t, got, want, "buildCompactOutputPrefix() missing")
-			}
-		})
-	}
-}
-
 func TestWriteCompactMetadata(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("ROBOREV_DATA_DIR", tmpDir)
@@ -22,8 +22,10 @@ import (
 	"go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/prompt"
 	"go.kenn.io/roborev/internal/prompt/analyze"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/streamfmt"
+	"go.kenn.io/roborev/internal/verify"
 )
 
 var (
@@ -252,6 +254,22 @@ func (e *agentLimitError) Error() string {
 	return formatAgentLimitMessage(e.Classification, time.Now())
 }
 
+// fixVerifyError is returned when a fix still fails the repo's
+// verify_command after the agent's repair attempts. Any fix commit was
+// rolled back and the changes are left uncommitted, so the fix loop stops
+// instead of running the next agent on top of them.
+type fixVerifyError struct {
+	Outcome    verify.Outcome
+	RolledBack bool // a fix commit was made and rolled back
+}
+
+func (e *fixVerifyError) Error() string {
+	if !e.RolledBack {
+		return fmt.Sprintf("fix %s; its changes are left uncommitted", e.Outcome.Summary())
+	}
+	return fmt.Sprintf("fix %s; the commit was rolled back and its changes are left uncommitted", e.Outcome.Summary())
+}
+
 // formatAgentLimitMessage builds the user-facing abort message. Pulled
 // out so tests can assert against it without depending on time.Now.
 // The label ("quota" / "session limit" / "rate limit") is derived from
@@ -316,6 +334,12 @@ type fixJobParams struct {
 	Agent    agent.Agent
 	Output   io.Writer // agent streaming output (nil = discard)
 	Metadata config.FixCommitMetadata
+	// Verify gates the fix commit on the repo's verify_command. A zero
+	// value skips verification.
+	Verify config.VerifySettings
+	// Redactor masks secrets in the prompts sent to the agent. nil masks
+	// nothing.
+	Redactor *redact.Redactor
 	// Classify is the rate-limit classifier used for the commit-retry
 	// path. nil defaults to agent.ClassifyLimit. Tests inject a stub.
 	Classify agent.LimitClassifier
@@ -327,6 +351,8 @@ type fixJobResult struct {
 	NewCommitSHA  string
 	NoChanges     bool
 	AgentOutput   string
+	// Verify is the verify_command outcome; nil when no verification ran.
+	Verify *verify.Outcome
 }
 
 // detectNewCommit checks whether HEAD has moved past headBefore.
@@ -348,6 +374,8 @@ func fixJobDirect(ctx context.Context, params fixJobParams, prompt string) (*fix
 	if out == nil {
 		out = io.Discard
 	}
+	// Review output and comments may quote secrets from the repo.
+	prompt = params.Redactor.String(prompt)
 
 	headBefore, err := gitrepo.Resolve(ctx, params.RepoRoot, "HEAD")
 	if err != nil {
@@ -379,7 +407,7 @@ func fixJobDirect(ctx context.Context, params fixJobParams, prompt string) (*fix
 	}
 
 	if sha, ok := detectNewCommit(ctx, params.RepoRoot, headBefore); ok {
-		return &fixJobResult{CommitCreated: true, NewCommitSHA: sha, AgentOutput: agentOutput}, nil
+		return verifyFixCommit(ctx, params, headBefore, &fixJobResult{CommitCreated: true, NewCommitSHA: sha, AgentOutput: agentOutput})
 	}
 
 	// No commit - retry if there are uncommitted changes
@@ -389,20 +417,12 @@ func fixJobDirect(ctx context.Context, params fixJobParams, prompt string) (*fix
 	}
 
 	fmt.Fprint(out, "\nNo commit was created. Re-running agent with commit instructions...\n\n")
-	retryAgent := params.Agent
 	// Thread the first call's session ID into the retry so the commit
 	// step continues the same agent context. Without this, the retry
 	// runs as a fresh session and the caller's tracker captures the
 	// pre-retry session — leaving subsequent jobs to resume stale
 	// context that's missing the actual fix work.
-	if capture, ok := out.(*agent.SessionCaptureWriter); ok {
-		capture.Flush()
-		if id := capture.SessionID(); id != "" {
-			if sa, ok := retryAgent.(agent.SessionAgent); ok {
-				retryAgent = sa.WithSessionID(id)
-			}
-		}
-	}
+	retryAgent := resumeFixSession(params.Agent, out)
 	if _, retryErr := retryAgent.Review(ctx, params.RepoRoot, "HEAD", buildGenericCommitPromptWithMetadata(params.Metadata), out); retryErr != nil {
 		// Classify the retry error so quota/session limits abort
 		// instead of being demoted to a warning — otherwise the fix
@@ -429,12 +449,73 @@ func fixJobDirect(ctx context.Context, params fixJobParams, prompt string) (*fix
 		fmt.Fprintf(out, "Warning: commit agent failed: %v\n", retryErr)
 	}
 	if sha, ok := detectNewCommit(ctx, params.RepoRoot, headBefore); ok {
-		return &fixJobResult{CommitCreated: true, NewCommitSHA: sha, AgentOutput: agentOutput}, nil
+		return verifyFixCommit(ctx, params, headBefore, &fixJobResult{CommitCreated: true, NewCommitSHA: sha, AgentOutput: agentOutput})
 	}
 
-	// Still no commit - report whether changes remain
+	// Still no commit - report whether changes remain, and verify them
+	// in the working tree when they do
 	hasChanges, _ = gitrepo.HasUncommittedChanges(ctx, params.RepoRoot)
-	return &fixJobResult{NoChanges: !hasChanges, AgentOutput: agentOutput}, nil
+	result := &fixJobResult{NoChanges: !hasChanges, AgentOutput: agentOutput}
+	if !hasChanges {
+		return result, nil
+	}
+	return verifyFixCommit(ctx, params, headBefore, result)
+}
+
+// resumeFixSession returns agent a resumed in the session captured by out.
+// It returns a unchanged when out captured no session or a cannot resume
+// one.
+func resumeFixSession(a agent.Agent, out io.Writer) agent.Agent {
+	if capture, ok := out.(*agent.SessionCaptureWriter); ok {
+		capture.Flush()
+		if id := capture.SessionID(); id != "" {
+			if sa, ok := a.(agent.SessionAgent); ok {
+				return sa.WithSessionID(id)
+			}
+		}
+	}
+	return a
+}
+
+// verifyFixCommit runs the repo's verify_command on a fix's changes,
+// committed or still in the working tree, and feeds failures back to the
+// agent. A commit made by a repair run is picked up into result. When
+// verification still fails, or a repair run errors, the commits made since
+// headBefore are rolled back with their changes left in the working tree.
+func verifyFixCommit(ctx context.Context, params fixJobParams, headBefore string, result *fixJobResult) (*fixJobResult, error) {
+	if !params.Verify.Enabled() {
+		return result, nil
+	}
+	out := params.Output
+	if out == nil {
+		out = io.Discard
+	}
+	commitPrompt := buildGenericCommitPromptWithMetadata(params.Metadata)
+	outcome, err := verify.Check(ctx, params.RepoRoot, params.Verify, func(ctx context.Context, prompt string) error {
+		_, err := resumeFixSession(params.Agent, out).Review(ctx, params.RepoRoot, "HEAD", params.Redactor.String(prompt)+"\n"+commitPrompt, out)
+		return err
+	}, out)
+	result.Verify = &outcome
+	if err == nil && outcome.Passed {
+		if sha, ok := detectNewCommit(ctx, params.RepoRoot, headBefore); ok {
+			result.CommitCreated, result.NewCommitSHA = true, sha
+		}
+		return result, nil
+	}
+
+	head, resolveErr := gitrepo.Resolve(ctx, params.RepoRoot, "HEAD")
+	verifyErr := &fixVerifyError{Outcome: outcome, RolledBack: resolveErr == nil && head != headBefore}
+	var cause error = verifyErr
+	if err != nil {
+		cause = fmt.Errorf("fix agent failed during verification: %w", err)
+	}
+	if resolveErr != nil {
+		return nil, fmt.Errorf("%w; cannot roll back: resolve HEAD: %w", cause, resolveErr)
+	}
+	if !verifyErr.RolledBack {
+		return nil, cause
+	}
+	return nil, rollbackRefineCommit(ctx, params.RepoRoot, headBefore, head, cause)
 }
 
 // resolveFixModel determines the model for a fix operation, skipping
@@ -474,6 +555,9 @@ func resolveFixAgent(repoPath string, opts fixOptions) (agent.Agent, error) {
 	if err := config.ValidateRepoConfig(repoPath); err != nil {
 		return nil, fmt.Errorf("resolve workflow config: %w", err)
 	}
+	if err := agent.ConfigureSandbox(cfg.Sandbox); err != nil {
+		return nil, err
+	}
 
 	resolution, err := agent.ResolveWorkflowConfig(
 		opts.agentName, repoPath, cfg, "fix", reasoning,
@@ -540,6 +624,12 @@ func runFixWithSeen(cmd *cobra.Command, jobIDs []int64, opts fixOptions, seen ma
 			if errors.As(err, &lim) {
 				return err
 			}
+			// A rolled-back fix leaves its changes in the working tree;
+			// fixing the next job on top of them would commit them.
+			var verifyErr *fixVerifyError
+			if errors.As(err, &verifyErr) {
+				return err
+			}
 			// In discovery mode (seen != nil), log a warning and
 			// continue best-effort. For explicit job IDs (seen ==
 			// nil), return the error so the CLI exits non-zero.
@@ -1074,6 +1164,10 @@ func fixSingleJob(cmd *cobra.Command, repoRoot string, jobID int64, opts fixOpti
 	// task/analyze jobs have free-form output without severity labels)
 	var minSev string
 	fixCfg, _ := config.LoadGlobal()
+	redactor, err := redact.ForRepo(repoRoot, fixCfg)
+	if err != nil {
+		return fmt.Errorf("redaction: %w", err)
+	}
 	if !job.IsTaskJob() {
 		minSev, err = config.ResolveFixMinSeverity(
 			opts.minSeverity, repoRoot, fixCfg,
@@ -1128,6 +1222,8 @@ func fixSingleJob(cmd *cobra.Command, repoRoot string, jobID int64, opts fixOpti
 		Agent:    currentAgent,
 		Output:   capture,
 		Metadata: metadata,
+		Verify:   config.ResolveVerify(repoRoot),
+		Redactor: redactor,
 		Classify: opts.classify,
 	}, buildGenericFixPromptWithMetadata(review.Output, minSev, comments, metadata))
 	// Flush capture FIRST so session extraction completes before reading SessionID.
@@ -1144,6 +1240,13 @@ func fixSingleJob(cmd *cobra.Command, repoRoot string, jobID int64, opts fixOpti
 		if errors.As(err, &lim) {
 			return err
 		}
+		var verifyErr *fixVerifyError
+		if errors.As(err, &verifyErr) {
+			if addErr := addJobResponse(ctx, addr, jobID, "roborev-fix", "Fix via `roborev fix` was not applied: "+verifyErr.Outcome.Summary()); addErr != nil && !opts.quiet {
+				cmd.Printf("Warning: could not add response to job: %v\n", addErr)
+			}
+			return err
+		}
 		cls := opts.classify(agent.CanonicalName(currentAgent.Name()), err.Error())
 		switch cls.Kind {
 		case agent.LimitKindQuota, agent.LimitKindSession:
@@ -1192,6 +1295,9 @@ func fixSingleJob(cmd *cobra.Command, repoRoot string, jobID int64, opts fixOpti
 	if result.CommitCreated {
 		responseText = fmt.Sprintf("Fix applied via `roborev fix` command (commit: %s)", gitrepo.ShortSHA(result.NewCommitSHA))
 	}
+	if result.Verify != nil {
+		responseText += "; " + result.Verify.Summary()
+	}
 
 	if err := addJobResponse(ctx, addr, jobID, "roborev-fix", responseText); err != nil {
 		if !opts.quiet {
@@ -1369,6 +1475,10 @@ func processFixBatch(ctx context.Context, cmd *cobra.Command, roots currentRepoR
 	if err != nil {
 		return fmt.Errorf("resolve fix commit metadata: %w", err)
 	}
+	redactor, err := redact.ForRepo(roots.worktreeRoot, cfg)
+	if err != nil {
+		return fmt.Errorf("redaction: %w", err)
+	}
 
 	// Resolve minimum severity filter. Suppress if any entry is a
 	// task job — task/analyze output has no severity labels, so the
@@ -1441,6 +1551,8 @@ func processFixBatch(ctx context.Context, cmd *cobra.Command, roots currentRepoR
 			Agent:    currentAgent,
 			Output:   capture,
 			Metadata: metadata,
+			Verify:   config.ResolveVerify(roots.worktreeRoot),
+			Redactor: redactor,
 			Classify: opts.classify,
 		}, prompt)
 		// Flush capture FIRST so session extraction completes before reading SessionID.
@@ -1456,6 +1568,12 @@ func processFixBatch(ctx context.Context, cmd *cobra.Command, roots currentRepoR
 			if errors.As(err, &lim) {
 				return err
 			}
+			// Stop on a rolled-back fix, whose changes are left in the
+			// working tree for the user.
+			var verifyErr *fixVerifyError
+			if errors.As(err, &verifyErr) {
+				return err
+			}
 			cls := opts.classify(agent.CanonicalName(currentAgent.Name()), err.Error())
 			switch cls.Kind {
 			case agent.LimitKindQuota, agent.LimitKindSession:
@@ -1504,6 +1622,9 @@ func processFixBatch(ctx context.Context, cmd *cobra.Command, roots currentRepoR
 		if result.CommitCreated {
 			responseText = fmt.Sprintf("Fix applied via `roborev fix %s` (commit: %s)", flagLabel, gitrepo.ShortSHA(result.NewCommitSHA))
 		}
+		if result.Verify != nil {
+			responseText += "; " + result.Verify.Summary()
+		}
 		for _, e := range batch {
 			if addErr := addJobResponse(ctx, batchAddr, e.jobID, "roborev-fix", responseText); addErr != nil && !opts.quiet {
 				cmd.Printf("Warning: could not add response to job %d: %v\n", e.jobID, addErr)
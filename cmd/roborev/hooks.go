@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func hooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Inspect webhook deliveries",
+		Long: `Inspect and retry webhook deliveries.
+
+Webhook hooks are queued in a persistent outbox and retried with
+exponential backoff, so a receiver outage delays an event instead of
+dropping it.
+
+Subcommands:
+  deliveries - List recent webhook deliveries and their status
+  redeliver  - Send a delivery again with a fresh retry budget
+`,
+	}
+
+	cmd.AddCommand(hooksDeliveriesCmd())
+	cmd.AddCommand(hooksRedeliverCmd())
+
+	return cmd
+}
+
+func hooksDeliveriesCmd() *cobra.Command {
+	var (
+		status  string
+		jobID   int64
+		limit   int
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "deliveries",
+		Short: "List recent webhook deliveries",
+		Long: `List webhook deliveries from the outbox, newest first, with the outcome of
+the latest attempt. URLs are shown as scheme and host only.
+
+Examples:
+  roborev hooks deliveries
+  roborev hooks deliveries --status failed
+  roborev hooks deliveries --job 42 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ensureDaemon(); err != nil {
+				return fmt.Errorf("daemon not running: %w", err)
+			}
+			params := url.Values{}
+			if status != "" {
+				params.Set("status", status)
+			}
+			if jobID > 0 {
+				params.Set("job_id", strconv.FormatInt(jobID, 10))
+			}
+			if limit > 0 {
+				params.Set("limit", strconv.Itoa(limit))
+			}
+
+			ep := getDaemonEndpoint()
+			resp, err := ep.HTTPClient(5 * time.Second).Get(
+				ep.BaseURL() + "/api/hooks/deliveries?" + params.Encode())
+			if err != nil {
+				return fmt.Errorf("failed to connect to daemon: %w", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("list webhook deliveries: %s", daemonErrorDetail(resp))
+			}
+
+			var result struct {
+				Deliveries []storage.WebhookDelivery `json:"deliveries"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+				return fmt.Errorf("parse response: %w", err)
+			}
+			if jsonOut {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(result.Deliveries)
+			}
+			if len(result.Deliveries) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No webhook deliveries.")
+				return nil
+			}
+			return printWebhookDeliveries(cmd.OutOrStdout(), result.Deliveries)
+		},
+	}
+
+	cmd.Flags().StringVar(&status, "status", "", "filter by status: pending, delivered, failed")
+	cmd.Flags().Int64Var(&jobID, "job", 0, "only deliveries for this job ID")
+	cmd.Flags().IntVar(&limit, "limit", 0, "maximum deliveries to show (default 50)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "output as JSON")
+
+	return cmd
+}
+
+// printWebhookDeliveries renders the delivery log as a table. RESULT shows
+// the latest attempt's status code or error, and when a pending delivery is
+// retried next.
+func printWebhookDeliveries(out io.Writer, deliveries []storage.WebhookDelivery) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tEVENT\tJOB\tSTATUS\tATTEMPTS\tRESULT\tURL\n")
+	for _, d := range deliveries {
+		job := "-"
+		if d.JobID > 0 {
+			job = strconv.FormatInt(d.JobID, 10)
+		}
+		var result []string
+		if d.LastStatusCode > 0 {
+			result = append(result, strconv.Itoa(d.LastStatusCode))
+		}
+		if d.LastError != "" && d.Status != storage.WebhookDelivered {
+			result = append(result, truncateString(d.LastError, 40))
+		}
+		if d.Status == storage.WebhookPending && d.NextAttemptAt != nil && d.Attempts > 0 {
+			result = append(result, "retry "+d.NextAttemptAt.Local().Format("15:04:05"))
+		}
+		if len(result) == 0 {
+			result = append(result, "-")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			d.ID, d.EventType, job, d.Status, d.Attempts, strings.Join(result, " "), d.URL)
+	}
+	return w.Flush()
+}
+
+func hooksRedeliverCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "redeliver <delivery-id>",
+		Short: "Send a webhook delivery again",
+		Long: `Requeue a webhook delivery so it is sent again immediately with the same
+payload and a fresh retry budget. Works for delivered and failed deliveries
+alike; find the ID with 'roborev hooks deliveries'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || id <= 0 {
+				return fmt.Errorf("invalid delivery ID %q", args[0])
+			}
+			if err := ensureDaemon(); err != nil {
+				return fmt.Errorf("daemon not running: %w", err)
+			}
+			reqBody, _ := json.Marshal(map[string]int64{"id": id})
+
+			ep := getDaemonEndpoint()
+			resp, err := ep.HTTPClient(5*time.Second).Post(
+				ep.BaseURL()+"/api/hooks/deliveries/redeliver", "application/json", bytes.NewReader(reqBody))
+			if err != nil {
+				return fmt.Errorf("failed to connect to daemon: %w", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("redeliver webhook: %s", daemonErrorDetail(resp))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Requeued webhook delivery %d\n", id)
+			return nil
+		},
+	}
+}
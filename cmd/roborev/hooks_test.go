@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func TestHooksDeliveriesCmd(t *testing.T) {
+	next := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	daemonFromHandler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/hooks/deliveries", r.URL.Path)
+		assert.Equal(t, "pending", r.URL.Query().Get("status"))
+		assert.Equal(t, "42", r.URL.Query().Get("job_id"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"deliveries": []storage.WebhookDelivery{
+			{ID: 7, EventType: "review.failed", JobID: 42, Status: storage.WebhookPending, Attempts: 2,
+				LastStatusCode: 503, LastError: "status 503 Service Unavailable", NextAttemptAt: &next,
+				URL: "https://hooks.example.com/..."},
+		}})
+	}))
+
+	var out strings.Builder
+	cmd := hooksCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"deliveries", "--status", "pending", "--job", "42"})
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2, "header plus one row: %q", out.String())
+	for _, want := range []string{"7", "review.failed", "42", "pending", "503", "retry", "https://hooks.example.com/..."} {
+		assert.Contains(t, lines[1], want)
+	}
+}
+
+func TestHooksRedeliverCmd(t *testing.T) {
+	var got map[string]int64
+	daemonFromHandler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/hooks/deliveries/redeliver" {
+			http.NotFound(w, r)
+			return
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if got["id"] != 7 {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"detail":"webhook delivery not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true})
+	}))
+
+	var out strings.Builder
+	cmd := hooksCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"redeliver", "7"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Requeued webhook delivery 7")
+
+	cmd = hooksCmd()
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	cmd.SetArgs([]string{"redeliver", "8"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "redeliver webhook: webhook delivery not found", err.Error())
+}
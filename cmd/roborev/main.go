@@ -46,11 +46,13 @@ func main() {
 	rootCmd.AddCommand(unpauseCmd())
 	rootCmd.AddCommand(listCmd())
 	rootCmd.AddCommand(showCmd())
+	rootCmd.AddCommand(panelCmd())
 	rootCmd.AddCommand(commentCmd())
 	rootCmd.AddCommand(respondCmd()) // hidden alias for backward compatibility
 	rootCmd.AddCommand(closeCmd())
 	rootCmd.AddCommand(installHookCmd())
 	rootCmd.AddCommand(uninstallHookCmd())
+	rootCmd.AddCommand(hooksCmd())
 	rootCmd.AddCommand(daemonCmd())
 	rootCmd.AddCommand(streamCmd())
 	rootCmd.AddCommand(tuiCmd())
@@ -75,6 +77,7 @@ func main() {
 	rootCmd.AddCommand(backfillVerdictsCmd())
 	rootCmd.AddCommand(configCmd())
 	rootCmd.AddCommand(backfillTokensCmd())
+	rootCmd.AddCommand(sandboxExecCmd())
 	rootCmd.AddCommand(updateCmd())
 	rootCmd.AddCommand(versionCmd())
 
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/tokens"
+)
+
+func panelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "panel",
+		Short: "Manage panel review runs",
+		Long: `Manage panel review runs.
+
+Subcommands:
+  resynthesize - Run a fresh synthesis over a run's existing member reviews
+  history      - List every synthesis of a run for comparison
+`,
+	}
+
+	cmd.AddCommand(panelResynthesizeCmd())
+	cmd.AddCommand(panelHistoryCmd())
+
+	return cmd
+}
+
+func panelResynthesizeCmd() *cobra.Command {
+	var agentName, model string
+
+	cmd := &cobra.Command{
+		Use:   "resynthesize <run-uuid>",
+		Short: "Re-synthesize a panel run without rerunning its members",
+		Long: `Enqueue a fresh synthesis job over a panel run's existing member reviews.
+
+Members are not rerun. The previous synthesis and its review are kept, so
+synthesis agents and models can be compared cheaply on the same member
+output with 'roborev panel history'. By default the new synthesis uses the
+previous synthesis agent and model. On a panel with a deterministic
+synthesis_strategy, --agent or --model re-synthesizes with the llm strategy.
+
+The panel run UUID is shown by 'roborev show --json' and in the TUI panel
+view. Every member and the previous synthesis must be finished.
+
+Examples:
+  roborev panel resynthesize 6f1c...
+  roborev panel resynthesize 6f1c... --agent gemini --model gemini-2.5-pro`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ensureDaemon(); err != nil {
+				return fmt.Errorf("daemon not running: %w", err)
+			}
+			reqBody, _ := json.Marshal(map[string]string{
+				"panel_run_uuid": args[0],
+				"agent":          agentName,
+				"model":          model,
+			})
+
+			ep := getDaemonEndpoint()
+			resp, err := ep.HTTPClient(10*time.Second).Post(
+				ep.BaseURL()+"/api/panel/resynthesize", "application/json", bytes.NewReader(reqBody))
+			if err != nil {
+				return fmt.Errorf("failed to connect to daemon: %w", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("re-synthesize panel: %s", daemonErrorDetail(resp))
+			}
+			var result struct {
+				SynthesisJobID int64 `json:"synthesis_job_id"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+				return fmt.Errorf("parse response: %w", err)
+			}
+			fmt.Printf("Enqueued synthesis job %d for panel run %s\n", result.SynthesisJobID, args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&agentName, "agent", "", "synthesis agent (default: previous synthesis agent)")
+	cmd.Flags().StringVar(&model, "model", "", "synthesis model (default: previous synthesis model, or the agent default with --agent)")
+
+	return cmd
+}
+
+func panelHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <run-uuid>",
+		Short: "List every synthesis of a panel run",
+		Long: `List every synthesis job of a panel run, newest first, so re-synthesized
+outputs can be compared. Use 'roborev show --job <id>' to read each one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ensureDaemon(); err != nil {
+				return fmt.Errorf("daemon not running: %w", err)
+			}
+			ep := getDaemonEndpoint()
+			syntheses, err := fetchPanelSyntheses(ep.HTTPClient(5*time.Second), ep.BaseURL(), args[0])
+			if err != nil {
+				return err
+			}
+			if len(syntheses) == 0 {
+				return fmt.Errorf("panel run %s not found", args[0])
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "JOB\tAGENT\tMODEL\tSTATUS\tVERDICT\tCOST\tFINISHED\n")
+			for _, j := range syntheses {
+				verdict := "-"
+				if j.Verdict != nil && *j.Verdict != "" {
+					verdict = *j.Verdict
+				}
+				cost := "-"
+				if tu := tokens.ParseJSON(j.TokenUsage); tu != nil && tu.HasCost {
+					cost = tu.FormatCost()
+				}
+				finished := "-"
+				if j.FinishedAt != nil {
+					finished = j.FinishedAt.Local().Format("2006-01-02 15:04")
+				}
+				model := j.Model
+				if model == "" {
+					model = "-"
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					j.ID, j.Agent, model, j.Status, verdict, cost, finished)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// fetchPanelSyntheses loads every synthesis row of a panel run, newest first,
+// via the same GET /api/jobs?panel_run=<uuid> listing fetchPanelMembers uses.
+func fetchPanelSyntheses(client *http.Client, addr, runUUID string) ([]storage.ReviewJob, error) {
+	u := addr + "/api/jobs?panel_run=" + url.QueryEscape(runUUID) + "&limit=0"
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list panel syntheses: server returned %s", resp.Status)
+	}
+
+	var result struct {
+		Jobs []storage.ReviewJob `json:"jobs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	var syntheses []storage.ReviewJob
+	for _, j := range result.Jobs {
+		if j.PanelRole == storage.PanelRoleSynthesis {
+			syntheses = append(syntheses, j)
+		}
+	}
+	sort.Slice(syntheses, func(i, j int) bool {
+		return syntheses[i].ID > syntheses[j].ID
+	})
+	return syntheses, nil
+}
+
+// daemonErrorDetail extracts the human-readable message from a daemon error
+// response: the "detail" of a problem+json body, else the raw body.
+func daemonErrorDetail(resp *http.Response) string {
+	body, _ := io.ReadAll(resp.Body)
+	var problem struct {
+		Detail string `json:"detail"`
+	}
+	if json.Unmarshal(body, &problem) == nil && problem.Detail != "" {
+		return problem.Detail
+	}
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		return msg
+	}
+	return resp.Status
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func TestPanelResynthesizeCmd(t *testing.T) {
+	var got map[string]string
+	daemonFromHandler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/panel/resynthesize" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "synthesis_job_id": 42})
+	}))
+
+	out := captureStdout(t, func() {
+		cmd := panelCmd()
+		cmd.SetArgs([]string{"resynthesize", "run-1", "--agent", "gemini", "--model", "gemini-2.5-pro"})
+		require.NoError(t, cmd.Execute())
+	})
+	assert.Equal(t, map[string]string{
+		"panel_run_uuid": "run-1", "agent": "gemini", "model": "gemini-2.5-pro",
+	}, got)
+	assert.Contains(t, out, "Enqueued synthesis job 42")
+}
+
+func TestPanelResynthesizeCmdReportsDaemonDetail(t *testing.T) {
+	daemonFromHandler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"status":409,"detail":"panel members and synthesis must all be finished to re-synthesize"}`))
+	}))
+
+	cmd := panelCmd()
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	cmd.SetArgs([]string{"resynthesize", "run-1"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "re-synthesize panel: panel members and synthesis must all be finished to re-synthesize", err.Error())
+}
+
+func TestPanelHistoryCmd(t *testing.T) {
+	finished := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	pass := "P"
+	daemonFromHandler(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "run-1", r.URL.Query().Get("panel_run"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"jobs": []storage.ReviewJob{
+			{ID: 10, Agent: "claude-code", Status: storage.JobStatusDone, PanelRole: storage.PanelRoleSynthesis, FinishedAt: &finished},
+			{ID: 11, Agent: "codex", Status: storage.JobStatusDone, PanelRole: storage.PanelRoleMember},
+			{ID: 12, Agent: "gemini", Model: "gemini-2.5-pro", Status: storage.JobStatusDone, PanelRole: storage.PanelRoleSynthesis,
+				Verdict: &pass, TokenUsage: `{"cost_usd":0.12,"has_cost":true}`, FinishedAt: &finished},
+		}})
+	}))
+
+	out := captureStdout(t, func() {
+		cmd := panelCmd()
+		cmd.SetArgs([]string{"history", "run-1"})
+		require.NoError(t, cmd.Execute())
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3, "header plus one row per synthesis: %q", out)
+	assert.Contains(t, lines[1], "12")
+	assert.Contains(t, lines[1], "gemini-2.5-pro")
+	assert.Contains(t, lines[1], "$0.12")
+	assert.Contains(t, lines[2], "claude-code", "older syntheses are listed after newer ones")
+	assert.NotContains(t, out, "codex", "members are not listed")
+}
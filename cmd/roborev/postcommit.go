@@ -81,8 +81,12 @@ func postCommitCmd() *cobra.Command {
 			}
 
 			var gitRef string
+			var incremental bool
 			if ref, ok := tryBranchReview(ctx, root, baseBranch); ok {
 				gitRef = ref
+				// The daemon narrows the branch range to the commits
+				// since the branch's last completed review.
+				incremental = config.ResolveIncrementalReview(root)
 			} else {
 				gitRef = "HEAD"
 			}
@@ -90,10 +94,11 @@ func postCommitCmd() *cobra.Command {
 			branchName := gitrepo.CurrentBranch(ctx, root)
 
 			reqBody, _ := json.Marshal(daemon.EnqueueRequest{
-				RepoPath: root,
-				GitRef:   gitRef,
-				Branch:   branchName,
-				Source:   "post_commit",
+				RepoPath:    root,
+				GitRef:      gitRef,
+				Branch:      branchName,
+				Source:      "post_commit",
+				Incremental: incremental,
 			})
 
 			// Resolve the hook timeout from config (per-repo > global >
@@ -78,6 +78,24 @@ func TestPostCommitBranchReview(t *testing.T) {
 	assert.Equal(t, want, req.GitRef)
 }
 
+func TestPostCommitIncrementalBranchReview(t *testing.T) {
+	repo, mux := setupTestEnvironment(t)
+	reqCh := mockEnqueueCapture(t, mux)
+
+	repo.Run("symbolic-ref", "HEAD", "refs/heads/main")
+	repo.CommitFile("file.txt", "content", "initial")
+	repo.Run("checkout", "-b", "feature")
+	repo.CommitFile("feature.txt", "feature", "feature commit")
+	writeRoborevConfig(t, repo, "post_commit_review = \"branch\"\nincremental_review = true")
+
+	_, _, err := executePostCommitCmd("--repo", repo.Dir)
+	require.NoError(t, err)
+
+	req := <-reqCh
+	assert.True(t, req.Incremental)
+	assert.Equal(t, "feature", req.Branch)
+}
+
 func TestPostCommitFallsBackOnBaseBranch(t *testing.T) {
 	repo, mux := setupTestEnvironment(t)
 	reqCh := mockEnqueue(t, mux)
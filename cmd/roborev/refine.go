@@ -22,8 +22,10 @@ import (
 	"go.kenn.io/roborev/internal/daemon"
 	"go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/streamfmt"
+	"go.kenn.io/roborev/internal/verify"
 )
 
 // postCommitWaitDelay is the delay after creating a commit before checking
@@ -385,6 +387,10 @@ func runRefine(runCtx RunContext, opts refineOptions) error {
 	if err != nil {
 		return fmt.Errorf("resolve fix commit metadata: %w", err)
 	}
+	redactor, err := redact.ForRepo(repoPath, cfg)
+	if err != nil {
+		return fmt.Errorf("redaction: %w", err)
+	}
 	commitOpts := git.CommitOptions{
 		Author:    metadata.Author,
 		CoAuthors: metadata.CoAuthors,
@@ -426,6 +432,9 @@ func runRefine(runCtx RunContext, opts refineOptions) error {
 	agent.SetAllowUnsafeAgents(allowUnsafe)
 	if cfg != nil {
 		agent.SetAnthropicAPIKey(cfg.AnthropicAPIKey)
+		if err := agent.ConfigureSandbox(cfg.Sandbox); err != nil {
+			return err
+		}
 	}
 
 	// Get the agent with configured reasoning level (model applied after
@@ -451,6 +460,13 @@ func runRefine(runCtx RunContext, opts refineOptions) error {
 		return fmt.Errorf("resolve min-severity: %w", err)
 	}
 
+	// Edits must pass the repo's verify_command, if any, before commit.
+	verifySettings := config.ResolveVerify(repoPath)
+	var verifyOutput io.Writer = os.Stdout
+	if opts.quiet {
+		verifyOutput = io.Discard
+	}
+
 	// 3. Refinement loop
 	// Track current failed review - when a fix fails, we continue fixing it
 	// before moving on to the next oldest failed commit
@@ -586,6 +602,8 @@ func runRefine(runCtx RunContext, opts refineOptions) error {
 		if err != nil {
 			return fmt.Errorf("build address prompt: %w", err)
 		}
+		// The findings and the original diff may quote secrets.
+		addressPrompt = redactor.String(addressPrompt)
 
 		// Record pre-agent state for safety checks
 		wasCleanBefore := git.IsWorkingTreeClean(repoPath)
@@ -741,6 +759,53 @@ func runRefine(runCtx RunContext, opts refineOptions) error {
 			continue
 		}
 
+		// Gate the edits on the repo's verify_command before they reach
+		// the branch. Failing edits are dropped with the worktree.
+		if verifySettings.Enabled() {
+			outcome, verifyErr := verify.Check(ctx, worktreePath, verifySettings, func(ctx context.Context, prompt string) error {
+				repairCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+				defer cancel()
+				_, err := addressAgent.Review(repairCtx, worktreePath, "HEAD", redactor.String(prompt), agentOutput)
+				if fmtr != nil {
+					fmtr.Flush()
+				}
+				return err
+			}, verifyOutput)
+			if err := client.SaveVerifyResult(currentFailedReview.JobID, storage.VerifyResult{
+				Passed:     verifyErr == nil && outcome.Passed,
+				Attempts:   outcome.Attempts,
+				DurationMs: outcome.Duration.Milliseconds(),
+				Command:    outcome.Failure.Command,
+				Output:     outcome.Failure.Output,
+			}); err != nil {
+				fmt.Printf("Warning: failed to save verify result for job %d: %v\n", currentFailedReview.JobID, err)
+			}
+			if verifyErr == nil && outcome.Passed {
+				fmt.Printf("Verify: %s\n", outcome.Summary())
+			} else {
+				_ = wt.Close(ctx)
+				reason := outcome.Summary()
+				if verifyErr != nil {
+					reason = verifyErr.Error()
+				}
+				fmt.Printf("Discarding changes: %s\n", reason)
+				fmt.Println("Will retry in next iteration")
+				if err := client.AddComment(currentFailedReview.JobID, "roborev-refine", "Refine attempt discarded: "+reason); err != nil {
+					fmt.Printf("Warning: failed to add comment to job %d: %v\n", currentFailedReview.JobID, err)
+				}
+				continue
+			}
+			changedSubmodules, err := changedRefineSubmodules(ctx, worktreePath, submodulesBeforeAgent)
+			if err != nil {
+				_ = wt.Close(ctx)
+				return fmt.Errorf("check submodule changes: %w", err)
+			}
+			if len(changedSubmodules) > 0 {
+				_ = wt.Close(ctx)
+				return refineSubmoduleChangesError(changedSubmodules)
+			}
+		}
+
 		// Capture patch from worktree and apply to main repo
 		patch, err := wt.CapturePatch(ctx)
 		if err != nil {
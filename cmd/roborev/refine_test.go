@@ -28,6 +28,7 @@ type mockDaemonClient struct {
 
 	closedJobIDs    []int64
 	addedComments   []addedComment
+	verifyResults   map[int64]storage.VerifyResult
 	enqueuedReviews []enqueuedReview
 
 	nextReviewID int64
@@ -50,9 +51,10 @@ type enqueuedReview struct {
 
 func newMockDaemonClient() *mockDaemonClient {
 	return &mockDaemonClient{
-		reviews:   make(map[string]*storage.Review),
-		jobs:      make(map[int64]*storage.ReviewJob),
-		responses: make(map[int64][]storage.Response),
+		reviews:       make(map[string]*storage.Review),
+		jobs:          make(map[int64]*storage.ReviewJob),
+		responses:     make(map[int64][]storage.Response),
+		verifyResults: make(map[int64]storage.VerifyResult),
 	}
 }
 
@@ -88,6 +90,11 @@ func (m *mockDaemonClient) AddComment(jobID int64, commenter, comment string) er
 	return nil
 }
 
+func (m *mockDaemonClient) SaveVerifyResult(jobID int64, result storage.VerifyResult) error {
+	m.verifyResults[jobID] = result
+	return nil
+}
+
 func (m *mockDaemonClient) EnqueueReview(repoPath, gitRef, agentName string) (int64, error) {
 	m.enqueuedReviews = append(m.enqueuedReviews, enqueuedReview{repoPath, gitRef, agentName})
 	return int64(len(m.enqueuedReviews)), nil
@@ -22,6 +22,7 @@ import (
 	"go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/kata"
 	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/storage"
 )
 
@@ -66,6 +67,7 @@ func reviewCmd() *cobra.Command {
 		provider    string
 		minSeverity string
 		panel       string
+		noCache     bool
 	)
 
 	cmd := &cobra.Command{
@@ -351,6 +353,7 @@ Examples:
 				DirtyFiles:  dirtyFiles,
 				MinSeverity: minSeverity,
 				Panel:       panel,
+				NoCache:     noCache,
 			}
 
 			reqBody, _ := json.Marshal(reqFields)
@@ -427,6 +430,7 @@ Examples:
 	cmd.Flags().StringVar(&provider, "provider", "", "provider for pi agent (e.g. anthropic, openai)")
 	cmd.Flags().StringVar(&minSeverity, "min-severity", "", "minimum severity threshold: critical, high, medium, low")
 	cmd.Flags().StringVar(&panel, "panel", "", "review panel to fan out to (config panel name; 'none' forces single-agent)")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "run the agent even when a recent review of an identical patch can be reused")
 	registerAgentCompletion(cmd)
 	registerReasoningCompletion(cmd)
 	registerReviewTypeCompletion(cmd)
@@ -512,8 +516,13 @@ func runLocalReview(cmd *cobra.Command, repoPath, gitRef, diffContent string, di
 		fmt.Fprintf(out, "Running %s review (model: %s, reasoning: %s)...\n\n", a.Name(), model, reasoning)
 	}
 
+	redactor, err := redact.ForRepo(repoPath, cfg)
+	if err != nil {
+		return fmt.Errorf("redaction: %w", err)
+	}
+
 	// Build prompt
-	pb := prompt.NewBuilderWithConfig(nil, cfg).WithContext(ctx).ForRepo(repoPath, 0).WithKataClient(kata.NewCLIClient(repoPath))
+	pb := prompt.NewBuilderWithConfig(nil, cfg).WithContext(ctx).ForRepo(repoPath, 0).WithKataClient(kata.NewCLIClient(repoPath)).WithRedactor(redactor)
 	var reviewPrompt string
 	var snapshotCleanup func()
 	if diffContent != "" || len(dirtyFiles) > 0 {
@@ -535,6 +544,7 @@ func runLocalReview(cmd *cobra.Command, repoPath, gitRef, diffContent string, di
 	if err != nil {
 		return fmt.Errorf("build prompt: %w", err)
 	}
+	reviewPrompt = redactor.String(reviewPrompt)
 
 	// Run review with output writer
 	_, err = a.Review(cmd.Context(), repoPath, gitRef, reviewPrompt, out)
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"go.kenn.io/roborev/internal/sandbox"
+)
+
+// sandboxExecCmd runs inside the agent sandbox and bridges the agent's
+// HTTP(S) traffic to the daemon's egress proxy. It is internal plumbing
+// started by the sandbox, not a user-facing command.
+func sandboxExecCmd() *cobra.Command {
+	var proxySocket string
+	cmd := &cobra.Command{
+		Use:    sandbox.HelperCommand + " --proxy <socket> -- <command> [args...]",
+		Short:  "Run a command inside the agent sandbox (internal)",
+		Hidden: true,
+		Args:   cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if proxySocket == "" {
+				return usageErr(cmd, fmt.Errorf("--proxy is required"))
+			}
+			code, err := sandbox.RunHelper(proxySocket, args)
+			if err != nil {
+				return err
+			}
+			if code != 0 {
+				return silentExit(cmd, code)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().StringVar(&proxySocket, "proxy", "", "egress proxy unix socket")
+	return cmd
+}
@@ -75,6 +75,27 @@ func formatReviewersSummary(members []storage.ReviewJob) string {
 	return fmt.Sprintf("%d reviewers: %s", len(members), strings.Join(parts, ", "))
 }
 
+// formatAttemptsSummary renders a job's agent attempts as one line, e.g.
+// "Attempts: codex/gpt-5 failed (quota) -> claude-code succeeded". It returns
+// "" unless the job moved past a failed or skipped attempt.
+func formatAttemptsSummary(attempts []storage.JobAttempt) string {
+	if len(attempts) < 2 {
+		return ""
+	}
+	parts := make([]string, len(attempts))
+	for i, a := range attempts {
+		name := a.Agent
+		if a.Model != "" {
+			name += "/" + a.Model
+		}
+		parts[i] = name + " " + a.Outcome
+		if a.FailureClass != "" {
+			parts[i] += " (" + a.FailureClass + ")"
+		}
+	}
+	return "Attempts: " + strings.Join(parts, " -> ")
+}
+
 func showCmd() *cobra.Command {
 	var forceJobID bool
 	var showPrompt bool
@@ -181,11 +202,13 @@ Examples:
 				// Include comments so tools/skills can see developer feedback.
 				type reviewWithComments struct {
 					storage.Review
-					Comments []storage.Response `json:"comments,omitempty"`
-					Panel    *showPanelBlock    `json:"panel,omitempty"`
+					Comments []storage.Response   `json:"comments,omitempty"`
+					Attempts []storage.JobAttempt `json:"attempts,omitempty"`
+					Panel    *showPanelBlock      `json:"panel,omitempty"`
 				}
 				out := reviewWithComments{Review: review}
 				out.Comments = fetchShowComments(client, addr, review)
+				out.Attempts = fetchJobAttempts(client, addr, review.JobID)
 				if review.Job != nil && review.Job.IsSynthesisJob() && review.Job.PanelRunUUID != "" {
 					if members, err := fetchPanelMembers(client, addr, review.Job.PanelRunUUID); err == nil && len(members) > 0 {
 						block := buildShowPanelBlock(review.JobID, review.Job.PanelRunUUID, review.Job.PanelName, members)
@@ -207,6 +230,23 @@ Examples:
 				if tu := tokens.ParseJSON(review.Job.TokenUsage); tu != nil {
 					fmt.Printf("Tokens: %s\n", tu.FormatSummary())
 				}
+				if review.Job.Route != "" {
+					fmt.Printf("Route: %s\n", review.Job.Route)
+				}
+				if review.Job.CachedFrom != "" {
+					fmt.Printf("Cached: reused review of job %s\n", review.Job.CachedFrom)
+				}
+				if line := formatAttemptsSummary(fetchJobAttempts(client, addr, review.JobID)); line != "" {
+					fmt.Println(line)
+				}
+				if v := review.Job.Verify; v != nil {
+					status := "passed"
+					if !v.Passed {
+						status = "failed: " + v.Command
+					}
+					fmt.Printf("Verify: %s (%d repair attempts, %s)\n", status, v.Attempts,
+						(time.Duration(v.DurationMs) * time.Millisecond).Round(time.Second))
+				}
 			}
 			if review.Job != nil && review.Job.IsSynthesisJob() && review.Job.PanelRunUUID != "" {
 				if members, err := fetchPanelMembers(client, addr, review.Job.PanelRunUUID); err == nil && len(members) > 0 {
@@ -276,6 +316,26 @@ func fetchPanelMembers(client *http.Client, addr, runUUID string) ([]storage.Rev
 	return members, nil
 }
 
+// fetchJobAttempts loads a job's agent attempts via GET /api/job/attempts.
+// Errors yield no attempts, since the history is supplementary.
+func fetchJobAttempts(client *http.Client, addr string, jobID int64) []storage.JobAttempt {
+	resp, err := client.Get(addr + fmt.Sprintf("/api/job/attempts?job_id=%d", jobID))
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	var result struct {
+		Attempts []storage.JobAttempt `json:"attempts"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&result) != nil {
+		return nil
+	}
+	return result.Attempts
+}
+
 // fetchShowComments retrieves comments for a review, merging legacy
 // SHA-based comments via storage.MergeResponses.
 func fetchShowComments(client *http.Client, addr string, review storage.Review) []storage.Response {
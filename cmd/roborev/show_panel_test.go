@@ -89,3 +89,18 @@ func TestFormatReviewersSummaryFallsBackToAgent(t *testing.T) {
 	}
 	assert.Equal("1 reviewers: codex P", formatReviewersSummary(members))
 }
+
+func TestFormatAttemptsSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(formatAttemptsSummary(nil))
+	assert.Empty(formatAttemptsSummary([]storage.JobAttempt{
+		{Attempt: 1, Agent: "codex", Outcome: storage.AttemptSucceeded},
+	}), "a first-try success has no history worth showing")
+	assert.Equal("Attempts: codex/gpt-5 failed (quota) -> gemini skipped (circuit_open) -> claude-code/sonnet succeeded",
+		formatAttemptsSummary([]storage.JobAttempt{
+			{Attempt: 1, Agent: "codex", Model: "gpt-5", Outcome: storage.AttemptFailed, FailureClass: storage.AttemptFailureQuota},
+			{Attempt: 2, Agent: "gemini", Outcome: storage.AttemptSkipped, FailureClass: storage.AttemptFailureCircuitOpen},
+			{Attempt: 3, Agent: "claude-code", Model: "sonnet", Outcome: storage.AttemptSucceeded},
+		}))
+}
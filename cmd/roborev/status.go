@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"text/tabwriter"
 	"time"
@@ -19,6 +20,7 @@ type statusJSONResult struct {
 	Running bool                  `json:"running"`
 	Daemon  *storage.DaemonStatus `json:"daemon,omitempty"`
 	Health  *storage.HealthStatus `json:"health,omitempty"`
+	Agents  []storage.AgentHealth `json:"agents,omitempty"`
 	Jobs    []storage.ReviewJob   `json:"jobs,omitempty"`
 	Error   string                `json:"error,omitempty"`
 }
@@ -85,6 +87,19 @@ func statusCmd() *cobra.Command {
 				}
 			}
 
+			// Get the latest agent probes
+			var agents []storage.AgentHealth
+			if agentsResp, err := client.Get(addr + "/api/agents"); err == nil {
+				defer agentsResp.Body.Close()
+				var decoded struct {
+					Agents []storage.AgentHealth `json:"agents"`
+				}
+				if agentsResp.StatusCode == http.StatusOK &&
+					json.NewDecoder(agentsResp.Body).Decode(&decoded) == nil {
+					agents = decoded.Agents
+				}
+			}
+
 			// Get recent jobs
 			var jobs []storage.ReviewJob
 			resp, err = client.Get(addr + "/api/jobs?limit=10")
@@ -104,6 +119,7 @@ func statusCmd() *cobra.Command {
 					Running: true,
 					Daemon:  &status,
 					Health:  health,
+					Agents:  agents,
 					Jobs:    jobs,
 				})
 			}
@@ -161,6 +177,12 @@ func statusCmd() *cobra.Command {
 				}
 			}
 
+			if len(agents) > 0 {
+				fmt.Println("Agents:")
+				printAgentHealth(os.Stdout, agents)
+				fmt.Println()
+			}
+
 			if len(jobs) > 0 {
 				fmt.Println("Recent Jobs:")
 				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -78,3 +78,30 @@ func TestStatusCmdJSONIncludesDaemonEndpoint(t *testing.T) {
 	assert.Equal(t, "127.0.0.1:7373", parsed.Daemon.Address)
 	assert.Equal(t, 7373, parsed.Daemon.Port)
 }
+
+func TestStatusCmdShowsAgentHealth(t *testing.T) {
+	md := NewMockDaemon(t, MockRefineHooks{
+		OnUnhandled: func(w http.ResponseWriter, r *http.Request, _ *mockRefineState) bool {
+			if r.URL.Path != "/api/agents" {
+				return false
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"agents": []storage.AgentHealth{
+				{Agent: "codex", Status: storage.AgentHealthOK, Auth: "ok", Version: "codex-cli 0.50.0",
+					LatencyMs: 1200, CheckedAt: time.Now()},
+				{Agent: "claude-code", Model: "sonnet", Status: storage.AgentHealthAuthFailed, Auth: "failed",
+					Error: "Not logged in · Please run /login", CheckedAt: time.Now()},
+			}})
+			return true
+		},
+	})
+	defer md.Close()
+
+	output := captureStdout(t, func() {
+		require.NoError(t, statusCmd().Execute())
+	})
+
+	assert.Contains(t, output, "Agents:")
+	assert.Regexp(t, `\+ codex\s+ok\s+1\.2s\s+codex-cli 0\.50\.0`, output)
+	assert.Regexp(t, `! claude-code/sonnet\s+auth_failed`, output)
+	assert.Contains(t, output, "Not logged in")
+}
@@ -4,11 +4,13 @@ import (
 	"bufio"
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	gitrepo "go.kenn.io/kit/git/repo"
@@ -16,17 +18,22 @@ import (
 
 func streamCmd() *cobra.Command {
 	var repoFilter string
+	var since string
 
 	cmd := &cobra.Command{
 		Use:   "stream",
 		Short: "Stream review events in real-time",
 		Long: `Stream review events from the daemon in real-time.
 
-Events are printed as JSONL (one JSON object per line).
+Events are printed as JSONL (one JSON object per line). Each event carries an
+"id" from the daemon's event journal. Pass the last id you processed to
+--since to resume without missing events, even across daemon restarts.
 
 Examples:
-  roborev stream              # Stream all events
-  roborev stream --repo .     # Stream events for current repo only
+  roborev stream                  # Stream all events
+  roborev stream --repo .         # Stream events for current repo only
+  roborev stream --since 1842     # Replay events after id 1842, then stream
+  roborev stream --since 2h       # Replay the last two hours, then stream
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -44,11 +51,23 @@ Examples:
 				repoFilter = root
 			}
 
-			// Build URL with optional repo filter
+			sinceParam, err := streamSinceParam(since, time.Now())
+			if err != nil {
+				return err
+			}
+
+			// Build URL with optional repo filter and resume point
 			ep := getDaemonEndpoint()
 			streamURL := ep.BaseURL() + "/api/stream/events"
+			params := url.Values{}
 			if repoFilter != "" {
-				streamURL += "?" + url.Values{"repo": {repoFilter}}.Encode()
+				params.Set("repo", repoFilter)
+			}
+			if sinceParam != "" {
+				params.Set("since", sinceParam)
+			}
+			if len(params) > 0 {
+				streamURL += "?" + params.Encode()
 			}
 
 			// Create request
@@ -79,8 +98,7 @@ Examples:
 			defer resp.Body.Close()
 
 			if resp.StatusCode != http.StatusOK {
-				body, _ := io.ReadAll(resp.Body)
-				return fmt.Errorf("stream failed: %s", body)
+				return fmt.Errorf("stream failed: %s", daemonErrorDetail(resp))
 			}
 
 			// Stream events - pass through lines directly to preserve all fields.
@@ -102,6 +120,27 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&repoFilter, "repo", "", "filter events by repository path")
+	cmd.Flags().StringVar(&since, "since", "", "replay journaled events first: after an event id, or within a duration (2h) or since an RFC 3339 time")
 
 	return cmd
 }
+
+// streamSinceParam converts --since into the stream endpoint's since
+// parameter: event IDs and timestamps pass through, durations become a
+// timestamp relative to now.
+func streamSinceParam(since string, now time.Time) (string, error) {
+	since = strings.TrimSpace(since)
+	if since == "" {
+		return "", nil
+	}
+	if id, err := strconv.ParseInt(since, 10, 64); err == nil && id >= 0 {
+		return since, nil
+	}
+	if d, err := time.ParseDuration(since); err == nil && d > 0 {
+		return now.Add(-d).UTC().Format(time.RFC3339), nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("invalid --since %q: use an event id, a duration like 2h, or an RFC 3339 time", since)
+}
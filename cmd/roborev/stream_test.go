@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamSinceParam(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in, want, wantErr string
+	}{
+		{in: "", want: ""},
+		{in: "1842", want: "1842"},
+		{in: "0", want: "0"},
+		{in: "2h", want: "2026-03-02T10:00:00Z"},
+		{in: "2026-03-01T08:00:00+01:00", want: "2026-03-01T07:00:00Z"},
+		{in: "yesterday", wantErr: "invalid --since"},
+		{in: "-5", wantErr: "invalid --since"},
+	}
+	for _, tt := range tests {
+		got, err := streamSinceParam(tt.in, now)
+		if tt.wantErr != "" {
+			require.ErrorContains(t, err, tt.wantErr, tt.in)
+			continue
+		}
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
+
+func TestStreamCommandSince(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/stream/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "41", r.URL.Query().Get("since"))
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, `{"id":42,"type":"review.completed","job_id":7}`+"\n")
+	})
+	daemonFromHandler(t, mux)
+
+	out := captureStdout(t, func() {
+		cmd := streamCmd()
+		cmd.SetArgs([]string{"--since", "41"})
+		require.NoError(t, cmd.Execute())
+	})
+	assert.Equal(t, `{"id":42,"type":"review.completed","job_id":7}`+"\n", out)
+}
+
+func TestStreamCommandRejectedSince(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/stream/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = io.WriteString(w, `{"detail":"event journal unavailable"}`)
+	})
+	daemonFromHandler(t, mux)
+
+	cmd := streamCmd()
+	cmd.SetArgs([]string{"--since", "1"})
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	require.ErrorContains(t, err, "event journal unavailable")
+}
@@ -24,6 +24,7 @@ func summaryCmd() *cobra.Command {
 		branch     string
 		since      string
 		allRepos   bool
+		agents     bool
 		jsonOutput bool
 	)
 
@@ -41,7 +42,15 @@ Examples:
   roborev summary --since 30d         # Last 30 days
   roborev summary --branch main       # Filter by branch
   roborev summary --repo /path/to/repo
-  roborev summary --json              # Structured output for scripting`,
+  roborev summary --agents            # Agent quality scoreboard
+  roborev summary --json              # Structured output for scripting
+
+The --agents scoreboard rates each agent/model by what happened to its
+failing reviews. A review is accepted when a fix for it was applied or it
+was closed, and dismissed when a comment calls it a false positive (for
+example "false positive", "not a bug", or "won't fix"). Precision is
+accepted/(accepted+dismissed). Agreement is the share of an agent's panel
+findings that another member of the same run also reported.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if err := ensureDaemon(); err != nil {
@@ -77,6 +86,9 @@ Examples:
 			if allRepos {
 				params.Set("all", "true")
 			}
+			if agents {
+				params.Set("agents", "true")
+			}
 
 			client := ep.HTTPClient(10 * time.Second)
 			resp, err := client.Get(addr + "/api/summary?" + params.Encode())
@@ -100,6 +112,10 @@ Examples:
 				return enc.Encode(summary)
 			}
 
+			if agents {
+				printAgentScoreboard(cmd, summary)
+				return nil
+			}
 			printSummary(cmd, summary)
 			return nil
 		},
@@ -109,6 +125,7 @@ Examples:
 	cmd.Flags().StringVar(&branch, "branch", "", "scope to a single branch")
 	cmd.Flags().StringVar(&since, "since", "7d", "time window (e.g. 24h, 7d, 30d)")
 	cmd.Flags().BoolVar(&allRepos, "all", false, "show summary across all repos")
+	cmd.Flags().BoolVar(&agents, "agents", false, "show the agent quality scoreboard")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "structured output for scripting")
 	cmd.MarkFlagsMutuallyExclusive("all", "repo")
 
@@ -247,6 +264,52 @@ func printSummary(cmd *cobra.Command, s storage.Summary) {
 	}
 }
 
+// printAgentScoreboard prints the per-agent/model quality scoreboard.
+func printAgentScoreboard(cmd *cobra.Command, s storage.Summary) {
+	repoLabel := "all repos"
+	if s.RepoPath != "" {
+		repoLabel = filepath.Base(s.RepoPath)
+	}
+	header := fmt.Sprintf("Agent Scoreboard: %s", repoLabel)
+	if s.Branch != "" {
+		header += fmt.Sprintf(" (%s)", s.Branch)
+	}
+	header += fmt.Sprintf(" [last %s]", formatSince(s.Since))
+	cmd.Println(header)
+	cmd.Println()
+
+	if len(s.AgentScores) == 0 {
+		cmd.Println("No review data for this time window.")
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  Agent\tModel\tReviews\tFindings\tAccepted\tDismissed\tOpen\tPrecision\tFP Rate\tAgreement\tCost/Accepted\n")
+	for _, a := range s.AgentScores {
+		model := a.Model
+		if model == "" {
+			model = "-"
+		}
+		precision, fpRate := "-", "-"
+		if a.Accepted+a.Dismissed > 0 {
+			precision = fmt.Sprintf("%.0f%%", a.Precision*100)
+			fpRate = fmt.Sprintf("%.0f%%", a.FalsePositiveRate*100)
+		}
+		agreement := "-"
+		if a.PanelFindings > 0 {
+			agreement = fmt.Sprintf("%.0f%%", a.AgreementRate*100)
+		}
+		costPer := "-"
+		if a.AcceptedFindings > 0 && a.CostUSD > 0 {
+			costPer = fmt.Sprintf("$%.2f", a.CostPerAcceptedFinding)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			a.Agent, model, a.Reviews, a.Findings, a.Accepted, a.Dismissed, a.Open,
+			precision, fpRate, agreement, costPer)
+	}
+	w.Flush()
+}
+
 // repoLabels returns display labels for repos, disambiguating duplicate names
 // by prepending parent directory components until all labels are unique.
 func repoLabels(repos []storage.RepoSummary) []string {
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.kenn.io/roborev/internal/storage"
 )
@@ -132,3 +137,27 @@ func TestRepoLabels_Windows(t *testing.T) {
 	got := repoLabels(repos)
 	assert.Equal(t, []string{`C:\work\repo`, `D:\work\repo`}, got)
 }
+
+func TestPrintAgentScoreboard(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+
+	printAgentScoreboard(cmd, storage.Summary{
+		Since:    time.Now().Add(-7 * 24 * time.Hour),
+		RepoPath: "/home/user/project",
+		AgentScores: []storage.AgentScore{
+			{Agent: "codex", Model: "gpt-5", Reviews: 10, Findings: 6, Accepted: 3, Dismissed: 1,
+				Precision: 0.75, FalsePositiveRate: 0.25, AcceptedFindings: 4, CostUSD: 2, CostPerAcceptedFinding: 0.5},
+			{Agent: "gemini", Reviews: 2},
+		},
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 5, buf.String())
+	assert.Contains(t, lines[0], "Agent Scoreboard: project")
+	assert.Equal(t, []string{"codex", "gpt-5", "10", "6", "3", "1", "0", "75%", "25%", "-", "$0.50"},
+		strings.Fields(lines[3]))
+	assert.Equal(t, []string{"gemini", "-", "2", "0", "0", "0", "0", "-", "-", "-", "-"},
+		strings.Fields(lines[4]))
+}
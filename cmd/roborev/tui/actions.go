@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -231,6 +233,43 @@ func (m model) rerunJob(snap rerunSnapshot) tea.Cmd {
 	}
 }
 
+// rerunPanelMember asks the daemon to rerun one member of a finished panel
+// run in place; the daemon re-blocks the synthesis until the member is done.
+// The generated client predates the panel endpoints, so this posts directly.
+func (m model) rerunPanelMember(runUUID string, jobID int64, label string) tea.Cmd {
+	return m.postPanelAction(runUUID, "/api/panel/rerun-member",
+		map[string]any{"job_id": jobID},
+		fmt.Sprintf("Rerunning %s; synthesis will run again when it finishes", label))
+}
+
+// resynthesizePanel asks the daemon to re-run a panel's synthesis over the
+// stored member outputs.
+func (m model) resynthesizePanel(runUUID string) tea.Cmd {
+	return m.postPanelAction(runUUID, "/api/panel/resynthesize",
+		map[string]any{"panel_run_uuid": runUUID},
+		"Re-synthesizing panel")
+}
+
+func (m model) postPanelAction(runUUID, path string, body any, done string) tea.Cmd {
+	baseURL := m.endpoint.BaseURL()
+	client := m.client
+	return func() tea.Msg {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return panelActionMsg{runUUID: runUUID, err: err}
+		}
+		resp, err := client.Post(baseURL+path, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return panelActionMsg{runUUID: runUUID, err: err}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return panelActionMsg{runUUID: runUUID, err: fmt.Errorf("%s", readErrorBody(resp.Body, resp.Status))}
+		}
+		return panelActionMsg{runUUID: runUUID, done: done}
+	}
+}
+
 // rerunSnapshot captures job state before an optimistic rerun
 // update so it can be rolled back if the server request fails.
 type rerunSnapshot struct {
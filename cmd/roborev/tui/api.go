@@ -18,18 +18,24 @@ import (
 // Callers can detect it with errors.Is(err, errNotFound).
 var errNotFound = errors.New("not found")
 
-// readErrorBody reads a JSON error response body and returns the "error" field,
-// falling back to the raw body text or HTTP status.
+// readErrorBody reads a JSON error response body and returns the "error" field
+// (or a Huma problem "detail"), falling back to the raw body text or HTTP status.
 func readErrorBody(body io.Reader, status string) string {
 	data, err := io.ReadAll(io.LimitReader(body, 1024))
 	if err != nil || len(data) == 0 {
 		return status
 	}
 	var errResp struct {
-		Error string `json:"error"`
+		Error  string `json:"error"`
+		Detail string `json:"detail"` // Huma problem+json
 	}
-	if json.Unmarshal(data, &errResp) == nil && errResp.Error != "" {
-		return errResp.Error
+	if json.Unmarshal(data, &errResp) == nil {
+		if errResp.Error != "" {
+			return errResp.Error
+		}
+		if errResp.Detail != "" {
+			return errResp.Detail
+		}
 	}
 	if s := strings.TrimSpace(string(data)); s != "" {
 		return s
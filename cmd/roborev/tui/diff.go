@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.kenn.io/roborev/internal/review"
+)
+
+type diffRowKind int
+
+const (
+	diffRowContext diffRowKind = iota
+	diffRowAdd
+	diffRowDel
+	diffRowHunk
+	diffRowMeta    // diff/index/---/+++ lines
+	diffRowFile    // "diff --git" line that starts a file section
+	diffRowFinding // finding annotation pinned below its anchor
+)
+
+// diffRow is one rendered row of the diff view. Diff rows carry the file
+// they belong to and, for context/added lines, their new-file line number;
+// annotation rows carry the index of their finding and, when pinned, the
+// file they are pinned in.
+type diffRow struct {
+	kind    diffRowKind
+	text    string
+	file    string
+	line    int
+	finding int
+}
+
+// diffLayout is the diff with findings interleaved. findingRows lists the
+// annotation row of each pinned finding in display order, which is the
+// order n/N navigate; fileRows lists the row that starts each file.
+type diffLayout struct {
+	rows        []diffRow
+	findingRows []int
+	fileRows    []int
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// parseDiffRows classifies unified diff lines and tracks the file and
+// new-file line number each belongs to.
+func parseDiffRows(diff string) []diffRow {
+	diff = strings.TrimRight(diff, "\n")
+	if diff == "" {
+		return nil
+	}
+	var (
+		rows    []diffRow
+		file    string
+		newLine int
+		inHunk  bool
+	)
+	for line := range strings.SplitSeq(diff, "\n") {
+		line = strings.TrimRight(line, "\r")
+		row := diffRow{text: line, finding: -1}
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			file = diffGitPath(line)
+			inHunk = false
+			row.kind = diffRowFile
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			if p := strings.TrimPrefix(line, "+++ "); p != "/dev/null" {
+				file = strings.TrimPrefix(p, "b/")
+			}
+			row.kind = diffRowMeta
+		case strings.HasPrefix(line, "@@"):
+			if m := hunkHeaderRe.FindStringSubmatch(line); m != nil {
+				newLine, _ = strconv.Atoi(m[1])
+			}
+			inHunk = true
+			row.kind = diffRowHunk
+		case !inHunk:
+			row.kind = diffRowMeta
+		case strings.HasPrefix(line, "+"):
+			row.kind = diffRowAdd
+			row.line = newLine
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			row.kind = diffRowDel
+		case strings.HasPrefix(line, `\`):
+			row.kind = diffRowMeta
+		default:
+			row.kind = diffRowContext
+			row.line = newLine
+			newLine++
+		}
+		row.file = file
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// diffGitPath returns the b/ path of a "diff --git a/x b/y" header.
+func diffGitPath(line string) string {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return rest[i+3:]
+	}
+	return ""
+}
+
+// diffPathMatches reports whether a finding's path refers to a diff path.
+// Agents may cite paths relative to a subdirectory, with a "./" prefix,
+// or as absolute paths, so either may be a suffix of the other.
+func diffPathMatches(diffPath, findingPath string) bool {
+	findingPath = strings.TrimPrefix(findingPath, "./")
+	if diffPath == "" || findingPath == "" {
+		return false
+	}
+	return diffPath == findingPath ||
+		strings.HasSuffix(diffPath, "/"+findingPath) ||
+		strings.HasSuffix(findingPath, "/"+diffPath)
+}
+
+// buildDiffLayout pins each finding below the diff line it cites. A
+// finding whose line is not in the diff is pinned below its file's
+// header; one whose file is not in the diff is listed above the diff.
+func buildDiffLayout(diff string, findings []review.Finding) diffLayout {
+	base := parseDiffRows(diff)
+	anchored := make(map[int][]int) // base row → findings pinned below it
+	var unpinned []int
+	for i, f := range findings {
+		anchor := -1
+		fileRow := -1 // last header row before the file's first hunk
+		for r, row := range base {
+			if !diffPathMatches(row.file, f.File) {
+				continue
+			}
+			if (row.kind == diffRowFile && fileRow < 0) ||
+				(row.kind == diffRowMeta && fileRow >= 0 && fileRow == r-1) {
+				fileRow = r
+			}
+			if f.Line > 0 && row.line == f.Line &&
+				(row.kind == diffRowAdd || row.kind == diffRowContext) {
+				anchor = r
+				break
+			}
+		}
+		if anchor < 0 && fileRow >= 0 {
+			anchor = fileRow
+		}
+		if anchor < 0 {
+			unpinned = append(unpinned, i)
+			continue
+		}
+		anchored[anchor] = append(anchored[anchor], i)
+	}
+
+	var layout diffLayout
+	addFinding := func(idx int, file string) {
+		layout.findingRows = append(layout.findingRows, len(layout.rows))
+		layout.rows = append(layout.rows, diffRow{kind: diffRowFinding, file: file, finding: idx})
+	}
+	for _, idx := range unpinned {
+		addFinding(idx, "")
+	}
+	for r, row := range base {
+		if row.kind == diffRowFile {
+			layout.fileRows = append(layout.fileRows, len(layout.rows))
+		}
+		layout.rows = append(layout.rows, row)
+		for _, idx := range anchored[r] {
+			addFinding(idx, row.file)
+		}
+	}
+	return layout
+}
+
+// setDiff installs a freshly loaded diff and overlays the findings of the
+// current review when it belongs to the same job.
+func (m *model) setDiff(diff string) {
+	m.diffText = diff
+	m.diffFindings = nil
+	if m.currentReview != nil && m.currentReview.JobID == m.diffJobID {
+		m.diffFindings = review.ParseFindings(m.currentReview.Output)
+	}
+	m.diffLayout = buildDiffLayout(diff, m.diffFindings)
+	m.diffScroll = 0
+	m.diffFindingIdx = -1
+}
+
+// closeDiff returns to the view the diff was opened from.
+func (m *model) closeDiff() {
+	m.currentView = m.diffFromView
+	if m.diffOwnsReview {
+		m.currentReview = nil
+	}
+	m.diffOwnsReview = false
+	m.diffJobID = 0
+	m.diffText = ""
+	m.diffFindings = nil
+	m.diffLayout = diffLayout{}
+	m.diffScroll = 0
+	m.diffFindingIdx = -1
+}
+
+// diffVisibleRows is the number of diff rows that fit between the
+// two-line header and the scroll indicator and help footer.
+func (m model) diffVisibleRows() int {
+	help := len(reflowHelpRows(m.diffHelpRows(), m.width))
+	return max(m.height-3-help, 1)
+}
+
+func (m model) diffMaxScroll() int {
+	return max(len(m.diffLayout.rows)-m.diffVisibleRows(), 0)
+}
+
+// selectedDiffFinding returns the finding selected with n/N, if any.
+func (m model) selectedDiffFinding() (review.Finding, bool) {
+	if m.diffFindingIdx < 0 || m.diffFindingIdx >= len(m.diffLayout.findingRows) {
+		return review.Finding{}, false
+	}
+	row := m.diffLayout.rows[m.diffLayout.findingRows[m.diffFindingIdx]]
+	return m.diffFindings[row.finding], true
+}
+
+// jumpToDiffFinding selects the finding at idx and scrolls it into view a
+// third of the way down the screen, leaving its anchor line visible.
+func (m *model) jumpToDiffFinding(idx int) {
+	m.diffFindingIdx = idx
+	row := m.diffLayout.findingRows[idx]
+	m.diffScroll = min(max(row-m.diffVisibleRows()/3, 0), m.diffMaxScroll())
+}
+
+// jumpToDiffFile scrolls to the next (dir > 0) or previous file header
+// relative to the top visible row.
+func (m *model) jumpToDiffFile(dir int) bool {
+	files := m.diffLayout.fileRows
+	if dir > 0 {
+		for _, row := range files {
+			if row > m.diffScroll {
+				m.diffScroll = min(row, m.diffMaxScroll())
+				return true
+			}
+		}
+		return false
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i] < m.diffScroll {
+			m.diffScroll = files[i]
+			return true
+		}
+	}
+	return false
+}
+
+// formatFindingForClipboard renders a finding with its location so it can
+// be pasted into an issue or chat without the surrounding review.
+func formatFindingForClipboard(f review.Finding) string {
+	header := "[" + strings.ToUpper(f.Severity[:1]) + f.Severity[1:] + "]"
+	if loc := f.Location(); loc != "" {
+		header += " " + loc
+	}
+	return header + "\n\n" + f.Text + "\n"
+}
@@ -771,6 +771,86 @@ func (m model) fetchPanelMembers(runUUID string) tea.Cmd {
 	}
 }
 
+// fetchPanelDetail loads a whole panel run for the panel view: the run's jobs
+// via GET /api/jobs?panel_run (raw, like fetchPanelMembers) and the review
+// output of every finished job in one /api/jobs/batch request.
+func (m model) fetchPanelDetail(runUUID string) tea.Cmd {
+	baseURL := m.endpoint.BaseURL()
+	client := m.client
+	return func() tea.Msg {
+		url := fmt.Sprintf("%s/api/jobs?panel_run=%s&limit=0", baseURL, neturl.QueryEscape(runUUID))
+		resp, err := client.Get(url)
+		if err != nil {
+			return panelDetailMsg{runUUID: runUUID, err: err}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return panelDetailMsg{runUUID: runUUID, err: fmt.Errorf("list panel run: %s", resp.Status)}
+		}
+		var result struct {
+			Jobs []storage.ReviewJob `json:"jobs"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return panelDetailMsg{runUUID: runUUID, err: err}
+		}
+
+		msg := panelDetailMsg{runUUID: runUUID, outputs: map[int64]string{}}
+		var doneIDs []int64
+		for i := range result.Jobs {
+			job := result.Jobs[i]
+			switch job.PanelRole {
+			case storage.PanelRoleSynthesis:
+				// A resynthesized run keeps its newest synthesis.
+				if msg.synthesis == nil || job.ID > msg.synthesis.ID {
+					msg.synthesis = &job
+				}
+			case storage.PanelRoleMember:
+				msg.members = append(msg.members, job)
+			default:
+				continue
+			}
+			if job.Status == storage.JobStatusDone {
+				doneIDs = append(doneIDs, job.ID)
+			}
+		}
+		if msg.synthesis == nil {
+			return panelDetailMsg{runUUID: runUUID, err: fmt.Errorf("panel run not found")}
+		}
+		sort.Slice(msg.members, func(i, j int) bool {
+			return msg.members[i].PanelMemberIndex < msg.members[j].PanelMemberIndex
+		})
+		if len(doneIDs) == 0 {
+			return msg
+		}
+
+		batch, err := m.api.BatchJobsWithResponse(
+			m.apiContext(),
+			&daemonclient.BatchJobsRequestOptions{
+				Body: &daemonclient.BatchJobsBody{JobIds: doneIDs},
+			},
+		)
+		if err != nil && batch == nil {
+			return panelDetailMsg{runUUID: runUUID, err: err}
+		}
+		if batch.StatusCode != http.StatusOK {
+			return panelDetailMsg{runUUID: runUUID, err: fmt.Errorf("load panel reviews: %w",
+				apiStatusError(batch.StatusCode, apiStatus(batch.StatusCode), batch.Body))}
+		}
+		var reviews struct {
+			Results map[int64]storage.JobWithReview `json:"results"`
+		}
+		if err := decodeAPIBody(batch.Body, &reviews); err != nil {
+			return panelDetailMsg{runUUID: runUUID, err: err}
+		}
+		for id, r := range reviews.Results {
+			if r.Review != nil {
+				msg.outputs[id] = r.Review.Output
+			}
+		}
+		return msg
+	}
+}
+
 // fetchJobLog fetches raw JSONL from /api/job/log, renders it
 // through streamFormatter, and returns pre-styled logLines.
 // Uses incremental fetching: only new bytes since logOffset are
@@ -1001,6 +1081,49 @@ func (m model) fetchCommitMsg(job *storage.ReviewJob) tea.Cmd {
 	}
 }
 
+// fetchDiff loads the diff a job reviewed. Commit and range diffs are
+// read from the local repo; dirty reviews use the diff captured at
+// enqueue time. withReview also fetches the review so findings can be
+// overlaid when the diff is opened from the queue.
+func (m model) fetchDiff(job *storage.ReviewJob, withReview bool) tea.Cmd {
+	jobID := job.ID
+	return func() tea.Msg {
+		diff, err := jobDiff(job)
+		if err != nil {
+			return diffMsg{jobID: jobID, err: err}
+		}
+		msg := diffMsg{jobID: jobID, diff: diff}
+		if withReview {
+			// A job without a review (failed, or still running) still has
+			// a diff worth showing, so a missing review is not an error.
+			msg.review, _ = m.loadReview(jobID)
+		}
+		return msg
+	}
+}
+
+func jobDiff(job *storage.ReviewJob) (string, error) {
+	if job.IsTaskJob() {
+		return "", fmt.Errorf("no diff for task jobs")
+	}
+	if job.DiffContent != nil {
+		return *job.DiffContent, nil
+	}
+	if job.IsDirtyJob() {
+		return "", fmt.Errorf("diff for uncommitted changes is not available")
+	}
+	if job.GitRef == "" {
+		return "", fmt.Errorf("no git reference available for this job")
+	}
+	if job.RepoPath == "" {
+		return "", fmt.Errorf("no local checkout for this job")
+	}
+	if git.IsRange(job.GitRef) {
+		return git.GetRangeDiff(job.RepoPath, job.GitRef)
+	}
+	return git.GetDiff(job.RepoPath, job.GitRef)
+}
+
 func (m model) fetchPatch(jobID int64) tea.Cmd {
 	return func() tea.Msg {
 		patch, err := m.loadPatch(jobID)
@@ -33,6 +33,10 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handlePatchKey(msg)
 	case viewColumnOptions:
 		return m.handleColumnOptionsInput(msg)
+	case viewDiff:
+		return m.handleDiffKey(msg)
+	case viewPanel:
+		return m.handlePanelKey(msg)
 	}
 
 	// Global keys shared across queue/review/prompt/commitMsg/help views
@@ -156,6 +160,10 @@ func (m model) handleGlobalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleCopyKey()
 	case "m":
 		return m.handleCommitMsgKey()
+	case "v":
+		return m.handleDiffOpenKey()
+	case "e":
+		return m.handlePanelOpenKey()
 	case "?":
 		return m.handleHelpKey()
 	case "esc":
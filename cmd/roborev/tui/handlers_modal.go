@@ -9,6 +9,7 @@ import (
 	"unicode"
 
 	tea "charm.land/bubbletea/v2"
+	gitrepo "go.kenn.io/kit/git/repo"
 
 	"go.kenn.io/roborev/internal/storage"
 )
@@ -558,6 +559,161 @@ func (m model) handlePatchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleDiffKey handles key input in the diff view.
+func (m model) handleDiffKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "ctrl+d", "esc", "q":
+		m.closeDiff()
+		return m, nil
+	case "up", "k":
+		if m.diffScroll > 0 {
+			m.diffScroll--
+		}
+		return m, nil
+	case "down", "j":
+		m.diffScroll = min(m.diffScroll+1, m.diffMaxScroll())
+		return m, nil
+	case "pgup":
+		m.diffScroll = max(0, m.diffScroll-m.diffVisibleRows())
+		return m, tea.ClearScreen
+	case "pgdown":
+		m.diffScroll = min(m.diffScroll+m.diffVisibleRows(), m.diffMaxScroll())
+		return m, tea.ClearScreen
+	case "home", "g":
+		m.diffScroll = 0
+		return m, nil
+	case "end", "G":
+		m.diffScroll = m.diffMaxScroll()
+		return m, nil
+	case "n", "N":
+		count := len(m.diffLayout.findingRows)
+		if count == 0 {
+			m.setFlash("No findings in this review", 2*time.Second, viewDiff)
+			return m, nil
+		}
+		idx := m.diffFindingIdx + 1
+		if msg.String() == "N" {
+			idx = m.diffFindingIdx - 1
+			if m.diffFindingIdx < 0 {
+				idx = count - 1
+			}
+		}
+		if idx < 0 || idx >= count {
+			m.setFlash("No more findings", 2*time.Second, viewDiff)
+			return m, nil
+		}
+		m.jumpToDiffFinding(idx)
+		return m, nil
+	case "]", "[":
+		dir := 1
+		if msg.String() == "[" {
+			dir = -1
+		}
+		if !m.jumpToDiffFile(dir) {
+			m.setFlash("No more files", 2*time.Second, viewDiff)
+		}
+		return m, nil
+	case "y":
+		f, ok := m.selectedDiffFinding()
+		if !ok {
+			m.setFlash("Select a finding with n/N first", 2*time.Second, viewDiff)
+			return m, nil
+		}
+		text := formatFindingForClipboard(f)
+		return m, func() tea.Msg {
+			return clipboardResultMsg{err: m.clipboard.WriteText(text), view: viewDiff}
+		}
+	case "c":
+		if m.commentJobID != m.diffJobID {
+			m.commentText = ""
+		}
+		if f, ok := m.selectedDiffFinding(); ok && m.commentText == "" {
+			ref := f.Location()
+			if ref == "" {
+				ref = f.Severity
+			}
+			m.commentText = fmt.Sprintf("%s — %s\n", ref, f.Title)
+		}
+		m.commentJobID = m.diffJobID
+		m.commentCommit = ""
+		if m.currentReview != nil && m.currentReview.Job != nil {
+			m.commentCommit = gitrepo.ShortSHA(m.currentReview.Job.GitRef)
+		}
+		m.commentFromView = viewDiff
+		m.currentView = viewKindComment
+		return m, nil
+	case "a":
+		if m.currentReview == nil || m.currentReview.ID == 0 {
+			m.setFlash("No review to close", 2*time.Second, viewDiff)
+			return m, nil
+		}
+		return m.handleCloseKey()
+	}
+	return m, nil
+}
+
+func (m model) handlePanelKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "ctrl+d", "esc", "q":
+		m.closePanel()
+		return m, nil
+	case "tab", "right":
+		m.selectPanelTab(m.panelTab + 1)
+		return m, nil
+	case "shift+tab", "left":
+		m.selectPanelTab(m.panelTab - 1)
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(key[0] - '1'); i < m.panelTabCount() {
+			m.selectPanelTab(i)
+		}
+		return m, nil
+	case "up", "k":
+		if m.panelScroll > 0 {
+			m.panelScroll--
+		}
+		return m, nil
+	case "down", "j":
+		m.panelScroll = min(m.panelScroll+1, m.panelMaxScroll())
+		return m, nil
+	case "pgup":
+		m.panelScroll = max(0, m.panelScroll-m.panelVisibleLines())
+		return m, tea.ClearScreen
+	case "pgdown":
+		m.panelScroll = min(m.panelScroll+m.panelVisibleLines(), m.panelMaxScroll())
+		return m, tea.ClearScreen
+	case "home", "g":
+		m.panelScroll = 0
+		return m, nil
+	case "end", "G":
+		m.panelScroll = m.panelMaxScroll()
+		return m, nil
+	case "r":
+		if m.panelTab == 0 {
+			m.setFlash("Select a member tab to rerun it; R re-synthesizes", 2*time.Second, viewPanel)
+			return m, nil
+		}
+		if !m.panelTerminal() {
+			m.setFlash("Wait for the panel run to finish before rerunning a member", 2*time.Second, viewPanel)
+			return m, nil
+		}
+		job := m.panelTabJob(m.panelTab)
+		return m, m.rerunPanelMember(m.panelRunUUID, job.ID, m.panelTabLabel(m.panelTab))
+	case "R":
+		if !m.panelTerminal() {
+			m.setFlash("Wait for the panel run to finish before re-synthesizing", 2*time.Second, viewPanel)
+			return m, nil
+		}
+		return m, m.resynthesizePanel(m.panelRunUUID)
+	}
+	return m, nil
+}
+
 // savePatchToFile writes the current patch text to path.
 func (m model) savePatchToFile(path string) tea.Cmd {
 	patch := m.patchText
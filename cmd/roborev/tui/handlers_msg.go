@@ -282,6 +282,44 @@ func (m model) handlePanelMembersMsg(msg panelMembersMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handlePanelDetailMsg installs a loaded panel run in the panel view and
+// refreshes the queue's member cache with it. Responses for a run the user
+// has since left are dropped.
+func (m model) handlePanelDetailMsg(msg panelDetailMsg) (tea.Model, tea.Cmd) {
+	if msg.runUUID != m.panelRunUUID || m.currentView != viewPanel {
+		return m, nil
+	}
+	m.panelLoading = false
+	if msg.err != nil {
+		m.setFlash(fmt.Sprintf("Couldn't load panel: %v", msg.err), 3*time.Second, viewPanel)
+		return m, nil
+	}
+	m.panelSynth = msg.synthesis
+	m.panelJobs = msg.members
+	m.panelOutputs = msg.outputs
+	if m.panelTab >= m.panelTabCount() {
+		m.selectPanelTab(0)
+	}
+	m.panelMembers[msg.runUUID] = msg.members
+	m.queueColGen++
+	return m, nil
+}
+
+// handlePanelActionMsg reports a member rerun or re-synthesis and reloads the
+// run so the table shows the re-queued jobs.
+func (m model) handlePanelActionMsg(msg panelActionMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.setFlash(fmt.Sprintf("Panel action failed: %v", msg.err), 3*time.Second, viewPanel)
+		return m, nil
+	}
+	m.setFlash(msg.done, 3*time.Second, viewPanel)
+	if msg.runUUID != m.panelRunUUID || m.currentView != viewPanel {
+		return m, nil
+	}
+	m.panelLoading = true
+	return m, m.fetchPanelDetail(msg.runUUID)
+}
+
 // handleCostMsg stores the latest cost aggregate, discarding responses from
 // before a filter change (same fetchSeq guard as handleJobsMsg). It records the
 // response's fetchSeq so costSegmentText can hide the segment after a later
@@ -1017,15 +1055,21 @@ func (m model) handleWindowSizeMsg(
 func (m model) handleTickMsg(
 	_ tickMsg,
 ) (tea.Model, tea.Cmd) {
+	// Poll an open panel run until its jobs finish.
+	var panelCmd tea.Cmd
+	if m.currentView == viewPanel && !m.panelLoading && m.panelRunActive() {
+		m.panelLoading = true
+		panelCmd = m.fetchPanelDetail(m.panelRunUUID)
+	}
 	// Skip job refresh while pagination or another refresh is in flight
 	if m.loadingMore || m.loadingJobs {
-		cmds := []tea.Cmd{m.tick()}
+		cmds := []tea.Cmd{m.tick(), panelCmd}
 		if cmd := m.startFetchStatus(); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
 		return m, tea.Batch(cmds...)
 	}
-	cmds := []tea.Cmd{m.tick(), m.fetchJobs()}
+	cmds := []tea.Cmd{m.tick(), m.fetchJobs(), panelCmd}
 	if cmd := m.startFetchStatus(); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
@@ -1159,6 +1203,25 @@ func (m model) handlePatchResultMsg(
 	return m, nil
 }
 
+// handleDiffMsg opens the diff view once the diff has loaded.
+func (m model) handleDiffMsg(msg diffMsg) (tea.Model, tea.Cmd) {
+	// Drop stale responses and ones that arrive after the user moved on.
+	if msg.jobID != m.diffJobID || m.currentView != m.diffFromView {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.setFlash(fmt.Sprintf("Diff unavailable: %v", msg.err), 3*time.Second, m.currentView)
+		return m, nil
+	}
+	if msg.review != nil {
+		m.currentReview = msg.review
+		m.diffOwnsReview = true
+	}
+	m.setDiff(msg.diff)
+	m.currentView = viewDiff
+	return m, nil
+}
+
 // handleApplyPatchResultMsg processes patch application results.
 func (m model) handleApplyPatchResultMsg(
 	msg applyPatchResultMsg,
@@ -47,7 +47,7 @@ func (m model) handlePromptKey() (tea.Model, tea.Cmd) {
 }
 
 func (m model) handleCloseKey() (tea.Model, tea.Cmd) {
-	if m.currentView == viewReview && m.currentReview != nil && m.currentReview.ID > 0 {
+	if (m.currentView == viewReview || m.currentView == viewDiff) && m.currentReview != nil && m.currentReview.ID > 0 {
 		if m.currentReview.Job != nil && m.currentReview.Job.PanelRole == storage.PanelRoleMember {
 			m.setFlash("Select the panel's synthesis row to close the panel", 3*time.Second, m.currentView)
 			return m, nil
@@ -265,6 +265,48 @@ func (m model) handleCommitMsgKey() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleDiffOpenKey opens the diff view for the selected job or the
+// review being viewed.
+func (m model) handleDiffOpenKey() (tea.Model, tea.Cmd) {
+	if job, ok := m.selectedJob(); m.currentView == viewQueue && ok {
+		m.diffFromView = m.currentView
+		m.diffJobID = job.ID
+		jobCopy := *job
+		return m, m.fetchDiff(&jobCopy, true)
+	} else if m.currentView == viewReview && m.currentReview != nil && m.currentReview.Job != nil {
+		m.diffFromView = m.currentView
+		m.diffJobID = m.currentReview.Job.ID
+		return m, m.fetchDiff(m.currentReview.Job, false)
+	}
+	return m, nil
+}
+
+// handlePanelOpenKey opens the panel view for the selected panel row, or for
+// the panel run of the review being viewed.
+func (m model) handlePanelOpenKey() (tea.Model, tea.Cmd) {
+	var job *storage.ReviewJob
+	if selected, ok := m.selectedJob(); m.currentView == viewQueue && ok {
+		job = selected
+	} else if m.currentView == viewReview && m.currentReview != nil {
+		job = m.currentReview.Job
+	} else {
+		return m, nil
+	}
+	if job == nil || job.PanelRunUUID == "" {
+		m.setFlash("Not part of a panel run", 2*time.Second, m.currentView)
+		return m, nil
+	}
+	m.openPanel(job.PanelRunUUID)
+	if job.PanelRole == storage.PanelRoleMember {
+		for i := range m.panelJobs {
+			if m.panelJobs[i].ID == job.ID {
+				m.panelTab = i + 1
+			}
+		}
+	}
+	return m, m.fetchPanelDetail(job.PanelRunUUID)
+}
+
 // handleFixKey opens the fix prompt modal for the currently selected job.
 func (m model) handleFixKey() (tea.Model, tea.Cmd) {
 	if m.currentView != viewQueue && m.currentView != viewReview {
@@ -354,6 +354,11 @@ type markdownCache struct {
 	promptWidth int
 	promptText  string // raw input text used to produce promptLines
 
+	panelLines []string
+	panelID    int64
+	panelWidth int
+	panelText  string // raw input text used to produce panelLines
+
 	// Max scroll positions computed during the last render.
 	// Stored here (in the shared pointer) so key handlers can clamp
 	// scroll values even though View() uses a value receiver.
@@ -578,6 +583,19 @@ func (c *markdownCache) getReviewLines(text string, wrapWidth, maxWidth int, rev
 	return c.reviewLines
 }
 
+// getPanelLines returns glamour-rendered lines for the selected panel-view
+// tab, using the cache when the inputs (job ID, width, text) haven't changed.
+func (c *markdownCache) getPanelLines(text string, wrapWidth, maxWidth int, jobID int64) []string {
+	if c.panelID == jobID && c.panelWidth == maxWidth && c.panelText == text {
+		return c.panelLines
+	}
+	c.panelLines = renderMarkdownLines(text, wrapWidth, maxWidth, c.glamourStyle, c.tabWidth, c.colorProfile)
+	c.panelID = jobID
+	c.panelWidth = maxWidth
+	c.panelText = text
+	return c.panelLines
+}
+
 // getPromptLines returns glamour-rendered lines for a prompt, using the cache
 // when the inputs (review ID, width, text) haven't changed.
 // wrapWidth is the glamour word-wrap column; maxWidth is the truncation limit.
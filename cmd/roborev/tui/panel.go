@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"strings"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// panelTabCount is the number of output tabs in the panel view: the
+// synthesis followed by one tab per member.
+func (m model) panelTabCount() int {
+	return 1 + len(m.panelJobs)
+}
+
+// panelTabJob returns the job shown in tab i: the synthesis for tab 0 and
+// member i-1 otherwise. Returns nil before the run has loaded.
+func (m model) panelTabJob(i int) *storage.ReviewJob {
+	if i == 0 {
+		return m.panelSynth
+	}
+	if i < 1 || i > len(m.panelJobs) {
+		return nil
+	}
+	return &m.panelJobs[i-1]
+}
+
+// panelTabLabel names tab i after the synthesis or the member's panel
+// name, falling back to its review type or agent.
+func (m model) panelTabLabel(i int) string {
+	if i == 0 {
+		return "synthesis"
+	}
+	job := m.panelTabJob(i)
+	if job == nil {
+		return ""
+	}
+	for _, name := range []string{job.PanelMemberName, job.ReviewType, job.Agent} {
+		if name != "" {
+			return name
+		}
+	}
+	return "member"
+}
+
+// panelTabOutput returns the stored review output for tab i, or a
+// placeholder describing why there is none yet.
+func (m model) panelTabOutput(i int) string {
+	job := m.panelTabJob(i)
+	if job == nil {
+		return ""
+	}
+	if out, ok := m.panelOutputs[job.ID]; ok && strings.TrimSpace(out) != "" {
+		return out
+	}
+	switch job.Status {
+	case storage.JobStatusQueued:
+		if job.IsSynthesisJob() && job.ClaimBlocked {
+			return "_Waiting for members to finish._"
+		}
+		return "_Queued._"
+	case storage.JobStatusRunning:
+		return "_Running…_"
+	case storage.JobStatusFailed:
+		if job.Error != "" {
+			return "**Failed:** " + job.Error
+		}
+		return "**Failed.**"
+	case storage.JobStatusCanceled:
+		return "_Canceled._"
+	case storage.JobStatusSkipped:
+		return "_Skipped._"
+	}
+	return "_No output._"
+}
+
+// panelRunActive reports whether any job of the open run is still queued or
+// running, so the view keeps polling for status changes.
+func (m model) panelRunActive() bool {
+	for i := range m.panelTabCount() {
+		job := m.panelTabJob(i)
+		if job != nil && (job.Status == storage.JobStatusQueued || job.Status == storage.JobStatusRunning) {
+			return true
+		}
+	}
+	return false
+}
+
+// panelTerminal reports whether every job of the open run has finished,
+// which the daemon requires before rerunning a member or re-synthesizing.
+func (m model) panelTerminal() bool {
+	return m.panelSynth != nil && !m.panelRunActive()
+}
+
+// openPanel switches to the panel view for runUUID, seeding the member
+// table from the queue's caches so it renders before the fetch returns.
+func (m *model) openPanel(runUUID string) {
+	m.panelFromView = m.currentView
+	m.currentView = viewPanel
+	m.panelRunUUID = runUUID
+	m.panelSynth = nil
+	for i := range m.jobs {
+		if m.jobs[i].PanelRunUUID == runUUID && m.jobs[i].IsSynthesisJob() {
+			synth := m.jobs[i]
+			m.panelSynth = &synth
+			break
+		}
+	}
+	m.panelJobs = append([]storage.ReviewJob(nil), m.panelMembers[runUUID]...)
+	m.panelOutputs = map[int64]string{}
+	m.panelTab = 0
+	m.panelScroll = 0
+	m.panelLoading = true
+}
+
+// closePanel returns to the view the panel was opened from.
+func (m *model) closePanel() {
+	m.currentView = m.panelFromView
+	m.panelRunUUID = ""
+	m.panelSynth = nil
+	m.panelJobs = nil
+	m.panelOutputs = nil
+	m.panelTab = 0
+	m.panelScroll = 0
+	m.panelLoading = false
+}
+
+// selectPanelTab switches the output pane to tab i, wrapping around.
+func (m *model) selectPanelTab(i int) {
+	n := m.panelTabCount()
+	m.panelTab = ((i % n) + n) % n
+	m.panelScroll = 0
+}
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func panelTestJobs() (storage.ReviewJob, []storage.ReviewJob) {
+	synth := makeJob(10, withSynthesis("run-1", storage.PanelSummary{MembersTotal: 2, MembersTerminal: 2}),
+		withAgent("claude-code"), withVerdict("F"))
+	synth.PanelName = "branch_final"
+	security := makeJob(11, withPanelMember("run-1", "security", 0), withAgent("codex"),
+		withReviewType("security"), withVerdict("F"))
+	security.Model = "gpt-5"
+	security.TokenUsage = `{"cost_usd":0.25,"has_cost":true}`
+	design := makeJob(12, withPanelMember("run-1", "design", 1), withAgent("gemini"),
+		withReviewType("design"), withVerdict("P"))
+	return synth, []storage.ReviewJob{security, design}
+}
+
+func newPanelTestModel(t *testing.T, opts ...func(*model)) model {
+	t.Helper()
+	synth, members := panelTestJobs()
+	m := setupTestModel([]storage.ReviewJob{synth}, func(m *model) {
+		m.currentView = viewQueue
+		m.selectedIdx = 0
+		m.selectedJobID = synth.ID
+		m.width, m.height = 120, 30
+	})
+	for _, opt := range opts {
+		opt(&m)
+	}
+	m, cmd := pressKey(m, 'e')
+	require.NotNil(t, cmd, "'e' should fetch the panel run")
+	require.Equal(t, viewPanel, m.currentView)
+	m, _ = updateModel(t, m, panelDetailMsg{
+		runUUID:   "run-1",
+		synthesis: &synth,
+		members:   members,
+		outputs: map[int64]string{
+			10: "Synthesized: one real issue.",
+			11: "SQL injection in the handler.",
+			12: "No issues found.",
+		},
+	})
+	return m
+}
+
+func TestTUIPanelViewShowsMembersAndTabs(t *testing.T) {
+	m := newPanelTestModel(t)
+	assert.Len(t, m.panelMembers["run-1"], 2, "detail refreshes the queue's member cache")
+
+	out := stripANSI(m.renderPanelView())
+	assert.Contains(t, out, "Panel branch_final #10")
+	assert.Contains(t, out, "security")
+	assert.Contains(t, out, "codex")
+	assert.Contains(t, out, "gpt-5")
+	assert.Contains(t, out, "$0.25")
+	assert.Contains(t, out, "Pass")
+	assert.Contains(t, out, "Synthesized: one real issue.")
+
+	m, _ = pressSpecial(m, tea.KeyTab)
+	assert.Equal(t, 1, m.panelTab)
+	assert.Contains(t, stripANSI(m.renderPanelView()), "SQL injection in the handler.")
+
+	m, _ = pressKey(m, '3')
+	assert.Equal(t, 2, m.panelTab)
+	assert.Contains(t, stripANSI(m.renderPanelView()), "No issues found.")
+
+	m, _ = pressSpecial(m, tea.KeyRight)
+	assert.Equal(t, 0, m.panelTab, "tabs wrap around")
+	m, _ = pressSpecial(m, tea.KeyLeft)
+	assert.Equal(t, 2, m.panelTab)
+
+	m, _ = pressSpecial(m, tea.KeyEscape)
+	assert.Equal(t, viewQueue, m.currentView)
+	assert.Empty(t, m.panelRunUUID)
+}
+
+func TestTUIPanelViewOpensOnMemberTab(t *testing.T) {
+	synth, members := panelTestJobs()
+	m := setupTestModel([]storage.ReviewJob{synth}, func(m *model) {
+		m.currentView = viewReview
+		m.currentReview = makeReview(99, &members[1], withReviewOutput("No issues found."))
+		m.panelMembers["run-1"] = members
+	})
+	m, cmd := pressKey(m, 'e')
+	require.NotNil(t, cmd)
+	assert.Equal(t, viewPanel, m.currentView)
+	assert.Equal(t, 2, m.panelTab, "opens on the member being viewed")
+
+	m, _ = pressKey(m, 'q')
+	assert.Equal(t, viewReview, m.currentView)
+}
+
+func TestTUIPanelViewRequiresPanelJob(t *testing.T) {
+	m := setupTestModel([]storage.ReviewJob{makeJob(1)}, func(m *model) {
+		m.currentView = viewQueue
+		m.selectedJobID = 1
+	})
+	m, cmd := pressKey(m, 'e')
+	assert.Nil(t, cmd)
+	assert.Equal(t, viewQueue, m.currentView)
+	assert.Contains(t, m.flashMessage, "Not part of a panel run")
+}
+
+func TestTUIPanelViewActions(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls = map[string]map[string]any{}
+	)
+	ts, _ := mockServerModel(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		calls[r.URL.Path] = body
+		mu.Unlock()
+		if r.URL.Path == "/api/panel/resynthesize" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"title":"Conflict","status":409,"detail":"panel members and synthesis must all be finished to re-synthesize"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"synthesis_job_id":10}`))
+	})
+	m := newPanelTestModel(t, func(m *model) {
+		m.endpoint = testEndpointFromURL(ts.URL)
+		m.api = newDaemonAPI(m.endpoint, m.client)
+	})
+
+	m, cmd := pressKey(m, 'r')
+	assert.Nil(t, cmd, "the synthesis tab has no member to rerun")
+	assert.Contains(t, m.flashMessage, "Select a member tab")
+
+	m, _ = pressSpecial(m, tea.KeyTab)
+	m, cmd = pressKey(m, 'r')
+	require.NotNil(t, cmd)
+	msg := cmd()
+	result, ok := msg.(panelActionMsg)
+	require.True(t, ok)
+	require.NoError(t, result.err)
+	assert.Equal(t, map[string]any{"job_id": float64(11)}, calls["/api/panel/rerun-member"])
+	m, cmd = updateModel(t, m, result)
+	assert.NotNil(t, cmd, "a successful action reloads the run")
+	assert.Contains(t, m.flashMessage, "Rerunning security")
+
+	m.panelLoading = false
+	_, cmd = pressKey(m, 'R')
+	require.NotNil(t, cmd)
+	result, ok = cmd().(panelActionMsg)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"panel_run_uuid": "run-1"}, calls["/api/panel/resynthesize"])
+	require.Error(t, result.err)
+	assert.Contains(t, result.err.Error(), "must all be finished")
+}
+
+func TestTUIPanelViewBlocksActionsWhileRunning(t *testing.T) {
+	m := newPanelTestModel(t)
+	m.panelJobs[0].Status = storage.JobStatusRunning
+	assert.True(t, m.panelRunActive())
+
+	m, _ = pressSpecial(m, tea.KeyTab)
+	_, cmd := pressKey(m, 'r')
+	assert.Nil(t, cmd)
+	_, cmd = pressKey(m, 'R')
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.panelTabOutput(1), "SQL injection", "stored output still shown")
+}
+
+func TestFetchPanelDetail(t *testing.T) {
+	synth, members := panelTestJobs()
+	running := members[1]
+	running.Status = storage.JobStatusRunning
+	ts, _ := mockServerModel(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/jobs":
+			assert.Equal(t, "run-1", r.URL.Query().Get("panel_run"))
+			// Members out of index order, synthesis last.
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"jobs": []storage.ReviewJob{running, members[0], synth},
+			})
+		case "/api/jobs/batch":
+			var req struct {
+				JobIDs []int64 `json:"job_ids"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.ElementsMatch(t, []int64{10, 11}, req.JobIDs, "only finished jobs have reviews")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"results": map[int64]storage.JobWithReview{
+					10: {Job: synth, Review: &storage.Review{JobID: 10, Output: "synth"}},
+					11: {Job: members[0], Review: &storage.Review{JobID: 11, Output: "security"}},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	m := newModel(testEndpointFromURL(ts.URL), withExternalIODisabled())
+
+	msg, ok := m.fetchPanelDetail("run-1")().(panelDetailMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	require.NotNil(t, msg.synthesis)
+	assert.Equal(t, int64(10), msg.synthesis.ID)
+	require.Len(t, msg.members, 2)
+	assert.Equal(t, int64(11), msg.members[0].ID)
+	assert.Equal(t, int64(12), msg.members[1].ID)
+	assert.Equal(t, map[int64]string{10: "synth", 11: "security"}, msg.outputs)
+}
+
+func TestTUIPanelDetailIgnoredAfterClose(t *testing.T) {
+	m := newPanelTestModel(t)
+	m, _ = pressKey(m, 'q')
+	synth, members := panelTestJobs()
+	m, _ = updateModel(t, m, panelDetailMsg{runUUID: "run-1", synthesis: &synth, members: members})
+	assert.Equal(t, viewQueue, m.currentView)
+	assert.Nil(t, m.panelSynth)
+}
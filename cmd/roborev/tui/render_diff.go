@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+
+	"go.kenn.io/roborev/internal/termstyle"
+)
+
+var (
+	diffAddStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("34"))  // green
+	diffDelStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("160")) // red
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))  // blue
+	diffMetaStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("245")) // gray
+	diffFileStyle    = lipgloss.NewStyle().Bold(true)
+	diffFindingStyle = lipgloss.NewStyle().Foreground(adaptiveColor("136", "226")) // Yellow/Gold
+)
+
+func (m model) diffHelpRows() [][]helpItem {
+	return [][]helpItem{
+		{{"n/N", "next/prev finding"}, {"]/[", "next/prev file"}, {"y", "copy finding"}, {"c", "comment"}, {"a", "close"}},
+		{{"j/k/↑/↓", "scroll"}, {"g/G", "top/bottom"}, {"esc", "back"}},
+	}
+}
+
+func (m model) renderDiffView() string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("Diff #%d", m.diffJobID)
+	var closed bool
+	if r := m.currentReview; r != nil && r.Job != nil && r.Job.ID == m.diffJobID {
+		title += " " + shortJobRef(*r.Job)
+		closed = r.Closed
+	}
+	b.WriteString(titleStyle.Render(title))
+	if closed {
+		b.WriteString(" ")
+		b.WriteString(closedStyle.Render("[CLOSED]"))
+	}
+	b.WriteString("\x1b[K\n")
+
+	layout := m.diffLayout
+	visibleRows := m.diffVisibleRows()
+	maxScroll := m.diffMaxScroll()
+	start := max(min(m.diffScroll, maxScroll), 0)
+	end := min(start+visibleRows, len(layout.rows))
+
+	b.WriteString(statusStyle.Render(m.diffStatusLine(start)))
+	b.WriteString("\x1b[K\n")
+
+	if len(layout.rows) == 0 {
+		b.WriteString("\n  Empty diff.\x1b[K\n")
+	}
+	selectedRow := -1
+	if m.diffFindingIdx >= 0 && m.diffFindingIdx < len(layout.findingRows) {
+		selectedRow = layout.findingRows[m.diffFindingIdx]
+	}
+	highlight := m.mdCache == nil || m.mdCache.colorProfile != termenv.Ascii
+	width := max(m.width-2, 20)
+	for i := start; i < end; i++ {
+		line := m.renderDiffRow(layout.rows[i], i == selectedRow, highlight)
+		b.WriteString("  ")
+		b.WriteString(xansi.Truncate(line, width, ""))
+		b.WriteString("\x1b[K\n")
+	}
+	// Status line: flash message takes priority, then scroll indicator.
+	if flash := m.renderFlash(viewDiff); flash != "" {
+		b.WriteString(flash)
+	} else if len(layout.rows) > visibleRows {
+		scrollInfo := fmt.Sprintf("[%d-%d of %d lines]", start+1, end, len(layout.rows))
+		b.WriteString(statusStyle.Render(scrollInfo))
+	}
+	b.WriteString("\x1b[K\n")
+
+	b.WriteString(renderHelpTable(m.diffHelpRows(), m.width))
+	b.WriteString("\x1b[K\x1b[J")
+	return b.String()
+}
+
+// diffStatusLine summarizes the file at the top of the screen and the
+// selected finding, e.g. "file 2/5: a.go · finding 1/3".
+func (m model) diffStatusLine(top int) string {
+	layout := m.diffLayout
+	var parts []string
+	if n := len(layout.fileRows); n > 0 {
+		cur := 0
+		for i, row := range layout.fileRows {
+			if row <= top {
+				cur = i
+			}
+		}
+		file := layout.rows[layout.fileRows[cur]].file
+		parts = append(parts, fmt.Sprintf("file %d/%d: %s", cur+1, n, file))
+	}
+	if n := len(layout.findingRows); n > 0 {
+		if m.diffFindingIdx >= 0 {
+			parts = append(parts, fmt.Sprintf("finding %d/%d", m.diffFindingIdx+1, n))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d findings", n))
+		}
+	} else if m.currentReview != nil {
+		parts = append(parts, "no findings")
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (m model) renderDiffRow(row diffRow, selected, highlight bool) string {
+	switch row.kind {
+	case diffRowFinding:
+		f := m.diffFindings[row.finding]
+		marker := "  ┃ "
+		if selected {
+			marker = "▶ ┃ "
+		}
+		text := fmt.Sprintf("%s[%s] %s", marker, strings.ToUpper(f.Severity), f.Title)
+		if row.file == "" && f.Location() != "" {
+			text += " (" + f.Location() + ")"
+		}
+		style := diffFindingStyle
+		if f.Severity == "critical" || f.Severity == "high" {
+			style = failStyle
+		}
+		if selected {
+			style = style.Bold(true)
+		}
+		return style.Render(sanitizeForDisplay(text))
+	case diffRowFile:
+		return diffFileStyle.Render(sanitizeForDisplay(row.text))
+	case diffRowMeta:
+		return diffMetaStyle.Render(sanitizeForDisplay(row.text))
+	case diffRowHunk:
+		return diffHunkStyle.Render(sanitizeForDisplay(row.text))
+	case diffRowDel:
+		return diffDelStyle.Render(sanitizeForDisplay(row.text))
+	}
+	prefix, code := row.text[:min(1, len(row.text))], row.text[min(1, len(row.text)):]
+	code = sanitizeForDisplay(code)
+	if highlight {
+		code = highlightCode(row.file, code)
+	}
+	if row.kind == diffRowAdd {
+		return diffAddStyle.Render(prefix) + code
+	}
+	return prefix + code
+}
+
+// highlightCode syntax-highlights a single line of code using the lexer for
+// file's name. Lines are highlighted independently, so constructs spanning
+// lines (block comments, raw strings) may be colored imperfectly.
+func highlightCode(file, code string) string {
+	lexer := lexers.Match(path.Base(file))
+	if lexer == nil || strings.TrimSpace(code) == "" {
+		return code
+	}
+	it, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return code
+	}
+	styleName := "monokai"
+	if !termstyle.DarkBackground() {
+		styleName = "monokailight"
+	}
+	var b strings.Builder
+	if err := formatters.TTY256.Format(&b, styles.Get(styleName), it); err != nil {
+		return code
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -281,6 +281,8 @@ func helpLines(tasksEnabled, noQuit bool) []string {
 				{"p", "View prompt"},
 				{"l", "View agent log"},
 				{"m", "View commit message"},
+				{"v", "View diff with findings"},
+				{"e", "View panel run details"},
 			},
 		},
 		{
@@ -315,10 +317,36 @@ func helpLines(tasksEnabled, noQuit bool) []string {
 				{"c", "Add comment"},
 				{"y", "Copy review to clipboard"},
 				{"m", "View commit message"},
+				{"v", "View diff with findings"},
+				{"e", "View panel run details"},
 				{"F", "Trigger fix (opens inline panel)"},
 				{"esc/q", "Back to queue"},
 			},
 		},
+		{
+			group: "Diff View",
+			keys: []struct{ key, desc string }{
+				{"↑/↓", "Scroll diff"},
+				{"PgUp/PgDn", "Page through diff"},
+				{"n/N", "Next / previous finding"},
+				{"]/[", "Next / previous file"},
+				{"y", "Copy selected finding"},
+				{"c", "Comment on selected finding"},
+				{"a", "Toggle closed"},
+				{"esc/q", "Back"},
+			},
+		},
+		{
+			group: "Panel View",
+			keys: []struct{ key, desc string }{
+				{"tab/←/→", "Switch between synthesis and member outputs"},
+				{"1-9", "Jump to output tab"},
+				{"↑/↓", "Scroll output"},
+				{"r", "Rerun selected member and re-synthesize"},
+				{"R", "Re-synthesize without rerunning members"},
+				{"esc/q", "Back"},
+			},
+		},
 		{
 			group: "Prompt View",
 			keys: []struct{ key, desc string }{
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/tokens"
+)
+
+var panelTabStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+
+func (m model) panelHelpRows() [][]helpItem {
+	return [][]helpItem{
+		{{"tab/←/→", "switch output"}, {"1-9", "jump to tab"}, {"r", "rerun member"}, {"R", "re-synthesize"}},
+		{{"j/k/↑/↓", "scroll"}, {"g/G", "top/bottom"}, {"esc", "back"}},
+	}
+}
+
+// panelHeaderLines is the number of lines above the output pane: title,
+// status line, table header, one row per tab, and the tab bar.
+func (m model) panelHeaderLines() int {
+	return 4 + m.panelTabCount()
+}
+
+func (m model) panelVisibleLines() int {
+	help := len(reflowHelpRows(m.panelHelpRows(), m.width))
+	return max(m.height-m.panelHeaderLines()-1-help, 1)
+}
+
+// panelOutputLines renders the selected tab's output as markdown.
+func (m model) panelOutputLines() []string {
+	text := m.panelTabOutput(m.panelTab)
+	maxWidth := max(20, m.width-4)
+	wrapWidth := min(maxWidth, 100)
+	if m.mdCache == nil {
+		return sanitizeLines(wrapText(text, wrapWidth))
+	}
+	var jobID int64
+	if job := m.panelTabJob(m.panelTab); job != nil {
+		jobID = job.ID
+	}
+	return m.mdCache.getPanelLines(text, wrapWidth, maxWidth, jobID)
+}
+
+func (m model) panelMaxScroll() int {
+	return max(len(m.panelOutputLines())-m.panelVisibleLines(), 0)
+}
+
+func (m model) renderPanelView() string {
+	var b strings.Builder
+
+	title := "Panel"
+	synth := m.panelSynth
+	if synth != nil {
+		if synth.PanelName != "" {
+			title += " " + synth.PanelName
+		}
+		title += fmt.Sprintf(" #%d %s", synth.ID, shortJobRef(*synth))
+	}
+	b.WriteString(titleStyle.Render(sanitizeForDisplay(title)))
+	b.WriteString("\x1b[K\n")
+	b.WriteString(statusStyle.Render(sanitizeForDisplay(m.panelStatusLine())))
+	b.WriteString("\x1b[K\n")
+
+	for _, line := range m.renderPanelTable() {
+		b.WriteString(xansi.Truncate(line, max(m.width, 20), ""))
+		b.WriteString("\x1b[K\n")
+	}
+	b.WriteString(xansi.Truncate(m.renderPanelTabs(), max(m.width, 20), ""))
+	b.WriteString("\x1b[K\n")
+
+	lines := m.panelOutputLines()
+	visible := m.panelVisibleLines()
+	start := max(min(m.panelScroll, len(lines)-visible), 0)
+	end := min(start+visible, len(lines))
+	written := 0
+	for i := start; i < end; i++ {
+		line := lines[i]
+		if m.width > 0 {
+			line = xansi.Truncate(line, m.width, "")
+		}
+		b.WriteString(line)
+		b.WriteString("\x1b[K\n")
+		written++
+	}
+	for ; written < visible; written++ {
+		b.WriteString("\x1b[K\n")
+	}
+
+	if flash := m.renderFlash(viewPanel); flash != "" {
+		b.WriteString(flash)
+	} else if len(lines) > visible {
+		b.WriteString(statusStyle.Render(fmt.Sprintf("[%d-%d of %d lines]", start+1, end, len(lines))))
+	}
+	b.WriteString("\x1b[K\n")
+
+	b.WriteString(renderHelpTable(m.panelHelpRows(), m.width))
+	b.WriteString("\x1b[K\x1b[J")
+	return b.String()
+}
+
+// panelStatusLine summarizes the run, e.g.
+// "roborev · 3 members · finished · total $0.42".
+func (m model) panelStatusLine() string {
+	synth := m.panelSynth
+	if synth == nil {
+		if m.panelLoading {
+			return "Loading panel…"
+		}
+		return "Panel run not found"
+	}
+	parts := []string{}
+	if name := m.getDisplayName(synth.RepoPath, synth.RepoName); name != "" {
+		parts = append(parts, name)
+	}
+	parts = append(parts, fmt.Sprintf("%d members", len(m.panelJobs)))
+	if m.panelRunActive() {
+		parts = append(parts, "in progress")
+	} else {
+		parts = append(parts, "finished")
+	}
+	if cost := m.jobCostCell(*synth); cost != "" {
+		parts = append(parts, "total "+cost)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// renderPanelTable lays out the synthesis and members side by side, one row
+// each, with the selected tab's row marked.
+func (m model) renderPanelTable() []string {
+	header := []string{"", "NAME", "AGENT", "MODEL", "TYPE", "STATUS", "TIME", "COST", "VERDICT"}
+	rows := [][]string{header}
+	var jobs []*storage.ReviewJob
+	for i := range m.panelTabCount() {
+		job := m.panelTabJob(i)
+		jobs = append(jobs, job)
+		marker := " "
+		if i == m.panelTab {
+			marker = "▶"
+		}
+		if job == nil {
+			rows = append(rows, []string{marker, m.panelTabLabel(i), "", "", "", "", "", "", ""})
+			continue
+		}
+		reviewType := job.ReviewType
+		if reviewType == "" && !job.IsSynthesisJob() {
+			reviewType = "default"
+		}
+		rows = append(rows, []string{
+			marker,
+			m.panelTabLabel(i),
+			job.Agent,
+			job.Model,
+			reviewType,
+			statusLabel(*job),
+			panelJobDuration(*job),
+			panelJobCost(*job),
+			panelVerdictLabel(job.Verdict),
+		})
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for c, cell := range row {
+			widths[c] = max(widths[c], runewidth.StringWidth(sanitizeForDisplay(cell)))
+		}
+	}
+	lines := make([]string, 0, len(rows))
+	for r, row := range rows {
+		var line strings.Builder
+		for c, cell := range row {
+			cell = sanitizeForDisplay(cell)
+			padded := runewidth.FillRight(cell, widths[c])
+			if c > 0 {
+				line.WriteString("  ")
+			}
+			switch {
+			case r == 0:
+				padded = statusStyle.Render(padded)
+			case c == 5 && jobs[r-1] != nil:
+				if col := statusColor(jobs[r-1].Status); col != nil {
+					padded = lipgloss.NewStyle().Foreground(col).Render(padded)
+				}
+			case c == 8 && jobs[r-1] != nil:
+				if col := verdictColor(jobs[r-1].Verdict); col != nil {
+					padded = lipgloss.NewStyle().Foreground(col).Render(padded)
+				}
+			case r-1 == m.panelTab:
+				padded = selectedStyle.Render(padded)
+			}
+			line.WriteString(padded)
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// renderPanelTabs renders the tab bar above the output pane.
+func (m model) renderPanelTabs() string {
+	var b strings.Builder
+	for i := range m.panelTabCount() {
+		label := fmt.Sprintf(" %d %s ", i+1, sanitizeForDisplay(m.panelTabLabel(i)))
+		if i == m.panelTab {
+			b.WriteString(panelTabStyle.Render(label))
+		} else {
+			b.WriteString(statusStyle.Render(label))
+		}
+		b.WriteString(" ")
+	}
+	return b.String()
+}
+
+// panelJobDuration is the job's own run time; unlike jobElapsedCell it does
+// not widen a synthesis to the whole run.
+func panelJobDuration(job storage.ReviewJob) string {
+	if job.StartedAt == nil {
+		return ""
+	}
+	end := time.Now()
+	if job.FinishedAt != nil {
+		end = *job.FinishedAt
+	}
+	return end.Sub(*job.StartedAt).Round(time.Second).String()
+}
+
+// panelJobCost is the job's own priced cost, without member aggregation.
+func panelJobCost(job storage.ReviewJob) string {
+	if tu := tokens.ParseJSON(job.TokenUsage); tu != nil && tu.HasCost {
+		return tu.FormatCost()
+	}
+	return ""
+}
+
+func panelVerdictLabel(verdict *string) string {
+	if verdict == nil || *verdict == "" {
+		return ""
+	}
+	if *verdict == "P" {
+		return "Pass"
+	}
+	return "Fail"
+}
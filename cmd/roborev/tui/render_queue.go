@@ -115,6 +115,7 @@ func (m model) queueHelpRows() [][]helpItem {
 		{"c", "comment"},
 		{"y", "copy"},
 		{"m", "commit"},
+		{"v", "diff"},
 	}
 	if m.tasksWorkflowEnabled() {
 		row1 = append(row1, helpItem{"F", "fix"})
@@ -353,6 +354,13 @@ func (m model) renderQueueStatusLine(done, closed, open int) string {
 	if text, ok := m.costSegmentText(); ok {
 		segs = append(segs, statusSeg{rendered: statusStyle.Render(text), prio: 80})
 	}
+	// Agents whose latest health probe failed are flagged before a review
+	// runs into them.
+	if unhealthy := m.status.UnhealthyAgents; len(unhealthy) == 1 {
+		segs = append(segs, statusSeg{rendered: errorStyle.Render("Agent " + unhealthy[0]), prio: 85})
+	} else if len(unhealthy) > 1 {
+		segs = append(segs, statusSeg{rendered: errorStyle.Render(fmt.Sprintf("%d agents unhealthy", len(unhealthy))), prio: 85})
+	}
 
 	line := fitStatusSegments(segs, sep, width)
 	if xansi.StringWidth(line) > width {
@@ -78,6 +78,9 @@ func (m model) renderReviewView() string {
 		if m.currentBranch != "" {
 			locationLine += " on " + m.currentBranch
 		}
+		if review.Job.Route != "" {
+			locationLine += " [route: " + sanitizeForDisplay(review.Job.Route) + "]"
+		}
 		locationLineLen = runewidth.StringWidth(locationLine)
 		b.WriteString(statusStyle.Render(locationLine))
 		b.WriteString("\x1b[K") // Clear to end of line
@@ -162,7 +165,7 @@ func (m model) renderReviewView() string {
 
 	// Help table rows
 	reviewHelpRows := [][]helpItem{
-		{{"p", "prompt"}, {"c", "comment"}, {"m", "commit"}, {"a", "close"}, {"y", "copy"}},
+		{{"p", "prompt"}, {"c", "comment"}, {"m", "commit"}, {"v", "diff"}, {"a", "close"}, {"y", "copy"}},
 		{{"↑/↓", "scroll"}, {"←/→", "prev/next"}, {"?", "commands"}, {"esc", "back"}},
 	}
 	if m.tasksWorkflowEnabled() {
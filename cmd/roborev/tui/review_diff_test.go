@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/review"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const testDiff = `diff --git a/internal/a.go b/internal/a.go
+index 1111111..2222222 100644
+--- a/internal/a.go
++++ b/internal/a.go
+@@ -10,3 +10,4 @@ func a() {
+ 	x := 1
+-	y := 2
++	y := 3
++	z := 4
+ 	return
+diff --git a/b.go b/b.go
+index 3333333..4444444 100644
+--- a/b.go
++++ b/b.go
+@@ -1,2 +1,2 @@
+-package old
++package b
+
+`
+
+const testDiffReview = `## Review Findings
+
+- **Severity**: High
+- **Location**: internal/a.go:12
+- **Problem**: z is never used.
+
+---
+
+- **Severity**: Low
+- **Location**: b.go:40
+- **Problem**: Package rename breaks importers.
+
+---
+
+- **Severity**: Medium
+- **Location**: docs/usage.md:3
+- **Problem**: Docs not updated.
+`
+
+func TestBuildDiffLayoutPinsFindings(t *testing.T) {
+	findings := review.ParseFindings(testDiffReview)
+	require.Len(t, findings, 3)
+
+	layout := buildDiffLayout(testDiff, findings)
+	require.Len(t, layout.findingRows, 3)
+	require.Len(t, layout.fileRows, 2)
+
+	// The finding whose file is not in the diff is listed first.
+	first := layout.rows[layout.findingRows[0]]
+	assert.Equal(t, 2, first.finding)
+	assert.Empty(t, first.file)
+
+	// The line-level finding sits directly below "+	z := 4" (new line 12).
+	second := layout.findingRows[1]
+	assert.Equal(t, 0, layout.rows[second].finding)
+	anchor := layout.rows[second-1]
+	assert.Equal(t, diffRowAdd, anchor.kind)
+	assert.Equal(t, 12, anchor.line)
+	assert.Equal(t, "internal/a.go", anchor.file)
+
+	// Line 40 is outside b.go's hunk, so it is pinned below the header,
+	// before the first hunk.
+	third := layout.findingRows[2]
+	assert.Equal(t, 1, layout.rows[third].finding)
+	assert.Equal(t, "+++ b/b.go", layout.rows[third-1].text)
+	assert.Equal(t, diffRowHunk, layout.rows[third+1].kind)
+}
+
+func TestDiffPathMatches(t *testing.T) {
+	assert.True(t, diffPathMatches("internal/a.go", "internal/a.go"))
+	assert.True(t, diffPathMatches("internal/a.go", "./internal/a.go"))
+	assert.True(t, diffPathMatches("internal/a.go", "a.go"))
+	assert.True(t, diffPathMatches("internal/a.go", "/home/u/repo/internal/a.go"))
+	assert.False(t, diffPathMatches("internal/a.go", "ba.go"))
+	assert.False(t, diffPathMatches("", "a.go"))
+}
+
+func TestJobDiffRejectsJobsWithoutDiff(t *testing.T) {
+	_, err := jobDiff(&storage.ReviewJob{JobType: storage.JobTypeTask})
+	require.Error(t, err)
+
+	_, err = jobDiff(&storage.ReviewJob{GitRef: "dirty"})
+	require.Error(t, err)
+
+	captured := "diff --git a/x b/x\n"
+	diff, err := jobDiff(&storage.ReviewJob{GitRef: "dirty", DiffContent: &captured})
+	require.NoError(t, err)
+	assert.Equal(t, captured, diff)
+}
+
+func newDiffTestModel(t *testing.T) model {
+	t.Helper()
+	job := makeJob(7, withRef("abc1234"))
+	m := setupTestModel([]storage.ReviewJob{job}, func(m *model) {
+		m.currentView = viewReview
+		m.currentReview = makeReview(70, &job, withReviewOutput(testDiffReview))
+		m.width, m.height = 100, 16
+	})
+	m2, cmd := pressKey(m, 'v')
+	require.NotNil(t, cmd, "'v' should fetch the diff")
+	assert.Equal(t, int64(7), m2.diffJobID)
+	m3, _ := updateModel(t, m2, diffMsg{jobID: 7, diff: testDiff})
+	require.Equal(t, viewDiff, m3.currentView)
+	return m3
+}
+
+func TestTUIDiffViewFindingNavigation(t *testing.T) {
+	m := newDiffTestModel(t)
+	assert.Equal(t, -1, m.diffFindingIdx)
+	assert.Contains(t, m.renderDiffView(), "[HIGH] z is never used.")
+
+	m, _ = pressKey(m, 'n')
+	assert.Equal(t, 0, m.diffFindingIdx)
+	m, _ = pressKey(m, 'n')
+	f, ok := m.selectedDiffFinding()
+	require.True(t, ok)
+	assert.Equal(t, "high", f.Severity)
+
+	m, _ = pressKey(m, 'N')
+	assert.Equal(t, 0, m.diffFindingIdx)
+	m, _ = pressKey(m, 'N')
+	assert.Equal(t, 0, m.diffFindingIdx, "N stops at the first finding")
+
+	m, _ = pressKey(m, ']')
+	assert.Positive(t, m.diffScroll)
+}
+
+func TestTUIDiffViewCopyAndComment(t *testing.T) {
+	clip := &mockClipboard{}
+	m := newDiffTestModel(t)
+	m.clipboard = clip
+
+	_, cmd := pressKey(m, 'y')
+	assert.Nil(t, cmd, "copy needs a selected finding")
+
+	m, _ = pressKey(m, 'n')
+	m, _ = pressKey(m, 'n')
+	_, cmd = pressKey(m, 'y')
+	require.NotNil(t, cmd)
+	msg := cmd()
+	result, ok := msg.(clipboardResultMsg)
+	require.True(t, ok)
+	require.NoError(t, result.err)
+	assert.Equal(t, viewDiff, result.view)
+	assert.Contains(t, clip.lastText, "[High] internal/a.go:12")
+	assert.Contains(t, clip.lastText, "z is never used.")
+
+	m, _ = pressKey(m, 'c')
+	assert.Equal(t, viewKindComment, m.currentView)
+	assert.Equal(t, int64(7), m.commentJobID)
+	assert.Equal(t, "internal/a.go:12 — z is never used.\n", m.commentText)
+
+	m, _ = pressSpecial(m, tea.KeyEscape)
+	assert.Equal(t, viewDiff, m.currentView)
+}
+
+func TestTUIDiffViewCloseAndExit(t *testing.T) {
+	m := newDiffTestModel(t)
+
+	m, cmd := pressKey(m, 'a')
+	assert.NotNil(t, cmd)
+	assert.True(t, m.currentReview.Closed)
+
+	m, _ = pressSpecial(m, tea.KeyEscape)
+	assert.Equal(t, viewReview, m.currentView)
+	assert.NotNil(t, m.currentReview, "review opened before the diff is kept")
+	assert.Zero(t, m.diffJobID)
+}
+
+func TestTUIDiffViewFromQueueOwnsReview(t *testing.T) {
+	job := makeJob(7, withRef("abc1234"))
+	m := setupTestModel([]storage.ReviewJob{job}, func(m *model) {
+		m.currentView = viewQueue
+		m.selectedIdx = 0
+		m.selectedJobID = 7
+	})
+	m, cmd := pressKey(m, 'v')
+	require.NotNil(t, cmd)
+
+	rev := makeReview(70, &job, withReviewOutput(testDiffReview))
+	m, _ = updateModel(t, m, diffMsg{jobID: 7, diff: testDiff, review: rev})
+	require.Equal(t, viewDiff, m.currentView)
+	assert.Len(t, m.diffFindings, 3)
+
+	m, _ = pressKey(m, 'q')
+	assert.Equal(t, viewQueue, m.currentView)
+	assert.Nil(t, m.currentReview)
+}
+
+func TestTUIDiffMsgIgnoredAfterNavigatingAway(t *testing.T) {
+	m := newModel(localhostEndpoint, withExternalIODisabled())
+	m.currentView = viewTasks
+	m.diffFromView = viewQueue
+	m.diffJobID = 7
+	m, _ = updateModel(t, m, diffMsg{jobID: 7, diff: testDiff})
+	assert.Equal(t, viewTasks, m.currentView)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -20,6 +21,8 @@ type sseEventMsg struct{}
 // daemon's /api/stream/events endpoint. On each received event it sends
 // a non-blocking signal to sseCh. The goroutine reconnects with
 // exponential backoff on errors and exits when stopCh is closed.
+// Reconnects resume after the last event ID seen, so events broadcast
+// while disconnected still trigger a refresh.
 func startSSESubscription(
 	endpoint daemon.DaemonEndpoint,
 	sseCh chan<- struct{},
@@ -27,9 +30,10 @@ func startSSESubscription(
 ) {
 	const maxBackoff = 30 * time.Second
 	backoff := time.Second
+	var lastID int64
 
 	for {
-		connected, err := sseReadLoop(endpoint, sseCh, stopCh)
+		connected, err := sseReadLoop(endpoint, sseCh, stopCh, &lastID)
 		if err == nil {
 			return
 		}
@@ -53,11 +57,13 @@ func startSSESubscription(
 // sseReadLoop connects to the event stream and reads NDJSON lines until
 // the connection drops or stopCh fires. Returns (false, nil) when stopCh
 // is closed, (connected, err) on connection/decode failure. connected is
-// true if at least one event was successfully read.
+// true if at least one event was successfully read. A nonzero *lastID
+// resumes after that event; it is advanced as events arrive.
 func sseReadLoop(
 	endpoint daemon.DaemonEndpoint,
 	sseCh chan<- struct{},
 	stopCh <-chan struct{},
+	lastID *int64,
 ) (connected bool, err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -71,10 +77,11 @@ func sseReadLoop(
 	}()
 
 	client := endpoint.HTTPClient(0)
-	req, err := http.NewRequestWithContext(
-		ctx, http.MethodGet,
-		endpoint.BaseURL()+"/api/stream/events", nil,
-	)
+	streamURL := endpoint.BaseURL() + "/api/stream/events"
+	if *lastID > 0 {
+		streamURL += "?since=" + strconv.FormatInt(*lastID, 10)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
 	if err != nil {
 		return false, err
 	}
@@ -100,6 +107,9 @@ func sseReadLoop(
 			}
 		}
 		connected = true
+		if event.ID > 0 {
+			*lastID = event.ID
+		}
 
 		select {
 		case sseCh <- struct{}{}:
@@ -71,6 +71,18 @@ func TestRenderQueueStatusLineAdaptsToWidth(t *testing.T) {
 	assert.Contains(narrow, "~$12.50", "cost is kept longer than Completed/Closed")
 }
 
+func TestRenderQueueStatusLineFlagsUnhealthyAgents(t *testing.T) {
+	assert := assert.New(t)
+	m := model{width: 200}
+	assert.NotContains(m.renderQueueStatusLine(0, 0, 0), "Agent")
+
+	m.status.UnhealthyAgents = []string{"codex: auth_failed"}
+	assert.Contains(m.renderQueueStatusLine(0, 0, 0), "Agent codex: auth_failed")
+
+	m.status.UnhealthyAgents = append(m.status.UnhealthyAgents, "gemini: limited")
+	assert.Contains(m.renderQueueStatusLine(0, 0, 0), "2 agents unhealthy")
+}
+
 func TestRenderQueueTitleShowsMismatch(t *testing.T) {
 	assert := assert.New(t)
 
@@ -24,6 +24,7 @@ import (
 	"go.kenn.io/roborev/internal/config"
 	"go.kenn.io/roborev/internal/daemon"
 	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/review"
 	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/streamfmt"
 	roborevclient "go.kenn.io/roborev/pkg/client"
@@ -384,6 +385,26 @@ type model struct {
 	commitMsgJobID    int64    // Job ID for the commit message being viewed
 	commitMsgFromView viewKind // View to return to after closing commit message view
 
+	// Diff view state
+	diffJobID      int64            // Job whose diff is shown
+	diffText       string           // Raw unified diff
+	diffFindings   []review.Finding // Findings parsed from the review output
+	diffLayout     diffLayout       // Diff rows with findings interleaved
+	diffScroll     int              // First visible row
+	diffFindingIdx int              // Selected index into diffLayout.findingRows, -1 for none
+	diffFromView   viewKind         // View to return to after closing the diff
+	diffOwnsReview bool             // currentReview was fetched for the diff and is cleared on exit
+
+	// Panel view state
+	panelRunUUID  string              // Run shown in the panel view
+	panelSynth    *storage.ReviewJob  // Synthesis job of the run
+	panelJobs     []storage.ReviewJob // Member jobs ordered by index
+	panelOutputs  map[int64]string    // Review output by job ID
+	panelTab      int                 // Selected tab: 0 = synthesis, i = member i-1
+	panelScroll   int                 // Scroll position in the output pane
+	panelFromView viewKind            // View to return to after closing the panel
+	panelLoading  bool                // A panel fetch is in flight
+
 	// Help view state
 	helpFromView viewKind // View to return to after closing help
 	helpScroll   int      // Scroll position in help view
@@ -807,7 +828,7 @@ func (m *model) getBranchForJob(job storage.ReviewJob) string {
 // released to allow native terminal text selection (copy/paste).
 func mouseDisabledView(v viewKind) bool {
 	switch v {
-	case viewLog, viewReview, viewKindPrompt, viewPatch, viewCommitMsg:
+	case viewLog, viewReview, viewKindPrompt, viewPatch, viewCommitMsg, viewDiff, viewPanel:
 		return true
 	}
 	return false
@@ -903,6 +924,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		result, cmd = m.handleFixTriggerResultMsg(msg)
 	case patchMsg:
 		result, cmd = m.handlePatchResultMsg(msg)
+	case diffMsg:
+		result, cmd = m.handleDiffMsg(msg)
+	case panelDetailMsg:
+		result, cmd = m.handlePanelDetailMsg(msg)
+	case panelActionMsg:
+		result, cmd = m.handlePanelActionMsg(msg)
 	case panelMembersMsg:
 		result, cmd = m.handlePanelMembersMsg(msg)
 	case applyPatchResultMsg:
@@ -961,6 +988,12 @@ func (m model) viewContent() string {
 	if m.currentView == viewColumnOptions {
 		return m.renderColumnOptionsView()
 	}
+	if m.currentView == viewDiff {
+		return m.renderDiffView()
+	}
+	if m.currentView == viewPanel {
+		return m.renderPanelView()
+	}
 	if m.currentView == viewKindPrompt && m.currentReview != nil {
 		return m.renderPromptView()
 	}
@@ -29,6 +29,8 @@ const (
 	viewKindWorktreeConfirm // Confirm creating a worktree to apply patch
 	viewPatch               // Patch viewer for fix jobs
 	viewColumnOptions       // Column toggle modal
+	viewDiff                // Reviewed diff with findings pinned to lines
+	viewPanel               // Panel run drill-down: members and their outputs
 )
 
 // queuePrefetchBuffer is the number of extra rows to fetch beyond what's visible,
@@ -284,6 +286,34 @@ type patchMsg struct {
 	err   error
 }
 
+// diffMsg delivers a job's diff. review is set only when the diff view was
+// opened from the queue and the review had to be fetched alongside it.
+type diffMsg struct {
+	jobID  int64
+	diff   string
+	review *storage.Review
+	err    error
+}
+
+// panelDetailMsg delivers a panel run for the panel view: its synthesis,
+// its members ordered by index, and the review output of every job that
+// has one, keyed by job ID.
+type panelDetailMsg struct {
+	runUUID   string
+	synthesis *storage.ReviewJob
+	members   []storage.ReviewJob
+	outputs   map[int64]string
+	err       error
+}
+
+// panelActionMsg reports the outcome of rerunning a panel member or
+// re-synthesizing a panel run from the panel view.
+type panelActionMsg struct {
+	runUUID string
+	done    string // flash shown on success
+	err     error
+}
+
 // panelMembersMsg delivers the side-fetched member rows for a panel run, or an
 // error. On error the handler leaves the panel uncached so a later expand
 // retries.
@@ -201,6 +201,9 @@ func (a *ACPAgent) runPrompt(
 	// Build the command with arguments
 	cmd := exec.CommandContext(ctx, a.Command, a.Args...)
 	procutil.HideConsole(cmd)
+	if err := sandboxSubprocess(cmd, repoPath, a.mutatingOperationsAllowed()); err != nil {
+		return "", fmt.Errorf("acp: %w", err)
+	}
 
 	// Set up stdio pipes for communication with the agent
 	var stdinPipe io.WriteCloser
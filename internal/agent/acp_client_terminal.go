@@ -358,6 +358,14 @@ func (c *acpClient) CreateTerminal(ctx context.Context, params acp.CreateTermina
 		}
 		cmd.Env = env
 	}
+	workTree := repoRoot
+	if workTree == "" {
+		workTree = cwd
+	}
+	if err := sandboxSubprocess(cmd, workTree, c.agent.mutatingOperationsAllowed()); err != nil {
+		cancelFunc()
+		return acp.CreateTerminalResponse{}, fmt.Errorf("failed to sandbox terminal command: %w", err)
+	}
 
 	// Create output buffer with mutex for thread safety
 	output := &bytes.Buffer{}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/sandbox"
 )
 
 // ReasoningLevel controls how much reasoning/thinking an agent uses
@@ -115,6 +120,10 @@ var (
 	allowUnsafeAgents    atomic.Bool
 	codexSandboxDisabled atomic.Bool
 	anthropicAPIKey      atomic.Value
+	agentSandbox         atomic.Pointer[sandbox.Sandbox]
+
+	sandboxMu  sync.Mutex
+	sandboxCfg *config.SandboxConfig
 )
 
 func AllowUnsafeAgents() bool {
@@ -137,6 +146,49 @@ func SetCodexSandboxDisabled(v bool) {
 	codexSandboxDisabled.Store(v)
 }
 
+// SetSandbox sets the sandbox every agent process runs in. nil runs agents
+// directly.
+func SetSandbox(s *sandbox.Sandbox) {
+	agentSandbox.Store(s)
+}
+
+// ConfigureSandbox builds the agent sandbox from cfg, replacing and closing
+// the previous one if cfg changed. A disabled cfg removes the sandbox. When
+// a required sandbox cannot be built, agents are refused rather than run
+// unsandboxed, and the error is returned for logging.
+func ConfigureSandbox(cfg config.SandboxConfig) error {
+	sandboxMu.Lock()
+	defer sandboxMu.Unlock()
+	if sandboxCfg != nil && reflect.DeepEqual(*sandboxCfg, cfg) {
+		return nil
+	}
+	sandboxCfg = &cfg
+
+	var next *sandbox.Sandbox
+	var err error
+	if cfg.Enabled {
+		var exe string
+		if exe, err = os.Executable(); err == nil {
+			next, err = sandbox.New(cfg, exe)
+		}
+		if err != nil {
+			err = fmt.Errorf("agent sandbox: %w", err)
+			if cfg.IsRequired() {
+				next = sandbox.Failed(err)
+			}
+		}
+	}
+	if prev := agentSandbox.Swap(next); prev != nil {
+		_ = prev.Close()
+	}
+	return err
+}
+
+// sandboxActive reports whether agent processes are wrapped in a sandbox.
+func sandboxActive() bool {
+	return agentSandbox.Load().Active()
+}
+
 // AnthropicAPIKey returns the configured Anthropic API key, or empty string if not set
 func AnthropicAPIKey() string {
 	if v := anthropicAPIKey.Load(); v != nil {
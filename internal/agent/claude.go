@@ -323,13 +323,14 @@ func (a *ClaudeAgent) Review(ctx context.Context, repoPath, commitSHA, prompt st
 	}
 
 	runResult, runErr := runStreamingCLI(ctx, streamingCLISpec{
-		Name:    "claude",
-		Command: a.Command,
-		Args:    args,
-		Dir:     repoPath,
-		Env:     env,
-		Stdin:   strings.NewReader(prompt),
-		Output:  output,
+		Name:     "claude",
+		Command:  a.Command,
+		Args:     args,
+		Dir:      repoPath,
+		Writable: a.Agentic,
+		Env:      env,
+		Stdin:    strings.NewReader(prompt),
+		Output:   output,
 		Parse: func(r io.Reader, sw *syncWriter) (string, error) {
 			if sw == nil {
 				return parseStreamJSON(r, nil)
@@ -696,6 +697,9 @@ func (a *ClaudeAgent) ClassifyWithSchema(
 	}
 	cmd.Env = env
 	cmd.Stdin = strings.NewReader(prompt)
+	if err := sandboxSubprocess(cmd, "", false); err != nil {
+		return nil, fmt.Errorf("claude: %w", err)
+	}
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
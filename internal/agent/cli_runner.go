@@ -13,6 +13,7 @@ type streamingCLISpec struct {
 	Command       string
 	Args          []string
 	Dir           string
+	Writable      bool // the agent may edit Dir (sandbox binds it read-write)
 	Env           []string
 	Stdin         io.Reader
 	Output        io.Writer
@@ -40,6 +41,9 @@ func runStreamingCLI(ctx context.Context, spec streamingCLISpec) (streamingCLIRe
 	}
 	cmd.Stdin = spec.Stdin
 	tracker := configureSubprocess(cmd)
+	if err := sandboxSubprocess(cmd, "", spec.Writable); err != nil {
+		return result, fmt.Errorf("%s: %w", spec.Name, err)
+	}
 
 	sw := newSyncWriter(spec.Output)
 
@@ -147,12 +147,20 @@ func (a *CodexAgent) CommandLine() string {
 	args := a.commandArgs(codexArgOptions{
 		agenticMode:   agenticMode,
 		autoApprove:   !agenticMode,
-		sandboxBroken: CodexSandboxDisabled(),
+		sandboxBroken: codexSkipSandbox(),
 		preview:       true,
 	})
 	return a.Command + " " + strings.Join(args, " ")
 }
 
+// codexSkipSandbox reports whether codex should run without its own
+// sandbox: either it is disabled in config, or codex already runs inside
+// roborev's agent sandbox, which enforces the same read-only work tree and
+// where codex's own bwrap often cannot nest.
+func codexSkipSandbox() bool {
+	return CodexSandboxDisabled() || sandboxActive()
+}
+
 func (a *CodexAgent) buildArgs(
 	repoPath string,
 	agenticMode, autoApprove, sandboxBroken bool,
@@ -348,8 +356,8 @@ func (a *CodexAgent) Review(ctx context.Context, repoPath, commitSHA, prompt str
 
 	// Use codex exec with --json for JSONL streaming output
 	// The prompt is piped via stdin using "-" to avoid command line length limits on Windows
-	sandboxBroken := CodexSandboxDisabled()
-	if sandboxBroken && autoApprove {
+	sandboxBroken := codexSkipSandbox()
+	if CodexSandboxDisabled() && autoApprove {
 		log.Printf("codex: sandbox disabled via config, using %s", codexAutoApproveFlag)
 	}
 	args := runAgent.buildArgs(repoPath, agenticMode, autoApprove, sandboxBroken)
@@ -359,6 +367,7 @@ func (a *CodexAgent) Review(ctx context.Context, repoPath, commitSHA, prompt str
 		Command:      a.Command,
 		Args:         args,
 		Dir:          repoPath,
+		Writable:     a.Agentic,
 		Stdin:        strings.NewReader(prompt),
 		Output:       output,
 		StreamStderr: true,
@@ -213,6 +213,9 @@ func (a *CopilotAgent) Review(ctx context.Context, repoPath, commitSHA, prompt s
 	cmd.Stdin = strings.NewReader(prompt)
 	cmd.Dir = repoPath
 	tracker := configureSubprocess(cmd)
+	if err := sandboxSubprocess(cmd, "", a.Agentic); err != nil {
+		return "", fmt.Errorf("copilot: %w", err)
+	}
 
 	var stdout, stderr bytes.Buffer
 	if sw := newSyncWriter(output); sw != nil {
@@ -97,13 +97,14 @@ func (a *CursorAgent) Review(ctx context.Context, repoPath, commitSHA, prompt st
 	args := a.buildArgs(agenticMode)
 
 	runResult, runErr := runStreamingCLI(ctx, streamingCLISpec{
-		Name:    "cursor agent",
-		Command: a.Command,
-		Args:    args,
-		Dir:     repoPath,
-		Env:     os.Environ(),
-		Stdin:   strings.NewReader(prompt),
-		Output:  output,
+		Name:     "cursor agent",
+		Command:  a.Command,
+		Args:     args,
+		Dir:      repoPath,
+		Writable: a.Agentic,
+		Env:      os.Environ(),
+		Stdin:    strings.NewReader(prompt),
+		Output:   output,
 		Parse: func(r io.Reader, sw *syncWriter) (string, error) {
 			if sw == nil {
 				return a.parseStreamJSON(r, nil)
@@ -109,6 +109,9 @@ func (a *DroidAgent) Review(ctx context.Context, repoPath, commitSHA, prompt str
 	cmd.Dir = repoPath
 	cmd.Stdin = strings.NewReader(prompt)
 	tracker := configureSubprocess(cmd)
+	if err := sandboxSubprocess(cmd, "", a.Agentic); err != nil {
+		return "", fmt.Errorf("droid: %w", err)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
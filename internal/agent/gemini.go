@@ -189,6 +189,7 @@ func (a *GeminiAgent) runGemini(ctx context.Context, repoPath, prompt string, ar
 		Command:      a.Command,
 		Args:         args,
 		Dir:          repoPath,
+		Writable:     a.Agentic,
 		Stdin:        strings.NewReader(prompt),
 		Output:       output,
 		StreamStderr: true,
@@ -225,6 +226,7 @@ func (a *GeminiAgent) runAntigravity(ctx context.Context, repoPath, prompt strin
 		Command:      a.Command,
 		Args:         args,
 		Dir:          repoPath,
+		Writable:     a.Agentic,
 		Stdin:        strings.NewReader(strings.TrimRight(prompt, "\n") + "\n"),
 		Output:       output,
 		StreamStderr: true,
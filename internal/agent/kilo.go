@@ -119,6 +119,7 @@ func (a *KiloAgent) Review(
 		Command:       a.Command,
 		Args:          a.buildArgs(),
 		Dir:           repoPath,
+		Writable:      a.Agentic,
 		Stdin:         strings.NewReader(prompt),
 		Output:        output,
 		StreamStderr:  true,
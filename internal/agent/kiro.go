@@ -166,6 +166,9 @@ func (a *KiroAgent) Review(ctx context.Context, repoPath, commitSHA, prompt stri
 	cmd.Dir = repoPath
 	cmd.Env = os.Environ()
 	tracker := configureSubprocess(cmd)
+	if err := sandboxSubprocess(cmd, "", a.Agentic); err != nil {
+		return "", fmt.Errorf("kiro: %w", err)
+	}
 
 	// kiro-cli emits ANSI terminal escape codes that are not
 	// suitable for streaming. Capture and return stripped text.
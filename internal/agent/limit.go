@@ -16,6 +16,20 @@ const (
 	LimitKindSession
 )
 
+// String returns the lowercase kind name, or "" for LimitKindNone.
+func (k LimitKind) String() string {
+	switch k {
+	case LimitKindTransient:
+		return "transient"
+	case LimitKindQuota:
+		return "quota"
+	case LimitKindSession:
+		return "session"
+	default:
+		return ""
+	}
+}
+
 // LimitClassification is the result of inspecting an agent error.
 type LimitClassification struct {
 	Kind        LimitKind
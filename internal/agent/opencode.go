@@ -102,12 +102,13 @@ func (a *OpenCodeAgent) Review(
 	args := a.buildArgs()
 
 	runResult, runErr := runStreamingCLI(ctx, streamingCLISpec{
-		Name:    "opencode",
-		Command: a.Command,
-		Args:    args,
-		Dir:     repoPath,
-		Stdin:   strings.NewReader(prompt),
-		Output:  output,
+		Name:     "opencode",
+		Command:  a.Command,
+		Args:     args,
+		Dir:      repoPath,
+		Writable: a.Agentic,
+		Stdin:    strings.NewReader(prompt),
+		Output:   output,
 		// opencode prints sqlite-migration progress to stderr
 		// on every invocation, drowning the live log with
 		// noise. Skip stderr streaming; the full stderr is
@@ -198,6 +198,9 @@ func (a *PiAgent) ClassifyWithSchema(
 	cmd := exec.CommandContext(ctx, a.Command, args...)
 	cmd.Dir = repoPath
 	tracker := configureSubprocess(cmd)
+	if err := sandboxSubprocess(cmd, "", false, tmpDir); err != nil {
+		return nil, fmt.Errorf("pi: %w", err)
+	}
 
 	var stdoutBuf bytes.Buffer
 	var stderrBuf bytes.Buffer
@@ -285,6 +288,9 @@ func (a *PiAgent) Review(
 	cmd := exec.CommandContext(ctx, a.Command, args...)
 	cmd.Dir = repoPath
 	tracker := configureSubprocess(cmd)
+	if err := sandboxSubprocess(cmd, "", a.Agentic, tmpFile.Name()); err != nil {
+		return "", fmt.Errorf("pi: %w", err)
+	}
 
 	// Capture stdout for the result
 	var stdoutBuf bytes.Buffer
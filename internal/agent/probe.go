@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ProbePrompt is the canary prompt an active probe sends. It is small and
+// asks for a fixed reply so the probe costs next to nothing.
+const ProbePrompt = "Respond with exactly: OK"
+
+// AuthStatus is what a probe learned about an agent's credentials.
+type AuthStatus string
+
+const (
+	AuthUnknown AuthStatus = "unknown" // the probe could not tell
+	AuthOK      AuthStatus = "ok"      // the agent answered, so it is logged in
+	AuthFailed  AuthStatus = "failed"  // the agent reported missing or bad credentials
+)
+
+// authFailureSubstrings are lowercase fragments of the errors agent CLIs
+// print when they are not logged in or their API key is rejected. Like the
+// limit rules, only wording seen from real CLIs is listed.
+var authFailureSubstrings = []string{
+	"not logged in",
+	"please log in",
+	"please run /login",
+	"invalid api key",
+	"invalid_api_key",
+	"authentication_error",
+	"401 unauthorized",
+	"status: 401",
+	"status 401",
+}
+
+// IsAuthError reports whether an agent error message says the agent is not
+// authenticated.
+func IsAuthError(errMsg string) bool {
+	lower := strings.ToLower(errMsg)
+	for _, s := range authFailureSubstrings {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProbeResult is the outcome of running the canary prompt on one agent and
+// model.
+type ProbeResult struct {
+	Agent           string
+	Model           string
+	Installed       bool
+	Command         string   // resolved executable path, for command agents
+	Version         string   // first line of "<command> --version"
+	ReasoningLevels []string // levels that change how the agent is invoked
+	Latency         time.Duration
+	Auth            AuthStatus
+	Limit           LimitClassification
+	Err             string
+}
+
+// OK reports whether the agent answered the canary prompt.
+func (r ProbeResult) OK() bool {
+	return r.Installed && r.Err == ""
+}
+
+// Probe runs the canary prompt on the named agent with model (empty for the
+// agent's default) at fast reasoning, in workDir. It never returns an error:
+// failures are recorded on the result, with rate limits classified by
+// ClassifyLimit and credential problems reported as AuthFailed.
+func Probe(ctx context.Context, name, model, workDir string) ProbeResult {
+	canonical := CanonicalName(name)
+	res := ProbeResult{Agent: canonical, Model: model, Auth: AuthUnknown}
+	a, err := Get(canonical)
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	if !IsAvailable(canonical) {
+		res.Err = "not found in PATH"
+		return res
+	}
+	res.Installed = true
+
+	a = applyResolvedCommand(a).WithModel(model)
+	if ca, ok := a.(CommandAgent); ok {
+		if path, err := exec.LookPath(ca.CommandName()); err == nil {
+			res.Command = path
+		}
+		res.Version = CommandVersion(ctx, ca)
+	}
+	res.ReasoningLevels = SupportedReasoningLevels(a)
+
+	start := time.Now()
+	out, err := a.WithReasoning(ReasoningFast).Review(ctx, workDir, "HEAD", ProbePrompt, nil)
+	res.Latency = time.Since(start)
+	switch {
+	case err != nil:
+		res.Err = err.Error()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			res.Err = "timed out: " + res.Err
+		}
+		res.Limit = ClassifyLimit(canonical, res.Err)
+		if IsAuthError(res.Err) {
+			res.Auth = AuthFailed
+		}
+	case strings.TrimSpace(out) == "":
+		res.Err = "empty response"
+	default:
+		res.Auth = AuthOK
+	}
+	return res
+}
+
+// CommandVersion returns the first line printed by "<command> --version",
+// or "" when the command does not report one.
+func CommandVersion(ctx context.Context, a CommandAgent) string {
+	command := firstAvailableCommand(a)
+	if command == "" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command, "--version")
+	configureCapabilityProbe(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	for line := range strings.SplitSeq(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if len(line) > 120 {
+				line = line[:120]
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// SupportedReasoningLevels returns the reasoning levels that change how the
+// agent is invoked, judged by its command line. Standard is always listed
+// when any other level is; an agent that ignores reasoning returns nil.
+func SupportedReasoningLevels(a Agent) []string {
+	standard := a.WithReasoning(ReasoningStandard).CommandLine()
+	differs := map[string]bool{}
+	for _, level := range ReasoningLevels() {
+		if a.WithReasoning(ReasoningLevel(level)).CommandLine() != standard {
+			differs[level] = true
+		}
+	}
+	if len(differs) == 0 {
+		return nil
+	}
+	var levels []string
+	for _, level := range ReasoningLevels() {
+		if differs[level] || level == string(ReasoningStandard) {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAuthError(t *testing.T) {
+	assert.True(t, IsAuthError("Not logged in · Please run /login"))
+	assert.True(t, IsAuthError(`API Error: 401 {"type":"error","error":{"type":"authentication_error"}}`))
+	assert.False(t, IsAuthError("quota exceeded"))
+	assert.False(t, IsAuthError(""))
+}
+
+func TestSupportedReasoningLevels(t *testing.T) {
+	// Codex maps medium to its default effort, the same as standard.
+	assert.Equal(t, []string{"fast", "standard", "thorough", "maximum"},
+		SupportedReasoningLevels(NewCodexAgent("codex")))
+	assert.Nil(t, SupportedReasoningLevels(NewTestAgent()))
+}
+
+func TestProbe(t *testing.T) {
+	res := Probe(context.Background(), "no-such-agent", "", t.TempDir())
+	assert.False(t, res.Installed)
+	assert.NotEmpty(t, res.Err)
+
+	res = Probe(context.Background(), "test", "", t.TempDir())
+	assert.True(t, res.OK())
+	assert.Equal(t, AuthOK, res.Auth)
+
+	Register(&FakeAgent{
+		NameStr: "probe-limited",
+		ReviewFn: func(context.Context, string, string, string, io.Writer) (string, error) {
+			return "", errors.New("Error: quota exceeded")
+		},
+	})
+	t.Cleanup(func() { Unregister("probe-limited") })
+	res = Probe(context.Background(), "probe-limited", "", t.TempDir())
+	require.True(t, res.Installed)
+	assert.False(t, res.OK())
+	assert.Equal(t, LimitKindQuota, res.Limit.Kind)
+	assert.Equal(t, "quota", res.Limit.Kind.String())
+	assert.Equal(t, AuthUnknown, res.Auth)
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"go.kenn.io/roborev/internal/procutil"
+	"go.kenn.io/roborev/internal/sandbox"
 )
 
 var subprocessWaitDelay = 5 * time.Second
@@ -58,6 +59,23 @@ func configureSubprocess(cmd *exec.Cmd) *subprocessTracker {
 	return tracker
 }
 
+// sandboxSubprocess wraps cmd in the agent sandbox, if one is set. dir is the
+// work tree to expose (cmd.Dir when empty) and writable lets the agent edit
+// it. share lists files outside the work tree the agent needs, such as
+// prompt files. Call it after cmd.Dir and cmd.Env are final.
+func sandboxSubprocess(cmd *exec.Cmd, dir string, writable bool, share ...string) error {
+	return agentSandbox.Load().Wrap(cmd, sandbox.Options{
+		Dir: dir, Writable: writable, Share: share,
+	})
+}
+
+// SandboxCommand wraps a repo-configured command, such as a verify command,
+// in the agent sandbox with dir writable, so it runs under the same
+// restrictions as the agents. It is a no-op when no sandbox is set.
+func SandboxCommand(cmd *exec.Cmd, dir string) error {
+	return sandboxSubprocess(cmd, dir, true)
+}
+
 func configureCapabilityProbe(cmd *exec.Cmd) {
 	procutil.HideConsole(cmd)
 	if cmd.Path != "" &&
@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"errors"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
@@ -12,6 +13,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/sandbox"
 )
 
 type countingCloser struct {
@@ -206,3 +210,24 @@ func TestConfigureSubprocessDoesNotMarkCanceledWhenProcessAlreadyExited(t *testi
 	}
 	require.False(t, tracker.canceledByContext.Load(), "tracker should stay false when cancel runs after process exit")
 }
+
+func TestRequiredSandboxRefusesAgents(t *testing.T) {
+	skipIfWindows(t)
+	t.Cleanup(func() { require.NoError(t, ConfigureSandbox(config.SandboxConfig{})) })
+
+	// An invalid required sandbox must block agents, not run them bare.
+	err := ConfigureSandbox(config.SandboxConfig{Enabled: true, Home: "bogus"})
+	require.ErrorContains(t, err, "sandbox home")
+	require.False(t, sandboxActive())
+
+	_, err = runStreamingCLI(context.Background(), streamingCLISpec{
+		Name:    "sh",
+		Command: "sh",
+		Args:    []string{"-c", "true"},
+		Parse:   func(io.Reader, *syncWriter) (string, error) { return "", nil },
+	})
+	require.ErrorIs(t, err, sandbox.ErrUnavailable)
+
+	require.NoError(t, ConfigureSandbox(config.SandboxConfig{}))
+	require.Nil(t, agentSandbox.Load())
+}
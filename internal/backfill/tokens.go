@@ -82,6 +82,15 @@ func LogTokenCandidates(jobs []storage.ReviewJob) []storage.ReviewJob {
 	return out
 }
 
+// UsageModel returns the model to price job-log usage as: the model the
+// agent stream reported, else the job's effective model.
+func UsageModel(usage *tokens.Usage, job storage.ReviewJob) string {
+	if usage != nil && usage.Model != "" {
+		return usage.Model
+	}
+	return job.Model
+}
+
 func MergeTokenUsage(existingJSON string, fetched *tokens.Usage) *tokens.Usage {
 	if fetched == nil {
 		return tokens.ParseJSON(existingJSON)
@@ -111,6 +120,9 @@ func MergeTokenUsage(existingJSON string, fetched *tokens.Usage) *tokens.Usage {
 	if merged.EventOffset == 0 {
 		merged.EventOffset = existing.EventOffset
 	}
+	if merged.Model == "" {
+		merged.Model = existing.Model
+	}
 	if !merged.HasCost && existing.HasCost {
 		merged.CostUSD = existing.CostUSD
 		merged.HasCost = true
@@ -0,0 +1,49 @@
+// Chunked review configuration.
+
+package config
+
+// DefaultMaxChunks caps how many file-group child reviews one oversized
+// target is split into.
+const DefaultMaxChunks = 8
+
+// ChunkingConfig is the [review.chunking] table. Chunking is opt-in: when a
+// commit or range diff exceeds the chunk size, the review is split into file
+// groups, each reviewed by its own child job, and the results are merged into
+// one parent review.
+type ChunkingConfig struct {
+	Enabled   *bool `toml:"enabled" comment:"Split oversized commits and ranges into per-file-group reviews. Off by default; a repo setting overrides the global one."`
+	ChunkSize int   `toml:"chunk_size" comment:"Diff bytes per file group. Defaults to three quarters of the max prompt size."`
+	MaxChunks int   `toml:"max_chunks" comment:"Most file groups one review is split into (default 8). Groups grow past chunk_size to stay under it."`
+}
+
+// ChunkingSettings is the resolved chunking config for one repo.
+type ChunkingSettings struct {
+	Enabled   bool
+	ChunkSize int
+	MaxChunks int
+}
+
+// mergeChunking returns the repo chunking settings over the global ones.
+func mergeChunking(repo, global ChunkingConfig) ChunkingConfig {
+	enabled := repo.Enabled
+	if enabled == nil {
+		enabled = global.Enabled
+	}
+	return ChunkingConfig{
+		Enabled:   enabled,
+		ChunkSize: resolve(0, clampPositive(repo.ChunkSize), clampPositive(global.ChunkSize)),
+		MaxChunks: resolve(0, clampPositive(repo.MaxChunks), clampPositive(global.MaxChunks)),
+	}
+}
+
+// ResolveChunking resolves the chunking settings for repoPath. The default
+// chunk size leaves a quarter of the prompt budget for the instructions,
+// commit messages and review history that surround the diff.
+func ResolveChunking(repoPath string, globalCfg *Config) ChunkingSettings {
+	chunking := MergedReviewConfig(repoPath, globalCfg).Chunking
+	return ChunkingSettings{
+		Enabled:   resolveBool(false, chunking.Enabled),
+		ChunkSize: resolve(ResolveMaxPromptSize(repoPath, globalCfg)*3/4, chunking.ChunkSize),
+		MaxChunks: resolve(DefaultMaxChunks, chunking.MaxChunks),
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveChunking(t *testing.T) {
+	enabled := true
+	global := &Config{DefaultMaxPromptSize: 40000}
+	global.Review.Chunking = ChunkingConfig{Enabled: &enabled, MaxChunks: 4}
+
+	repoDir := t.TempDir()
+	assert.Equal(t, ChunkingSettings{Enabled: true, ChunkSize: 30000, MaxChunks: 4},
+		ResolveChunking(repoDir, global), "chunk size defaults to three quarters of the prompt budget")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".roborev.toml"),
+		[]byte("[review.chunking]\nenabled = false\nchunk_size = 5000\n"), 0o644))
+	assert.Equal(t, ChunkingSettings{Enabled: false, ChunkSize: 5000, MaxChunks: 4},
+		ResolveChunking(repoDir, global), "repo settings override global ones")
+
+	assert.Equal(t, ChunkingSettings{ChunkSize: DefaultMaxPromptSize * 3 / 4, MaxChunks: DefaultMaxChunks},
+		ResolveChunking("", nil), "chunking is off by default")
+}
@@ -140,6 +140,11 @@ type CIConfig struct {
 	// Model overrides the model for CI reviews (empty = use workflow resolution)
 	Model string `toml:"model"`
 
+	// Fallbacks is the ordered failover chain for CI review members, as
+	// "agent" or "agent:model" entries. Members whose subagent sets its own
+	// fallbacks use those instead. Empty means CI reviews never fail over.
+	Fallbacks []string `toml:"fallbacks"`
+
 	// SynthesisAgent is the agent used to synthesize multiple review outputs into one comment.
 	// Defaults to the first available agent.
 	SynthesisAgent string `toml:"synthesis_agent"`
@@ -175,6 +180,11 @@ type CIConfig struct {
 	// Default: false (omit costs from GitHub comments).
 	IncludeCosts bool `toml:"include_costs"`
 
+	// IncrementalReview reviews only the commits pushed since the PR's last
+	// completed panel run, with that run's review as context, and closes
+	// prior reviews the new run reports resolved. Default: false.
+	IncrementalReview bool `toml:"incremental_review"`
+
 	// BatchTimeout is how long to wait for all batch jobs to complete before
 	// posting results with available reviews. Jobs still running after this
 	// timeout are canceled. Default: "15m". Set to "0" to disable.
@@ -339,6 +349,9 @@ type RepoCIConfig struct {
 	// overriding the agents x review_types matrix.
 	Panel string `toml:"panel" comment:"Named [review.panels.X] panel for CI."`
 
+	// Fallbacks overrides the CI review failover chain for this repo.
+	Fallbacks []string `toml:"fallbacks" comment:"Override the ordered CI review fallbacks for this repo, as agent or agent:model entries."`
+
 	// Reasoning overrides the reasoning level for CI reviews.
 	Reasoning string `toml:"reasoning" comment:"Override the CI reasoning level for this repo: fast, standard, medium, thorough, or maximum."`
 
@@ -352,6 +365,9 @@ type RepoCIConfig struct {
 	// IncludeCosts overrides the global ci.include_costs setting.
 	// Use a pointer so we can distinguish "not set" from "explicitly false".
 	IncludeCosts *bool `toml:"include_costs" comment:"Override whether CI PR comments include token cost estimates."`
+
+	// IncrementalReview overrides the global ci.incremental_review setting.
+	IncrementalReview *bool `toml:"incremental_review" comment:"Override whether CI reviews only the commits pushed since the PR's last completed review."`
 }
 
 // ResolveCIAgents determines which agents to use for CI review execution.
@@ -375,6 +391,19 @@ func ResolveCIAgents(
 	return resolveSlice([]string{""}, repoAgents, globalAgents)
 }
 
+// ResolveCIFallbacks returns the normalized failover chain for CI review
+// members. Priority: repo [ci].fallbacks > global [ci].fallbacks > none.
+func ResolveCIFallbacks(repoCfg *RepoConfig, globalCfg *Config) []string {
+	var repoFallbacks, globalFallbacks []string
+	if repoCfg != nil {
+		repoFallbacks = repoCfg.CI.Fallbacks
+	}
+	if globalCfg != nil {
+		globalFallbacks = globalCfg.CI.Fallbacks
+	}
+	return FormatFallbacks(ParseFallbacks(resolveSlice(nil, repoFallbacks, globalFallbacks)))
+}
+
 // ResolveCIReviewTypes determines which review types to use for CI review execution.
 // Priority: explicit CSV flag > repo [ci].review_types > global [ci].review_types > ["security"].
 func ResolveCIReviewTypes(
@@ -478,6 +507,24 @@ func ResolveCIIncludeCosts(
 	return resolveBool(false, repoVal, globalVal)
 }
 
+// ResolveCIIncrementalReview determines whether CI panels review only the
+// commits pushed since the PR's last completed review.
+// Priority: repo [ci].incremental_review > global [ci].incremental_review > false.
+func ResolveCIIncrementalReview(
+	repoCfg *RepoConfig,
+	globalCfg *Config,
+) bool {
+	var repoVal *bool
+	if repoCfg != nil {
+		repoVal = repoCfg.CI.IncrementalReview
+	}
+	var globalVal *bool
+	if globalCfg != nil {
+		globalVal = &globalCfg.CI.IncrementalReview
+	}
+	return resolveBool(false, repoVal, globalVal)
+}
+
 // ResolveCIWorkflowAgents determines which agents to encode into a generated CI workflow.
 // Priority: explicit CSV flag > repo [ci].agents > repo agent > global [ci].agents > global default_agent > ["codex"].
 func ResolveCIWorkflowAgents(
@@ -18,6 +18,7 @@ import (
 	gitrepo "go.kenn.io/kit/git/repo"
 
 	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/tokens"
 )
 
 // ConfigParseError is returned when .roborev.toml exists but
@@ -46,14 +47,66 @@ func IsConfigParseError(err error) bool {
 
 // HookConfig defines a hook that runs on review events
 type HookConfig struct {
-	Event    string   `toml:"event"`                // "review.failed", "review.completed", "review.*"
-	Branches []string `toml:"branches"`             // optional branch globs (path.Match); empty = all branches
-	Command  string   `toml:"command"`              // shell command with {var} templates
-	Type     string   `toml:"type"`                 // "beads", "kata", or "webhook"; empty or "command" runs Command
-	URL      string   `toml:"url" sensitive:"true"` // webhook destination URL when Type is "webhook"
-	Project  string   `toml:"project"`              // kata: project name (defaults to .kata.toml binding)
-	Labels   []string `toml:"labels"`               // kata: extra labels (roborev is always added)
-	Priority *int     `toml:"priority"`             // kata: issue priority (0..4); nil = kata default
+	Event    string   `toml:"event"`                   // "review.failed", "review.*", "ci.deferred", "*"
+	Branches []string `toml:"branches"`                // optional branch globs (path.Match); empty = all branches
+	Command  string   `toml:"command"`                 // shell command with {var} templates
+	Type     string   `toml:"type"`                    // "beads", "kata", "webhook", "slack", "teams", or "email"; empty or "command" runs Command
+	URL      string   `toml:"url" sensitive:"true"`    // webhook, slack, teams: incoming webhook URL
+	Secret   string   `toml:"secret" sensitive:"true"` // webhook: HMAC-SHA256 key for X-Roborev-Signature over timestamp.body
+	Project  string   `toml:"project"`                 // kata: project name (defaults to .kata.toml binding)
+	Labels   []string `toml:"labels"`                  // kata: extra labels (roborev is always added)
+	Priority *int     `toml:"priority"`                // kata: issue priority (0..4); nil = kata default
+	Token    string   `toml:"token" sensitive:"true"`  // slack: bot token; teams: Graph access token. Supports ${ENV_VAR}
+	Channel  string   `toml:"channel"`                 // slack: channel ID; teams: "<team-id>/<channel-id>". Used with Token
+	Thread   bool     `toml:"thread"`                  // slack, teams: reply in one thread per PR (or branch); requires Token
+	Link     string   `toml:"link"`                    // slack, teams, email: review link URL template, e.g. "https://ci.example.com/roborev/{job_id}"
+	To       []string `toml:"to"`                      // email: recipient addresses
+
+	// Conditions narrow which events fire the hook beyond Event and
+	// Branches. Every set condition must hold; an unset one matches all.
+	MinSeverity string   `toml:"min_severity"` // only when a finding is at or above this severity
+	Verdict     string   `toml:"verdict"`      // "pass" or "fail"
+	Repos       []string `toml:"repos"`        // globs matched against repo path, repo name, or GitHub owner/repo
+	Agents      []string `toml:"agents"`       // agent name globs
+	ReviewTypes []string `toml:"review_types"` // e.g. "security", "design", "default"
+	JobSources  []string `toml:"job_sources"`  // "ci", "auto_design", or "user" for explicitly enqueued jobs
+}
+
+// Validate reports invalid condition values, so a typo is rejected when the
+// config loads instead of quietly keeping the hook from firing.
+func (h HookConfig) Validate() error {
+	if h.Verdict != "" {
+		if _, err := NormalizeHookVerdict(h.Verdict); err != nil {
+			return err
+		}
+	}
+	_, err := NormalizeMinSeverity(h.MinSeverity)
+	return err
+}
+
+// NormalizeHookVerdict maps a verdict condition to the stored "P"/"F" form.
+func NormalizeHookVerdict(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "pass", "p":
+		return "P", nil
+	case "fail", "f":
+		return "F", nil
+	default:
+		return "", fmt.Errorf("invalid verdict condition: %q (valid: pass, fail)", v)
+	}
+}
+
+// validateLoaded reports the hook and review route problems that would
+// otherwise only surface when an event or enqueue reaches them.
+func validateLoaded(hooks []HookConfig, review ReviewConfig) error {
+	var errs []error
+	for i, h := range hooks {
+		if err := h.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("hook %d (%s): %w", i+1, h.Event, err))
+		}
+	}
+	errs = append(errs, review.validateRouteEntries())
+	return errors.Join(errs...)
 }
 
 type AdvancedConfig struct {
@@ -93,6 +146,14 @@ type AgentConfig struct {
 type CostConfig struct {
 	Endpoint string `toml:"endpoint" comment:"HTTP usage endpoint template for cost/token lookup. Use {session_id}; set empty to use agentsview CLI lookup only."`
 	Timeout  string `toml:"timeout" comment:"Timeout for HTTP usage endpoint lookups."`
+	// Pricing overrides or extends the built-in per-model price list used
+	// to estimate cost from token counts in agent output.
+	Pricing tokens.Pricing `toml:"pricing" comment:"Per-model prices in USD per million tokens, keyed by model name prefix. Overrides the built-in price list."`
+}
+
+// ResolvedPricing returns the built-in price list with configured overrides.
+func (c CostConfig) ResolvedPricing() tokens.Pricing {
+	return tokens.WithOverrides(c.Pricing)
 }
 
 // ResolvedTimeout returns the HTTP usage lookup timeout.
@@ -122,6 +183,7 @@ type Config struct {
 	JobTimeoutMinutes          int    `toml:"job_timeout_minutes"`
 	HookTimeoutSeconds         int    `toml:"hook_timeout_seconds" comment:"Post-commit hook request timeout in seconds. 0 or negative uses the platform default (3 on most systems, 30 on Windows where git subprocess spawns are slow)."`
 	AgentQuotaCooldown         string `toml:"agent_quota_cooldown" comment:"Maximum daemon-wide cooldown after an agent quota error, as a Go duration such as 30m."`
+	AgentProbeInterval         string `toml:"agent_probe_interval" comment:"How often the daemon probes configured agents with a canary prompt, as a Go duration such as 6h. Each pass spends agent quota. Empty or 0 disables periodic probes."`
 	ReviewReasoning            string `toml:"review_reasoning" comment:"Default reasoning level for reviews: fast, standard, medium, thorough, or maximum."`
 	RefineReasoning            string `toml:"refine_reasoning" comment:"Default reasoning level for refine: fast, standard, medium, thorough, or maximum."`
 	FixReasoning               string `toml:"fix_reasoning" comment:"Default reasoning level for fix: fast, standard, medium, thorough, or maximum."`
@@ -198,6 +260,10 @@ type Config struct {
 	ClassifyBackupAgent string `toml:"classify_backup_agent" comment:"Fallback classifier agent on quota exhaustion / failure."`
 	ClassifyBackupModel string `toml:"classify_backup_model" comment:"Fallback classifier model."`
 
+	// Resolution check workflow (closes open reviews fixed by later commits)
+	ResolutionCheckAgent string `toml:"resolution_check_agent" comment:"Agent for resolution checks. Empty = default_agent."`
+	ResolutionCheckModel string `toml:"resolution_check_model" comment:"Model for resolution checks. Empty = agent default."`
+
 	// Backup agents for failover
 	ReviewBackupAgent   string `toml:"review_backup_agent"`
 	RefineBackupAgent   string `toml:"refine_backup_agent"`
@@ -212,6 +278,14 @@ type Config struct {
 	SecurityBackupModel string `toml:"security_backup_model"`
 	DesignBackupModel   string `toml:"design_backup_model"`
 
+	// Ordered fallback chains, as "agent" or "agent:model" entries, tried
+	// ahead of the workflow's backup agent
+	ReviewFallbacks   []string `toml:"review_fallbacks"`
+	RefineFallbacks   []string `toml:"refine_fallbacks"`
+	FixFallbacks      []string `toml:"fix_fallbacks"`
+	SecurityFallbacks []string `toml:"security_fallbacks"`
+	DesignFallbacks   []string `toml:"design_fallbacks"`
+
 	// Minimum severity thresholds (global defaults)
 	ReviewMinSeverity string `toml:"review_min_severity" comment:"Minimum severity for reviews: critical, high, medium, or low. Empty disables filtering."`
 	RefineMinSeverity string `toml:"refine_min_severity" comment:"Minimum severity for refine: critical, high, medium, or low. Empty disables filtering."`
@@ -239,6 +313,18 @@ type Config struct {
 	// Hooks configuration
 	Hooks []HookConfig `toml:"hooks,omitempty"`
 
+	// Outgoing mail for email hooks and digests
+	SMTP SMTPConfig `toml:"smtp"`
+
+	// Scheduled email digests
+	Digests []DigestConfig `toml:"digests,omitempty"`
+
+	// Recurring analyze, insights, compact, and summary runs
+	Schedules []ScheduleConfig `toml:"schedule,omitempty"`
+
+	// OS-level sandbox for agent processes (global only)
+	Sandbox SandboxConfig `toml:"sandbox"`
+
 	// Sync configuration for PostgreSQL
 	Sync SyncConfig `toml:"sync"`
 
@@ -268,11 +354,16 @@ type Config struct {
 	// Diff exclusion patterns (filenames or glob patterns to exclude from review diffs)
 	ExcludePatterns []string `toml:"exclude_patterns" comment:"Filenames or glob patterns to exclude from review diffs globally."`
 
+	// Secret redaction for prompts, stored diffs, exports and PR comments
+	Redaction RedactionConfig `toml:"redaction"`
+
 	// Analysis settings
 	DefaultMaxPromptSize int `toml:"default_max_prompt_size"` // Max prompt size in bytes before falling back to paths (default: 200KB)
 
 	// Behavior
-	AutoClosePassingReviews bool `toml:"auto_close_passing_reviews" comment:"Automatically close reviews that pass with no findings."`
+	AutoClosePassingReviews bool  `toml:"auto_close_passing_reviews" comment:"Automatically close reviews that pass with no findings."`
+	ResolutionCheck         bool  `toml:"resolution_check" comment:"Close open reviews whose findings later branch commits fixed, checked by a background agent."`
+	ReviewCache             *bool `toml:"review_cache" comment:"Reuse a recent review of an identical patch with the same review type, agent, model and guidelines instead of running the agent again. Default true."`
 
 	// UI preferences
 	HideClosedByDefault    bool     `toml:"hide_closed_by_default" comment:"Hide closed reviews by default in the TUI queue."`
@@ -312,31 +403,36 @@ type ACPAgentConfig struct {
 
 // RepoConfig holds per-repo overrides
 type RepoConfig struct {
-	Agent                           string   `toml:"agent" comment:"Default agent for this repo when no workflow-specific agent is set."`
-	Model                           string   `toml:"model" comment:"Default model for this repo when no workflow-specific model is set."` // Model for agents (format varies by agent)
-	BackupAgent                     string   `toml:"backup_agent" comment:"Backup agent for this repo if the primary agent fails."`
-	BackupModel                     string   `toml:"backup_model" comment:"Backup model for this repo if the primary model fails."`
-	ReviewContextCount              int      `toml:"review_context_count" comment:"Number of related reviews to include as context for this repo."`
-	ReviewGuidelines                string   `toml:"review_guidelines" comment:"Extra review instructions added to prompts for this repo."`
-	ReviewGuidelinesSupersedeGlobal bool     `toml:"review_guidelines_supersede_global" comment:"Use repo review_guidelines instead of appending global review_guidelines."`
-	JobTimeoutMinutes               int      `toml:"job_timeout_minutes" comment:"Override the review job timeout in minutes for this repo."`
-	HookTimeoutSeconds              int      `toml:"hook_timeout_seconds" comment:"Override the post-commit hook request timeout (in seconds) for this repo. Useful for large repos where the enqueue handler's git calls are slow. 0 or negative inherits the global / platform default."`
-	ExcludedBranches                []string `toml:"excluded_branches" comment:"Branches that should be skipped for automatic review in this repo."`
-	ExcludedCommitPatterns          []string `toml:"excluded_commit_patterns" comment:"Commit message substrings that should skip review for this repo."`
-	DisplayName                     string   `toml:"display_name" comment:"Display name shown for this repo in the TUI and output."`
-	ReviewReasoning                 string   `toml:"review_reasoning" comment:"Reasoning level for reviews in this repo: fast, standard, medium, thorough, or maximum."`
-	RefineReasoning                 string   `toml:"refine_reasoning" comment:"Reasoning level for refine in this repo: fast, standard, medium, thorough, or maximum."`
-	FixReasoning                    string   `toml:"fix_reasoning" comment:"Reasoning level for fix in this repo: fast, standard, medium, thorough, or maximum."`
-	FixMinSeverity                  string   `toml:"fix_min_severity" comment:"Minimum severity for fix in this repo: critical, high, medium, or low."`     // Minimum severity for fix: critical, high, medium, low
-	RefineMinSeverity               string   `toml:"refine_min_severity" comment:"Minimum severity for refine in this repo: critical, high, medium, low."`  // Minimum severity for refine: critical, high, medium, low
-	ReviewMinSeverity               string   `toml:"review_min_severity" comment:"Minimum severity for reviews in this repo: critical, high, medium, low."` // Minimum severity for review: critical, high, medium, low
-	FixCommitAuthor                 string   `toml:"fix_commit_author" comment:"Author for roborev-owned fix commits in this repo, formatted as Name <email>."`
-	FixCommitCoAuthoredBy           []string `toml:"fix_commit_co_authored_by" comment:"Co-authored-by trailers for roborev-owned fix commits in this repo, each formatted as Name <email>."`
-	ExcludePatterns                 []string `toml:"exclude_patterns" comment:"Filenames or glob patterns to exclude from review diffs for this repo."`
-	SnapshotDir                     string   `toml:"snapshot_dir" comment:"Repo-local directory for temporary oversized diff snapshots."`
-	PostCommitReview                string   `toml:"post_commit_review" comment:"Automatic post-commit review mode for this repo: commit or branch."` // "commit" (default) or "branch"
-	ReuseReviewSession              *bool    `toml:"reuse_review_session"`
-	ReuseReviewSessionLookback      int      `toml:"reuse_review_session_lookback"` // 0 means no candidate cap
+	Agent                           string         `toml:"agent" comment:"Default agent for this repo when no workflow-specific agent is set."`
+	Model                           string         `toml:"model" comment:"Default model for this repo when no workflow-specific model is set."` // Model for agents (format varies by agent)
+	BackupAgent                     string         `toml:"backup_agent" comment:"Backup agent for this repo if the primary agent fails."`
+	BackupModel                     string         `toml:"backup_model" comment:"Backup model for this repo if the primary model fails."`
+	ReviewContextCount              int            `toml:"review_context_count" comment:"Number of related reviews to include as context for this repo."`
+	ReviewGuidelines                string         `toml:"review_guidelines" comment:"Extra review instructions added to prompts for this repo."`
+	ReviewGuidelinesSupersedeGlobal bool           `toml:"review_guidelines_supersede_global" comment:"Use repo review_guidelines instead of appending global review_guidelines."`
+	JobTimeoutMinutes               int            `toml:"job_timeout_minutes" comment:"Override the review job timeout in minutes for this repo."`
+	HookTimeoutSeconds              int            `toml:"hook_timeout_seconds" comment:"Override the post-commit hook request timeout (in seconds) for this repo. Useful for large repos where the enqueue handler's git calls are slow. 0 or negative inherits the global / platform default."`
+	ExcludedBranches                []string       `toml:"excluded_branches" comment:"Branches that should be skipped for automatic review in this repo."`
+	ExcludedCommitPatterns          []string       `toml:"excluded_commit_patterns" comment:"Commit message substrings that should skip review for this repo."`
+	DisplayName                     string         `toml:"display_name" comment:"Display name shown for this repo in the TUI and output."`
+	ReviewReasoning                 string         `toml:"review_reasoning" comment:"Reasoning level for reviews in this repo: fast, standard, medium, thorough, or maximum."`
+	RefineReasoning                 string         `toml:"refine_reasoning" comment:"Reasoning level for refine in this repo: fast, standard, medium, thorough, or maximum."`
+	FixReasoning                    string         `toml:"fix_reasoning" comment:"Reasoning level for fix in this repo: fast, standard, medium, thorough, or maximum."`
+	FixMinSeverity                  string         `toml:"fix_min_severity" comment:"Minimum severity for fix in this repo: critical, high, medium, or low."`     // Minimum severity for fix: critical, high, medium, low
+	RefineMinSeverity               string         `toml:"refine_min_severity" comment:"Minimum severity for refine in this repo: critical, high, medium, low."`  // Minimum severity for refine: critical, high, medium, low
+	ReviewMinSeverity               string         `toml:"review_min_severity" comment:"Minimum severity for reviews in this repo: critical, high, medium, low."` // Minimum severity for review: critical, high, medium, low
+	FixCommitAuthor                 string         `toml:"fix_commit_author" comment:"Author for roborev-owned fix commits in this repo, formatted as Name <email>."`
+	FixCommitCoAuthoredBy           []string       `toml:"fix_commit_co_authored_by" comment:"Co-authored-by trailers for roborev-owned fix commits in this repo, each formatted as Name <email>."`
+	ExcludePatterns                 []string       `toml:"exclude_patterns" comment:"Filenames or glob patterns to exclude from review diffs for this repo."`
+	SnapshotDir                     string         `toml:"snapshot_dir" comment:"Repo-local directory for temporary oversized diff snapshots."`
+	RedactPatterns                  []string       `toml:"redact_patterns" comment:"Extra regular expressions for secrets to mask in this repo's prompts and diffs."`
+	VerifyCommand                   VerifyCommands `toml:"verify_command" comment:"Shell command, or list of commands, that must pass after fix and refine edit this repo, e.g. \"go build ./... && go test ./...\"."`
+	VerifyAttempts                  int            `toml:"verify_attempts" comment:"Times the agent is asked to repair a failing verify_command before its changes are rolled back (default 2)."`
+	VerifyTimeoutSeconds            int            `toml:"verify_timeout_seconds" comment:"Timeout for each verify command in seconds (default 600)."`
+	PostCommitReview                string         `toml:"post_commit_review" comment:"Automatic post-commit review mode for this repo: commit or branch."` // "commit" (default) or "branch"
+	IncrementalReview               bool           `toml:"incremental_review" comment:"With post_commit_review = \"branch\", review only the commits since the branch's last completed review."`
+	ReuseReviewSession              *bool          `toml:"reuse_review_session"`
+	ReuseReviewSessionLookback      int            `toml:"reuse_review_session_lookback"` // 0 means no candidate cap
 
 	// CI-specific overrides (used by CI poller for this repo)
 	CI RepoCIConfig `toml:"ci"`
@@ -419,6 +515,10 @@ type RepoConfig struct {
 	ClassifyBackupAgent string `toml:"classify_backup_agent" comment:"Override classifier backup agent for this repo."`
 	ClassifyBackupModel string `toml:"classify_backup_model" comment:"Override classifier backup model for this repo."`
 
+	// Resolution check workflow (per-repo overrides)
+	ResolutionCheckAgent string `toml:"resolution_check_agent" comment:"Override resolution check agent for this repo."`
+	ResolutionCheckModel string `toml:"resolution_check_model" comment:"Override resolution check model for this repo."`
+
 	// Backup agents for failover
 	ReviewBackupAgent   string `toml:"review_backup_agent" comment:"Backup agent for review in this repo."`
 	RefineBackupAgent   string `toml:"refine_backup_agent" comment:"Backup agent for refine in this repo."`
@@ -433,8 +533,17 @@ type RepoConfig struct {
 	SecurityBackupModel string `toml:"security_backup_model" comment:"Backup model for security review in this repo."`
 	DesignBackupModel   string `toml:"design_backup_model" comment:"Backup model for design review in this repo."`
 
+	// Ordered fallback chains, tried ahead of the workflow's backup agent
+	ReviewFallbacks   []string `toml:"review_fallbacks" comment:"Ordered review fallbacks for this repo, as agent or agent:model entries."`
+	RefineFallbacks   []string `toml:"refine_fallbacks" comment:"Ordered refine fallbacks for this repo, as agent or agent:model entries."`
+	FixFallbacks      []string `toml:"fix_fallbacks" comment:"Ordered fix fallbacks for this repo, as agent or agent:model entries."`
+	SecurityFallbacks []string `toml:"security_fallbacks" comment:"Ordered security review fallbacks for this repo, as agent or agent:model entries."`
+	DesignFallbacks   []string `toml:"design_fallbacks" comment:"Ordered design review fallbacks for this repo, as agent or agent:model entries."`
+
 	// Behavior
 	AutoClosePassingReviews *bool `toml:"auto_close_passing_reviews" comment:"Automatically close reviews that pass with no findings in this repo."`
+	ResolutionCheck         *bool `toml:"resolution_check" comment:"Close open reviews whose findings later commits fixed in this repo."`
+	ReviewCache             *bool `toml:"review_cache" comment:"Override whether reviews in this repo reuse a cached review of an identical patch. Omit to inherit."`
 	ShowClassifyJobs        *bool `toml:"show_classify_jobs" comment:"Override whether the TUI queue shows auto-design-review classifier rows for this repo. Omit to inherit."`
 
 	// Hooks configuration (per-repo)
@@ -453,6 +562,10 @@ type RepoConfig struct {
 const (
 	DefaultPiJSONSchemaExtension = "npm:@nqbao/pi-json-schema@0.1.1"
 	DefaultAgentQuotaCooldown    = 30 * time.Minute
+	// DefaultAgentProbeInterval leaves periodic agent probes off: every pass
+	// runs a canary prompt on each configured agent and model, which spends
+	// quota on metered plans.
+	DefaultAgentProbeInterval = time.Duration(0)
 
 	// DefaultHookTimeout bounds how long the post-commit hook waits for the
 	// daemon's enqueue handler before giving up so a stalled daemon never
@@ -551,6 +664,9 @@ func LoadGlobalFrom(path string) (*Config, error) {
 	if err := cfg.CI.NormalizeInstallations(); err != nil {
 		return nil, fmt.Errorf("config: %w", err)
 	}
+	if err := validateLoaded(cfg.Hooks, cfg.Review); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
 
 	return cfg, nil
 }
@@ -654,6 +770,9 @@ func LoadRepoConfig(repoPath string) (*RepoConfig, error) {
 	if _, err := toml.DecodeFile(path, &cfg); err != nil {
 		return nil, err
 	}
+	if err := validateLoaded(cfg.Hooks, cfg.Review); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 
 	return &cfg, nil
 }
@@ -681,6 +800,16 @@ func ResolvePostCommitReview(repoPath string) string {
 	return "commit"
 }
 
+// ResolveIncrementalReview returns whether branch post-commit reviews should
+// cover only the commits since the branch's last completed review.
+func ResolveIncrementalReview(repoPath string) bool {
+	cfg, err := LoadRepoConfig(repoPath)
+	if err != nil || cfg == nil {
+		return false
+	}
+	return cfg.IncrementalReview
+}
+
 // ResolveReuseReviewSession returns whether reviews should try to resume a
 // prior session from the same branch. Priority: repo > global > default false.
 func ResolveReuseReviewSession(repoPath string, globalCfg *Config) bool {
@@ -942,6 +1071,20 @@ func ResolveAgentQuotaCooldown(globalCfg *Config) time.Duration {
 	return d
 }
 
+// ResolveAgentProbeInterval returns how often the daemon probes configured
+// agents. Zero disables periodic probes; an empty, invalid or negative value
+// uses the default, which is off.
+func ResolveAgentProbeInterval(globalCfg *Config) time.Duration {
+	if globalCfg == nil || strings.TrimSpace(globalCfg.AgentProbeInterval) == "" {
+		return DefaultAgentProbeInterval
+	}
+	d, err := time.ParseDuration(globalCfg.AgentProbeInterval)
+	if err != nil || d < 0 {
+		return DefaultAgentProbeInterval
+	}
+	return d
+}
+
 // ResolveAutoClosePassingReviews returns whether passing reviews should
 // be automatically closed. Per-repo config overrides global.
 func ResolveAutoClosePassingReviews(repoPath string, globalCfg *Config) bool {
@@ -956,6 +1099,33 @@ func ResolveAutoClosePassingReviews(repoPath string, globalCfg *Config) bool {
 	return resolveBool(globalVal, repoVal)
 }
 
+// ResolveReviewCache returns whether a review may reuse the result of a
+// recent review of an identical patch. Priority: repo > global > default true.
+func ResolveReviewCache(repoPath string, globalCfg *Config) bool {
+	if repoCfg, err := LoadRepoConfig(repoPath); err == nil && repoCfg != nil && repoCfg.ReviewCache != nil {
+		return *repoCfg.ReviewCache
+	}
+	if globalCfg != nil && globalCfg.ReviewCache != nil {
+		return *globalCfg.ReviewCache
+	}
+	return true
+}
+
+// ResolveResolutionCheck returns whether open reviews of a branch should be
+// checked against its later commits and closed once fixed. Priority: repo >
+// global > default false.
+func ResolveResolutionCheck(repoPath string, globalCfg *Config) bool {
+	var repoVal *bool
+	if repoCfg, err := LoadRepoConfig(repoPath); err == nil && repoCfg != nil {
+		repoVal = repoCfg.ResolutionCheck
+	}
+	var globalVal bool
+	if globalCfg != nil {
+		globalVal = globalCfg.ResolutionCheck
+	}
+	return resolveBool(globalVal, repoVal)
+}
+
 // ResolveExcludePatterns returns the merged exclude patterns from
 // repo config and global config. Repo patterns are read from the
 // default branch (like review guidelines) to prevent untrusted
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.kenn.io/roborev/internal/testenv"
+	"go.kenn.io/roborev/internal/tokens"
 )
 
 func TestMain(m *testing.M) {
@@ -164,6 +165,11 @@ func TestLoadGlobalCostConfigFromTOML(t *testing.T) {
 	require.NoError(t, os.WriteFile(path, []byte(`[cost]
 endpoint = "https://usage.example.test/api/v1/sessions/{session_id}/usage"
 timeout = "250ms"
+
+[cost.pricing."gpt-5"]
+input = 2.0
+cached_input = 0.2
+output = 16.0
 `), 0o600))
 
 	cfg, err := LoadGlobalFrom(path)
@@ -171,6 +177,12 @@ timeout = "250ms"
 	assert.Equal(t, "https://usage.example.test/api/v1/sessions/{session_id}/usage", cfg.Cost.Endpoint)
 	assert.Equal(t, "250ms", cfg.Cost.Timeout)
 	assert.Equal(t, 250*time.Millisecond, cfg.Cost.ResolvedTimeout())
+
+	price, ok := cfg.Cost.ResolvedPricing().Lookup("gpt-5-codex")
+	require.True(t, ok)
+	assert.Equal(t, tokens.Price{Input: 2, CachedInput: 0.2, Output: 16}, price)
+	_, ok = cfg.Cost.ResolvedPricing().Lookup("claude-sonnet-4-5")
+	assert.True(t, ok, "built-in prices stay available")
 }
 
 func TestCostConfigResolvedTimeoutFallsBackToDefault(t *testing.T) {
@@ -630,6 +642,26 @@ func TestResolveAgentQuotaCooldown(t *testing.T) {
 	}
 }
 
+func TestResolveAgentProbeInterval(t *testing.T) {
+	tests := []struct {
+		name         string
+		globalConfig *Config
+		want         time.Duration
+	}{
+		{name: "off when no config", want: 0},
+		{name: "off when invalid", globalConfig: &Config{AgentProbeInterval: "often"}, want: 0},
+		{name: "off when negative", globalConfig: &Config{AgentProbeInterval: "-1h"}, want: 0},
+		{name: "zero disables", globalConfig: &Config{AgentProbeInterval: "0"}, want: 0},
+		{name: "configured", globalConfig: &Config{AgentProbeInterval: "90m"}, want: 90 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ResolveAgentProbeInterval(tt.globalConfig))
+		})
+	}
+}
+
 func TestResolveAutoClosePassingReviews(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -669,6 +701,27 @@ func TestResolveAutoClosePassingReviews(t *testing.T) {
 	}
 }
 
+func TestResolveResolutionCheck(t *testing.T) {
+	assert.False(t, ResolveResolutionCheck(newTempRepo(t, ""), nil))
+	assert.True(t, ResolveResolutionCheck(newTempRepo(t, ""), &Config{ResolutionCheck: true}))
+	assert.False(t, ResolveResolutionCheck(newTempRepo(t, `resolution_check = false`), &Config{ResolutionCheck: true}))
+
+	repo := newTempRepo(t, `resolution_check = true
+resolution_check_agent = "gemini"`)
+	assert.True(t, ResolveResolutionCheck(repo, nil))
+	global := &Config{DefaultAgent: "codex", ResolutionCheckModel: "gemini-flash"}
+	assert.Equal(t, "gemini", ResolveAgentForWorkflow("", repo, global, "resolution_check", "fast"))
+	assert.Equal(t, "gemini-flash", ResolveModelForWorkflow("", repo, global, "resolution_check", "fast"))
+}
+
+func TestResolveReviewCache(t *testing.T) {
+	off := false
+	assert.True(t, ResolveReviewCache(newTempRepo(t, ""), nil))
+	assert.False(t, ResolveReviewCache(newTempRepo(t, ""), &Config{ReviewCache: &off}))
+	assert.True(t, ResolveReviewCache(newTempRepo(t, `review_cache = true`), &Config{ReviewCache: &off}))
+	assert.False(t, ResolveReviewCache(newTempRepo(t, `review_cache = false`), nil))
+}
+
 func TestResolveShowClassifyJobs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2393,6 +2446,57 @@ func TestResolveBackupAgentForWorkflow(t *testing.T) {
 	}
 }
 
+func TestResolveBackupChainForWorkflowFromConfig(t *testing.T) {
+	repo := &RepoConfig{ReviewBackupAgent: "gemini", BackupAgent: "codex", BackupModel: "gpt-5"}
+	global := &Config{ReviewBackupAgent: "codex", DefaultBackupAgent: "claude-code", DefaultBackupModel: "sonnet"}
+	assert.Equal(t, []FallbackTarget{
+		// The first backup keeps the workflow backup model resolution.
+		{Agent: "gemini", Model: "gpt-5"},
+		{Agent: "codex", Model: "gpt-5"},
+		// Entries are distinct by agent and model.
+		{Agent: "codex"},
+		{Agent: "claude-code", Model: "sonnet"},
+	}, ResolveBackupChainForWorkflowFromConfig(repo, global, "review"))
+	assert.Equal(t, []FallbackTarget{
+		{Agent: "codex", Model: "gpt-5"},
+		{Agent: "claude-code", Model: "sonnet"},
+	}, ResolveBackupChainForWorkflowFromConfig(repo, global, "fix"))
+	assert.Empty(t, ResolveBackupChainForWorkflowFromConfig(nil, nil, "review"))
+
+	// Fallbacks lists come first at their level and carry their own models.
+	repo.ReviewFallbacks = []string{"codex:gpt-5-mini", " ", "opencode:anthropic/claude:latest"}
+	global.ReviewFallbacks = []string{"gemini", "droid"}
+	assert.Equal(t, []FallbackTarget{
+		{Agent: "codex", Model: "gpt-5-mini"},
+		{Agent: "opencode", Model: "anthropic/claude:latest"},
+		{Agent: "gemini"},
+		{Agent: "codex", Model: "gpt-5"},
+		{Agent: "droid"},
+		{Agent: "codex"},
+		{Agent: "claude-code", Model: "sonnet"},
+	}, ResolveBackupChainForWorkflowFromConfig(repo, global, "review"))
+	assert.Equal(t, "codex", ResolveBackupAgentForWorkflowFromConfig(repo, global, "review"))
+	assert.Equal(t, "gpt-5-mini", ResolveBackupModelForWorkflowFromConfig(repo, global, "review"))
+}
+
+func TestParseFallbackTarget(t *testing.T) {
+	for spec, want := range map[string]FallbackTarget{
+		"gemini":           {Agent: "gemini"},
+		" codex : gpt-5 ":  {Agent: "codex", Model: "gpt-5"},
+		"ollama:qwen3:30b": {Agent: "ollama", Model: "qwen3:30b"},
+	} {
+		got, ok := ParseFallbackTarget(spec)
+		assert.True(t, ok, spec)
+		assert.Equal(t, want, got, spec)
+	}
+	for _, spec := range []string{"", "  ", ":gpt-5"} {
+		_, ok := ParseFallbackTarget(spec)
+		assert.False(t, ok, spec)
+	}
+	assert.Equal(t, "codex:gpt-5", FallbackTarget{Agent: "codex", Model: "gpt-5"}.String())
+	assert.Equal(t, "gemini", FallbackTarget{Agent: "gemini"}.String())
+}
+
 func TestResolveBackupModelForWorkflow(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3592,6 +3696,12 @@ func TestResolvePostCommitReview(t *testing.T) {
 	}
 }
 
+func TestResolveIncrementalReview(t *testing.T) {
+	assert.False(t, ResolveIncrementalReview(t.TempDir()))
+	assert.False(t, ResolveIncrementalReview(newTempRepo(t, `post_commit_review = "branch"`)))
+	assert.True(t, ResolveIncrementalReview(newTempRepo(t, "post_commit_review = \"branch\"\nincremental_review = true")))
+}
+
 func TestResolveReuseReviewSession(t *testing.T) {
 	boolTrue := true
 	boolFalse := false
@@ -3864,6 +3974,25 @@ func TestResolveCIIncludeCosts(t *testing.T) {
 	})
 }
 
+func TestResolveCIFallbacks(t *testing.T) {
+	assert.Empty(t, ResolveCIFallbacks(nil, &Config{}))
+	globalCfg := &Config{CI: CIConfig{Fallbacks: []string{"codex:gpt-5", "gemini"}}}
+	assert.Equal(t, []string{"codex:gpt-5", "gemini"}, ResolveCIFallbacks(nil, globalCfg))
+	repoCfg := &RepoConfig{CI: RepoCIConfig{Fallbacks: []string{" claude-code : sonnet "}}}
+	assert.Equal(t, []string{"claude-code:sonnet"}, ResolveCIFallbacks(repoCfg, globalCfg))
+}
+
+func TestResolveCIIncrementalReview(t *testing.T) {
+	assert.False(t, ResolveCIIncrementalReview(nil, &Config{}))
+
+	globalCfg := &Config{CI: CIConfig{IncrementalReview: true}}
+	assert.True(t, ResolveCIIncrementalReview(nil, globalCfg))
+
+	repoFalse := false
+	repoCfg := &RepoConfig{CI: RepoCIConfig{IncrementalReview: &repoFalse}}
+	assert.False(t, ResolveCIIncrementalReview(repoCfg, globalCfg))
+}
+
 func TestIsThrottleBypassed(t *testing.T) {
 	ci := CIConfig{
 		ThrottleBypassUsers: []string{"wesm", "mariusvniekerk"},
@@ -0,0 +1,195 @@
+// SMTP email and scheduled digest configuration.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP TLS modes.
+const (
+	SMTPTLSStartTLS = "starttls"
+	SMTPTLSImplicit = "tls"
+	SMTPTLSNone     = "none"
+)
+
+// SMTPConfig configures the outgoing mail server used by email hooks and
+// digests.
+type SMTPConfig struct {
+	Host     string `toml:"host" comment:"SMTP server host. Email hooks and digests are disabled when empty."`
+	Port     int    `toml:"port" comment:"SMTP server port. 0 uses 587, or 465 when tls = \"tls\"."`
+	Username string `toml:"username" comment:"SMTP username; empty sends without authentication."`
+	// Password supports environment variable expansion via ${VAR} syntax.
+	Password string `toml:"password" sensitive:"true" comment:"SMTP password. Supports ${ENV_VAR}."`
+	From     string `toml:"from" comment:"From address, e.g. \"roborev <roborev@example.com>\"."`
+	TLS      string `toml:"tls" comment:"TLS mode: starttls (default), tls for implicit TLS, or none for a local relay."`
+}
+
+// Enabled reports whether a mail server is configured.
+func (c SMTPConfig) Enabled() bool {
+	return strings.TrimSpace(c.Host) != ""
+}
+
+// ResolvedTLS returns the TLS mode, defaulting to STARTTLS.
+func (c SMTPConfig) ResolvedTLS() string {
+	switch strings.ToLower(strings.TrimSpace(c.TLS)) {
+	case SMTPTLSImplicit:
+		return SMTPTLSImplicit
+	case SMTPTLSNone:
+		return SMTPTLSNone
+	default:
+		return SMTPTLSStartTLS
+	}
+}
+
+// Addr returns host:port with the port defaulted for the TLS mode.
+func (c SMTPConfig) Addr() string {
+	port := c.Port
+	if port <= 0 {
+		port = 587
+		if c.ResolvedTLS() == SMTPTLSImplicit {
+			port = 465
+		}
+	}
+	return net.JoinHostPort(strings.TrimSpace(c.Host), strconv.Itoa(port))
+}
+
+// PasswordExpanded returns the password with environment variables expanded.
+func (c SMTPConfig) PasswordExpanded() string {
+	return os.ExpandEnv(c.Password)
+}
+
+// Digest schedules and groupings.
+const (
+	DigestDaily        = "daily"
+	DigestWeekly       = "weekly"
+	DigestGroupRepo    = "repo"
+	DigestGroupAuthor  = "author"
+	defaultDigestClock = "09:00"
+)
+
+// DigestConfig schedules one email digest. Each run covers the period since
+// the previous run (one day or one week) and sends one email per repo, or per
+// commit author, with activity in that period.
+type DigestConfig struct {
+	Name     string   `toml:"name"`     // identifies the digest's schedule state; defaults to schedule/group_by
+	Schedule string   `toml:"schedule"` // "daily" or "weekly"
+	Weekday  string   `toml:"weekday"`  // weekly: day to send (default "monday")
+	Time     string   `toml:"time"`     // local time of day to send, "HH:MM" (default "09:00")
+	GroupBy  string   `toml:"group_by"` // "repo" (default) or "author"
+	Repos    []string `toml:"repos"`    // repo globs on path or name; empty = all repos
+	To       []string `toml:"to"`       // recipients of every email
+	// AuthorEmails maps commit author names to addresses. With group_by =
+	// "author", each author's digest also goes to their mapped address.
+	AuthorEmails map[string]string `toml:"author_emails"`
+}
+
+// Key returns the name under which the digest's last run is recorded.
+func (d DigestConfig) Key() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.ResolvedSchedule() + "/" + d.ResolvedGroupBy()
+}
+
+// ResolvedSchedule returns "daily" or "weekly", defaulting to daily.
+func (d DigestConfig) ResolvedSchedule() string {
+	if strings.EqualFold(strings.TrimSpace(d.Schedule), DigestWeekly) {
+		return DigestWeekly
+	}
+	return DigestDaily
+}
+
+// ResolvedGroupBy returns "repo" or "author", defaulting to repo.
+func (d DigestConfig) ResolvedGroupBy() string {
+	if strings.EqualFold(strings.TrimSpace(d.GroupBy), DigestGroupAuthor) {
+		return DigestGroupAuthor
+	}
+	return DigestGroupRepo
+}
+
+// Period returns how much history one run covers.
+func (d DigestConfig) Period() time.Duration {
+	if d.ResolvedSchedule() == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Validate reports configuration errors for the digest.
+func (d DigestConfig) Validate() error {
+	switch strings.ToLower(strings.TrimSpace(d.Schedule)) {
+	case "", DigestDaily, DigestWeekly:
+	default:
+		return fmt.Errorf("digest schedule %q must be daily or weekly", d.Schedule)
+	}
+	switch strings.ToLower(strings.TrimSpace(d.GroupBy)) {
+	case "", DigestGroupRepo, DigestGroupAuthor:
+	default:
+		return fmt.Errorf("digest group_by %q must be repo or author", d.GroupBy)
+	}
+	if _, _, err := d.clock(); err != nil {
+		return err
+	}
+	if _, err := d.weekday(); err != nil {
+		return err
+	}
+	if len(d.To) == 0 && (d.ResolvedGroupBy() != DigestGroupAuthor || len(d.AuthorEmails) == 0) {
+		return fmt.Errorf("digest %q has no recipients: set to, or author_emails with group_by = \"author\"", d.Key())
+	}
+	return nil
+}
+
+// NextRun returns the first scheduled send time strictly after t, in t's
+// location.
+func (d DigestConfig) NextRun(t time.Time) time.Time {
+	hour, minute, err := d.clock()
+	if err != nil {
+		hour, minute = 9, 0
+	}
+	next := time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	if d.ResolvedSchedule() == DigestWeekly {
+		day, err := d.weekday()
+		if err != nil {
+			day = time.Monday
+		}
+		for next.Weekday() != day {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+	return next
+}
+
+func (d DigestConfig) clock() (int, int, error) {
+	clock := strings.TrimSpace(d.Time)
+	if clock == "" {
+		clock = defaultDigestClock
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, fmt.Errorf("digest time %q must be HH:MM", d.Time)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func (d DigestConfig) weekday() (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(d.Weekday))
+	if name == "" {
+		return time.Monday, nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("digest weekday %q is not a day of the week", d.Weekday)
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPConfigAddr(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("mail.example.com:587", SMTPConfig{Host: "mail.example.com"}.Addr())
+	assert.Equal("mail.example.com:465", SMTPConfig{Host: "mail.example.com", TLS: "tls"}.Addr())
+	assert.Equal("127.0.0.1:2525", SMTPConfig{Host: "127.0.0.1", Port: 2525, TLS: "none"}.Addr())
+	assert.Equal(SMTPTLSStartTLS, SMTPConfig{TLS: "bogus"}.ResolvedTLS())
+
+	t.Setenv("ROBOREV_TEST_SMTP_PASSWORD", "s3cret")
+	assert.Equal("s3cret", SMTPConfig{Password: "${ROBOREV_TEST_SMTP_PASSWORD}"}.PasswordExpanded())
+}
+
+func TestDigestConfigNextRun(t *testing.T) {
+	loc := time.UTC
+	// Wednesday 2026-10-14 08:00
+	wed8 := time.Date(2026, 10, 14, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		digest DigestConfig
+		after  time.Time
+		want   time.Time
+	}{
+		{"daily later today", DigestConfig{}, wed8, time.Date(2026, 10, 14, 9, 0, 0, 0, loc)},
+		{"daily at the send time rolls over", DigestConfig{Time: "08:00"}, wed8, time.Date(2026, 10, 15, 8, 0, 0, 0, loc)},
+		{"weekly default monday", DigestConfig{Schedule: "weekly"}, wed8, time.Date(2026, 10, 19, 9, 0, 0, 0, loc)},
+		{"weekly same day later", DigestConfig{Schedule: "weekly", Weekday: "wed", Time: "17:30"}, wed8, time.Date(2026, 10, 14, 17, 30, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.digest.NextRun(tt.after))
+		})
+	}
+}
+
+func TestDigestConfigValidate(t *testing.T) {
+	require.NoError(t, DigestConfig{To: []string{"a@example.com"}}.Validate())
+	require.NoError(t, DigestConfig{
+		GroupBy: "author", AuthorEmails: map[string]string{"Alice": "alice@example.com"},
+	}.Validate())
+
+	for _, d := range []DigestConfig{
+		{Schedule: "hourly", To: []string{"a@example.com"}},
+		{GroupBy: "team", To: []string{"a@example.com"}},
+		{Time: "9am", To: []string{"a@example.com"}},
+		{Schedule: "weekly", Weekday: "someday", To: []string{"a@example.com"}},
+		{},
+	} {
+		assert.Error(t, d.Validate(), "%+v", d)
+	}
+	assert.Equal(t, "weekly/author", DigestConfig{Schedule: "weekly", GroupBy: "author"}.Key())
+}
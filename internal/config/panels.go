@@ -23,19 +23,73 @@ type SubagentSpec struct {
 	Instructions string `toml:"instructions"`
 	AllowFailure bool   `toml:"allow_failure"`
 	Timeout      string `toml:"timeout"`
+	// Fallbacks is the member's ordered failover chain, as "agent" or
+	// "agent:model" entries.
+	Fallbacks []string `toml:"fallbacks"`
 }
 
 // PanelSpec is a named set of subagent members plus an optional synthesis
 // agent/model. Synthesis falls back to the fix-workflow resolution when unset.
 // The synthesis backup agent/model are an explicit opt-in: they pass straight
 // through to the synthesis job's stored failover backup, with no resolution or
-// fallback.
+// fallback. SynthesisStrategy selects how member reviews are combined (see
+// NormalizeSynthesisStrategy); SynthesisQuorum is the member count a finding
+// needs under the majority strategy, defaulting to more than half.
 type PanelSpec struct {
 	Members              []string `toml:"members"`
 	SynthesisAgent       string   `toml:"synthesis_agent"`
 	SynthesisModel       string   `toml:"synthesis_model"`
 	SynthesisBackupAgent string   `toml:"synthesis_backup_agent"`
 	SynthesisBackupModel string   `toml:"synthesis_backup_model"`
+	SynthesisStrategy    string   `toml:"synthesis_strategy"`
+	SynthesisQuorum      int      `toml:"synthesis_quorum"`
+}
+
+// Panel synthesis strategies. The llm strategy (the default) asks the
+// synthesis agent to verify and merge member reviews; the others combine
+// member findings deterministically without an agent call.
+const (
+	SynthesisStrategyLLM       = "llm"
+	SynthesisStrategyUnion     = "union"
+	SynthesisStrategyMajority  = "majority"
+	SynthesisStrategyStrictest = "strictest"
+	// SynthesisStrategyChunks merges the file-group reviews of a chunked
+	// review. It is set by the daemon and not accepted in panel config.
+	SynthesisStrategyChunks = "chunks"
+)
+
+// NormalizeSynthesisStrategy validates a synthesis_strategy value and returns
+// its canonical form. Empty means the llm default.
+func NormalizeSynthesisStrategy(value string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	switch normalized {
+	case "":
+		return SynthesisStrategyLLM, nil
+	case SynthesisStrategyLLM, SynthesisStrategyUnion,
+		SynthesisStrategyMajority, SynthesisStrategyStrictest:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("invalid synthesis_strategy: %q (valid: llm, union, majority, strictest)", value)
+	}
+}
+
+// validateSynthesisStrategy checks a panel's synthesis_strategy and
+// synthesis_quorum against each other and the panel's member count.
+func validateSynthesisStrategy(panel PanelSpec) error {
+	strategy, err := NormalizeSynthesisStrategy(panel.SynthesisStrategy)
+	if err != nil {
+		return err
+	}
+	switch {
+	case panel.SynthesisQuorum == 0:
+		return nil
+	case strategy != SynthesisStrategyMajority:
+		return fmt.Errorf("synthesis_quorum requires synthesis_strategy = %q", SynthesisStrategyMajority)
+	case panel.SynthesisQuorum < 0 || panel.SynthesisQuorum > len(panel.Members):
+		return fmt.Errorf("synthesis_quorum %d must be between 1 and the member count (%d)",
+			panel.SynthesisQuorum, len(panel.Members))
+	}
+	return nil
 }
 
 // ReviewConfig holds the [review] table: panel selection defaults plus the
@@ -46,17 +100,22 @@ type ReviewConfig struct {
 	HookPanel    string                  `toml:"hook_review_panel"`
 	Subagents    map[string]SubagentSpec `toml:"subagents"`
 	Panels       map[string]PanelSpec    `toml:"panels"`
+	Routes       []ReviewRoute           `toml:"routes"`
+	Chunking     ChunkingConfig          `toml:"chunking"`
 }
 
 // MergeReviewConfig returns the effective review config: the subagent and
 // panel maps are the union of global and repo with repo keys overriding global
-// keys; DefaultPanel and HookPanel are repo-over-global. Inputs are not mutated.
+// keys; DefaultPanel, HookPanel and chunking settings are repo-over-global;
+// repo routes are tried before global routes. Inputs are not mutated.
 func MergeReviewConfig(repo, global ReviewConfig) ReviewConfig {
 	merged := ReviewConfig{
 		DefaultPanel: resolve("", repo.DefaultPanel, global.DefaultPanel),
 		HookPanel:    resolve("", repo.HookPanel, global.HookPanel),
 		Subagents:    make(map[string]SubagentSpec, len(global.Subagents)+len(repo.Subagents)),
 		Panels:       make(map[string]PanelSpec, len(global.Panels)+len(repo.Panels)),
+		Routes:       slices.Concat(repo.Routes, global.Routes),
+		Chunking:     mergeChunking(repo.Chunking, global.Chunking),
 	}
 	maps.Copy(merged.Subagents, global.Subagents)
 	maps.Copy(merged.Subagents, repo.Subagents)
@@ -84,8 +143,9 @@ func MergedReviewConfig(repoPath string, globalCfg *Config) ReviewConfig {
 }
 
 // Validate reports every cross-reference problem in the review config: panels
-// with no members, panel members that name an undefined subagent, and
-// DefaultPanel/HookPanel that name an undefined panel. It aggregates all
+// with no members, panel members that name an undefined subagent, invalid
+// synthesis strategies, DefaultPanel/HookPanel that name an undefined
+// panel, and invalid routes. It aggregates all
 // problems into one error (deterministic, panel-name-sorted order) rather than
 // failing on the first, and returns nil when clean.
 func (rc ReviewConfig) Validate() error {
@@ -101,9 +161,13 @@ func (rc ReviewConfig) Validate() error {
 				errs = append(errs, fmt.Errorf("panel %q references undefined subagent %q", name, member))
 			}
 		}
+		if err := validateSynthesisStrategy(panel); err != nil {
+			errs = append(errs, fmt.Errorf("panel %q: %w", name, err))
+		}
 	}
 	errs = append(errs, rc.checkPanelRef("default_panel", rc.DefaultPanel))
 	errs = append(errs, rc.checkPanelRef("hook_review_panel", rc.HookPanel))
+	errs = append(errs, rc.validateRoutes())
 	return errors.Join(errs...)
 }
 
@@ -165,17 +229,37 @@ type ResolvedMember struct {
 	Instructions  string `json:"instructions"`
 	AllowFailure  bool   `json:"allow_failure,omitempty"`
 	Timeout       string `json:"timeout,omitempty"`
+	// Paths limits the member to one file group of a chunked review.
+	Paths []string `json:"paths,omitempty"`
+	// Fallbacks is the member's ordered failover chain, as normalized
+	// "agent" or "agent:model" entries.
+	Fallbacks []string `json:"fallbacks,omitempty"`
 }
 
 // SynthesisSpec is the resolved agent/model/reasoning for a panel's synthesis
 // job. BackupAgent/BackupModel are the explicit synthesis failover backup,
 // passed through verbatim from the panel spec (no resolution or fallback).
+// Strategy and Quorum are frozen onto the synthesis job so a config change
+// after enqueue does not change how the run is combined.
 type SynthesisSpec struct {
 	Agent       string `json:"agent"`
 	Model       string `json:"model"`
 	Reasoning   string `json:"reasoning"`
 	BackupAgent string `json:"backup_agent"`
 	BackupModel string `json:"backup_model"`
+	Strategy    string `json:"strategy,omitempty"`
+	Quorum      int    `json:"quorum,omitempty"`
+}
+
+// Deterministic reports whether the strategy combines member reviews
+// without a synthesis agent call.
+func (s SynthesisSpec) Deterministic() bool {
+	switch s.Strategy {
+	case SynthesisStrategyUnion, SynthesisStrategyMajority, SynthesisStrategyStrictest:
+		return true
+	default:
+		return false
+	}
 }
 
 // ResolvePanel resolves a named panel into ordered members and a synthesis
@@ -346,6 +430,7 @@ func resolveMemberFromConfig(
 		Instructions:  spec.Instructions,
 		AllowFailure:  spec.AllowFailure,
 		Timeout:       spec.Timeout,
+		Fallbacks:     FormatFallbacks(ParseFallbacks(spec.Fallbacks)),
 	}, nil
 }
 
@@ -399,10 +484,16 @@ func resolveSynthesisFromConfig(
 			model = ResolveModelForWorkflowFromConfig("", repoCfg, globalCfg, "fix", reasoning)
 		}
 	}
+	if err := validateSynthesisStrategy(panel); err != nil {
+		return SynthesisSpec{}, err
+	}
+	strategy, _ := NormalizeSynthesisStrategy(panel.SynthesisStrategy)
 	return SynthesisSpec{
 		Agent: agent, Model: model, Reasoning: reasoning,
 		BackupAgent: panel.SynthesisBackupAgent,
 		BackupModel: panel.SynthesisBackupModel,
+		Strategy:    strategy,
+		Quorum:      panel.SynthesisQuorum,
 	}, nil
 }
 
@@ -202,6 +202,7 @@ func TestResolvePanel(t *testing.T) {
 					Instructions: "Focus on authz.",
 					AllowFailure: true,
 					Timeout:      "3m",
+					Fallbacks:    []string{"codex: gpt-5", "", "gemini"},
 				},
 			},
 			Panels: map[string]PanelSpec{
@@ -240,6 +241,8 @@ func TestResolvePanel(t *testing.T) {
 	assert.Equal("Focus on authz.", members[1].Instructions)
 	assert.True(members[1].AllowFailure)
 	assert.Equal("3m", members[1].Timeout)
+	assert.Equal([]string{"codex:gpt-5", "gemini"}, members[1].Fallbacks)
+	assert.Empty(members[0].Fallbacks)
 
 	// Synthesis from the panel's explicit fields; reasoning is the fix default.
 	assert.Equal("codex", synth.Agent)
@@ -393,6 +396,54 @@ func TestResolveSynthesisBackupPassThrough(t *testing.T) {
 	assert.Empty(ciSynthNone.BackupModel)
 }
 
+func TestResolveSynthesisStrategy(t *testing.T) {
+	assert := assert.New(t)
+	cfg := &Config{
+		ReviewAgent: "codex",
+		Review: ReviewConfig{
+			Subagents: map[string]SubagentSpec{"a": {}, "b": {}, "c": {}},
+			Panels: map[string]PanelSpec{
+				"default_llm": {Members: []string{"a", "b"}},
+				"voting": {
+					Members:           []string{"a", "b", "c"},
+					SynthesisStrategy: " Majority ",
+					SynthesisQuorum:   2,
+				},
+				"strict":     {Members: []string{"a", "b"}, SynthesisStrategy: "strictest"},
+				"bad":        {Members: []string{"a"}, SynthesisStrategy: "consensus"},
+				"bad_quorum": {Members: []string{"a", "b"}, SynthesisStrategy: "majority", SynthesisQuorum: 3},
+				"union_q":    {Members: []string{"a", "b"}, SynthesisStrategy: "union", SynthesisQuorum: 1},
+			},
+		},
+	}
+
+	_, synth, err := ResolvePanel("default_llm", "", cfg)
+	require.NoError(t, err)
+	assert.Equal(SynthesisStrategyLLM, synth.Strategy)
+	assert.False(synth.Deterministic())
+
+	_, synth, err = ResolvePanel("voting", "", cfg)
+	require.NoError(t, err)
+	assert.Equal(SynthesisStrategyMajority, synth.Strategy)
+	assert.Equal(2, synth.Quorum)
+	assert.True(synth.Deterministic())
+
+	_, synth, err = ResolveCIPanel("strict", &RepoConfig{Review: cfg.Review}, &Config{})
+	require.NoError(t, err)
+	assert.Equal(SynthesisStrategyStrictest, synth.Strategy)
+
+	_, _, err = ResolvePanel("bad", "", cfg)
+	require.ErrorContains(t, err, `invalid synthesis_strategy: "consensus"`)
+
+	err = cfg.Review.Validate()
+	require.Error(t, err)
+	msg := err.Error()
+	assert.Contains(msg, `panel "bad": invalid synthesis_strategy`)
+	assert.Contains(msg, `panel "bad_quorum": synthesis_quorum 3 must be between 1 and the member count (2)`)
+	assert.Contains(msg, `panel "union_q": synthesis_quorum requires synthesis_strategy = "majority"`)
+	assert.NotContains(msg, `panel "voting"`)
+}
+
 func TestResolveCIPanelUsesProvidedConfigNotWorkingTree(t *testing.T) {
 	repoCfg := &RepoConfig{Review: ReviewConfig{
 		Panels:    map[string]PanelSpec{"ci": {Members: []string{"a"}, SynthesisAgent: "codex"}},
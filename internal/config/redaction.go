@@ -0,0 +1,35 @@
+package config
+
+// RedactionConfig controls masking of secrets before prompts, diffs and
+// review output leave the machine.
+type RedactionConfig struct {
+	// Enabled defaults to true; set false to send content verbatim.
+	Enabled *bool `toml:"enabled" comment:"Mask secrets in prompts, stored diffs, exports and PR comments. Default true."`
+	// Disable lists built-in detectors to turn off, e.g. "high-entropy".
+	Disable []string `toml:"disable" comment:"Built-in detectors to turn off: aws-access-key, aws-secret-key, github-token, private-key, slack-token, stripe-key, google-api-key, model-api-key, high-entropy."`
+	// Patterns are extra regular expressions. A pattern with a capture
+	// group masks only the first group.
+	Patterns []string `toml:"patterns" comment:"Extra regular expressions to mask. With a capture group, only the first group is masked."`
+}
+
+// IsEnabled reports whether redaction runs.
+func (c RedactionConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// ResolveRedaction returns the global redaction settings with the repo's
+// redact_patterns appended. Repo config can add patterns but cannot disable
+// redaction or its detectors.
+func ResolveRedaction(repoPath string, globalCfg *Config) RedactionConfig {
+	var cfg RedactionConfig
+	if globalCfg != nil {
+		cfg = globalCfg.Redaction
+		cfg.Patterns = append([]string(nil), cfg.Patterns...)
+	}
+	if repoPath != "" {
+		if repoCfg, err := LoadRepoConfig(repoPath); err == nil && repoCfg != nil {
+			cfg.Patterns = append(cfg.Patterns, repoCfg.RedactPatterns...)
+		}
+	}
+	return cfg
+}
@@ -0,0 +1,175 @@
+// Per-path review routing.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ReviewRoute is one [[review.routes]] entry. Routes are tried in order and
+// the first whose matchers all hold picks the review settings. Within one
+// matcher kind any entry may match; a route with no matchers matches every
+// target and works as a catch-all at the end of the list. Empty settings
+// leave the normal resolution in place.
+type ReviewRoute struct {
+	Name            string   `toml:"name" comment:"Label shown on jobs that take this route."`
+	Paths           []string `toml:"paths" comment:"Doublestar globs; the route matches when any changed file matches."`
+	Authors         []string `toml:"authors" comment:"Globs over commit author names (and the PR author login for CI), case-insensitive."`
+	MessagePatterns []string `toml:"message_patterns" comment:"Regexes over the commit messages; the route matches when any message matches."`
+
+	Panel       string `toml:"panel" comment:"Panel to run. none forces a single-agent review."`
+	ReviewType  string `toml:"review_type" comment:"Review type: default, security, or design."`
+	Reasoning   string `toml:"reasoning" comment:"Reasoning level: fast, standard, medium, thorough, or maximum."`
+	MinSeverity string `toml:"min_severity" comment:"Minimum severity: critical, high, medium, or low."`
+}
+
+// Label names the route for display: its name, or its position when unnamed.
+func (r ReviewRoute) Label(index int) string {
+	if name := strings.TrimSpace(r.Name); name != "" {
+		return name
+	}
+	return fmt.Sprintf("route %d", index+1)
+}
+
+// RouteTarget is what a route is matched against: the files a review covers,
+// the commit authors, and the commit messages. Dirty reviews have no authors
+// or messages, so routes that require them never match one.
+type RouteTarget struct {
+	Files    []string
+	Authors  []string
+	Messages []string
+}
+
+// SelectedRoute is a matched route with its settings normalized.
+type SelectedRoute struct {
+	Label       string
+	Panel       string
+	ReviewType  string
+	Reasoning   string
+	MinSeverity string
+}
+
+// MatchRoute returns the first route matching target, or nil when none
+// does. Config loading rejects invalid routes; one that still reaches here
+// is reported as an error rather than skipped, so a typo cannot silently
+// send security-sensitive paths down the default path.
+func (rc ReviewConfig) MatchRoute(target RouteTarget) (*SelectedRoute, error) {
+	for i, route := range rc.Routes {
+		ok, err := route.matches(target)
+		if err != nil {
+			return nil, fmt.Errorf("review route %q: %w", route.Label(i), err)
+		}
+		if !ok {
+			continue
+		}
+		selected, err := route.normalize(i)
+		if err != nil {
+			return nil, fmt.Errorf("review route %q: %w", route.Label(i), err)
+		}
+		return selected, nil
+	}
+	return nil, nil
+}
+
+// matches reports whether every matcher the route sets holds for target.
+// All globs and patterns are checked even when an earlier one decides the
+// result, so a bad entry is reported regardless of the target.
+func (r ReviewRoute) matches(target RouteTarget) (bool, error) {
+	paths, err := anyGlobMatch(r.Paths, target.Files, false)
+	if err != nil {
+		return false, err
+	}
+	authors, err := anyGlobMatch(r.Authors, target.Authors, true)
+	if err != nil {
+		return false, err
+	}
+	messages := false
+	for _, pattern := range r.MessagePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid message pattern %q: %w", pattern, err)
+		}
+		messages = messages || slices.ContainsFunc(target.Messages, re.MatchString)
+	}
+	return (len(r.Paths) == 0 || paths) &&
+		(len(r.Authors) == 0 || authors) &&
+		(len(r.MessagePatterns) == 0 || messages), nil
+}
+
+// anyGlobMatch reports whether any value matches any pattern.
+func anyGlobMatch(patterns, values []string, foldCase bool) (bool, error) {
+	matched := false
+	for _, p := range patterns {
+		if foldCase {
+			p = strings.ToLower(p)
+		}
+		if !doublestar.ValidatePattern(p) {
+			return false, fmt.Errorf("invalid glob %q", p)
+		}
+		for _, v := range values {
+			if foldCase {
+				v = strings.ToLower(v)
+			}
+			if ok, _ := doublestar.Match(p, v); ok {
+				matched = true
+			}
+		}
+	}
+	return matched, nil
+}
+
+func (r ReviewRoute) normalize(index int) (*SelectedRoute, error) {
+	selected := &SelectedRoute{Label: r.Label(index), Panel: strings.TrimSpace(r.Panel)}
+	var err error
+	if strings.TrimSpace(r.ReviewType) != "" {
+		canonical, vErr := ValidateReviewTypes([]string{r.ReviewType})
+		if vErr != nil {
+			return nil, vErr
+		}
+		selected.ReviewType = canonical[0]
+	}
+	if selected.Reasoning, err = NormalizeReasoning(r.Reasoning); err != nil {
+		return nil, err
+	}
+	if selected.MinSeverity, err = NormalizeMinSeverity(r.MinSeverity); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// validateRouteEntries reports routes with bad globs, patterns or settings.
+// It needs no other config, so config loading runs it on each file.
+func (rc ReviewConfig) validateRouteEntries() error {
+	var errs []error
+	for i, route := range rc.Routes {
+		label := route.Label(i)
+		if _, err := route.matches(RouteTarget{}); err != nil {
+			errs = append(errs, fmt.Errorf("review route %q: %w", label, err))
+		}
+		if _, err := route.normalize(i); err != nil {
+			errs = append(errs, fmt.Errorf("review route %q: %w", label, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateRoutes reports routes with bad globs, patterns or settings, and
+// routes naming an undefined panel.
+func (rc ReviewConfig) validateRoutes() error {
+	errs := []error{rc.validateRouteEntries()}
+	for i, route := range rc.Routes {
+		label := route.Label(i)
+		if panel := strings.TrimSpace(route.Panel); panel != "" && panel != PanelNone {
+			if _, ok := rc.Panels[panel]; !ok {
+				errs = append(errs, fmt.Errorf("review route %q: panel %q is not defined", label, panel))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRoute(t *testing.T) {
+	rc := ReviewConfig{Routes: []ReviewRoute{
+		{Name: "crypto", Paths: []string{"crypto/**"}, Panel: "security", Reasoning: "max"},
+		{Name: "bots", Authors: []string{"dependabot*"}, MessagePatterns: []string{`^chore\(deps\)`}, MinSeverity: "High"},
+		{Paths: []string{"docs/**", "*.md"}, Reasoning: "fast"},
+	}}
+
+	tests := []struct {
+		name   string
+		target RouteTarget
+		want   *SelectedRoute
+	}{
+		{
+			name:   "first matching path wins",
+			target: RouteTarget{Files: []string{"README.md", "crypto/aes/gcm.go"}},
+			want:   &SelectedRoute{Label: "crypto", Panel: "security", Reasoning: "maximum"},
+		},
+		{
+			name: "all matcher kinds must hold",
+			target: RouteTarget{
+				Files: []string{"go.mod"}, Authors: []string{"Dependabot[bot]"},
+				Messages: []string{"chore(deps): bump x"},
+			},
+			want: &SelectedRoute{Label: "bots", MinSeverity: "high"},
+		},
+		{
+			name:   "author without message does not match",
+			target: RouteTarget{Files: []string{"go.mod"}, Authors: []string{"dependabot[bot]"}},
+		},
+		{
+			name:   "unnamed route labeled by position",
+			target: RouteTarget{Files: []string{"docs/guide/intro.md"}},
+			want:   &SelectedRoute{Label: "route 3", Reasoning: "fast"},
+		},
+		{
+			name:   "no match",
+			target: RouteTarget{Files: []string{"main.go"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rc.MatchRoute(tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchRouteReportsInvalidRoutes(t *testing.T) {
+	rc := ReviewConfig{Routes: []ReviewRoute{
+		{Name: "bad", Paths: []string{"src/**"}, MessagePatterns: []string{"("}},
+	}}
+	_, err := rc.MatchRoute(RouteTarget{Files: []string{"main.go"}})
+	require.ErrorContains(t, err, `review route "bad": invalid message pattern`)
+}
+
+func TestReviewConfigValidateRoutes(t *testing.T) {
+	rc := ReviewConfig{
+		Subagents: map[string]SubagentSpec{"default": {Agent: "codex"}},
+		Panels:    map[string]PanelSpec{"quick": {Members: []string{"default"}}},
+		Routes: []ReviewRoute{
+			{Name: "ok", Paths: []string{"a/**"}, Panel: "quick"},
+			{Name: "none", Panel: PanelNone},
+			{Name: "missing", Panel: "secure"},
+			{Name: "glob", Paths: []string{"a/[b"}},
+			{Name: "level", Reasoning: "extreme", ReviewType: "style"},
+		},
+	}
+	err := rc.Validate()
+	require.Error(t, err)
+	msg := err.Error()
+	assert.Contains(t, msg, `review route "missing": panel "secure" is not defined`)
+	assert.Contains(t, msg, `review route "glob": invalid glob "a/[b"`)
+	assert.Contains(t, msg, `review route "level"`)
+	assert.NotContains(t, msg, `"ok"`)
+	assert.NotContains(t, msg, `review route "none"`)
+}
+
+func TestConfigLoadRejectsInvalidRoutesAndHookConditions(t *testing.T) {
+	globalPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(globalPath, []byte(`
+[[hooks]]
+event = "review.completed"
+verdict = "maybe"
+command = "true"
+
+[[review.routes]]
+name = "bad"
+message_patterns = ["("]
+`), 0o600))
+	_, err := LoadGlobalFrom(globalPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `hook 1 (review.completed): invalid verdict condition: "maybe"`)
+	assert.Contains(t, err.Error(), `review route "bad": invalid message pattern`)
+
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".roborev.toml"), []byte(`
+[[hooks]]
+event = "review.failed"
+min_severity = "urgent"
+command = "true"
+
+[[review.routes]]
+paths = ["a/[b"]
+panel = "defined-in-global"
+`), 0o600))
+	_, err = LoadRepoConfig(repoDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `hook 1 (review.failed): invalid min_severity level: "urgent"`)
+	assert.Contains(t, err.Error(), `review route "route 1": invalid glob "a/[b"`)
+	assert.NotContains(t, err.Error(), "not defined", "panels may come from the other config file")
+}
+
+func TestMergeReviewConfigRoutesRepoFirst(t *testing.T) {
+	merged := MergeReviewConfig(
+		ReviewConfig{Routes: []ReviewRoute{{Name: "repo"}}},
+		ReviewConfig{Routes: []ReviewRoute{{Name: "global"}}},
+	)
+	require.Len(t, merged.Routes, 2)
+	assert.Equal(t, "repo", merged.Routes[0].Name)
+	assert.Equal(t, "global", merged.Routes[1].Name)
+}
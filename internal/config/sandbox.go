@@ -0,0 +1,151 @@
+// Agent sandbox configuration.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sandbox home modes.
+const (
+	SandboxHomeOverlay  = "overlay"
+	SandboxHomeReadOnly = "readonly"
+)
+
+// DefaultSandboxAllowHosts are the model endpoints the built-in agents use.
+// The sandbox proxy accepts connections to these hosts when allow_hosts is
+// not set.
+var DefaultSandboxAllowHosts = []string{
+	"api.anthropic.com",
+	"statsig.anthropic.com",
+	"api.openai.com",
+	"chatgpt.com",
+	"auth.openai.com",
+	"generativelanguage.googleapis.com",
+	"cloudcode-pa.googleapis.com",
+	"oauth2.googleapis.com",
+	"*.githubcopilot.com",
+	"api.github.com",
+	"*.cursor.sh",
+	"api.factory.ai",
+	"openrouter.ai",
+}
+
+// DefaultSandboxHidePaths are home-relative paths that are hidden from
+// sandboxed agents because they hold credentials unrelated to the model.
+var DefaultSandboxHidePaths = []string{
+	"~/.ssh",
+	"~/.gnupg",
+	"~/.aws",
+	"~/.azure",
+	"~/.config/gcloud",
+	"~/.config/gh",
+	"~/.docker",
+	"~/.kube",
+	"~/.netrc",
+	"~/.git-credentials",
+	"~/.npmrc",
+	"~/.pypirc",
+	"~/.roborev",
+}
+
+// SandboxConfig runs every agent process inside a roborev-managed sandbox.
+// It is read from the global config only, so a repository under review
+// cannot weaken it.
+type SandboxConfig struct {
+	Enabled bool `toml:"enabled" comment:"Run agents inside a bubblewrap sandbox (Linux only)."`
+	// Required refuses to run agents when the sandbox cannot be set up.
+	// nil = true when enabled.
+	Required *bool `toml:"required" comment:"Fail jobs instead of running agents unsandboxed when bubblewrap is unavailable. Default true."`
+	// AllowHosts are host or host:port globs agents may reach through the
+	// sandbox's egress proxy. Empty uses DefaultSandboxAllowHosts; ["none"]
+	// blocks all network access.
+	AllowHosts []string `toml:"allow_hosts" comment:"Hosts agents may connect to. Default: the built-in agents' model endpoints."`
+	// Home is "overlay" (writes to $HOME land in a throwaway layer) or
+	// "readonly".
+	Home string `toml:"home" comment:"Home directory mode: overlay (default; writes are discarded) or readonly."`
+	// WritablePaths are bound read-write, e.g. an agent's credential file
+	// that must persist token refreshes.
+	WritablePaths []string `toml:"writable_paths" comment:"Paths agents may write to persistently."`
+	// HidePaths are added to DefaultSandboxHidePaths.
+	HidePaths []string `toml:"hide_paths" comment:"Extra paths to hide from agents, in addition to the built-in credential paths."`
+	// PassEnv names extra environment variables to keep; variables that
+	// look like secrets are otherwise stripped.
+	PassEnv []string `toml:"pass_env" comment:"Extra environment variables to pass through despite looking like secrets."`
+}
+
+// IsRequired reports whether agents must not run unsandboxed.
+func (c SandboxConfig) IsRequired() bool {
+	return c.Required == nil || *c.Required
+}
+
+// ResolvedAllowHosts returns the proxy allowlist. An empty result means no
+// network access.
+func (c SandboxConfig) ResolvedAllowHosts() []string {
+	if len(c.AllowHosts) == 0 {
+		return DefaultSandboxAllowHosts
+	}
+	var hosts []string
+	for _, h := range c.AllowHosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h != "" && h != "none" {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// ResolvedHome returns the home directory mode, defaulting to overlay.
+func (c SandboxConfig) ResolvedHome() string {
+	if strings.EqualFold(strings.TrimSpace(c.Home), SandboxHomeReadOnly) {
+		return SandboxHomeReadOnly
+	}
+	return SandboxHomeOverlay
+}
+
+// ResolvedHidePaths returns the built-in and configured hidden paths with
+// "~" expanded.
+func (c SandboxConfig) ResolvedHidePaths() []string {
+	return expandHomePaths(append(append([]string(nil), DefaultSandboxHidePaths...), c.HidePaths...))
+}
+
+// ResolvedWritablePaths returns the writable paths with "~" expanded.
+func (c SandboxConfig) ResolvedWritablePaths() []string {
+	return expandHomePaths(c.WritablePaths)
+}
+
+// Validate reports configuration errors.
+func (c SandboxConfig) Validate() error {
+	switch strings.ToLower(strings.TrimSpace(c.Home)) {
+	case "", SandboxHomeOverlay, SandboxHomeReadOnly:
+	default:
+		return fmt.Errorf("sandbox home %q must be overlay or readonly", c.Home)
+	}
+	for _, p := range append(append([]string(nil), c.WritablePaths...), c.HidePaths...) {
+		if p = strings.TrimSpace(p); p != "" && !filepath.IsAbs(p) && !strings.HasPrefix(p, "~") {
+			return fmt.Errorf("sandbox path %q must be absolute or start with ~", p)
+		}
+	}
+	return nil
+}
+
+func expandHomePaths(paths []string) []string {
+	home, _ := os.UserHomeDir()
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "":
+			continue
+		case p == "~":
+			p = home
+		case strings.HasPrefix(p, "~/"):
+			p = filepath.Join(home, p[2:])
+		}
+		out = append(out, filepath.Clean(p))
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxConfigDefaults(t *testing.T) {
+	assert := assert.New(t)
+	var cfg SandboxConfig
+	assert.True(cfg.IsRequired())
+	assert.Equal(SandboxHomeOverlay, cfg.ResolvedHome())
+	assert.Equal(DefaultSandboxAllowHosts, cfg.ResolvedAllowHosts())
+
+	no := false
+	cfg = SandboxConfig{Required: &no, Home: "ReadOnly", AllowHosts: []string{"none"}}
+	assert.False(cfg.IsRequired())
+	assert.Equal(SandboxHomeReadOnly, cfg.ResolvedHome())
+	assert.Empty(cfg.ResolvedAllowHosts())
+	assert.Equal([]string{"api.example.com"}, SandboxConfig{AllowHosts: []string{" API.example.com "}}.ResolvedAllowHosts())
+}
+
+func TestSandboxConfigPaths(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	cfg := SandboxConfig{
+		WritablePaths: []string{"~/.claude", "/var/cache/agent/"},
+		HidePaths:     []string{"~/secrets"},
+	}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{filepath.Join(home, ".claude"), "/var/cache/agent"}, cfg.ResolvedWritablePaths())
+	assert.Contains(t, cfg.ResolvedHidePaths(), filepath.Join(home, ".ssh"))
+	assert.Contains(t, cfg.ResolvedHidePaths(), filepath.Join(home, "secrets"))
+
+	require.ErrorContains(t, SandboxConfig{WritablePaths: []string{"relative/dir"}}.Validate(), "must be absolute")
+	require.ErrorContains(t, SandboxConfig{Home: "tmpfs"}.Validate(), "overlay or readonly")
+}
+
+func TestSandboxConfigLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[sandbox]
+enabled = true
+allow_hosts = ["api.anthropic.com"]
+pass_env = ["MY_TOKEN"]
+`), 0o644))
+	cfg, err := LoadGlobalFrom(path)
+	require.NoError(t, err)
+	assert.True(t, cfg.Sandbox.Enabled)
+	assert.Equal(t, []string{"api.anthropic.com"}, cfg.Sandbox.ResolvedAllowHosts())
+	assert.Equal(t, []string{"MY_TOKEN"}, cfg.Sandbox.PassEnv)
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scheduled job kinds.
+const (
+	ScheduleAnalyze  = "analyze"
+	ScheduleInsights = "insights"
+	ScheduleCompact  = "compact"
+	ScheduleSummary  = "summary"
+)
+
+// ScheduleConfig runs one recurring job on a cron schedule. Job kinds
+// enqueue one job per matching repo; summary sends a report instead.
+type ScheduleConfig struct {
+	Name     string   `toml:"name"`     // identifies the schedule's run state; defaults to kind and cron
+	Cron     string   `toml:"cron"`     // five-field cron expression in local time, or @daily, @weekly, ...
+	Kind     string   `toml:"kind"`     // "analyze", "insights", "compact", or "summary"
+	Repos    []string `toml:"repos"`    // repo globs on path or name; empty = all repos
+	Analysis string   `toml:"analysis"` // analyze: analysis type, e.g. "security"
+	Files    []string `toml:"files"`    // analyze: path globs relative to the repo root; empty = all tracked source files
+	Since    string   `toml:"since"`    // insights and summary: history window, e.g. "30d"
+	Branch   string   `toml:"branch"`   // compact: branch whose open reviews are consolidated; empty = all branches
+	Limit    int      `toml:"limit"`    // compact: most reviews consolidated per run (default 20)
+	To       []string `toml:"to"`       // summary: email recipients
+	Webhook  string   `toml:"webhook"`  // summary: URL the summary JSON is posted to
+
+	Agent     string `toml:"agent"`
+	Model     string `toml:"model"`
+	Reasoning string `toml:"reasoning"`
+}
+
+// Key returns the name under which the schedule's last run is recorded.
+func (s ScheduleConfig) Key() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.ResolvedKind() + " " + strings.TrimSpace(s.Cron)
+}
+
+// ResolvedKind returns the normalized job kind.
+func (s ScheduleConfig) ResolvedKind() string {
+	return strings.ToLower(strings.TrimSpace(s.Kind))
+}
+
+// Validate reports configuration errors for the schedule.
+func (s ScheduleConfig) Validate() error {
+	if _, err := parseCron(s.Cron); err != nil {
+		return err
+	}
+	switch s.ResolvedKind() {
+	case ScheduleAnalyze:
+		if strings.TrimSpace(s.Analysis) == "" {
+			return fmt.Errorf("schedule %q: analyze needs an analysis type", s.Key())
+		}
+	case ScheduleInsights, ScheduleCompact:
+	case ScheduleSummary:
+		if len(s.To) == 0 && s.Webhook == "" {
+			return fmt.Errorf("schedule %q: summary needs to or webhook", s.Key())
+		}
+	default:
+		return fmt.Errorf("schedule %q: kind %q must be analyze, insights, compact, or summary", s.Key(), s.Kind)
+	}
+	if s.Limit < 0 {
+		return fmt.Errorf("schedule %q: limit must not be negative", s.Key())
+	}
+	return nil
+}
+
+// NextRun returns the first scheduled time strictly after t, in t's
+// location. It returns the zero time for an invalid cron expression or one
+// that never matches, such as February 30th.
+func (s ScheduleConfig) NextRun(t time.Time) time.Time {
+	c, err := parseCron(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return c.next(t)
+}
+
+// cronSchedule is a parsed five-field cron expression. Each field is a
+// bitset of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// As in Vixie cron, when both day fields are restricted a day matches
+	// if either does.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] is value min+i
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	// Day of week accepts 7 as a second Sunday.
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses "minute hour day-of-month month day-of-week" with the
+// usual *, lists, ranges, steps, and month and weekday names.
+func parseCron(expr string) (*cronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron %q must have five fields: minute hour day-of-month month day-of-week", expr)
+	}
+	var c cronSchedule
+	var err error
+	if c.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, fmt.Errorf("cron %q: %w", expr, err)
+	}
+	if c.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, fmt.Errorf("cron %q: %w", expr, err)
+	}
+	if c.dom, err = cronDom.parse(fields[2]); err != nil {
+		return nil, fmt.Errorf("cron %q: %w", expr, err)
+	}
+	if c.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, fmt.Errorf("cron %q: %w", expr, err)
+	}
+	if c.dow, err = cronDow.parse(fields[4]); err != nil {
+		return nil, fmt.Errorf("cron %q: %w", expr, err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+	return &c, nil
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var set uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepPart)
+			}
+			step = n
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = f.value(a); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(b); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rangePart)
+			}
+		default:
+			v, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, s)
+	}
+	return v, nil
+}
+
+// cronSearchYears bounds the search for a matching time, so an expression
+// that can never match returns instead of looping forever.
+const cronSearchYears = 5
+
+// next returns the first matching minute strictly after t, in t's location,
+// or the zero time when none exists within cronSearchYears.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		// Jump straight to the next matching minute in this hour.
+		rest := c.minute >> uint(t.Minute())
+		if rest == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		return t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleConfigNextRun(t *testing.T) {
+	loc := time.UTC
+	// Wednesday 2026-10-14 08:00
+	wed8 := time.Date(2026, 10, 14, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		cron  string
+		after time.Time
+		want  time.Time
+	}{
+		{"every minute", "* * * * *", wed8, time.Date(2026, 10, 14, 8, 1, 0, 0, loc)},
+		{"daily macro", "@daily", wed8, time.Date(2026, 10, 15, 0, 0, 0, 0, loc)},
+		{"step minutes", "*/15 * * * *", wed8.Add(7 * time.Minute), time.Date(2026, 10, 14, 8, 15, 0, 0, loc)},
+		{"weekly monday by name", "0 3 * * mon", wed8, time.Date(2026, 10, 19, 3, 0, 0, 0, loc)},
+		{"sunday as 7", "30 6 * * 7", wed8, time.Date(2026, 10, 18, 6, 30, 0, 0, loc)},
+		{"monthly rolls to next month", "0 9 1 * *", wed8, time.Date(2026, 11, 1, 9, 0, 0, 0, loc)},
+		{"range and list", "0 9-17/4 * * 1,3", wed8, time.Date(2026, 10, 14, 9, 0, 0, 0, loc)},
+		{"day of month or weekday", "0 0 20 * fri", wed8, time.Date(2026, 10, 16, 0, 0, 0, 0, loc)},
+		{"month name", "0 0 1 jan *", wed8, time.Date(2027, 1, 1, 0, 0, 0, 0, loc)},
+		{"never matches", "0 0 30 feb *", wed8, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ScheduleConfig{Cron: tt.cron}.NextRun(tt.after))
+		})
+	}
+}
+
+func TestScheduleConfigValidate(t *testing.T) {
+	require.NoError(t, ScheduleConfig{Cron: "@weekly", Kind: "analyze", Analysis: "security"}.Validate())
+	require.NoError(t, ScheduleConfig{Cron: "0 0 1 * *", Kind: "Insights"}.Validate())
+	require.NoError(t, ScheduleConfig{Cron: "@daily", Kind: "summary", Webhook: "https://example.com/hook"}.Validate())
+
+	for _, s := range []ScheduleConfig{
+		{Kind: "insights"},
+		{Cron: "0 0 * *", Kind: "insights"},
+		{Cron: "60 * * * *", Kind: "insights"},
+		{Cron: "0 0 * * 8", Kind: "insights"},
+		{Cron: "*/0 * * * *", Kind: "insights"},
+		{Cron: "0 5-2 * * *", Kind: "insights"},
+		{Cron: "@daily", Kind: "review"},
+		{Cron: "@daily", Kind: "analyze"},
+		{Cron: "@daily", Kind: "summary"},
+		{Cron: "@daily", Kind: "compact", Limit: -1},
+	} {
+		assert.Error(t, s.Validate(), "%+v", s)
+	}
+	assert.Equal(t, "insights @monthly", ScheduleConfig{Kind: "insights", Cron: "@monthly"}.Key())
+	assert.Equal(t, "weekly-security", ScheduleConfig{Name: "weekly-security"}.Key())
+}
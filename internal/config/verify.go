@@ -0,0 +1,77 @@
+// Fix verification configuration.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultVerifyAttempts is how many times a fix agent is asked to repair
+	// a failing verify_command before its changes are rolled back.
+	DefaultVerifyAttempts = 2
+	// DefaultVerifyTimeout bounds each verify command.
+	DefaultVerifyTimeout = 10 * time.Minute
+)
+
+// VerifyCommands is the verify_command setting. It accepts a single shell
+// command or a list of commands run in order.
+type VerifyCommands []string
+
+// UnmarshalTOML implements toml.Unmarshaler.
+func (v *VerifyCommands) UnmarshalTOML(data any) error {
+	switch d := data.(type) {
+	case string:
+		*v = VerifyCommands{d}
+	case []any:
+		cmds := make(VerifyCommands, 0, len(d))
+		for _, item := range d {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("verify_command: expected string, got %T", item)
+			}
+			cmds = append(cmds, s)
+		}
+		*v = cmds
+	default:
+		return fmt.Errorf("verify_command: expected a string or a list of strings, got %T", data)
+	}
+	return nil
+}
+
+// VerifySettings is the resolved fix verification config for one repo.
+type VerifySettings struct {
+	Commands []string
+	Attempts int
+	Timeout  time.Duration
+}
+
+// Enabled reports whether any verify command is configured.
+func (s VerifySettings) Enabled() bool {
+	return len(s.Commands) > 0
+}
+
+// ResolveVerify resolves the verification gate for fix and refine in
+// repoPath. Verify commands build and test the repository, so they are read
+// from the repo config only.
+func ResolveVerify(repoPath string) VerifySettings {
+	settings := VerifySettings{Attempts: DefaultVerifyAttempts, Timeout: DefaultVerifyTimeout}
+	repoCfg, err := LoadRepoConfig(repoPath)
+	if err != nil || repoCfg == nil {
+		return settings
+	}
+	for _, c := range repoCfg.VerifyCommand {
+		if c = strings.TrimSpace(c); c != "" {
+			settings.Commands = append(settings.Commands, c)
+		}
+	}
+	if rawRepo, rawErr := LoadRawRepo(repoPath); rawErr == nil && IsKeyInTOMLFile(rawRepo, "verify_attempts") {
+		settings.Attempts = max(repoCfg.VerifyAttempts, 0)
+	}
+	if repoCfg.VerifyTimeoutSeconds > 0 {
+		settings.Timeout = time.Duration(repoCfg.VerifyTimeoutSeconds) * time.Second
+	}
+	return settings
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoCfg string
+		want    VerifySettings
+		wantErr bool
+	}{
+		{
+			name: "no repo config",
+			want: VerifySettings{Attempts: DefaultVerifyAttempts, Timeout: DefaultVerifyTimeout},
+		},
+		{
+			name:    "single command",
+			repoCfg: `verify_command = "go build ./..."`,
+			want: VerifySettings{
+				Commands: []string{"go build ./..."}, Attempts: DefaultVerifyAttempts, Timeout: DefaultVerifyTimeout,
+			},
+		},
+		{
+			name: "command list with overrides",
+			repoCfg: "verify_command = [\"go build ./...\", \"  \", \"go test ./...\"]\n" +
+				"verify_attempts = 0\nverify_timeout_seconds = 90\n",
+			want: VerifySettings{
+				Commands: []string{"go build ./...", "go test ./..."}, Attempts: 0, Timeout: 90 * time.Second,
+			},
+		},
+		{
+			name:    "non-string list entry",
+			repoCfg: `verify_command = ["make", 3]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.repoCfg != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".roborev.toml"), []byte(tt.repoCfg), 0o644))
+			}
+			if tt.wantErr {
+				require.ErrorContains(t, ValidateRepoConfig(dir), "verify_command")
+				assert.False(t, ResolveVerify(dir).Enabled())
+				return
+			}
+			got := ResolveVerify(dir)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.want.Commands) > 0, got.Enabled())
+		})
+	}
+}
@@ -566,13 +566,16 @@ func ResolveWorkflowModelFromConfig(
 }
 
 // ResolveBackupAgentForWorkflow returns the backup agent for a workflow,
-// or empty string if none is configured.
+// or empty string if none is configured. It is the first agent of the
+// workflow's fallback chain.
 // Priority:
-//  1. Repo {workflow}_backup_agent
-//  2. Repo backup_agent (generic)
-//  3. Global {workflow}_backup_agent
-//  4. Global default_backup_agent
-//  5. "" (no backup)
+//  1. Repo {workflow}_fallbacks (first entry)
+//  2. Repo {workflow}_backup_agent
+//  3. Repo backup_agent (generic)
+//  4. Global {workflow}_fallbacks (first entry)
+//  5. Global {workflow}_backup_agent
+//  6. Global default_backup_agent
+//  7. "" (no backup)
 func ResolveBackupAgentForWorkflow(repoPath string, globalCfg *Config, workflow string) string {
 	repoCfg, _ := LoadRepoConfig(repoPath)
 	return ResolveBackupAgentForWorkflowFromConfig(repoCfg, globalCfg, workflow)
@@ -582,31 +585,117 @@ func ResolveBackupAgentForWorkflow(repoPath string, globalCfg *Config, workflow
 // ResolveBackupAgentForWorkflow: it resolves entirely from the passed repoCfg
 // and globalCfg, never reading the working tree.
 func ResolveBackupAgentForWorkflowFromConfig(repoCfg *RepoConfig, globalCfg *Config, workflow string) string {
-	// Repo layer: workflow-specific > generic
-	if repoCfg != nil {
-		if s := lookupFieldByTag(reflect.ValueOf(*repoCfg), workflow+"_backup_agent"); s != "" {
-			return s
-		}
-		if s := strings.TrimSpace(repoCfg.BackupAgent); s != "" {
-			return s
+	if chain, _ := backupChainFromConfig(repoCfg, globalCfg, workflow); len(chain) > 0 {
+		return chain[0].Agent
+	}
+	return ""
+}
+
+// FallbackTarget is one agent, and optionally its model, in a workflow's
+// ordered fallback chain.
+type FallbackTarget struct {
+	Agent string
+	Model string
+}
+
+// ParseFallbackTarget parses an "agent" or "agent:model" fallback entry. The
+// model is everything after the first colon, so model names may contain
+// colons. It reports false for an entry without an agent.
+func ParseFallbackTarget(spec string) (FallbackTarget, bool) {
+	name, model, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	t := FallbackTarget{Agent: strings.TrimSpace(name), Model: strings.TrimSpace(model)}
+	return t, t.Agent != ""
+}
+
+// ParseFallbacks parses a fallbacks list in order, skipping entries without
+// an agent.
+func ParseFallbacks(specs []string) []FallbackTarget {
+	var out []FallbackTarget
+	for _, spec := range specs {
+		if t, ok := ParseFallbackTarget(spec); ok {
+			out = append(out, t)
 		}
 	}
+	return out
+}
 
-	// Global layer: workflow-specific > default
-	if globalCfg != nil {
-		if s := lookupFieldByTag(reflect.ValueOf(*globalCfg), workflow+"_backup_agent"); s != "" {
-			return s
+// FormatFallbacks formats targets as fallbacks entries, returning nil for
+// an empty chain.
+func FormatFallbacks(chain []FallbackTarget) []string {
+	var out []string
+	for _, t := range chain {
+		out = append(out, t.String())
+	}
+	return out
+}
+
+// String formats the target as a fallbacks entry.
+func (t FallbackTarget) String() string {
+	if t.Model == "" {
+		return t.Agent
+	}
+	return t.Agent + ":" + t.Model
+}
+
+// ResolveBackupChainForWorkflowFromConfig returns every distinct fallback
+// agent configured for a workflow, in ResolveBackupAgentForWorkflow priority
+// order: at each level the {workflow}_fallbacks entries come first, then the
+// backup agents. Fallbacks entries carry their own model; backup agents carry
+// the backup model set at the same level, except that a first entry coming
+// from a backup agent keeps the ResolveBackupModelForWorkflowFromConfig
+// model.
+func ResolveBackupChainForWorkflowFromConfig(repoCfg *RepoConfig, globalCfg *Config, workflow string) []FallbackTarget {
+	chain, firstListed := backupChainFromConfig(repoCfg, globalCfg, workflow)
+	if len(chain) > 0 && !firstListed {
+		chain[0].Model = ResolveBackupModelForWorkflowFromConfig(repoCfg, globalCfg, workflow)
+	}
+	return chain
+}
+
+// backupChainFromConfig builds the fallback chain and reports whether its
+// first entry came from a {workflow}_fallbacks list.
+func backupChainFromConfig(repoCfg *RepoConfig, globalCfg *Config, workflow string) ([]FallbackTarget, bool) {
+	var chain []FallbackTarget
+	firstListed := false
+	seen := map[string]bool{}
+	add := func(t FallbackTarget, listed bool) {
+		t.Agent = strings.TrimSpace(t.Agent)
+		t.Model = strings.TrimSpace(t.Model)
+		// The same agent may recur with another model, e.g. a cheaper
+		// model of the primary agent as its first fallback.
+		key := t.Agent + "\x00" + t.Model
+		if t.Agent == "" || seen[key] {
+			return
 		}
-		if s := strings.TrimSpace(globalCfg.DefaultBackupAgent); s != "" {
-			return s
+		seen[key] = true
+		if len(chain) == 0 {
+			firstListed = listed
 		}
+		chain = append(chain, t)
 	}
-
-	return ""
+	addLevel := func(v reflect.Value, genericAgent, genericModel string) {
+		for _, t := range ParseFallbacks(lookupListByTag(v, workflow+"_fallbacks")) {
+			add(t, true)
+		}
+		add(FallbackTarget{
+			Agent: lookupFieldByTag(v, workflow+"_backup_agent"),
+			Model: lookupFieldByTag(v, workflow+"_backup_model"),
+		}, false)
+		add(FallbackTarget{Agent: genericAgent, Model: genericModel}, false)
+	}
+	if repoCfg != nil {
+		addLevel(reflect.ValueOf(*repoCfg), repoCfg.BackupAgent, repoCfg.BackupModel)
+	}
+	if globalCfg != nil {
+		addLevel(reflect.ValueOf(*globalCfg), globalCfg.DefaultBackupAgent, globalCfg.DefaultBackupModel)
+	}
+	return chain, firstListed
 }
 
 // ResolveBackupModelForWorkflow returns the backup model for a workflow,
-// or empty string if none is configured.
+// or empty string if none is configured. When the backup agent is the
+// first entry of a {workflow}_fallbacks list, its model is that entry's
+// model. Otherwise:
 // Priority:
 //  1. Repo {workflow}_backup_model
 //  2. Repo backup_model (generic)
@@ -622,6 +711,10 @@ func ResolveBackupModelForWorkflow(repoPath string, globalCfg *Config, workflow
 // ResolveBackupModelForWorkflow: it resolves entirely from the passed repoCfg
 // and globalCfg, never reading the working tree.
 func ResolveBackupModelForWorkflowFromConfig(repoCfg *RepoConfig, globalCfg *Config, workflow string) string {
+	if chain, firstListed := backupChainFromConfig(repoCfg, globalCfg, workflow); firstListed {
+		return chain[0].Model
+	}
+
 	// Repo layer: workflow-specific > generic
 	if repoCfg != nil {
 		if s := lookupFieldByTag(reflect.ValueOf(*repoCfg), workflow+"_backup_model"); s != "" {
@@ -660,6 +753,21 @@ func lookupFieldByTag(v reflect.Value, key string) string {
 	return ""
 }
 
+// lookupListByTag finds a string-list struct field by its TOML tag.
+func lookupListByTag(v reflect.Value, key string) []string {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("toml"), ",")[0] != key {
+			continue
+		}
+		if list, ok := v.Field(i).Interface().([]string); ok {
+			return list
+		}
+		return nil
+	}
+	return nil
+}
+
 // getWorkflowValue looks up agent or model config following Option A priority.
 // The per-type [analyze.<workflow>] map is used only for review workflows that
 // have no dedicated primary fields (e.g. "lookahead"), so legacy analyze tables
@@ -0,0 +1,337 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const (
+	// agentProbePoll is how often the prober checks whether a periodic pass
+	// is due. The first check happens one poll after start, so a daemon
+	// restart does not immediately spend agent quota.
+	agentProbePoll = time.Minute
+	// agentProbeStateKey is the daemon_state key holding the last full
+	// probe pass time.
+	agentProbeStateKey = "agent_probe:last"
+	// agentProbeTimeout bounds one agent's canary run.
+	agentProbeTimeout = 2 * time.Minute
+)
+
+// agentProbeWorkflows are the workflows whose primary and backup agents are
+// probed.
+var agentProbeWorkflows = []string{"review", "security", "design", "refine", "fix"}
+
+// agentProbeTarget is one agent and model to probe; Model is "" for the
+// agent's default.
+type agentProbeTarget struct {
+	Agent string
+	Model string
+}
+
+// agentProbeTargets returns the distinct primary and backup agents and
+// models selected for the probed workflows: the global config's first, in
+// workflow order, then those each repo config adds.
+func agentProbeTargets(cfg *config.Config, repoCfgs []*config.RepoConfig) []agentProbeTarget {
+	seen := map[agentProbeTarget]bool{}
+	var targets []agentProbeTarget
+	add := func(name, model string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		t := agentProbeTarget{Agent: agent.CanonicalName(name), Model: strings.TrimSpace(model)}
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	for _, repoCfg := range append([]*config.RepoConfig{nil}, repoCfgs...) {
+		for _, wf := range agentProbeWorkflows {
+			add(config.ResolveAgentForWorkflowFromConfig("", repoCfg, cfg, wf, ""),
+				config.ResolveModelForWorkflowFromConfig("", repoCfg, cfg, wf, ""))
+			add(config.ResolveBackupAgentForWorkflowFromConfig(repoCfg, cfg, wf),
+				config.ResolveBackupModelForWorkflowFromConfig(repoCfg, cfg, wf))
+		}
+	}
+	return targets
+}
+
+// repoConfigs loads the config of every registered repo that has one.
+func (p *AgentProber) repoConfigs() []*config.RepoConfig {
+	repos, err := p.db.ListRepos()
+	if err != nil {
+		p.logger.Printf("Agent probe: list repos: %v", err)
+		return nil
+	}
+	var cfgs []*config.RepoConfig
+	for _, r := range repos {
+		repoCfg, err := config.LoadRepoConfig(r.RootPath)
+		if err != nil {
+			p.logger.Printf("Agent probe: %v", err)
+			continue
+		}
+		if repoCfg != nil {
+			cfgs = append(cfgs, repoCfg)
+		}
+	}
+	return cfgs
+}
+
+// agentHealthFromProbe converts a probe result to its stored form.
+func agentHealthFromProbe(res agent.ProbeResult, now time.Time) storage.AgentHealth {
+	h := storage.AgentHealth{
+		Agent:           res.Agent,
+		Model:           res.Model,
+		Auth:            string(res.Auth),
+		LimitKind:       res.Limit.Kind.String(),
+		Command:         res.Command,
+		Version:         res.Version,
+		ReasoningLevels: res.ReasoningLevels,
+		LatencyMs:       res.Latency.Milliseconds(),
+		Error:           res.Err,
+		CheckedAt:       now,
+	}
+	switch {
+	case !res.Installed:
+		h.Status = storage.AgentHealthUnavailable
+	case res.OK():
+		h.Status = storage.AgentHealthOK
+	case res.Auth == agent.AuthFailed:
+		h.Status = storage.AgentHealthAuthFailed
+	case res.Limit.Kind != agent.LimitKindNone:
+		h.Status = storage.AgentHealthLimited
+	default:
+		h.Status = storage.AgentHealthFailed
+	}
+	if !res.Limit.ResetAt.IsZero() {
+		resetAt := res.Limit.ResetAt
+		h.LimitResetAt = &resetAt
+	} else if res.Limit.CooldownFor > 0 {
+		resetAt := now.Add(res.Limit.CooldownFor)
+		h.LimitResetAt = &resetAt
+	}
+	return h
+}
+
+// unhealthyAgentLabels summarizes agents whose latest probe failed, as
+// "agent: status" or "agent/model: status".
+func unhealthyAgentLabels(health []storage.AgentHealth) []string {
+	var labels []string
+	for _, h := range health {
+		if h.Healthy() {
+			continue
+		}
+		name := h.Agent
+		if h.Model != "" {
+			name += "/" + h.Model
+		}
+		labels = append(labels, name+": "+h.Status)
+	}
+	return labels
+}
+
+// AgentProber runs the canary prompt on every agent and model the global or
+// a repo config selects, on demand and every agent_probe_interval (off by
+// default, since each pass spends agent quota), and records the results in the
+// agent_health table. The last periodic pass is recorded in daemon_state, so
+// a restart does not probe again before the interval has passed.
+type AgentProber struct {
+	db        *storage.DB
+	cfgGetter ConfigGetter
+	logger    *log.Logger
+	now       func() time.Time
+	probe     func(ctx context.Context, name, model, workDir string) agent.ProbeResult
+	workDir   string
+
+	// passMu serializes probe passes, so an on-demand probe never runs
+	// the same agent concurrently with a periodic one.
+	passMu sync.Mutex
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	doneCh    chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   bool
+	mu        sync.Mutex
+}
+
+// NewAgentProber creates a prober over db. Probes run in a scratch
+// repository under the data directory. Call Start to begin periodic passes.
+func NewAgentProber(db *storage.DB, cfgGetter ConfigGetter, logger *log.Logger) *AgentProber {
+	if logger == nil {
+		logger = log.Default()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AgentProber{
+		db:        db,
+		cfgGetter: cfgGetter,
+		logger:    logger,
+		now:       time.Now,
+		probe:     agent.Probe,
+		workDir:   filepath.Join(config.DataDir(), "agent-probe"),
+		ctx:       ctx,
+		cancel:    cancel,
+		doneCh:    make(chan struct{}),
+	}
+}
+
+// Start launches the periodic loop. Safe to call more than once.
+func (p *AgentProber) Start() {
+	p.startOnce.Do(func() {
+		p.mu.Lock()
+		p.started = true
+		p.mu.Unlock()
+		go p.run()
+	})
+}
+
+// Stop cancels any running probe and waits for the loop to exit.
+func (p *AgentProber) Stop() {
+	p.stopOnce.Do(func() {
+		p.cancel()
+		p.mu.Lock()
+		started := p.started
+		p.mu.Unlock()
+		if started {
+			<-p.doneCh
+		}
+	})
+}
+
+func (p *AgentProber) run() {
+	defer close(p.doneCh)
+	ticker := time.NewTicker(agentProbePoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		p.runDue()
+	}
+}
+
+// runDue probes every configured agent when the interval has passed since
+// the last periodic pass.
+func (p *AgentProber) runDue() {
+	interval := config.ResolveAgentProbeInterval(p.cfgGetter.Config())
+	if interval == 0 {
+		return
+	}
+	raw, err := p.db.GetDaemonState(agentProbeStateKey)
+	if err != nil {
+		p.logger.Printf("Agent probe: %v", err)
+		return
+	}
+	if last, err := time.Parse(time.RFC3339, raw); err == nil && p.now().Sub(last) < interval {
+		return
+	}
+	health, err := p.ProbeAll(p.ctx, "")
+	if err != nil {
+		p.logger.Printf("Agent probe: %v", err)
+		return
+	}
+	if err := p.db.SetDaemonState(agentProbeStateKey, p.now().UTC().Format(time.RFC3339)); err != nil {
+		p.logger.Printf("Agent probe: record run: %v", err)
+	}
+	if labels := unhealthyAgentLabels(health); len(labels) > 0 {
+		p.logger.Printf("Agent probe: unhealthy agents: %s", strings.Join(labels, ", "))
+	}
+}
+
+// ProbeAll probes every configured agent and model, or only those of
+// agentName when it is set (its default model when it is not configured),
+// records the results and returns them in probe order.
+func (p *AgentProber) ProbeAll(ctx context.Context, agentName string) ([]storage.AgentHealth, error) {
+	p.passMu.Lock()
+	defer p.passMu.Unlock()
+
+	targets := agentProbeTargets(p.cfgGetter.Config(), p.repoConfigs())
+	if agentName != "" {
+		want := agent.CanonicalName(agentName)
+		var filtered []agentProbeTarget
+		for _, t := range targets {
+			if t.Agent == want {
+				filtered = append(filtered, t)
+			}
+		}
+		if len(filtered) == 0 {
+			filtered = []agentProbeTarget{{Agent: want}}
+		}
+		targets = filtered
+	}
+	if err := git.InitRepo(ctx, p.workDir); err != nil {
+		return nil, fmt.Errorf("prepare probe directory: %w", err)
+	}
+
+	health := make([]storage.AgentHealth, 0, len(targets))
+	for _, t := range targets {
+		if ctx.Err() != nil {
+			return health, ctx.Err()
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, agentProbeTimeout)
+		res := p.probe(probeCtx, t.Agent, t.Model, p.workDir)
+		cancel()
+		h := agentHealthFromProbe(res, p.now())
+		if err := p.db.SaveAgentHealth(h); err != nil {
+			return health, err
+		}
+		health = append(health, h)
+	}
+	return health, nil
+}
+
+func (s *Server) humaListAgents(
+	_ context.Context, _ *struct{},
+) (*ListAgentsOutput, error) {
+	health, err := s.db.ListAgentHealth()
+	if err != nil {
+		return nil, huma.Error500InternalServerError(fmt.Sprintf("list agent health: %v", err))
+	}
+	out := &ListAgentsOutput{}
+	out.Body.Agents = health
+	if out.Body.Agents == nil {
+		out.Body.Agents = []storage.AgentHealth{}
+	}
+	return out, nil
+}
+
+func (s *Server) humaProbeAgents(
+	ctx context.Context, input *ProbeAgentsInput,
+) (*ListAgentsOutput, error) {
+	if s.agentProber == nil {
+		return nil, huma.Error503ServiceUnavailable("agent prober not running")
+	}
+	health, err := s.agentProber.ProbeAll(ctx, input.Agent)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(fmt.Sprintf("probe agents: %v", err))
+	}
+	out := &ListAgentsOutput{}
+	out.Body.Agents = health
+	return out, nil
+}
+
+func (s *Server) humaListAgentCircuits(
+	_ context.Context, _ *struct{},
+) (*ListAgentCircuitsOutput, error) {
+	out := &ListAgentCircuitsOutput{}
+	out.Body.Circuits = s.workerPool.circuits.snapshot()
+	if out.Body.Circuits == nil {
+		out.Body.Circuits = []AgentCircuit{}
+	}
+	return out, nil
+}
@@ -0,0 +1,158 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestAgentProbeTargets(t *testing.T) {
+	cfg := &config.Config{
+		DefaultAgent:       "claude",
+		DefaultModel:       "sonnet",
+		SecurityAgent:      "codex",
+		SecurityModel:      "gpt-5",
+		DefaultBackupAgent: "gemini",
+	}
+	assert.Equal(t, []agentProbeTarget{
+		{Agent: "claude-code", Model: "sonnet"},
+		{Agent: "gemini"},
+		{Agent: "codex", Model: "gpt-5"},
+	}, agentProbeTargets(cfg, nil))
+
+	repoCfgs := []*config.RepoConfig{
+		{Agent: "opencode", Model: "qwen"},
+		{Agent: "claude-code", Model: "sonnet", BackupAgent: "droid"},
+	}
+	assert.Equal(t, []agentProbeTarget{
+		{Agent: "claude-code", Model: "sonnet"},
+		{Agent: "gemini"},
+		{Agent: "codex", Model: "gpt-5"},
+		{Agent: "opencode", Model: "qwen"},
+		{Agent: "droid"},
+	}, agentProbeTargets(cfg, repoCfgs))
+}
+
+func TestAgentProberProbesRepoAgents(t *testing.T) {
+	t.Setenv("ROBOREV_DATA_DIR", t.TempDir())
+	db := testutil.OpenTestDB(t)
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".roborev.toml"),
+		[]byte("agent = \"opencode\"\nmodel = \"qwen\"\n"), 0o644))
+	_, err := db.GetOrCreateRepo(repoDir)
+	require.NoError(t, err)
+
+	p := NewAgentProber(db, NewStaticConfig(&config.Config{DefaultAgent: "codex"}), log.Default())
+	p.probe = fakeAgentProbe(nil)
+	health, err := p.ProbeAll(context.Background(), "")
+	require.NoError(t, err)
+	var probed []string
+	for _, h := range health {
+		probed = append(probed, h.Agent+"/"+h.Model)
+	}
+	assert.Equal(t, []string{"codex/", "opencode/qwen"}, probed)
+}
+
+// fakeAgentProbe answers like agent.Probe from canned per-agent errors.
+func fakeAgentProbe(failures map[string]string) func(context.Context, string, string, string) agent.ProbeResult {
+	return func(_ context.Context, name, model, _ string) agent.ProbeResult {
+		res := agent.ProbeResult{Agent: name, Model: model, Installed: true, Auth: agent.AuthOK, Latency: 1500 * time.Millisecond}
+		if msg, ok := failures[name]; ok {
+			res.Auth = agent.AuthUnknown
+			res.Err = msg
+			res.Limit = agent.ClassifyLimit(name, msg)
+			if agent.IsAuthError(msg) {
+				res.Auth = agent.AuthFailed
+			}
+		}
+		return res
+	}
+}
+
+func TestAgentProberRecordsHealth(t *testing.T) {
+	t.Setenv("ROBOREV_DATA_DIR", t.TempDir())
+	db := testutil.OpenTestDB(t)
+	cfg := &config.Config{DefaultAgent: "codex", DefaultBackupAgent: "gemini", ReviewBackupAgent: "claude-code"}
+	p := NewAgentProber(db, NewStaticConfig(cfg), log.Default())
+	p.probe = fakeAgentProbe(map[string]string{
+		"gemini":      "Error: quota exceeded, resets in 2h",
+		"claude-code": "Not logged in · Please run /login",
+	})
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	// Periodic probes are off until an interval is configured.
+	p.runDue()
+	health, err := db.ListAgentHealth()
+	require.NoError(t, err)
+	assert.Empty(t, health)
+
+	cfg.AgentProbeInterval = "6h"
+	p.runDue()
+	health, err = db.ListAgentHealth()
+	require.NoError(t, err)
+	require.Len(t, health, 3)
+	byAgent := map[string]storage.AgentHealth{}
+	for _, h := range health {
+		byAgent[h.Agent] = h
+	}
+	assert.Equal(t, storage.AgentHealthOK, byAgent["codex"].Status)
+	assert.Equal(t, int64(1500), byAgent["codex"].LatencyMs)
+	assert.Equal(t, storage.AgentHealthLimited, byAgent["gemini"].Status)
+	assert.Equal(t, "quota", byAgent["gemini"].LimitKind)
+	assert.Equal(t, storage.AgentHealthAuthFailed, byAgent["claude-code"].Status)
+	assert.Equal(t, "failed", byAgent["claude-code"].Auth)
+	assert.Equal(t, []string{"claude-code: auth_failed", "gemini: limited"}, unhealthyAgentLabels(health))
+
+	// Within the interval, a periodic pass is skipped but an on-demand
+	// probe of one agent still runs.
+	p.probe = fakeAgentProbe(nil)
+	now = now.Add(time.Hour)
+	p.runDue()
+	health, err = db.ListAgentHealth()
+	require.NoError(t, err)
+	assert.Len(t, unhealthyAgentLabels(health), 2)
+
+	probed, err := p.ProbeAll(context.Background(), "claude")
+	require.NoError(t, err)
+	require.Len(t, probed, 1)
+	assert.Equal(t, "claude-code", probed[0].Agent)
+	assert.True(t, probed[0].Healthy())
+}
+
+func TestHumaListAgents(t *testing.T) {
+	srv, db, _ := newTestServer(t)
+	require.NoError(t, db.SaveAgentHealth(storage.AgentHealth{
+		Agent: "codex", Model: "gpt-5", Status: storage.AgentHealthAuthFailed, Auth: "failed",
+		Version: "codex-cli 0.50.0", ReasoningLevels: []string{"fast", "standard"}, CheckedAt: time.Now(),
+	}))
+
+	rr := serveHuma(t, srv, http.MethodGet, "/api/agents", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var out struct {
+		Agents []storage.AgentHealth `json:"agents"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+	require.Len(t, out.Agents, 1)
+	assert.Equal(t, "codex-cli 0.50.0", out.Agents[0].Version)
+	assert.Equal(t, []string{"fast", "standard"}, out.Agents[0].ReasoningLevels)
+
+	rr = serveHuma(t, srv, http.MethodGet, "/api/status", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var status storage.DaemonStatus
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, []string{"codex/gpt-5: auth_failed"}, status.UnhealthyAgents)
+}
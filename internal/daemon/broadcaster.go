@@ -6,8 +6,13 @@ import (
 	"time"
 )
 
-// Event represents a review event that can be broadcast
+// Event represents a daemon event that can be broadcast. Review lifecycle
+// events use the flat fields; other families (see events.go) also set their
+// typed payload.
 type Event struct {
+	// ID is the event's position in the event journal; 0 when the event
+	// was not journaled.
+	ID           int64     `json:"id,omitempty"`
 	Type         string    `json:"type"`
 	TS           time.Time `json:"ts"`
 	JobID        int64     `json:"job_id"`
@@ -21,6 +26,12 @@ type Event struct {
 	Findings     string    `json:"findings,omitempty"`
 	Error        string    `json:"error,omitempty"`
 	WorktreePath string    `json:"worktree_path,omitempty"`
+
+	Fix     *FixEventData   `json:"fix,omitempty"`
+	Panel   *PanelEventData `json:"panel,omitempty"`
+	CI      *CIEventData    `json:"ci,omitempty"`
+	Sync    *SyncEventData  `json:"sync,omitempty"`
+	Circuit *AgentCircuit   `json:"circuit,omitempty"`
 }
 
 // Subscriber represents a client subscribed to events
@@ -38,11 +49,35 @@ type Broadcaster interface {
 	SubscriberCount() int
 }
 
+const (
+	// journalQueueSize is how many events can wait for the journal writer
+	// before Broadcast blocks.
+	journalQueueSize = 1024
+	// journalBatchMax caps how many queued events one journal write takes.
+	journalBatchMax = 256
+)
+
 // EventBroadcaster implements the Broadcaster interface
 type EventBroadcaster struct {
 	mu          sync.RWMutex
 	subscribers map[int]*Subscriber
 	nextID      int
+
+	// journal, when set, records every event before it is delivered.
+	// Broadcast only queues the event; a single writer goroutine drains
+	// the queue, journals events in batches, and delivers them in queue
+	// order, so a subscriber never sees a lower ID after a higher one.
+	journal *EventJournal
+	queue   chan journalItem
+	done    chan struct{}
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+// journalItem is one queued event, or a Flush marker when flushed is set.
+type journalItem struct {
+	event   Event
+	flushed chan struct{}
 }
 
 // NewBroadcaster creates a new event broadcaster
@@ -53,6 +88,21 @@ func NewBroadcaster() Broadcaster {
 	}
 }
 
+// NewJournaledBroadcaster creates a broadcaster that appends every event to
+// journal, stamping it with its journal ID, before delivering it. Journal
+// writes happen on a background goroutine; call Close to stop it.
+func NewJournaledBroadcaster(journal *EventJournal) Broadcaster {
+	b := &EventBroadcaster{
+		subscribers: make(map[int]*Subscriber),
+		nextID:      1,
+		journal:     journal,
+		queue:       make(chan journalItem, journalQueueSize),
+		done:        make(chan struct{}),
+	}
+	go b.journalLoop()
+	return b
+}
+
 // Subscribe adds a new subscriber with optional repo filter
 // Returns a subscriber ID and event channel
 func (b *EventBroadcaster) Subscribe(repoPath string) (int, <-chan Event) {
@@ -86,7 +136,98 @@ func (b *EventBroadcaster) Unsubscribe(id int) {
 
 // Broadcast sends an event to all matching subscribers
 // Non-blocking: if a subscriber's channel is full, the event is dropped for that subscriber
+// A journaled broadcaster queues the event for the journal writer, which
+// delivers it once journaled; after Close, events are delivered directly.
 func (b *EventBroadcaster) Broadcast(event Event) {
+	if b.journal == nil {
+		b.deliver(event)
+		return
+	}
+	b.closeMu.RLock()
+	defer b.closeMu.RUnlock()
+	if b.closed {
+		b.deliver(event)
+		return
+	}
+	b.queue <- journalItem{event: event}
+}
+
+// Flush waits until every event broadcast before the call has been
+// journaled and delivered.
+func (b *EventBroadcaster) Flush() {
+	if b.journal == nil {
+		return
+	}
+	flushed := make(chan struct{})
+	b.closeMu.RLock()
+	if b.closed {
+		b.closeMu.RUnlock()
+		<-b.done
+		return
+	}
+	b.queue <- journalItem{flushed: flushed}
+	b.closeMu.RUnlock()
+	<-flushed
+}
+
+// Close journals and delivers any queued events, then stops the journal
+// writer. It is safe to call more than once.
+func (b *EventBroadcaster) Close() {
+	if b.journal == nil {
+		return
+	}
+	b.closeMu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.closeMu.Unlock()
+	<-b.done
+}
+
+// journalLoop is the single journal writer. It takes whatever is queued,
+// up to journalBatchMax items, writes it in one journal append, and only
+// then fans the events out.
+func (b *EventBroadcaster) journalLoop() {
+	defer close(b.done)
+	batch := make([]journalItem, 0, journalBatchMax)
+	for item := range b.queue {
+		batch = append(batch[:0], item)
+	fill:
+		for len(batch) < journalBatchMax {
+			select {
+			case next, ok := <-b.queue:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, next)
+			default:
+				break fill
+			}
+		}
+		b.writeBatch(batch)
+	}
+}
+
+func (b *EventBroadcaster) writeBatch(batch []journalItem) {
+	events := make([]*Event, 0, len(batch))
+	for i := range batch {
+		if batch[i].flushed == nil {
+			events = append(events, &batch[i].event)
+		}
+	}
+	b.journal.Append(events...)
+	for _, item := range batch {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		b.deliver(item.event)
+	}
+}
+
+// deliver fans event out to the matching subscribers.
+func (b *EventBroadcaster) deliver(event Event) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
@@ -115,6 +256,7 @@ func (b *EventBroadcaster) SubscriberCount() int {
 // MarshalJSON converts an Event to JSON for streaming
 func (e Event) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
+		ID           int64  `json:"id,omitempty"`
 		Type         string `json:"type"`
 		TS           string `json:"ts"`
 		JobID        int64  `json:"job_id"`
@@ -128,7 +270,14 @@ func (e Event) MarshalJSON() ([]byte, error) {
 		Findings     string `json:"findings,omitempty"`
 		Error        string `json:"error,omitempty"`
 		WorktreePath string `json:"worktree_path,omitempty"`
+
+		Fix     *FixEventData   `json:"fix,omitempty"`
+		Panel   *PanelEventData `json:"panel,omitempty"`
+		CI      *CIEventData    `json:"ci,omitempty"`
+		Sync    *SyncEventData  `json:"sync,omitempty"`
+		Circuit *AgentCircuit   `json:"circuit,omitempty"`
 	}{
+		ID:           e.ID,
 		Type:         e.Type,
 		TS:           e.TS.UTC().Format(time.RFC3339),
 		JobID:        e.JobID,
@@ -142,5 +291,10 @@ func (e Event) MarshalJSON() ([]byte, error) {
 		Findings:     e.Findings,
 		Error:        e.Error,
 		WorktreePath: e.WorktreePath,
+		Fix:          e.Fix,
+		Panel:        e.Panel,
+		CI:           e.CI,
+		Sync:         e.Sync,
+		Circuit:      e.Circuit,
 	})
 }
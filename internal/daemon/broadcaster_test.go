@@ -1,12 +1,14 @@
 package daemon
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/testutil"
 )
 
@@ -198,3 +200,68 @@ func TestBroadcaster_NonBlockingBroadcast(t *testing.T) {
 	// Channel should be empty now
 	assertNoEventWithin(t, ch, 10*time.Millisecond)
 }
+
+func TestJournaledBroadcaster_BroadcastDoesNotWaitForJournal(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	journal := NewEventJournal(db, nil)
+	b := NewJournaledBroadcaster(journal).(*EventBroadcaster)
+	t.Cleanup(b.Close)
+	_, eventCh := b.Subscribe("")
+
+	// Hold SQLite's write lock so every journal write stalls.
+	tx, err := db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = tx.Exec(`INSERT INTO event_journal (event_type, payload, created_at) VALUES ('lock', '{}', '')`)
+	require.NoError(t, err)
+
+	returned := make(chan struct{})
+	go func() {
+		for i := 1; i <= 5; i++ {
+			b.Broadcast(newTestEvent(int64(i)))
+		}
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		require.Fail(t, "Broadcast waited for the journal write")
+	}
+	// Events are delivered only once journaled.
+	assertNoEventWithin(t, eventCh, 50*time.Millisecond)
+
+	require.NoError(t, tx.Rollback())
+	var lastID int64
+	for i := 1; i <= 5; i++ {
+		got := testutil.ReceiveWithTimeout(t, eventCh, 5*time.Second)
+		assert.EqualValues(t, i, got.JobID, "delivery order")
+		assert.Greater(t, got.ID, lastID, "journal IDs increase in delivery order")
+		lastID = got.ID
+	}
+
+	history, err := journal.Replay(storage.JournalQuery{})
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+	assert.Equal(t, lastID, history[4].ID)
+}
+
+func TestJournaledBroadcaster_CloseDrainsQueue(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	journal := NewEventJournal(db, nil)
+	b := NewJournaledBroadcaster(journal).(*EventBroadcaster)
+
+	for i := 1; i <= 300; i++ {
+		b.Broadcast(newTestEvent(int64(i)))
+	}
+	b.Close()
+	b.Close()
+
+	history, err := journal.Replay(storage.JournalQuery{})
+	require.NoError(t, err)
+	assert.Len(t, history, 300)
+
+	// After Close, events are still delivered live, unjournaled.
+	_, eventCh := b.Subscribe("")
+	b.Broadcast(newTestEvent(301))
+	got := testutil.ReceiveWithTimeout(t, eventCh, time.Second)
+	assert.Zero(t, got.ID)
+}
@@ -112,6 +112,39 @@ func TestCancelSynthesisCascades(t *testing.T) {
 	_ = runUUID
 }
 
+func TestCancelAfterResynthesisCascadesFromNewestSynthesisOnly(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+
+	runUUID, members, synth := enqueueServerPanelRun(t, db, 1)
+	_, err := db.Exec(`UPDATE review_jobs SET status = 'done', claim_blocked = 0 WHERE panel_run_uuid = ?`, runUUID)
+	require.NoError(t, err)
+	fresh, err := db.ResynthesizePanel(storage.EnqueueOpts{
+		RepoID: synth.RepoID, GitRef: synth.GitRef, Agent: "test", PanelRunUUID: runUUID,
+	})
+	require.NoError(t, err)
+
+	// The member is rerun and the earlier synthesis was re-queued on its own.
+	_, err = db.Exec(`UPDATE review_jobs SET status = 'queued' WHERE id IN (?, ?)`, members[0].ID, synth.ID)
+	require.NoError(t, err)
+
+	_, err = server.humaCancelJob(context.Background(), &CancelJobInput{
+		Body: CancelJobRequest{JobID: synth.ID},
+	})
+	require.NoError(t, err)
+	got, err := db.GetJobByID(members[0].ID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusQueued, got.Status, "a superseded synthesis does not cascade")
+
+	_, err = server.humaCancelJob(context.Background(), &CancelJobInput{
+		Body: CancelJobRequest{JobID: fresh.ID},
+	})
+	require.NoError(t, err)
+	got, err = db.GetJobByID(members[0].ID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusCanceled, got.Status, "the newest synthesis owns the members")
+}
+
 func TestCancelCISynthesisRetiresPanelMapping(t *testing.T) {
 	assert := assert.New(t)
 	server, db, _ := newTestServer(t)
@@ -0,0 +1,631 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.kenn.io/roborev/internal/config"
+	gitpkg "go.kenn.io/roborev/internal/git"
+	reviewpkg "go.kenn.io/roborev/internal/review"
+)
+
+const (
+	chatProviderSlack = "slack"
+	chatProviderTeams = "teams"
+
+	// chatTimeout bounds one chat API call.
+	chatTimeout = 10 * time.Second
+	// chatMaxFindings is how many findings a message lists before summarizing
+	// the rest as a count.
+	chatMaxFindings = 5
+	// chatFieldLimit caps long free text (errors, finding titles).
+	chatFieldLimit = 300
+)
+
+// API base URLs, replaced in tests.
+var (
+	slackAPIBaseURL   = "https://slack.com/api"
+	teamsGraphBaseURL = "https://graph.microsoft.com/v1.0"
+)
+
+// chatMessage is a notification rendered independently of the chat tool.
+type chatMessage struct {
+	Title    string
+	Status   string // "pass", "fail", "warn", or "info"; picks the accent color
+	Repo     string
+	PR       int // GitHub PR number, 0 outside CI
+	Branch   string
+	Commit   string // short SHA, or the ref for ranges
+	Subject  string // commit subject
+	Agent    string
+	Verdict  string // "Pass", "Fail", or ""
+	Findings []string
+	More     int // findings beyond Findings
+	Error    string
+	Link     string
+	Show     string // CLI command that shows the review
+}
+
+// chatThreads serializes threaded posts per conversation so two events for
+// the same branch do not both start a new thread.
+type chatThreads struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (t *chatThreads) lock(key string) func() {
+	t.mu.Lock()
+	if t.locks == nil {
+		t.locks = make(map[string]*sync.Mutex)
+	}
+	l := t.locks[key]
+	if l == nil {
+		l = &sync.Mutex{}
+		t.locks[key] = l
+	}
+	t.mu.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// chatHookConfigured reports whether a slack or teams hook has somewhere to
+// post: an incoming webhook URL, or an API token and channel.
+func chatHookConfigured(hook config.HookConfig) bool {
+	return hook.URL != "" || (hook.Token != "" && hook.Channel != "")
+}
+
+// runChatHook renders event and posts it to Slack or Teams. With an API token
+// and thread = true, notifications for the same PR (or, outside CI, the same
+// repo and branch) reply to the first one.
+func (hr *HookRunner) runChatHook(hook config.HookConfig, event Event) {
+	defer hr.wg.Done()
+
+	subject := newHookSubject(event, hr.db, hr.logger)
+	msg := buildChatMessage(hook, subject)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*chatTimeout)
+	defer cancel()
+
+	token := strings.TrimSpace(os.ExpandEnv(hook.Token))
+	var err error
+	switch {
+	case token != "" && hook.Channel != "":
+		threadKey := ""
+		if hook.Thread {
+			threadKey = chatThreadKey(subject)
+		}
+		err = hr.postChatThreaded(hook.Type, hook.Channel, threadKey, func(threadID string) (string, error) {
+			if hook.Type == chatProviderTeams {
+				return postTeamsGraph(ctx, token, hook.Channel, threadID, msg)
+			}
+			return postSlackAPI(ctx, token, hook.Channel, threadID, msg)
+		})
+	case hook.URL != "":
+		if hook.Type == chatProviderTeams {
+			err = postChatJSON(ctx, hook.URL, "", teamsWebhookPayload(msg), nil)
+		} else {
+			err = postChatJSON(ctx, hook.URL, "", slackPayload(msg, "", ""), nil)
+		}
+		if err != nil {
+			err = fmt.Errorf("url=%q: %w", redactWebhookURL(hook.URL), err)
+		}
+	}
+	if err != nil {
+		hr.logger.Printf("%s hook error (%s, job %d): %v",
+			chatProviderLabel(hook.Type), event.Type, event.JobID, err)
+	}
+}
+
+// postChatThreaded posts via send, replying in the stored thread for key when
+// one exists and recording the new message as the thread root otherwise. An
+// empty key, or no database, posts a standalone message.
+func (hr *HookRunner) postChatThreaded(
+	provider, channel, key string, send func(threadID string) (string, error),
+) error {
+	if key == "" || hr.db == nil {
+		_, err := send("")
+		return err
+	}
+	unlock := hr.chatThreads.lock(provider + "\x00" + channel + "\x00" + key)
+	defer unlock()
+
+	threadID, err := hr.db.GetNotificationThread(provider, channel, key)
+	if err != nil {
+		return err
+	}
+	id, err := send(threadID)
+	if err != nil {
+		return err
+	}
+	if threadID == "" && id != "" {
+		return hr.db.SaveNotificationThread(provider, channel, key, id)
+	}
+	return nil
+}
+
+func chatProviderLabel(provider string) string {
+	if provider == chatProviderTeams {
+		return "Teams"
+	}
+	return "Slack"
+}
+
+// chatPRRef returns the GitHub repo and PR number behind the event, from the
+// CI payload or, for panel jobs, the CI panel the job belongs to.
+func chatPRRef(s *hookSubject) (string, int) {
+	if s.event.CI != nil && s.event.CI.PRNumber > 0 {
+		return s.event.CI.GithubRepo, s.event.CI.PRNumber
+	}
+	runUUID := ""
+	if s.event.Panel != nil {
+		runUUID = s.event.Panel.RunUUID
+	} else if job := s.storedJob(); job != nil {
+		runUUID = job.PanelRunUUID
+	}
+	if runUUID == "" || s.db == nil {
+		return "", 0
+	}
+	panel, err := s.db.GetCIPanelByRunUUID(runUUID)
+	if err != nil || panel == nil {
+		return "", 0
+	}
+	return panel.GithubRepo, panel.PRNumber
+}
+
+// chatThreadKey names the conversation an event belongs to: its PR when it
+// has one, else its repo and branch. Events with neither are not threaded.
+func chatThreadKey(s *hookSubject) string {
+	if ghRepo, pr := chatPRRef(s); pr > 0 {
+		return fmt.Sprintf("pr:%s#%d", ghRepo, pr)
+	}
+	branch := chatBranch(s)
+	if s.event.Repo == "" || branch == "" {
+		return ""
+	}
+	return "branch:" + s.event.Repo + "@" + branch
+}
+
+func chatBranch(s *hookSubject) string {
+	if s.event.Branch != "" {
+		return s.event.Branch
+	}
+	if job := s.storedJob(); job != nil {
+		return job.HookBranch()
+	}
+	return ""
+}
+
+// buildChatMessage renders the event with the stored job and review filled in.
+func buildChatMessage(hook config.HookConfig, s *hookSubject) chatMessage {
+	event := s.event
+	job := s.storedJob()
+	ghRepo, pr := chatPRRef(s)
+
+	msg := chatMessage{
+		Repo:   event.RepoName,
+		Branch: chatBranch(s),
+		Agent:  s.agent(),
+		Error:  trimChatText(event.Error),
+	}
+	if msg.Repo == "" && event.Repo != "" {
+		msg.Repo = filepath.Base(event.Repo)
+	}
+	if ghRepo != "" {
+		msg.Repo = ghRepo
+	}
+	ref := event.SHA
+	if job != nil {
+		ref = firstNonEmpty(ref, job.GitRef)
+		msg.Subject = job.CommitSubject
+	}
+	if ref != "" {
+		msg.Commit = gitpkg.ShortSHA(headOf(ref))
+	}
+	if event.JobID > 0 {
+		msg.Show = fmt.Sprintf("roborev show %d", event.JobID)
+	}
+
+	verdict := ""
+	if job == nil || !job.IsFixJob() {
+		// A fix job's output is a patch summary, not a review.
+		verdict = s.verdict()
+	}
+	switch verdict {
+	case "P":
+		msg.Verdict = "Pass"
+	case "F":
+		msg.Verdict = "Fail"
+		findings := reviewpkg.ParseFindings(s.output())
+		for i, f := range findings {
+			if i == chatMaxFindings {
+				msg.More = len(findings) - i
+				break
+			}
+			msg.Findings = append(msg.Findings, formatChatFinding(f))
+		}
+	}
+
+	msg.PR = pr
+	msg.Title, msg.Status = chatTitle(event, msg.Verdict)
+
+	switch {
+	case hook.Link != "":
+		msg.Link = interpolateLink(hook.Link, event, msg.Branch, ghRepo, pr)
+	case pr > 0:
+		msg.Link = fmt.Sprintf("https://github.com/%s/pull/%d", ghRepo, pr)
+	}
+	return msg
+}
+
+// chatTitle returns the headline and status for an event.
+func chatTitle(event Event, verdict string) (string, string) {
+	switch event.Type {
+	case "review.completed":
+		if verdict == "Fail" {
+			return "Review found issues", "fail"
+		}
+		if verdict == "Pass" {
+			return "Review passed", "pass"
+		}
+		return "Review completed", "info"
+	case "review.failed":
+		return "Review job failed", "fail"
+	case EventFixCompleted:
+		return "Fix ready", "pass"
+	case EventFixFailed:
+		return "Fix failed", "fail"
+	case EventPanelCompleted:
+		if verdict == "Fail" {
+			return "Panel review found issues", "fail"
+		}
+		return "Panel review finished", "pass"
+	case EventPanelFailed:
+		return "Panel review failed", "fail"
+	case EventCIPosted:
+		return "PR review posted", "info"
+	case EventCIDeferred:
+		return "PR review deferred", "warn"
+	case EventCIGaveUp:
+		return "PR review gave up", "fail"
+	case EventSyncFailed:
+		return "Sync failing", "fail"
+	case EventSyncRecovered:
+		return "Sync recovered", "pass"
+	default:
+		return "roborev " + event.Type, "info"
+	}
+}
+
+func formatChatFinding(f reviewpkg.Finding) string {
+	sev := f.Severity
+	if sev != "" {
+		sev = strings.ToUpper(sev[:1]) + sev[1:]
+	}
+	parts := []string{sev}
+	// Inline findings ("High — a.go:3 leaks") already lead with the location.
+	if loc := f.Location(); loc != "" && !strings.Contains(f.Title, loc) {
+		parts = append(parts, loc)
+	}
+	if f.Title != "" {
+		parts = append(parts, trimChatText(f.Title))
+	}
+	return strings.Join(parts, " — ")
+}
+
+// interpolateLink fills a link template. Values are URL-escaped, unlike
+// command templates which are shell-escaped.
+func interpolateLink(tmpl string, event Event, branch, ghRepo string, pr int) string {
+	prNumber := ""
+	if pr > 0 {
+		prNumber = strconv.Itoa(pr)
+	}
+	return strings.NewReplacer(
+		"{job_id}", strconv.FormatInt(event.JobID, 10),
+		"{job_uuid}", neturl.PathEscape(event.JobUUID),
+		"{repo_name}", neturl.PathEscape(event.RepoName),
+		"{sha}", neturl.PathEscape(event.SHA),
+		"{branch}", neturl.PathEscape(branch),
+		"{github_repo}", ghRepo,
+		"{pr_number}", prNumber,
+	).Replace(tmpl)
+}
+
+func trimChatText(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= chatFieldLimit {
+		return s
+	}
+	return reviewpkg.TrimPartialRune(s[:chatFieldLimit-3]) + "..."
+}
+
+// headline is the title with the repo and PR it concerns.
+func (m chatMessage) headline() string {
+	title := m.Title
+	if m.Repo != "" {
+		title += ": " + m.Repo
+	}
+	if m.PR > 0 {
+		title += fmt.Sprintf(" (PR #%d)", m.PR)
+	}
+	return title
+}
+
+// chatFacts returns the label/value pairs shown under the title.
+func (m chatMessage) chatFacts() [][2]string {
+	var facts [][2]string
+	add := func(label, value string) {
+		if value != "" {
+			facts = append(facts, [2]string{label, value})
+		}
+	}
+	add("Repository", m.Repo)
+	add("Branch", m.Branch)
+	commit := m.Commit
+	if m.Subject != "" {
+		commit = strings.TrimSpace(commit + " " + m.Subject)
+	}
+	add("Commit", commit)
+	add("Agent", m.Agent)
+	add("Verdict", m.Verdict)
+	return facts
+}
+
+// --- Slack ---
+
+type slackMessage struct {
+	Channel  string       `json:"channel,omitempty"`
+	ThreadTS string       `json:"thread_ts,omitempty"`
+	Text     string       `json:"text"`
+	Blocks   []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+var slackStatusEmoji = map[string]string{
+	"pass": ":white_check_mark:",
+	"fail": ":x:",
+	"warn": ":warning:",
+	"info": ":information_source:",
+}
+
+func slackMrkdwn(text string) slackText {
+	return slackText{Type: "mrkdwn", Text: text}
+}
+
+// slackPayload renders msg as Block Kit. channel and threadTS are set only for
+// chat.postMessage.
+func slackPayload(msg chatMessage, channel, threadTS string) slackMessage {
+	title := msg.headline()
+	out := slackMessage{
+		Channel:  channel,
+		ThreadTS: threadTS,
+		Text:     title, // notification fallback
+	}
+	out.Blocks = append(out.Blocks, slackBlock{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: slackStatusEmoji[msg.Status] + " *" + slackEscaper.Replace(title) + "*"},
+	})
+	if facts := msg.chatFacts(); len(facts) > 0 {
+		block := slackBlock{Type: "section"}
+		for _, f := range facts {
+			block.Fields = append(block.Fields, slackMrkdwn("*"+f[0]+"*\n"+slackEscaper.Replace(f[1])))
+		}
+		out.Blocks = append(out.Blocks, block)
+	}
+	if len(msg.Findings) > 0 {
+		lines := make([]string, 0, len(msg.Findings)+1)
+		for _, f := range msg.Findings {
+			lines = append(lines, "• "+slackEscaper.Replace(f))
+		}
+		if msg.More > 0 {
+			lines = append(lines, fmt.Sprintf("_and %d more_", msg.More))
+		}
+		out.Blocks = append(out.Blocks, slackBlock{
+			Type: "section", Text: &slackText{Type: "mrkdwn", Text: strings.Join(lines, "\n")},
+		})
+	}
+	if msg.Error != "" {
+		out.Blocks = append(out.Blocks, slackBlock{
+			Type: "section", Text: &slackText{Type: "mrkdwn", Text: "```" + slackEscaper.Replace(msg.Error) + "```"},
+		})
+	}
+	var context []slackText
+	if msg.Link != "" {
+		context = append(context, slackMrkdwn("<"+msg.Link+"|View review>"))
+	}
+	if msg.Show != "" {
+		context = append(context, slackMrkdwn("`"+msg.Show+"`"))
+	}
+	if len(context) > 0 {
+		out.Blocks = append(out.Blocks, slackBlock{Type: "context", Elements: context})
+	}
+	return out
+}
+
+// postSlackAPI posts msg with chat.postMessage and returns the message ts.
+func postSlackAPI(ctx context.Context, token, channel, threadTS string, msg chatMessage) (string, error) {
+	var resp struct {
+		OK    bool   `json:"ok"`
+		TS    string `json:"ts"`
+		Error string `json:"error"`
+	}
+	if err := postChatJSON(ctx, slackAPIBaseURL+"/chat.postMessage", token,
+		slackPayload(msg, channel, threadTS), &resp); err != nil {
+		return "", err
+	}
+	if !resp.OK {
+		return "", fmt.Errorf("chat.postMessage: %s", nonEmpty(resp.Error, "unknown error"))
+	}
+	return resp.TS, nil
+}
+
+// --- Teams ---
+
+type teamsAttachment struct {
+	ID          string `json:"id,omitempty"`
+	ContentType string `json:"contentType"`
+	Content     any    `json:"content"`
+}
+
+var teamsStatusColor = map[string]string{
+	"pass": "Good",
+	"fail": "Attention",
+	"warn": "Warning",
+	"info": "Default",
+}
+
+// teamsCard renders msg as an Adaptive Card.
+func teamsCard(msg chatMessage) map[string]any {
+	body := []any{map[string]any{
+		"type": "TextBlock", "text": msg.headline(), "weight": "Bolder", "size": "Medium",
+		"color": teamsStatusColor[msg.Status], "wrap": true,
+	}}
+	if facts := msg.chatFacts(); len(facts) > 0 {
+		list := make([]map[string]string, 0, len(facts))
+		for _, f := range facts {
+			list = append(list, map[string]string{"title": f[0], "value": f[1]})
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": list})
+	}
+	if len(msg.Findings) > 0 {
+		lines := make([]string, 0, len(msg.Findings)+1)
+		for _, f := range msg.Findings {
+			lines = append(lines, "- "+f)
+		}
+		if msg.More > 0 {
+			lines = append(lines, fmt.Sprintf("- and %d more", msg.More))
+		}
+		body = append(body, map[string]any{"type": "TextBlock", "text": strings.Join(lines, "\n"), "wrap": true})
+	}
+	if msg.Error != "" {
+		body = append(body, map[string]any{
+			"type": "TextBlock", "text": msg.Error, "fontType": "Monospace", "wrap": true,
+		})
+	}
+	if msg.Show != "" {
+		body = append(body, map[string]any{
+			"type": "TextBlock", "text": msg.Show, "isSubtle": true, "fontType": "Monospace",
+		})
+	}
+	card := map[string]any{
+		"type":    "AdaptiveCard",
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"version": "1.4",
+		"body":    body,
+	}
+	if msg.Link != "" {
+		card["actions"] = []any{map[string]any{"type": "Action.OpenUrl", "title": "View review", "url": msg.Link}}
+	}
+	return card
+}
+
+// teamsWebhookPayload wraps the card for a Teams incoming webhook or
+// Workflows "post to channel" trigger.
+func teamsWebhookPayload(msg chatMessage) map[string]any {
+	return map[string]any{
+		"type": "message",
+		"attachments": []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     teamsCard(msg),
+		}},
+	}
+}
+
+// postTeamsGraph posts msg to a channel through Microsoft Graph, as a reply
+// to threadID when set, and returns the new message ID. channel is
+// "<team-id>/<channel-id>".
+func postTeamsGraph(ctx context.Context, token, channel, threadID string, msg chatMessage) (string, error) {
+	teamID, channelID, ok := strings.Cut(channel, "/")
+	if !ok || teamID == "" || channelID == "" {
+		return "", fmt.Errorf("teams channel %q must be <team-id>/<channel-id>", channel)
+	}
+	endpoint := fmt.Sprintf("%s/teams/%s/channels/%s/messages",
+		teamsGraphBaseURL, neturl.PathEscape(teamID), neturl.PathEscape(channelID))
+	if threadID != "" {
+		endpoint += "/" + neturl.PathEscape(threadID) + "/replies"
+	}
+	card, err := json.Marshal(teamsCard(msg))
+	if err != nil {
+		return "", fmt.Errorf("marshal card: %w", err)
+	}
+	payload := map[string]any{
+		"body": map[string]string{
+			"contentType": "html",
+			"content":     `<attachment id="roborev"></attachment>`,
+		},
+		"attachments": []teamsAttachment{{
+			ID:          "roborev",
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     string(card), // Graph takes the card as a JSON string
+		}},
+	}
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := postChatJSON(ctx, endpoint, token, payload, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// postChatJSON POSTs payload as JSON, with a bearer token when set, and
+// decodes a 2xx response into out when out is non-nil. Transport errors are
+// redacted so URLs with embedded secrets never reach the log.
+func postChatJSON(ctx context.Context, endpoint, token string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", redactURLError(err))
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: chatTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return redactURLError(err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg := "status " + resp.Status
+		if text := strings.TrimSpace(string(respBody)); text != "" {
+			msg += ": " + trimChatText(text)
+		}
+		return errors.New(msg)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return nil
+}
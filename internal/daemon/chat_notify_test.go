@@ -0,0 +1,229 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+// chatRequest is one request captured by newChatServer.
+type chatRequest struct {
+	Path string
+	Auth string
+	Body map[string]any
+}
+
+// newChatServer records every POST and answers with respond(path).
+func newChatServer(t *testing.T, respond func(path string) string) (*httptest.Server, func() []chatRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var reqs []chatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(raw, &body)
+		mu.Lock()
+		reqs = append(reqs, chatRequest{Path: r.URL.Path, Auth: r.Header.Get("Authorization"), Body: body})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, respond(r.URL.Path))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []chatRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]chatRequest(nil), reqs...)
+	}
+}
+
+func TestBuildChatMessageAndSlackPayload(t *testing.T) {
+	event := Event{
+		Type:     "review.completed",
+		JobID:    42,
+		Repo:     "/src/api",
+		SHA:      "0123456789abcdef",
+		Branch:   "main",
+		Agent:    "codex",
+		Verdict:  "F",
+		Findings: "- High — auth.go:10 token <logged>\n- Low — a.go:1 nit",
+	}
+	msg := buildChatMessage(config.HookConfig{
+		Link: "https://roborev.example.com/jobs/{job_id}?branch={branch}",
+	}, newHookSubject(event, nil, log.Default()))
+
+	assert := assert.New(t)
+	assert.Equal("Review found issues", msg.Title)
+	assert.Equal("fail", msg.Status)
+	assert.Equal("api", msg.Repo)
+	assert.Equal("Fail", msg.Verdict)
+	assert.Equal([]string{"High — auth.go:10 token <logged>", "Low — a.go:1 nit"}, msg.Findings)
+	assert.Equal("https://roborev.example.com/jobs/42?branch=main", msg.Link)
+	assert.Equal("roborev show 42", msg.Show)
+
+	payload := slackPayload(msg, "", "")
+	var texts []string
+	for _, b := range payload.Blocks {
+		if b.Text != nil {
+			texts = append(texts, b.Text.Text)
+		}
+		for _, e := range append(b.Fields, b.Elements...) {
+			texts = append(texts, e.Text)
+		}
+	}
+	text := strings.Join(texts, "\n")
+	assert.Contains(text, "token &lt;logged&gt;", "mrkdwn control characters are escaped")
+	assert.Contains(text, "<https://roborev.example.com/jobs/42?branch=main|View review>")
+	assert.Contains(text, "*Commit*\n0123456")
+	assert.Empty(payload.Channel, "incoming webhooks take no channel")
+	assert.Equal("Review found issues: api", payload.Text)
+}
+
+func TestBuildChatMessageMoreFindings(t *testing.T) {
+	var lines []string
+	for range chatMaxFindings + 2 {
+		lines = append(lines, "- Medium — a.go:1 race")
+	}
+	msg := buildChatMessage(config.HookConfig{}, newHookSubject(Event{
+		Type: "review.completed", Verdict: "F", Findings: strings.Join(lines, "\n"),
+	}, nil, log.Default()))
+	assert.Len(t, msg.Findings, chatMaxFindings)
+	assert.Equal(t, 2, msg.More)
+}
+
+func TestSlackHookThreadsByBranch(t *testing.T) {
+	srv, requests := newChatServer(t, func(string) string {
+		return `{"ok":true,"ts":"1700000000.000100"}`
+	})
+	orig := slackAPIBaseURL
+	slackAPIBaseURL = srv.URL
+	t.Cleanup(func() { slackAPIBaseURL = orig })
+
+	db := testutil.OpenTestDB(t)
+	t.Setenv("ROBOREV_TEST_SLACK_TOKEN", "xoxb-test")
+	cfg := &config.Config{Hooks: []config.HookConfig{{
+		Event:   "review.completed",
+		Type:    "slack",
+		Token:   "${ROBOREV_TEST_SLACK_TOKEN}",
+		Channel: "C123",
+		Thread:  true,
+	}}}
+	b := NewBroadcaster()
+	hr := NewHookRunner(NewStaticConfig(cfg), b, db, nil, log.Default())
+	t.Cleanup(hr.Stop)
+
+	for _, verdict := range []string{"F", "P"} {
+		b.Broadcast(Event{
+			Type: "review.completed", TS: time.Now(), Repo: "/src/api", RepoName: "api",
+			Branch: "feature", SHA: "abc123", Verdict: verdict,
+		})
+		hr.WaitUntilIdle()
+	}
+	// A different branch starts its own thread.
+	b.Broadcast(Event{
+		Type: "review.completed", TS: time.Now(), Repo: "/src/api", RepoName: "api",
+		Branch: "main", SHA: "def456", Verdict: "P",
+	})
+	hr.WaitUntilIdle()
+
+	reqs := requests()
+	require.Len(t, reqs, 3)
+	assert := assert.New(t)
+	for _, r := range reqs {
+		assert.Equal("/chat.postMessage", r.Path)
+		assert.Equal("Bearer xoxb-test", r.Auth, "token is expanded from the environment")
+		assert.Equal("C123", r.Body["channel"])
+	}
+	assert.Nil(reqs[0].Body["thread_ts"], "first message roots the thread")
+	assert.Equal("1700000000.000100", reqs[1].Body["thread_ts"])
+	assert.Nil(reqs[2].Body["thread_ts"])
+
+	id, err := db.GetNotificationThread("slack", "C123", "branch:/src/api@feature")
+	require.NoError(t, err)
+	assert.Equal("1700000000.000100", id)
+}
+
+func TestSlackAPIErrorIsReported(t *testing.T) {
+	srv, _ := newChatServer(t, func(string) string {
+		return `{"ok":false,"error":"channel_not_found"}`
+	})
+	orig := slackAPIBaseURL
+	slackAPIBaseURL = srv.URL
+	t.Cleanup(func() { slackAPIBaseURL = orig })
+
+	_, err := postSlackAPI(t.Context(), "xoxb", "C1", "", chatMessage{Title: "t"})
+	require.ErrorContains(t, err, "channel_not_found")
+}
+
+func TestTeamsHookThreadsByPR(t *testing.T) {
+	srv, requests := newChatServer(t, func(path string) string {
+		if strings.HasSuffix(path, "/replies") {
+			return `{"id":"reply-1"}`
+		}
+		return `{"id":"root-1"}`
+	})
+	orig := teamsGraphBaseURL
+	teamsGraphBaseURL = srv.URL
+	t.Cleanup(func() { teamsGraphBaseURL = orig })
+
+	db := testutil.OpenTestDB(t)
+	cfg := &config.Config{Hooks: []config.HookConfig{{
+		Event: "ci.*", Type: "teams", Token: "graph-token", Channel: "team-1/chan-1", Thread: true,
+	}}}
+	b := NewBroadcaster()
+	hr := NewHookRunner(NewStaticConfig(cfg), b, db, nil, log.Default())
+	t.Cleanup(hr.Stop)
+
+	for _, typ := range []string{EventCIDeferred, EventCIPosted} {
+		b.Broadcast(Event{
+			Type: typ, TS: time.Now(),
+			CI: &CIEventData{GithubRepo: "acme/api", PRNumber: 7, HeadSHA: "abc"},
+		})
+		hr.WaitUntilIdle()
+	}
+
+	reqs := requests()
+	require.Len(t, reqs, 2)
+	assert := assert.New(t)
+	assert.Equal("/teams/team-1/channels/chan-1/messages", reqs[0].Path)
+	assert.Equal("/teams/team-1/channels/chan-1/messages/root-1/replies", reqs[1].Path)
+	assert.Equal("Bearer graph-token", reqs[0].Auth)
+
+	attachments, ok := reqs[1].Body["attachments"].([]any)
+	require.True(t, ok)
+	card, ok := attachments[0].(map[string]any)["content"].(string)
+	require.True(t, ok, "Graph takes the card as a JSON string")
+	assert.Contains(card, "PR review posted: acme/api (PR #7)")
+	assert.Contains(card, "https://github.com/acme/api/pull/7")
+}
+
+func TestTeamsIncomingWebhook(t *testing.T) {
+	srv, requests := newChatServer(t, func(string) string { return "" })
+
+	cfg := &config.Config{Hooks: []config.HookConfig{{
+		Event: "review.failed", Type: "teams", URL: srv.URL + "/webhook", Thread: true,
+	}}}
+	hr, b := setupRunner(t, cfg)
+	b.Broadcast(Event{Type: "review.failed", TS: time.Now(), JobID: 3, RepoName: "api", Error: "agent timed out"})
+	hr.WaitUntilIdle()
+
+	reqs := requests()
+	require.Len(t, reqs, 1, "incoming webhooks post standalone messages")
+	assert.Equal(t, "/webhook", reqs[0].Path)
+	assert.Equal(t, "message", reqs[0].Body["type"])
+	raw, err := json.Marshal(reqs[0].Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "Review job failed: api")
+	assert.Contains(t, string(raw), "agent timed out")
+}
@@ -0,0 +1,219 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// chunkPanelName is the panel name recorded on chunked review runs.
+const chunkPanelName = "chunks"
+
+// reviewChunk is one file group of a chunked review.
+type reviewChunk struct {
+	paths []string
+	dirs  []string
+	bytes int
+}
+
+// chunkDir is the changed files of one directory, the unit a chunk is built
+// from so a package is reviewed together whenever it fits.
+type chunkDir struct {
+	name  string
+	files []git.FileDiffSize
+	bytes int
+}
+
+// planReviewChunks splits a diff into file groups of about chunkSize bytes.
+// Files are grouped by directory and directories are packed in path order,
+// so neighbouring packages share a chunk; a directory larger than chunkSize
+// is split between chunks by file. When that takes more than maxChunks
+// chunks the size grows until it does not. It returns nil when the diff fits
+// in one chunk.
+func planReviewChunks(sizes []git.FileDiffSize, chunkSize, maxChunks int) []reviewChunk {
+	total := 0
+	byDir := map[string]*chunkDir{}
+	for _, f := range sizes {
+		total += f.Bytes
+		name := path.Dir(f.Path)
+		d, ok := byDir[name]
+		if !ok {
+			d = &chunkDir{name: name}
+			byDir[name] = d
+		}
+		d.files = append(d.files, f)
+		d.bytes += f.Bytes
+	}
+	if total <= chunkSize || len(sizes) < 2 {
+		return nil
+	}
+	dirs := make([]*chunkDir, 0, len(byDir))
+	for _, d := range byDir {
+		dirs = append(dirs, d)
+	}
+	slices.SortFunc(dirs, func(a, b *chunkDir) int { return strings.Compare(a.name, b.name) })
+
+	budget := max(chunkSize, 1)
+	for {
+		chunks := packChunks(dirs, budget)
+		if len(chunks) <= max(maxChunks, 1) {
+			if len(chunks) < 2 {
+				return nil
+			}
+			return chunks
+		}
+		budget += budget/4 + 1
+	}
+}
+
+// packChunks packs dirs, in order, into chunks of at most budget bytes. Only
+// a single file larger than budget makes a chunk exceed it.
+func packChunks(dirs []*chunkDir, budget int) []reviewChunk {
+	var chunks []reviewChunk
+	var cur reviewChunk
+	flush := func() {
+		if len(cur.paths) > 0 {
+			chunks = append(chunks, cur)
+		}
+		cur = reviewChunk{}
+	}
+	addDir := func(name string) {
+		if !slices.Contains(cur.dirs, name) {
+			cur.dirs = append(cur.dirs, name)
+		}
+	}
+	for _, d := range dirs {
+		// A directory that fits in a chunk starts a new one rather than
+		// straddling two; a larger one is split anyway, so it tops up the
+		// current chunk first.
+		if d.bytes <= budget && cur.bytes+d.bytes > budget {
+			flush()
+		}
+		for _, f := range d.files {
+			if len(cur.paths) > 0 && cur.bytes+f.Bytes > budget {
+				flush()
+			}
+			cur.paths = append(cur.paths, f.Path)
+			cur.bytes += f.Bytes
+			addDir(d.name)
+		}
+	}
+	flush()
+	return chunks
+}
+
+// planChunksForTarget decides whether a single-agent commit or range review
+// is split into file groups: chunking must be enabled for the repo and the
+// review diff must exceed the chunk size. Dirty and prompt jobs are never
+// chunked.
+func (s *Server) planChunksForTarget(ctx context.Context, in singleAgentInputs) []reviewChunk {
+	d := in.descriptor
+	if d.prompt != "" || d.gitRef == "" || d.gitRef == "dirty" || d.jobType != "" ||
+		len(in.req.DependsOn) > 0 {
+		return nil
+	}
+	settings := config.ResolveChunking(in.resolutionPath, in.cfg)
+	if !settings.Enabled {
+		return nil
+	}
+	excludes := config.ResolveExcludePatterns(ctx, in.resolutionPath, in.cfg, in.req.ReviewType)
+	sizes, err := git.GetDiffFileSizesCtx(ctx, in.checkoutRoot, d.gitRef, excludes...)
+	if err != nil {
+		log.Printf("Chunked review: measure diff of %s: %v", d.gitRef, err)
+		return nil
+	}
+	return planReviewChunks(sizes, settings.ChunkSize, settings.MaxChunks)
+}
+
+// enqueueChunkedRun enqueues one member job per file group plus a
+// claim-blocked parent that merges their reviews, reusing the panel run
+// machinery. Every member runs the single-review agent, model and settings.
+func (s *Server) enqueueChunkedRun(
+	ctx context.Context, in singleAgentInputs, agentName, model string, chunks []reviewChunk,
+) (*RawJSONOutput, error) {
+	runUUID := uuid.NewString()
+	memberOpts := make([]storage.EnqueueOpts, len(chunks))
+	for i, c := range chunks {
+		m := config.ResolvedMember{
+			Name:         fmt.Sprintf("chunk %d/%d", i+1, len(chunks)),
+			Index:        i,
+			Agent:        agentName,
+			Model:        model,
+			Provider:     in.descriptor.requestedProvider,
+			Reasoning:    in.reasoning,
+			ReviewType:   in.req.ReviewType,
+			Instructions: chunkInstructions(i, len(chunks), c),
+			Paths:        c.paths,
+		}
+		cfgJSON, _ := json.Marshal(m)
+		o := in.descriptor.baseOpts()
+		o.Agent, o.Model, o.Provider = m.Agent, m.Model, m.Provider
+		o.Reasoning, o.ReviewType = m.Reasoning, m.ReviewType
+		o.PanelRunUUID, o.PanelRole = runUUID, storage.PanelRoleMember
+		o.PanelName, o.PanelMemberName, o.PanelMemberIndex = chunkPanelName, m.Name, m.Index
+		o.PanelMemberConfigJSON = string(cfgJSON)
+		memberOpts[i] = o
+	}
+	synthOpts := panelSynthesisOpts(in.descriptor, chunkPanelName, runUUID, config.SynthesisSpec{
+		Agent: agentName, Model: model, Reasoning: in.reasoning,
+		Strategy: config.SynthesisStrategyChunks,
+	})
+
+	memberJobs, synthJob, err := s.db.EnqueuePanelRun(memberOpts, synthOpts)
+	if err != nil {
+		return rawJSONOutput(http.StatusInternalServerError,
+			ErrorResponse{Error: fmt.Sprintf("enqueue chunked review: %v", err)})
+	}
+	log.Printf("Enqueue %s: split into %d file groups (run %s)", in.gitRef, len(chunks), runUUID)
+
+	synthJob.RepoPath = in.repo.RootPath
+	synthJob.RepoName = in.repo.Name
+	if in.descriptor.commitSubject != "" {
+		synthJob.CommitSubject = in.descriptor.commitSubject
+	}
+	memberIDs := make([]int64, len(memberJobs))
+	for i, mj := range memberJobs {
+		memberIDs[i] = mj.ID
+	}
+	if config.IsDefaultReviewType(in.req.ReviewType) && memberJobs[0].JobType == storage.JobTypeReview {
+		parent := *memberJobs[0]
+		parent.RepoPath = in.repo.RootPath
+		parent.RepoName = in.repo.Name
+		if err := s.maybeDispatchAutoDesign(ctx, &parent); err != nil {
+			log.Printf("auto-design dispatch failed: %v", err)
+		}
+	}
+	s.logEnqueueSideEffects(synthJob, enqueueSideEffectInputs{
+		repo:       in.repo,
+		gitRef:     in.gitRef,
+		agentName:  agentName,
+		reviewType: in.req.ReviewType,
+	})
+	return rawJSONOutput(http.StatusCreated, PanelEnqueueResponse{
+		ReviewJob:    synthJob,
+		PanelRunUUID: runUUID,
+		MemberJobIDs: memberIDs,
+	})
+}
+
+// chunkInstructions tells a member which part of the change it reviews, so
+// it does not report files of other groups as missing.
+func chunkInstructions(index, count int, c reviewChunk) string {
+	return fmt.Sprintf(
+		"This change was split into %d file groups because it is too large to review at once. "+
+			"You are reviewing group %d, which covers %d files in: %s. "+
+			"The diff above contains only these files; other groups are reviewed separately. "+
+			"Read the rest of the repository as needed for context, but only report issues in these files.",
+		count, index+1, len(c.paths), strings.Join(c.dirs, ", "))
+}
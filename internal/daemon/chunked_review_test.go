@@ -0,0 +1,149 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestPlanReviewChunks(t *testing.T) {
+	sizes := []git.FileDiffSize{
+		{Path: "api/a.go", Bytes: 40},
+		{Path: "api/b.go", Bytes: 40},
+		{Path: "web/x.ts", Bytes: 30},
+		{Path: "web/y.ts", Bytes: 30},
+		{Path: "README.md", Bytes: 10},
+	}
+	paths := func(chunks []reviewChunk) [][]string {
+		out := make([][]string, len(chunks))
+		for i, c := range chunks {
+			out[i] = c.paths
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		chunkSize int
+		maxChunks int
+		want      [][]string
+	}{
+		{"fits in one chunk", 150, 8, [][]string{}},
+		{"directories stay together", 80, 8, [][]string{
+			{"README.md"}, {"api/a.go", "api/b.go"}, {"web/x.ts", "web/y.ts"},
+		}},
+		{"neighbouring directories share a chunk", 90, 8, [][]string{
+			{"README.md", "api/a.go", "api/b.go"}, {"web/x.ts", "web/y.ts"},
+		}},
+		{"large directory splits by file", 50, 8, [][]string{
+			{"README.md", "api/a.go"}, {"api/b.go"}, {"web/x.ts"}, {"web/y.ts"},
+		}},
+		{"max chunks grows the chunk size", 50, 2, [][]string{
+			{"README.md", "api/a.go", "api/b.go"}, {"web/x.ts", "web/y.ts"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, paths(planReviewChunks(sizes, tt.chunkSize, tt.maxChunks)))
+		})
+	}
+}
+
+func TestEnqueueSplitsOversizedReview(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+
+	repo := testutil.NewGitRepo(t)
+	repo.CommitFile("README.md", "readme", "initial")
+	line := strings.Repeat("x", 60) + "\n"
+	sha := repo.CommitFiles(map[string]string{
+		"api/a.go": strings.Repeat(line, 10),
+		"web/b.ts": strings.Repeat(line, 10),
+	}, "large change")
+
+	// Chunking is opt-in.
+	job := enqueueViaHTTP(t, server, EnqueueRequest{RepoPath: repo.Path(), GitRef: sha, Agent: "test"})
+	assert.Empty(job.PanelRunUUID)
+
+	repo.WriteFile(".roborev.toml", "[review.chunking]\nenabled = true\nchunk_size = 800\n")
+	job = enqueueViaHTTP(t, server, EnqueueRequest{RepoPath: repo.Path(), GitRef: sha, Agent: "test"})
+	require.NotEmpty(t, job.PanelRunUUID)
+	assert.Equal(storage.JobTypeSynthesis, job.JobType)
+	assert.Equal(chunkPanelName, job.PanelName)
+	assert.Equal(config.SynthesisStrategyChunks, synthesisSpecOf(&job).Strategy)
+
+	members, err := db.GetPanelMemberReviews(job.PanelRunUUID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	var groups [][]string
+	for i, m := range members {
+		var member config.ResolvedMember
+		require.NoError(t, json.Unmarshal([]byte(m.PanelMemberConfigJSON), &member))
+		groups = append(groups, member.Paths)
+		assert.Equal("test", member.Agent)
+		assert.Contains(member.Instructions, "split into 2 file groups")
+		assert.Equal([]string{"chunk 1/2", "chunk 2/2"}[i], m.PanelMemberName)
+	}
+	assert.Equal([][]string{{"api/a.go"}, {"web/b.ts"}}, groups)
+
+	// A diff under the chunk size is reviewed whole.
+	small := repo.CommitFile("api/c.go", "package api", "small change")
+	job = enqueueViaHTTP(t, server, EnqueueRequest{RepoPath: repo.Path(), GitRef: small, Agent: "test"})
+	assert.Empty(job.PanelRunUUID)
+}
+
+func TestProcessJob_ChunkMemberReviewsOnlyItsFiles(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := tc.GitRepo.CommitFiles(map[string]string{
+		"api/a.go": "package api // chunk-a\n",
+		"web/b.ts": "export {} // chunk-b\n",
+	}, "two groups")
+	commit, err := tc.DB.GetOrCreateCommit(tc.Repo.ID, sha, "Author", "Subject", time.Now())
+	require.NoError(t, err)
+
+	var capturedPrompt string
+	agentName := "chunk-prompt-capture"
+	agent.Register(&agent.FakeAgent{
+		NameStr: agentName,
+		ReviewFn: func(_ context.Context, _, _, reviewPrompt string, _ io.Writer) (string, error) {
+			capturedPrompt = reviewPrompt
+			return "No issues found.", nil
+		},
+	})
+	t.Cleanup(func() { agent.Unregister(agentName) })
+
+	memberJSON, err := json.Marshal(config.ResolvedMember{
+		Name: "chunk 2/2", Index: 1, Agent: agentName,
+		Instructions: "You are reviewing group 2.", Paths: []string{"web/b.ts"},
+	})
+	require.NoError(t, err)
+	members, _, err := tc.DB.EnqueuePanelRun([]storage.EnqueueOpts{{
+		RepoID: tc.Repo.ID, CommitID: commit.ID, GitRef: sha, Agent: agentName,
+		PanelRunUUID: "chunk-run", PanelName: chunkPanelName, PanelMemberName: "chunk 2/2",
+		PanelMemberIndex: 1, PanelMemberConfigJSON: string(memberJSON),
+	}}, storage.EnqueueOpts{RepoID: tc.Repo.ID, CommitID: commit.ID, GitRef: sha, Agent: agentName,
+		PanelRunUUID: "chunk-run", PanelName: chunkPanelName})
+	require.NoError(t, err)
+
+	claimed, err := tc.DB.ClaimJob(testWorkerID)
+	require.NoError(t, err)
+	require.Equal(t, members[0].ID, claimed.ID)
+	tc.Pool.processJob(testWorkerID, claimed)
+
+	tc.assertJobStatus(t, claimed.ID, storage.JobStatusDone)
+	assert.Contains(t, capturedPrompt, "chunk-b")
+	assert.NotContains(t, capturedPrompt, "chunk-a")
+	assert.Contains(t, capturedPrompt, "You are reviewing group 2.")
+}
@@ -104,13 +104,18 @@ func canonicalDiscordAgent(job storage.ReviewJob, event Event) string {
 	return agent.CanonicalName(firstNonEmpty(event.Agent, job.Agent))
 }
 
+// quotaCooldownAgentName returns the agent named by an "agent <name> circuit
+// open" skip, or by the older "agent <name> quota cooldown active" form.
 func quotaCooldownAgentName(errorText string) string {
 	errorText = strings.TrimPrefix(strings.TrimSpace(errorText), reviewpkg.QuotaErrorPrefix)
 	parts := strings.Fields(errorText)
-	if len(parts) < 5 || parts[0] != "agent" || parts[2] != "quota" || parts[3] != "cooldown" || parts[4] != "active" {
-		return ""
+	switch {
+	case len(parts) >= 4 && parts[0] == "agent" && parts[2] == "circuit" && parts[3] == "open":
+		return parts[1]
+	case len(parts) >= 5 && parts[0] == "agent" && parts[2] == "quota" && parts[3] == "cooldown" && parts[4] == "active":
+		return parts[1]
 	}
-	return parts[1]
+	return ""
 }
 
 func postDiscordWebhook(ctx context.Context, webhookURL string, payload discordWebhookPayload, logf discordLogf) bool {
@@ -65,6 +65,9 @@ func TestCanonicalDiscordAgentUsesQuotaCooldownAgent(t *testing.T) {
 	event := Event{Agent: "synthesis"}
 
 	assert.Equal(t, "codex", canonicalDiscordAgent(job, event))
+
+	job.Error = review.QuotaErrorPrefix + "agent claude circuit open (quota limit)"
+	assert.Equal(t, "claude-code", canonicalDiscordAgent(job, event))
 }
 
 func TestBuildDiscordCIJobFailedPayloadIncludesContext(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 	ghpkg "go.kenn.io/roborev/internal/github"
 	"go.kenn.io/roborev/internal/procutil"
 	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/redact"
 	reviewpkg "go.kenn.io/roborev/internal/review"
 	"go.kenn.io/roborev/internal/review/autotype"
 	"go.kenn.io/roborev/internal/storage"
@@ -424,9 +425,23 @@ func (p *CIPoller) enqueuePanelRun(ctx context.Context, ghRepo string, pr ghPR,
 		return err
 	}
 
+	// A matching [[review.routes]] entry overrides the [ci] panel, review
+	// type, reasoning and min severity for this PR.
+	route, err := p.selectCIRoute(ctx, repo, repoCfg, cfg, pr, gitRef)
+	if err != nil {
+		return err
+	}
+	panelName := ciPanelName(repoCfg, cfg)
+	if route != nil && route.Panel != "" {
+		panelName = route.Panel
+		if panelName == config.PanelNone {
+			panelName = ""
+		}
+	}
+
 	// Resolve panel members + synthesis: a configured [ci].panel names a panel,
 	// else the agents x review_types matrix is adapted into members.
-	members, synth, err := p.resolveCIMembers(repo, repoCfg, cfg, ghRepo)
+	members, synth, err := p.resolveCIMembers(repo, repoCfg, cfg, ghRepo, panelName, route)
 	if err != nil {
 		// A member's agent could not be resolved (none installed / quota):
 		// surface it on the commit status, mirroring the pre-panel rollback.
@@ -444,6 +459,24 @@ func (p *CIPoller) enqueuePanelRun(ctx context.Context, ghRepo string, pr ghPR,
 	// no member already covers the design review type (F8, F12).
 	members = p.maybeAppendDesignMember(ctx, members, repo, repoCfg, cfg, mergeBase, pr.HeadRefOid)
 
+	// Incremental mode reviews only the commits pushed since the PR's last
+	// completed panel run, with that run's review as context. Routing and the
+	// design member above still see the whole PR.
+	var incremental *storage.IncrementalReview
+	if config.ResolveCIIncrementalReview(repoCfg, cfg) {
+		gitRef, incremental = p.narrowIncrementalPRRange(ctx, repo.RootPath, ghRepo, pr, gitRef)
+	}
+	if incremental != nil {
+		incrementalContext, err := incrementalReviewContext(p.db, incremental)
+		if err != nil {
+			log.Printf("CI poller: warning: incremental context for %s#%d: %v", ghRepo, pr.Number, err)
+		}
+		if prDiscussionContext != "" {
+			incrementalContext = strings.TrimSpace(prDiscussionContext) + "\n\n" + incrementalContext
+		}
+		prDiscussionContext = incrementalContext
+	}
+
 	memberOpts, synthOpts, err := p.buildPanelOpts(
 		ctx,
 		buildPanelOptsInput{
@@ -451,9 +484,9 @@ func (p *CIPoller) enqueuePanelRun(ctx context.Context, ghRepo string, pr ghPR,
 			// Gate hooks on the PR base (target) branch: it is maintainer-
 			// controlled, unlike the fork-author-controlled head ref.
 			baseBranch: pr.BaseRefName,
-			prNumber:   pr.Number, panelName: ciPanelName(repoCfg, cfg),
+			prNumber:   pr.Number, panelName: panelName,
 			prDiscussionContext: prDiscussionContext,
-			members:             members, synth: synth,
+			members:             members, synth: synth, route: route, incremental: incremental,
 		})
 	if err != nil {
 		return err
@@ -479,6 +512,32 @@ func (p *CIPoller) enqueuePanelRun(ctx context.Context, ghRepo string, pr ghPR,
 	return nil
 }
 
+// narrowIncrementalPRRange narrows a PR's merge-base range to the commits
+// pushed since its last completed panel run. It returns the range unchanged,
+// with a nil link, when no earlier run reviewed a commit still on the PR
+// branch, e.g. after a force push.
+func (p *CIPoller) narrowIncrementalPRRange(
+	ctx context.Context, repoPath, ghRepo string, pr ghPR, gitRef string,
+) (string, *storage.IncrementalReview) {
+	commits, err := gitpkg.GetRangeCommitsCtx(ctx, repoPath, gitRef)
+	if err != nil {
+		log.Printf("CI poller: incremental review of %s#%d: %v", ghRepo, pr.Number, err)
+		return gitRef, nil
+	}
+	prior, err := p.db.ListCIPanelReviews(ghRepo, pr.Number, incrementalReviewLookback)
+	if err != nil {
+		log.Printf("CI poller: incremental review of %s#%d: %v", ghRepo, pr.Number, err)
+		return gitRef, nil
+	}
+	inc := planIncrementalReview(prior, commits, pr.HeadRefOid)
+	if inc == nil {
+		return gitRef, nil
+	}
+	log.Printf("CI poller: incremental review of %s#%d since %s (job %d, %d open prior reviews)",
+		ghRepo, pr.Number, gitpkg.ShortSHA(inc.BaseSHA), inc.BaseJobID, len(inc.OpenJobIDs))
+	return inc.BaseSHA + ".." + pr.HeadRefOid, inc
+}
+
 // setNoAgentStatus sets an "error" commit status telling the PR author the
 // review could not start because no agent was available for a member.
 func (p *CIPoller) setNoAgentStatus(ghRepo string, pr ghPR) {
@@ -560,15 +619,15 @@ func (p *CIPoller) throttlePR(ghRepo string, pr ghPR, cfg *config.Config) (bool,
 }
 
 // resolveCIMembers resolves the panel members and synthesis spec for a PR. When
-// [ci].panel (repo over global) names a panel, it resolves that panel from the
-// default-branch config (F1). Otherwise it adapts the agents x review_types
-// matrix into members and resolves synthesis from the fix workflow. It returns
-// an empty member slice (no error) when the resolved matrix is empty, which the
-// caller treats as "skip this PR".
+// panelName (a route's panel, else [ci].panel) is set, it resolves that panel
+// from the default-branch config (F1). Otherwise it adapts the agents x
+// review_types matrix into members and resolves synthesis from the fix
+// workflow. It returns an empty member slice (no error) when the resolved
+// matrix is empty, which the caller treats as "skip this PR".
 func (p *CIPoller) resolveCIMembers(
-	repo *storage.Repo, repoCfg *config.RepoConfig, cfg *config.Config, ghRepo string,
+	repo *storage.Repo, repoCfg *config.RepoConfig, cfg *config.Config, ghRepo, panelName string,
+	route *config.SelectedRoute,
 ) ([]config.ResolvedMember, config.SynthesisSpec, error) {
-	panelName := ciPanelName(repoCfg, cfg)
 	if panelName != "" {
 		members, synth, err := config.ResolveCIPanel(panelName, repoCfg, cfg)
 		if err != nil {
@@ -580,7 +639,7 @@ func (p *CIPoller) resolveCIMembers(
 		}
 		return members, synth, nil
 	}
-	return p.resolveCIMatrixMembers(repo, repoCfg, cfg, ghRepo)
+	return p.resolveCIMatrixMembers(repo, repoCfg, cfg, ghRepo, route)
 }
 
 // ciPanelName returns the configured CI panel name (repo [ci].panel over global
@@ -598,10 +657,23 @@ func ciPanelName(repoCfg *config.RepoConfig, cfg *config.Config) string {
 // pre-panel behavior. The synthesis spec is resolved from the fix workflow off
 // the default-branch config — the same resolution an empty PanelSpec would use
 // via ResolveCIPanel — with the synthesis reasoning following the CI reasoning.
+// A route's review type and reasoning replace the matrix's.
 func (p *CIPoller) resolveCIMatrixMembers(
 	repo *storage.Repo, repoCfg *config.RepoConfig, cfg *config.Config, ghRepo string,
+	route *config.SelectedRoute,
 ) ([]config.ResolvedMember, config.SynthesisSpec, error) {
 	matrix, reasoning := resolveCIMatrix(repoCfg, cfg, ghRepo)
+	if route != nil {
+		if route.ReviewType != "" {
+			for i := range matrix {
+				matrix[i].ReviewType = route.ReviewType
+			}
+			matrix = canonicalizeMatrix(matrix)
+		}
+		if route.Reasoning != "" {
+			reasoning = route.Reasoning
+		}
+	}
 	if err := validateMatrixReviewTypes(matrix); err != nil {
 		return nil, config.SynthesisSpec{}, err
 	}
@@ -967,6 +1039,8 @@ type buildPanelOptsInput struct {
 	prDiscussionContext string
 	members             []config.ResolvedMember
 	synth               config.SynthesisSpec
+	route               *config.SelectedRoute // matched [[review.routes]] entry, or nil
+	incremental         *storage.IncrementalReview
 }
 
 // buildPanelOpts builds the member and synthesis EnqueueOpts for a CI panel run.
@@ -974,13 +1048,28 @@ type buildPanelOptsInput struct {
 // and enqueues without a stored prompt (the worker rebuilds it). Members are
 // JobTypeRange with the panel member columns set; the synthesis is a claim-
 // blocked JobTypeSynthesis carrying the panel name and any synthesis backup.
-// CreateCIPanelRun stamps the shared panel_run_uuid and enforces the roles, so
-// PanelRunUUID is left empty here.
+// Each member's stored config carries its fallbacks chain. CreateCIPanelRun
+// stamps the shared panel_run_uuid and enforces the roles, so PanelRunUUID is
+// left empty here.
 func (p *CIPoller) buildPanelOpts(ctx context.Context, in buildPanelOptsInput) ([]storage.EnqueueOpts, storage.EnqueueOpts, error) {
 	synthesisMinSeverity := resolveMinSeverity(in.cfg.CI.MinSeverity, in.repo.RootPath, in.ghRepo)
 	reviewMinSeverity := resolveCIReviewMinSeverity(in.repoCfg, in.cfg, in.ghRepo)
+	var routeLabel string
+	if in.route != nil {
+		routeLabel = in.route.Label
+		if in.route.MinSeverity != "" {
+			reviewMinSeverity = in.route.MinSeverity
+			synthesisMinSeverity = in.route.MinSeverity
+		}
+	}
+	// Members whose subagent sets no fallbacks of its own fail over along
+	// the [ci] fallbacks chain, if any.
+	ciFallbacks := config.ResolveCIFallbacks(in.repoCfg, in.cfg)
 	memberOpts := make([]storage.EnqueueOpts, 0, len(in.members))
 	for i, m := range in.members {
+		if len(m.Fallbacks) == 0 {
+			m.Fallbacks = ciFallbacks
+		}
 		storedPrompt, err := p.callBuildReviewPrompt(
 			ctx, in.repo.RootPath, in.gitRef, in.repo.ID, in.cfg.ReviewContextCount,
 			m.Agent, m.ReviewType, reviewMinSeverity, in.prDiscussionContext, in.cfg,
@@ -1014,23 +1103,29 @@ func (p *CIPoller) buildPanelOpts(ctx context.Context, in buildPanelOptsInput) (
 			PanelMemberName:       m.Name,
 			PanelMemberIndex:      i,
 			PanelMemberConfigJSON: string(cfgJSON),
+			Route:                 routeLabel,
+			Incremental:           in.incremental,
 		})
 	}
 
+	synthJSON, _ := json.Marshal(in.synth)
 	synthOpts := storage.EnqueueOpts{
-		RepoID:       in.repo.ID,
-		GitRef:       in.gitRef,
-		CIBaseBranch: in.baseBranch,
-		Agent:        in.synth.Agent,
-		Model:        in.synth.Model,
-		Reasoning:    in.synth.Reasoning,
-		BackupAgent:  in.synth.BackupAgent,
-		BackupModel:  in.synth.BackupModel,
-		MinSeverity:  synthesisMinSeverity,
-		JobType:      storage.JobTypeSynthesis,
-		PanelRole:    storage.PanelRoleSynthesis,
-		PanelName:    in.panelName,
-		ClaimBlocked: true,
+		RepoID:                in.repo.ID,
+		GitRef:                in.gitRef,
+		CIBaseBranch:          in.baseBranch,
+		Agent:                 in.synth.Agent,
+		Model:                 in.synth.Model,
+		Reasoning:             in.synth.Reasoning,
+		BackupAgent:           in.synth.BackupAgent,
+		BackupModel:           in.synth.BackupModel,
+		MinSeverity:           synthesisMinSeverity,
+		JobType:               storage.JobTypeSynthesis,
+		PanelRole:             storage.PanelRoleSynthesis,
+		PanelName:             in.panelName,
+		PanelMemberConfigJSON: string(synthJSON),
+		ClaimBlocked:          true,
+		Route:                 routeLabel,
+		Incremental:           in.incremental,
 	}
 	return memberOpts, synthOpts, nil
 }
@@ -1848,7 +1943,7 @@ func (p *CIPoller) finalizePanelRun(row *storage.CIPanel, members []storage.Batc
 	case OutcomePost, OutcomeAllSkip:
 		p.postPanelComment(row, members)
 	case OutcomeGenuineGiveUp:
-		p.postPanelGiveUp(row,
+		p.postPanelGiveUp(row, "genuine", out.LastErrorExcerpt,
 			reviewpkg.FormatGenuineSoftNoteComment(row.HeadSHA, out.LastErrorExcerpt),
 			"error", "All reviews failed")
 	case OutcomeDeferTransient:
@@ -1931,14 +2026,17 @@ func (p *CIPoller) postPanelComment(row *storage.CIPanel, members []storage.Batc
 	}
 	log.Printf("CI poller: posted panel comment on %s#%d (panel %d, %d members)",
 		row.GithubRepo, row.PRNumber, row.ID, len(members))
+	p.broadcastCIEvent(row, EventCIPosted, CIEventData{}, "")
 }
 
 // postPanelGiveUp posts a give-up note, sets the requested commit status, marks
 // the attempt done, and finalizes the panel row. Transient/provider-unavailable
 // give-up remains non-blocking; deterministic genuine give-up is blocking. A
 // comment-post failure routes through the same permanent/transient handling as a
-// normal post.
-func (p *CIPoller) postPanelGiveUp(row *storage.CIPanel, body, statusState, statusDesc string) {
+// normal post. errClass and excerpt describe the failure on the ci.gave_up event.
+func (p *CIPoller) postPanelGiveUp(
+	row *storage.CIPanel, errClass, excerpt, body, statusState, statusDesc string,
+) {
 	if err := p.callPostPRComment(row.GithubRepo, row.PRNumber, body); err != nil {
 		p.handlePanelPostError(row, err)
 		return
@@ -1953,6 +2051,7 @@ func (p *CIPoller) postPanelGiveUp(row *storage.CIPanel, body, statusState, stat
 	}
 	log.Printf("CI poller: posted give-up note on %s#%d (panel %d)",
 		row.GithubRepo, row.PRNumber, row.ID)
+	p.broadcastCIEvent(row, EventCIGaveUp, CIEventData{ErrorClass: errClass}, excerpt)
 }
 
 // deferTransientPanel handles an all-transient panel (no successful member, ≥1
@@ -1963,7 +2062,8 @@ func (p *CIPoller) postPanelGiveUp(row *storage.CIPanel, body, statusState, stat
 func (p *CIPoller) deferTransientPanel(row *storage.CIPanel, attempt *storage.ReviewAttempt, excerpt string) {
 	now := time.Now()
 	if reviewpkg.DefaultRetrySchedule.TransientExhausted(now.Sub(attempt.FirstAttemptAt)) {
-		p.postPanelGiveUp(row, reviewpkg.FormatTransientGiveUpComment(row.HeadSHA, excerpt),
+		p.postPanelGiveUp(row, "transient", excerpt,
+			reviewpkg.FormatTransientGiveUpComment(row.HeadSHA, excerpt),
 			"success", "Review unavailable")
 		return
 	}
@@ -2004,6 +2104,39 @@ func (p *CIPoller) recordDeferral(
 	log.Printf("CI poller: deferred %s panel run for %s#%d@%s (panel %d, run %s, attempt %d, next_attempt_at %s, last_error=%q)",
 		errClass, row.GithubRepo, row.PRNumber, gitpkg.ShortSHA(row.HeadSHA), row.ID, row.PanelRunUUID,
 		attempt.Attempt, nextAt.Format(time.RFC3339), logExcerpt(excerpt))
+	p.broadcastCIEvent(row, EventCIDeferred, CIEventData{
+		ErrorClass: errClass, Attempt: attempt.Attempt, NextAttemptAt: &nextAt,
+	}, excerpt)
+}
+
+// broadcastCIEvent emits a ci.* event for a panel run. The job fields come
+// from the run's synthesis job when it can be loaded, so repo-scoped stream
+// subscribers and branch-filtered hooks see the local clone and branch.
+func (p *CIPoller) broadcastCIEvent(row *storage.CIPanel, eventType string, data CIEventData, errMsg string) {
+	if p.broadcaster == nil {
+		return
+	}
+	data.GithubRepo = row.GithubRepo
+	data.PRNumber = row.PRNumber
+	data.HeadSHA = row.HeadSHA
+	data.PanelRunUUID = row.PanelRunUUID
+	event := Event{
+		Type:  eventType,
+		TS:    time.Now(),
+		SHA:   row.HeadSHA,
+		Error: errMsg,
+		CI:    &data,
+	}
+	if synth, err := p.db.GetSynthesisJob(row.PanelRunUUID); err == nil && synth != nil {
+		event.JobID = synth.ID
+		event.JobUUID = synth.UUID
+		event.Repo = synth.RepoPath
+		event.RepoName = synth.RepoName
+		event.Branch = synth.HookBranch()
+		event.Agent = synth.Agent
+		event.Verdict = derefString(synth.Verdict)
+	}
+	p.broadcaster.Broadcast(event)
 }
 
 // markAttemptDone marks the HEAD's attempt terminal. finalizePanelRun guarantees
@@ -3092,6 +3225,7 @@ func toReviewResult(
 		_ = json.Unmarshal([]byte(br.PanelMemberConfigJSON), &member)
 	}
 	return reviewpkg.ReviewResult{
+		Name:         br.PanelMemberName,
 		Agent:        br.Agent,
 		ReviewType:   br.ReviewType,
 		Output:       br.Output,
@@ -3178,9 +3312,26 @@ func formatPanelPRFooter(job *storage.ReviewJob, synthesisAgent string, members
 	if total := formatPanelTotal(job, members, includeCosts); total != "" {
 		footer = append(footer, "Total: "+total)
 	}
+	if inc := job.Incremental; inc != nil {
+		footer = append(footer, formatPanelIncremental(inc))
+	}
 	return fmt.Sprintf("\n\n---\n*%s*\n", strings.Join(footer, " | "))
 }
 
+// formatPanelIncremental describes an incremental run in the PR comment
+// footer: where its range starts and how many earlier reviews it resolved.
+func formatPanelIncremental(inc *storage.IncrementalReview) string {
+	s := fmt.Sprintf("Incremental: since `%s`", gitpkg.ShortSHA(inc.BaseSHA))
+	switch n := len(inc.ResolvedJobIDs); n {
+	case 0:
+	case 1:
+		s += ", resolved 1 earlier review"
+	default:
+		s += fmt.Sprintf(", resolved %d earlier reviews", n)
+	}
+	return s
+}
+
 func formatCompactPanelPRFooter(job *storage.ReviewJob, synthesisAgent string, members []storage.BatchReviewResult, includeCosts bool) string {
 	if job == nil {
 		return ""
@@ -3365,6 +3516,16 @@ func formatCost(tokenUsage string) string {
 // it finds and patches an existing marker comment; otherwise it
 // always creates a new comment.
 func (p *CIPoller) postPRComment(ghRepo string, prNumber int, body string) error {
+	var repoPath string
+	if repo, err := p.findLocalRepo(ghRepo); err == nil && repo != nil {
+		repoPath = repo.RootPath
+	}
+	redactor, err := redact.ForRepo(repoPath, p.cfgGetter.Config())
+	if err != nil {
+		return fmt.Errorf("redaction: %w", err)
+	}
+	body = redactor.String(body)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 	client, err := p.githubClientForRepo(ghRepo)
@@ -3019,7 +3019,7 @@ func TestResolveCIMatrixMembersUsesPassedRepoConfigForAgentModel(t *testing.T) {
 	}
 
 	members, _, err := h.Poller.resolveCIMatrixMembers(
-		h.Repo, repoCfg, h.Cfg, "acme/api",
+		h.Repo, repoCfg, h.Cfg, "acme/api", nil,
 	)
 	require.NoError(t, err)
 	require.Len(t, members, 1)
@@ -4262,6 +4262,62 @@ func TestBuildPanelOpts_RecordsPRBranchOnJobs(t *testing.T) {
 		"CI synthesis job must not set Branch (it would leak into branch-scoped local flows)")
 }
 
+func TestBuildPanelOpts_AppliesReviewRoute(t *testing.T) {
+	p := &CIPoller{}
+	p.buildReviewPromptFn = func(context.Context, string, string, int64, int, string, string, string, string, *config.Config) (string, error) {
+		return "prebuilt prompt", nil
+	}
+
+	memberOpts, synthOpts, err := p.buildPanelOpts(context.Background(), buildPanelOptsInput{
+		repo:     &storage.Repo{ID: 1, RootPath: t.TempDir()},
+		cfg:      config.DefaultConfig(),
+		ghRepo:   "kenn-io/roborev",
+		gitRef:   "base..head",
+		prNumber: 42,
+		members:  []config.ResolvedMember{{Name: "m1", Agent: "codex"}},
+		synth:    config.SynthesisSpec{Agent: "codex"},
+		route:    &config.SelectedRoute{Label: "crypto", MinSeverity: "high"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, memberOpts, 1)
+	assert.Equal(t, "crypto", memberOpts[0].Route)
+	assert.Equal(t, "high", memberOpts[0].MinSeverity)
+	assert.Equal(t, "crypto", synthOpts.Route)
+	assert.Equal(t, "high", synthOpts.MinSeverity)
+}
+
+func TestBuildPanelOpts_StoresMemberFallbacks(t *testing.T) {
+	p := &CIPoller{}
+	p.buildReviewPromptFn = func(context.Context, string, string, int64, int, string, string, string, string, *config.Config) (string, error) {
+		return "prebuilt prompt", nil
+	}
+	cfg := config.DefaultConfig()
+	cfg.CI.Fallbacks = []string{"claude-code:sonnet", "gemini"}
+
+	memberOpts, _, err := p.buildPanelOpts(context.Background(), buildPanelOptsInput{
+		repo:     &storage.Repo{ID: 1, RootPath: t.TempDir()},
+		cfg:      cfg,
+		ghRepo:   "kenn-io/roborev",
+		gitRef:   "base..head",
+		prNumber: 42,
+		members: []config.ResolvedMember{
+			{Name: "m1", Agent: "codex"},
+			{Name: "m2", Agent: "codex", Fallbacks: []string{"opencode"}},
+		},
+		synth: config.SynthesisSpec{Agent: "codex"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, memberOpts, 2)
+	var m1, m2 config.ResolvedMember
+	require.NoError(t, json.Unmarshal([]byte(memberOpts[0].PanelMemberConfigJSON), &m1))
+	require.NoError(t, json.Unmarshal([]byte(memberOpts[1].PanelMemberConfigJSON), &m2))
+	assert.Equal(t, []string{"claude-code:sonnet", "gemini"}, m1.Fallbacks,
+		"members without their own fallbacks use the [ci] chain")
+	assert.Equal(t, []string{"opencode"}, m2.Fallbacks)
+}
+
 // installFakeKata copies the test binary to a temp dir as `kata` and points
 // PATH and ROBOREV_TEST_FAKE_KATA at it, so any kata CLI invocation
 // deterministically returns an open issue (see TestMain) instead of depending
@@ -0,0 +1,368 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// Circuit breaker states, as reported by /api/agents/circuits and
+// agent.circuit events.
+const (
+	CircuitClosed   = "closed"    // jobs run on the agent
+	CircuitOpen     = "open"      // jobs are rerouted away from the agent
+	CircuitHalfOpen = "half_open" // one probe job may run on the agent
+)
+
+const (
+	// circuitWindow is how far back agent runs count toward the failure
+	// rate.
+	circuitWindow = 15 * time.Minute
+	// circuitMinSamples is the fewest runs in the window that can open a
+	// circuit on failure rate alone.
+	circuitMinSamples = 4
+	// circuitFailureRate is the fraction of failed runs in the window that
+	// opens a circuit.
+	circuitFailureRate = 0.5
+	// circuitFailureOpenFor is how long a failure-rate trip keeps a circuit
+	// open, capped by agent_quota_cooldown.
+	circuitFailureOpenFor = 5 * time.Minute
+	// circuitProbeDefer is how long a job without a fallback waits while
+	// another job probes a half-open circuit.
+	circuitProbeDefer = 30 * time.Second
+)
+
+// circuitKey identifies one breaker: a canonical agent name and, for agents
+// that route to several providers, the provider.
+type circuitKey struct {
+	Agent    string
+	Provider string
+}
+
+// circuitKeyFor keys a breaker by canonical agent and provider. The provider
+// is the job's explicit provider, or the prefix of a "provider/model" model
+// name; agents without providers share one circuit across their models.
+func circuitKeyFor(agentName, provider, model string) circuitKey {
+	provider = strings.TrimSpace(provider)
+	if provider == "" {
+		if p, _, ok := strings.Cut(strings.TrimSpace(model), "/"); ok {
+			provider = p
+		}
+	}
+	return circuitKey{Agent: agent.CanonicalName(agentName), Provider: strings.ToLower(provider)}
+}
+
+// jobCircuitKey keys the breaker a job uses when it runs on agentName and
+// model. Failover keeps the job's provider, so checking a fallback and
+// recording its run must both go through here to read the same breaker.
+func jobCircuitKey(job *storage.ReviewJob, agentName, model string) circuitKey {
+	return circuitKeyFor(agentName, job.Provider, model)
+}
+
+func (k circuitKey) String() string {
+	if k.Provider == "" {
+		return k.Agent
+	}
+	return k.Agent + "/" + k.Provider
+}
+
+// AgentCircuit is the breaker state of one agent and provider. Failures and
+// Runs count agent runs in the rolling window.
+type AgentCircuit struct {
+	Agent     string     `json:"agent"`
+	Provider  string     `json:"provider,omitempty"`
+	State     string     `json:"state"`
+	Reason    string     `json:"reason,omitempty"`
+	Failures  int        `json:"failures"`
+	Runs      int        `json:"runs"`
+	OpenUntil *time.Time `json:"open_until,omitempty"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+type circuitRun struct {
+	at     time.Time
+	failed bool
+}
+
+type agentCircuit struct {
+	state     string
+	runs      []circuitRun
+	openUntil time.Time
+	openFor   time.Duration
+	reason    string
+	probeJob  int64 // job holding the half-open probe; 0 when none
+	changedAt time.Time
+}
+
+// circuitBreakers tracks a circuit per agent and provider across every job
+// type. A quota or session limit opens a circuit at once; otherwise it opens
+// when the failure rate over the rolling window crosses the threshold. An
+// open circuit half-opens when its time is up, and the next job on that agent
+// probes it: success closes the circuit, failure reopens it. State is kept in
+// memory, so a daemon restart closes every circuit.
+type circuitBreakers struct {
+	mu       sync.Mutex
+	circuits map[circuitKey]*agentCircuit
+	now      func() time.Time
+	// maxOpen caps how long any circuit stays open.
+	maxOpen func() time.Duration
+	// onChange is called, outside the lock, after a circuit changes state.
+	onChange func(AgentCircuit)
+}
+
+func newCircuitBreakers(maxOpen func() time.Duration, onChange func(AgentCircuit)) *circuitBreakers {
+	return &circuitBreakers{
+		circuits: make(map[circuitKey]*agentCircuit),
+		now:      time.Now,
+		maxOpen:  maxOpen,
+		onChange: onChange,
+	}
+}
+
+// allow reports whether jobID may run on the agent now. A half-open circuit
+// admits only the job that claims its probe.
+func (b *circuitBreakers) allow(k circuitKey, jobID int64) bool {
+	b.mu.Lock()
+	c, changed := b.refreshLocked(k)
+	allowed := true
+	if c != nil {
+		switch c.state {
+		case CircuitOpen:
+			allowed = false
+		case CircuitHalfOpen:
+			if c.probeJob == 0 {
+				c.probeJob = jobID
+			}
+			allowed = c.probeJob == jobID
+		}
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+	return allowed
+}
+
+// blocked reports whether jobs should route away from the agent: its circuit
+// is open, or half-open with a probe already running.
+func (b *circuitBreakers) blocked(k circuitKey) bool {
+	return b.state(k) != CircuitClosed
+}
+
+// state returns the circuit state, reporting a half-open circuit whose
+// probe has not been claimed as closed since the next job may run.
+func (b *circuitBreakers) state(k circuitKey) string {
+	b.mu.Lock()
+	c, changed := b.refreshLocked(k)
+	state := CircuitClosed
+	if c != nil && (c.state == CircuitOpen || c.probeJob != 0) {
+		state = c.state
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+	return state
+}
+
+// release gives up a half-open probe claimed by jobID when the job ended
+// without an agent result, such as a cancel or a checkout failure.
+func (b *circuitBreakers) release(k circuitKey, jobID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c := b.circuits[k]; c != nil && c.state == CircuitHalfOpen && c.probeJob == jobID {
+		c.probeJob = 0
+	}
+}
+
+// trip opens the circuit until the given time, capped by maxOpen. Later
+// trips replace the expiry, so a provider hint may shorten it.
+func (b *circuitBreakers) trip(k circuitKey, until time.Time, reason string) {
+	b.mu.Lock()
+	c := b.getLocked(k)
+	now := b.now()
+	until = b.clamp(until, now)
+	wasOpen := c.state == CircuitOpen
+	c.runs = nil
+	c.probeJob = 0
+	c.openUntil = until
+	c.openFor = until.Sub(now)
+	c.reason = reason
+	var changed []AgentCircuit
+	if !wasOpen {
+		c.state = CircuitOpen
+		c.changedAt = now
+		changed = append(changed, b.viewLocked(k, c))
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+}
+
+// record feeds one agent run into the circuit. A half-open circuit closes on
+// success and reopens on failure; a closed one opens when the failure rate
+// over the window crosses the threshold.
+func (b *circuitBreakers) record(k circuitKey, failed bool, reason string) {
+	b.mu.Lock()
+	_, changed := b.refreshLocked(k)
+	c := b.getLocked(k)
+	now := b.now()
+	switch c.state {
+	case CircuitHalfOpen:
+		c.probeJob = 0
+		c.changedAt = now
+		if failed {
+			openFor := c.openFor
+			if openFor <= 0 {
+				openFor = circuitFailureOpenFor
+			}
+			c.state = CircuitOpen
+			c.openUntil = b.clamp(now.Add(openFor), now)
+			c.reason = "probe failed: " + reason
+		} else {
+			c.state = CircuitClosed
+			c.runs = nil
+			c.reason = ""
+		}
+		changed = append(changed, b.viewLocked(k, c))
+	case CircuitClosed:
+		c.runs = append(pruneCircuitRuns(c.runs, now), circuitRun{at: now, failed: failed})
+		if failures := countCircuitFailures(c.runs); failed && len(c.runs) >= circuitMinSamples &&
+			float64(failures) >= circuitFailureRate*float64(len(c.runs)) {
+			c.state = CircuitOpen
+			c.openUntil = b.clamp(now.Add(circuitFailureOpenFor), now)
+			c.openFor = c.openUntil.Sub(now)
+			c.reason = fmt.Sprintf("%d of %d recent runs failed: %s", failures, len(c.runs), reason)
+			c.runs = nil
+			c.changedAt = now
+			changed = append(changed, b.viewLocked(k, c))
+		}
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+}
+
+// snapshot returns every circuit that is not closed or has runs in the
+// window, ordered by agent then provider.
+func (b *circuitBreakers) snapshot() []AgentCircuit {
+	b.mu.Lock()
+	var out, changed []AgentCircuit
+	for k := range b.circuits {
+		c, ch := b.refreshLocked(k)
+		changed = append(changed, ch...)
+		c.runs = pruneCircuitRuns(c.runs, b.now())
+		if c.state == CircuitClosed && len(c.runs) == 0 {
+			continue
+		}
+		out = append(out, b.viewLocked(k, c))
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Agent != out[j].Agent {
+			return out[i].Agent < out[j].Agent
+		}
+		return out[i].Provider < out[j].Provider
+	})
+	return out
+}
+
+func (b *circuitBreakers) getLocked(k circuitKey) *agentCircuit {
+	c := b.circuits[k]
+	if c == nil {
+		c = &agentCircuit{state: CircuitClosed, changedAt: b.now()}
+		b.circuits[k] = c
+	}
+	return c
+}
+
+// refreshLocked returns the circuit for k, or nil when there is none, after
+// re-capping an open circuit to the current maxOpen and half-opening it when
+// its time is up.
+func (b *circuitBreakers) refreshLocked(k circuitKey) (*agentCircuit, []AgentCircuit) {
+	c := b.circuits[k]
+	if c == nil || c.state != CircuitOpen {
+		return c, nil
+	}
+	now := b.now()
+	c.openUntil = b.clamp(c.openUntil, now)
+	if now.Before(c.openUntil) {
+		return c, nil
+	}
+	c.state = CircuitHalfOpen
+	c.changedAt = now
+	return c, []AgentCircuit{b.viewLocked(k, c)}
+}
+
+func (b *circuitBreakers) clamp(until, now time.Time) time.Time {
+	if limit := now.Add(b.maxOpen()); until.After(limit) {
+		return limit
+	}
+	return until
+}
+
+func (b *circuitBreakers) viewLocked(k circuitKey, c *agentCircuit) AgentCircuit {
+	v := AgentCircuit{
+		Agent:     k.Agent,
+		Provider:  k.Provider,
+		State:     c.state,
+		Reason:    c.reason,
+		Failures:  countCircuitFailures(c.runs),
+		Runs:      len(c.runs),
+		ChangedAt: c.changedAt,
+	}
+	if c.state == CircuitOpen {
+		until := c.openUntil
+		v.OpenUntil = &until
+	}
+	return v
+}
+
+func (b *circuitBreakers) notify(changed []AgentCircuit) {
+	if b.onChange == nil {
+		return
+	}
+	for _, c := range changed {
+		b.onChange(c)
+	}
+}
+
+func pruneCircuitRuns(runs []circuitRun, now time.Time) []circuitRun {
+	cutoff := now.Add(-circuitWindow)
+	i := 0
+	for i < len(runs) && runs[i].at.Before(cutoff) {
+		i++
+	}
+	return runs[i:]
+}
+
+func countCircuitFailures(runs []circuitRun) int {
+	n := 0
+	for _, r := range runs {
+		if r.failed {
+			n++
+		}
+	}
+	return n
+}
+
+// broadcastCircuit logs a circuit state change and emits it as an
+// agent.circuit event.
+func (wp *WorkerPool) broadcastCircuit(c AgentCircuit) {
+	name := circuitKey{Agent: c.Agent, Provider: c.Provider}.String()
+	if c.Reason != "" && c.State == CircuitOpen {
+		log.Printf("Agent %s circuit %s: %s", name, c.State, c.Reason)
+	} else {
+		log.Printf("Agent %s circuit %s", name, c.State)
+	}
+	if wp.broadcaster == nil {
+		return
+	}
+	wp.broadcaster.Broadcast(Event{
+		Type:    EventAgentCircuit,
+		TS:      time.Now(),
+		Agent:   c.Agent,
+		Circuit: &c,
+	})
+}
@@ -0,0 +1,263 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+// circuitOpenUntil returns the expiry of an open circuit for agentName.
+func circuitOpenUntil(t *testing.T, b *circuitBreakers, agentName string) time.Time {
+	t.Helper()
+	for _, c := range b.snapshot() {
+		if c.Agent == agentName && c.State == CircuitOpen {
+			require.NotNil(t, c.OpenUntil)
+			return *c.OpenUntil
+		}
+	}
+	require.Failf(t, "circuit not open", "agent %s", agentName)
+	return time.Time{}
+}
+
+// newTestCircuitBreakers returns breakers on a fake clock, capped at maxOpen,
+// that collect their state changes.
+func newTestCircuitBreakers(maxOpen time.Duration) (*circuitBreakers, *time.Time, *[]AgentCircuit) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var changes []AgentCircuit
+	b := newCircuitBreakers(func() time.Duration { return maxOpen }, func(c AgentCircuit) {
+		changes = append(changes, c)
+	})
+	b.now = func() time.Time { return now }
+	return b, &now, &changes
+}
+
+func TestCircuitKeyFor(t *testing.T) {
+	assert.Equal(t, circuitKey{Agent: "claude-code"}, circuitKeyFor("claude", "", "sonnet"))
+	assert.Equal(t, circuitKey{Agent: "opencode", Provider: "anthropic"},
+		circuitKeyFor("opencode", "", "anthropic/claude-sonnet-4"))
+	assert.Equal(t, circuitKey{Agent: "pi", Provider: "openai"}, circuitKeyFor("pi", "OpenAI", "gpt-5"))
+	assert.Equal(t, "opencode/anthropic", circuitKeyFor("opencode", "", "anthropic/claude").String())
+}
+
+func TestCircuitBreakerTripAndProbe(t *testing.T) {
+	b, now, changes := newTestCircuitBreakers(30 * time.Minute)
+	codex := circuitKeyFor("codex", "", "")
+
+	assert.True(t, b.allow(codex, 1))
+	b.trip(codex, now.Add(time.Hour), "quota limit: usage limit")
+	assert.False(t, b.allow(codex, 1))
+	assert.True(t, b.blocked(codex))
+	assert.WithinDuration(t, now.Add(30*time.Minute), circuitOpenUntil(t, b, "codex"), 0,
+		"open time is capped by agent_quota_cooldown")
+	assert.False(t, b.blocked(circuitKeyFor("gemini", "", "")), "other agents are unaffected")
+
+	// A later, shorter provider hint replaces the expiry.
+	b.trip(codex, now.Add(2*time.Minute), "quota limit")
+	assert.WithinDuration(t, now.Add(2*time.Minute), circuitOpenUntil(t, b, "codex"), 0)
+
+	// Once the time is up, one job probes the half-open circuit.
+	*now = now.Add(3 * time.Minute)
+	assert.False(t, b.blocked(codex), "an unclaimed probe lets the next job run")
+	assert.True(t, b.allow(codex, 7))
+	assert.False(t, b.allow(codex, 8), "only the probe job runs while half-open")
+	assert.Equal(t, CircuitHalfOpen, b.state(codex))
+
+	// A probe that ends without a result frees the probe for another job.
+	b.release(codex, 7)
+	assert.True(t, b.allow(codex, 8))
+
+	// A failed probe reopens the circuit for the last open time.
+	b.record(codex, true, "connection reset")
+	assert.False(t, b.allow(codex, 9))
+	assert.WithinDuration(t, now.Add(2*time.Minute), circuitOpenUntil(t, b, "codex"), 0)
+
+	// A successful probe closes it.
+	*now = now.Add(2 * time.Minute)
+	assert.True(t, b.allow(codex, 9))
+	b.record(codex, false, "")
+	assert.True(t, b.allow(codex, 10))
+	assert.True(t, b.allow(codex, 11))
+
+	var states []string
+	for _, c := range *changes {
+		states = append(states, c.State)
+	}
+	assert.Equal(t, []string{
+		CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed,
+	}, states)
+	assert.Equal(t, "probe failed: connection reset", (*changes)[2].Reason)
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	b, now, _ := newTestCircuitBreakers(30 * time.Minute)
+	gemini := circuitKeyFor("gemini", "", "")
+
+	b.record(gemini, false, "")
+	b.record(gemini, true, "boom")
+	b.record(gemini, false, "")
+	assert.False(t, b.blocked(gemini), "too few runs to trip")
+	b.record(gemini, true, "boom")
+	assert.True(t, b.blocked(gemini), "2 of 4 runs failed")
+	assert.WithinDuration(t, now.Add(circuitFailureOpenFor), circuitOpenUntil(t, b, "gemini"), 0)
+	snap := b.snapshot()
+	require.Len(t, snap, 1)
+	assert.Equal(t, "2 of 4 recent runs failed: boom", snap[0].Reason)
+
+	// Runs older than the window no longer count.
+	claude := circuitKeyFor("claude-code", "", "")
+	b.record(claude, true, "boom")
+	b.record(claude, true, "boom")
+	b.record(claude, true, "boom")
+	*now = now.Add(circuitWindow + time.Minute)
+	b.record(claude, true, "boom")
+	assert.False(t, b.blocked(claude))
+}
+
+func TestCircuitBreakerClampsToCurrentConfig(t *testing.T) {
+	maxOpen := time.Hour
+	b, now, _ := newTestCircuitBreakers(0)
+	b.maxOpen = func() time.Duration { return maxOpen }
+	codex := circuitKeyFor("codex", "", "")
+	b.trip(codex, now.Add(time.Hour), "quota limit")
+
+	maxOpen = 5 * time.Minute
+	assert.True(t, b.blocked(codex))
+	assert.WithinDuration(t, now.Add(5*time.Minute), circuitOpenUntil(t, b, "codex"), 0)
+
+	// A trip that has already expired leaves the circuit ready for a probe.
+	b.trip(circuitKeyFor("gemini", "", ""), now.Add(-time.Second), "quota limit")
+	assert.False(t, b.blocked(circuitKeyFor("gemini", "", "")))
+}
+
+func TestProcessJob_OpenCircuitReroutesDownFallbackChain(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := testutil.GetHeadSHA(t, tc.TmpDir)
+	for _, name := range []string{"chain-first", "chain-second"} {
+		agent.Register(&agent.FakeAgent{NameStr: name})
+		t.Cleanup(func() { agent.Unregister(name) })
+	}
+	cfg := config.DefaultConfig()
+	cfg.ReviewBackupAgent = "chain-first"
+	cfg.DefaultBackupAgent = "chain-second"
+	cfg.DefaultBackupModel = "second-model"
+	tc.reconfigurePool(cfg)
+
+	_, eventCh := tc.Broadcaster.Subscribe("")
+	claimed := tc.createAndClaimJobWithAgent(t, sha, testWorkerID, "codex")
+	tc.Pool.circuits.trip(circuitKeyFor("codex", "", ""), time.Now().Add(time.Hour), "quota limit")
+	tc.Pool.circuits.trip(circuitKeyFor("chain-first", "", ""), time.Now().Add(time.Hour), "quota limit")
+
+	// The job moves before any agent runs, skipping the open first backup.
+	tc.Pool.processJob(testWorkerID, claimed)
+	updated := tc.assertJobStatus(t, claimed.ID, storage.JobStatusQueued)
+	assert.Equal(t, "chain-second", updated.Agent)
+	assert.Equal(t, "second-model", updated.Model)
+	retries, err := tc.DB.GetJobRetryCount(claimed.ID)
+	require.NoError(t, err)
+	assert.Zero(t, retries)
+
+	select {
+	case ev := <-eventCh:
+		assert.Equal(t, EventAgentCircuit, ev.Type)
+		require.NotNil(t, ev.Circuit)
+		assert.Equal(t, "codex", ev.Circuit.Agent)
+		assert.Equal(t, CircuitOpen, ev.Circuit.State)
+	case <-time.After(time.Second):
+		require.Fail(t, "no agent.circuit event")
+	}
+}
+
+func TestProcessJob_FailoverSkipsOpenProviderCircuit(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := testutil.GetHeadSHA(t, tc.TmpDir)
+	for _, name := range []string{"chain-first", "chain-second"} {
+		agent.Register(&agent.FakeAgent{NameStr: name})
+		t.Cleanup(func() { agent.Unregister(name) })
+	}
+	cfg := config.DefaultConfig()
+	cfg.ReviewBackupAgent = "chain-first"
+	cfg.DefaultBackupAgent = "chain-second"
+	tc.reconfigurePool(cfg)
+
+	claimed := tc.createAndClaimJobWithAgent(t, sha, testWorkerID, "codex")
+	_, err := tc.DB.Exec(`UPDATE review_jobs SET provider = ? WHERE id = ?`, "openrouter", claimed.ID)
+	require.NoError(t, err)
+	claimed.Provider = "openrouter"
+	tc.Pool.circuits.trip(jobCircuitKey(claimed, "codex", ""), time.Now().Add(time.Hour), "quota limit")
+	// Failover keeps the provider, so chain-first would run, and trip,
+	// under its openrouter breaker, not the provider-less one.
+	tc.Pool.circuits.trip(circuitKeyFor("chain-first", "openrouter", ""), time.Now().Add(time.Hour), "quota limit")
+
+	tc.Pool.processJob(testWorkerID, claimed)
+	updated := tc.assertJobStatus(t, claimed.ID, storage.JobStatusQueued)
+	assert.Equal(t, "chain-second", updated.Agent)
+	assert.Equal(t, "openrouter", updated.Provider)
+}
+
+func TestProcessJob_HalfOpenCircuitDefersOtherJobs(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := testutil.GetHeadSHA(t, tc.TmpDir)
+
+	claimed := tc.createAndClaimJobWithAgent(t, sha, testWorkerID, "codex")
+	codex := circuitKeyFor("codex", "", "")
+	tc.Pool.circuits.trip(codex, time.Now().Add(-time.Second), "quota limit")
+	require.True(t, tc.Pool.circuits.allow(codex, claimed.ID+1000), "another job holds the probe")
+
+	tc.Pool.processJob(testWorkerID, claimed)
+	updated := tc.assertJobStatus(t, claimed.ID, storage.JobStatusQueued)
+	assert.Equal(t, "codex", updated.Agent)
+	assert.Empty(t, updated.Error)
+}
+
+func TestWorkerRecordsAgentOutcomes(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := testutil.GetHeadSHA(t, tc.TmpDir)
+	agent.Register(&agent.FakeAgent{
+		NameStr: "flaky",
+		ReviewFn: func(context.Context, string, string, string, io.Writer) (string, error) {
+			return "", errors.New("connection reset")
+		},
+	})
+	t.Cleanup(func() { agent.Unregister("flaky") })
+
+	tc.Pool.processJob(testWorkerID, tc.createAndClaimJob(t, sha, testWorkerID))
+	tc.Pool.processJob(testWorkerID, tc.createAndClaimJobWithAgent(t, sha, testWorkerID, "flaky"))
+
+	byAgent := map[string]AgentCircuit{}
+	for _, c := range tc.Pool.circuits.snapshot() {
+		byAgent[c.Agent] = c
+	}
+	assert.Equal(t, CircuitClosed, byAgent["test"].State)
+	assert.Equal(t, 1, byAgent["test"].Runs)
+	assert.Zero(t, byAgent["test"].Failures)
+	assert.Equal(t, 1, byAgent["flaky"].Failures)
+}
+
+func TestHumaListAgentCircuits(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	srv.workerPool.circuits.trip(circuitKeyFor("codex", "", ""), time.Now().Add(time.Hour), "quota limit: usage limit")
+
+	rr := serveHuma(t, srv, http.MethodGet, "/api/agents/circuits", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var out struct {
+		Circuits []AgentCircuit `json:"circuits"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+	require.Len(t, out.Circuits, 1)
+	assert.Equal(t, "codex", out.Circuits[0].Agent)
+	assert.Equal(t, CircuitOpen, out.Circuits[0].State)
+	assert.Equal(t, "quota limit: usage limit", out.Circuits[0].Reason)
+	require.NotNil(t, out.Circuits[0].OpenUntil)
+}
@@ -31,6 +31,9 @@ type Client interface {
 	// AddComment adds a comment to a job
 	AddComment(jobID int64, commenter, comment string) error
 
+	// SaveVerifyResult records the verify_command outcome of edits made for a job
+	SaveVerifyResult(jobID int64, result storage.VerifyResult) error
+
 	// EnqueueReview enqueues a review job and returns the job ID
 	EnqueueReview(repoPath, gitRef, agentName string) (int64, error)
 
@@ -159,6 +162,23 @@ func (c *HTTPClient) AddComment(jobID int64, commenter, comment string) error {
 	return nil
 }
 
+func (c *HTTPClient) SaveVerifyResult(jobID int64, result storage.VerifyResult) error {
+	reqBody, _ := json.Marshal(JobVerifyRequest{JobID: jobID, Verify: result})
+
+	resp, err := c.httpClient.Post(c.baseURL+"/api/job/verify", "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("save verify result: %s: %s", resp.Status, body)
+	}
+
+	return nil
+}
+
 func (c *HTTPClient) EnqueueReview(repoPath, gitRef, agentName string) (int64, error) {
 	reqBody, _ := json.Marshal(EnqueueRequest{
 		RepoPath: repoPath,
@@ -144,6 +144,21 @@ func TestHTTPClientMarkReviewClosed(t *testing.T) {
 	assert.True(received.Closed)
 }
 
+func TestHTTPClientSaveVerifyResult(t *testing.T) {
+	assert := assert.New(t)
+
+	var received JobVerifyRequest
+	client := mockAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, http.MethodPost, "/api/job/verify")
+		decodeJSON(t, r, &received)
+		w.WriteHeader(http.StatusOK)
+	})
+	require.NoError(t, client.SaveVerifyResult(99, storage.VerifyResult{Passed: true, DurationMs: 250}))
+	assert.Equal(int64(99), received.JobID)
+	assert.True(received.Verify.Passed)
+	assert.Equal(int64(250), received.Verify.DurationMs)
+}
+
 func TestHTTPClientWaitForReviewUsesJobID(t *testing.T) {
 	assert := assert.New(t)
 
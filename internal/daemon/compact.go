@@ -36,6 +36,25 @@ func ReadCompactMetadata(jobID int64) (*CompactMetadata, error) {
 	return &metadata, nil
 }
 
+// WriteCompactMetadata records the source job IDs of a compact job, so the
+// worker can close them when it completes. Nothing is written for no sources.
+func WriteCompactMetadata(jobID int64, sourceJobIDs []int64) error {
+	if len(sourceJobIDs) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(CompactMetadata{SourceJobIDs: sourceJobIDs})
+	if err != nil {
+		return fmt.Errorf("marshal metadata: %w", err)
+	}
+	if err := os.MkdirAll(config.DataDir(), 0o755); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	if err := os.WriteFile(compactMetadataPath(jobID), data, 0o644); err != nil {
+		return fmt.Errorf("write metadata file: %w", err)
+	}
+	return nil
+}
+
 // DeleteCompactMetadata removes the metadata file after processing
 func DeleteCompactMetadata(jobID int64) error {
 	path := compactMetadataPath(jobID)
@@ -214,13 +214,16 @@ func (cw *ConfigWatcher) reloadConfig() {
 	agent.SetAllowUnsafeAgents(newCfg.AllowUnsafeAgents != nil && *newCfg.AllowUnsafeAgents)
 	agent.SetCodexSandboxDisabled(newCfg.DisableCodexSandbox)
 	agent.SetAnthropicAPIKey(newCfg.AnthropicAPIKey)
+	if err := agent.ConfigureSandbox(newCfg.Sandbox); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	// Log what changed (for debugging)
 	logConfigChanges(oldCfg, newCfg)
 
 	// Broadcast config reloaded event to notify connected clients
 	cw.broadcaster.Broadcast(Event{
-		Type: "config.reloaded",
+		Type: EventConfigReloaded,
 		TS:   time.Now(),
 	})
 
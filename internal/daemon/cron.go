@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// cronPoll is how often a cronLoop checks for due entries.
+const cronPoll = time.Minute
+
+// cronEntry is one configured item a cronLoop runs on its schedule, such as
+// a digest or a [[schedule]] entry.
+type cronEntry struct {
+	key  string                          // names the entry in logs and its daemon_state key
+	err  error                           // set for an invalid entry, which is skipped
+	next func(last time.Time) time.Time  // first run after last; zero for never
+	run  func(scheduled time.Time) error // performs the run that came due at scheduled
+}
+
+// cronLoop is the polling loop behind the digest and job schedulers. Each
+// entry's last run is recorded in daemon_state, so a restart neither
+// repeats a run nor skips one that came due while the daemon was down.
+// Entries run at most once per slot: a failed run is logged, not retried.
+type cronLoop struct {
+	db          *storage.DB
+	cfgGetter   ConfigGetter
+	logger      *log.Logger
+	now         func() time.Time
+	label       string // "Digest" or "Schedule", for log lines
+	statePrefix string
+	entries     func(cfg *config.Config) []cronEntry
+
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   bool
+	mu        sync.Mutex
+}
+
+func newCronLoop(
+	db *storage.DB, cfgGetter ConfigGetter, logger *log.Logger,
+	label, statePrefix string, entries func(cfg *config.Config) []cronEntry,
+) *cronLoop {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &cronLoop{
+		db:          db,
+		cfgGetter:   cfgGetter,
+		logger:      logger,
+		now:         time.Now,
+		label:       label,
+		statePrefix: statePrefix,
+		entries:     entries,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// Start launches the scheduling loop. Safe to call more than once.
+func (l *cronLoop) Start() {
+	l.startOnce.Do(func() {
+		l.mu.Lock()
+		l.started = true
+		l.mu.Unlock()
+		go l.run()
+	})
+}
+
+// Stop halts the loop and waits for an in-flight pass to finish.
+func (l *cronLoop) Stop() {
+	l.stopOnce.Do(func() {
+		close(l.stopCh)
+		l.mu.Lock()
+		started := l.started
+		l.mu.Unlock()
+		if started {
+			<-l.doneCh
+		}
+	})
+}
+
+func (l *cronLoop) run() {
+	defer close(l.doneCh)
+	ticker := time.NewTicker(cronPoll)
+	defer ticker.Stop()
+	for {
+		l.runDue()
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue runs every entry whose next run has passed.
+func (l *cronLoop) runDue() {
+	cfg := l.cfgGetter.Config()
+	if cfg == nil {
+		return
+	}
+	now := l.now()
+	for _, e := range l.entries(cfg) {
+		if e.err != nil {
+			l.logger.Printf("%s %q skipped: %v", l.label, e.key, e.err)
+			continue
+		}
+		key := l.statePrefix + e.key
+		raw, err := l.db.GetDaemonState(key)
+		if err != nil {
+			l.logger.Printf("%s %q: %v", l.label, e.key, err)
+			continue
+		}
+		if raw == "" {
+			// First sight of this entry: start its schedule from now rather
+			// than running at once because the daemon was just configured.
+			l.recordRun(key, now)
+			continue
+		}
+		last, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			l.logger.Printf("%s %q: invalid last run %q, resetting", l.label, e.key, raw)
+			l.recordRun(key, now)
+			continue
+		}
+		next := e.next(last.In(now.Location()))
+		if next.IsZero() || now.Before(next) {
+			continue
+		}
+		if err := e.run(next); err != nil {
+			l.logger.Printf("%s %q: %v", l.label, e.key, err)
+		}
+		// Runs missed while the daemon was down collapse into this one,
+		// and a failed run waits for the next slot instead of retrying
+		// every minute.
+		l.recordRun(key, now)
+	}
+}
+
+func (l *cronLoop) recordRun(key string, at time.Time) {
+	if err := l.db.SetDaemonState(key, at.UTC().Format(time.RFC3339)); err != nil {
+		l.logger.Printf("%s %q: record last run: %v", l.label, strings.TrimPrefix(key, l.statePrefix), err)
+	}
+}
+
+// matchingRepos returns the repos matching any of the globs, or every repo
+// when there are none.
+func (l *cronLoop) matchingRepos(globs []string) ([]storage.Repo, error) {
+	repos, err := l.db.ListRepos()
+	if err != nil {
+		return nil, fmt.Errorf("list repos: %w", err)
+	}
+	if len(globs) == 0 {
+		return repos, nil
+	}
+	matched := repos[:0]
+	for _, r := range repos {
+		if matchAnyGlob(globs, r.RootPath, r.Name) {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
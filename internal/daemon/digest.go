@@ -0,0 +1,341 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/git"
+	reviewpkg "go.kenn.io/roborev/internal/review"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const (
+	// digestStatePrefix prefixes the daemon_state key holding a digest's
+	// last run time.
+	digestStatePrefix = "digest:"
+	// digestMaxListed caps how many new failed reviews one email lists.
+	digestMaxListed = 20
+)
+
+// digestSeverities orders the open-findings breakdown.
+var digestSeverities = []string{"critical", "high", "medium", "low", "other"}
+
+// DigestScheduler sends the [[digests]] emails on their configured schedule.
+// Digests are sent at most once per run: a failed send is logged, not
+// retried.
+type DigestScheduler struct {
+	*cronLoop
+	send emailSender
+}
+
+// NewDigestScheduler creates a scheduler over db. Call Start to begin.
+func NewDigestScheduler(db *storage.DB, cfgGetter ConfigGetter, logger *log.Logger) *DigestScheduler {
+	s := &DigestScheduler{send: sendEmail}
+	s.cronLoop = newCronLoop(db, cfgGetter, logger, "Digest", digestStatePrefix, s.digestEntries)
+	return s
+}
+
+// digestEntries returns the configured digests as cron entries. A run
+// covers the period that ended at its scheduled time.
+func (s *DigestScheduler) digestEntries(cfg *config.Config) []cronEntry {
+	entries := make([]cronEntry, 0, len(cfg.Digests))
+	for _, d := range cfg.Digests {
+		entries = append(entries, cronEntry{
+			key:  d.Key(),
+			err:  d.Validate(),
+			next: d.NextRun,
+			run: func(scheduled time.Time) error {
+				if !cfg.SMTP.Enabled() {
+					return errors.New("smtp.host is not set")
+				}
+				return s.sendDigest(cfg.SMTP, d, scheduled.Add(-d.Period()))
+			},
+		})
+	}
+	return entries
+}
+
+// sendDigest builds and sends every email for one digest run covering
+// activity since the given time. Groups with nothing to report are skipped.
+func (s *DigestScheduler) sendDigest(smtpCfg config.SMTPConfig, d config.DigestConfig, since time.Time) error {
+	repos, err := s.matchingRepos(d.Repos)
+	if err != nil {
+		return err
+	}
+	var reports []digestReport
+	if d.ResolvedGroupBy() == config.DigestGroupAuthor {
+		reports, err = s.authorReports(d, repos, since)
+	} else {
+		reports, err = repoDigestReports(s.db, repos, since)
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, r := range reports {
+		if r.empty() {
+			continue
+		}
+		to := append([]string(nil), d.To...)
+		if addr := d.AuthorEmails[r.Author]; r.Author != "" && addr != "" {
+			to = append(to, addr)
+		}
+		if len(to) == 0 {
+			continue
+		}
+		if err := s.send(smtpCfg, r.email(d, to)); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.name(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("send failed for %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// repoDigestReports returns one report per repo.
+func repoDigestReports(db *storage.DB, repos []storage.Repo, since time.Time) ([]digestReport, error) {
+	var reports []digestReport
+	for _, repo := range repos {
+		r := digestReport{Repo: repo.Name, Since: since}
+		if err := r.collect(db, repo.RootPath, "", since); err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+func (s *DigestScheduler) authorReports(d config.DigestConfig, repos []storage.Repo, since time.Time) ([]digestReport, error) {
+	authors := map[string]bool{}
+	for _, repo := range repos {
+		active, err := s.db.ListActiveAuthors(since, repo.RootPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range active {
+			authors[a] = true
+		}
+		open, err := s.db.ListDigestReviews(storage.DigestReviewOptions{RepoPath: repo.RootPath, Since: since})
+		if err != nil {
+			return nil, err
+		}
+		for _, rv := range open {
+			if rv.Author != "" {
+				authors[rv.Author] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(authors))
+	for a := range authors {
+		if len(d.To) > 0 || d.AuthorEmails[a] != "" {
+			names = append(names, a)
+		}
+	}
+	sort.Strings(names)
+
+	var reports []digestReport
+	for _, author := range names {
+		r := digestReport{Author: author, Since: since}
+		for _, repo := range repos {
+			if err := r.collect(s.db, repo.RootPath, author, since); err != nil {
+				return nil, err
+			}
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// collect adds one repo's activity, optionally limited to one author, to r.
+func (r *digestReport) collect(db *storage.DB, repoPath, author string, since time.Time) error {
+	sum, err := db.GetSummary(storage.SummaryOptions{RepoPath: repoPath, Author: author, Since: since})
+	if err != nil {
+		return fmt.Errorf("summary for %s: %w", repoPath, err)
+	}
+	r.Reviews += sum.Verdicts.Total
+	r.Passed += sum.Verdicts.Passed
+	r.Failed += sum.Verdicts.Failed
+	r.Addressed += sum.Verdicts.Addressed
+	for _, jt := range sum.JobTypes {
+		if jt.Type == "fix" {
+			r.Fixes += jt.Count
+			r.FixesApplied += jt.Applied
+		}
+	}
+	r.CostUSD += sum.Cost.TotalUSD
+	if sum.Cost.JobsWithCost < sum.Cost.JobsTotal {
+		r.CostPartial = true
+	}
+
+	reviews, err := db.ListDigestReviews(storage.DigestReviewOptions{
+		RepoPath: repoPath, Author: author, Since: since,
+	})
+	if err != nil {
+		return err
+	}
+	if r.Open == nil {
+		r.Open = map[string]int{}
+	}
+	for _, rv := range reviews {
+		if !rv.EnqueuedAt.Before(since) {
+			r.New = append(r.New, rv)
+		}
+		if rv.Closed {
+			continue
+		}
+		r.OpenReviews++
+		findings := reviewpkg.ParseFindings(rv.Output)
+		if len(findings) == 0 {
+			r.Open["other"]++
+		}
+		for _, f := range findings {
+			sev := f.Severity
+			if sev == "" {
+				sev = "other"
+			}
+			r.Open[sev]++
+		}
+	}
+	return nil
+}
+
+// digestReport is the content of one digest email.
+type digestReport struct {
+	Repo     string // set for group_by = "repo"
+	Author   string // set for group_by = "author"
+	AllRepos bool   // set for a summary covering every repo
+	Since    time.Time
+
+	Reviews, Passed, Failed, Addressed int
+	Fixes, FixesApplied                int
+	CostUSD                            float64
+	CostPartial                        bool
+
+	OpenReviews int
+	Open        map[string]int         // open findings by severity
+	New         []storage.DigestReview // failed reviews enqueued in the period
+}
+
+func (r digestReport) name() string {
+	if r.AllRepos {
+		return "all repos"
+	}
+	if r.Author != "" {
+		return r.Author
+	}
+	return r.Repo
+}
+
+// empty reports whether there is nothing worth mailing.
+func (r digestReport) empty() bool {
+	return r.Reviews == 0 && r.Fixes == 0 && r.OpenReviews == 0 && len(r.New) == 0
+}
+
+func (r digestReport) email(d config.DigestConfig, to []string) emailMessage {
+	period := "Daily"
+	if d.ResolvedSchedule() == config.DigestWeekly {
+		period = "Weekly"
+	}
+	title := fmt.Sprintf("%s review digest: %s", period, r.name())
+
+	var text, body strings.Builder
+	r.render(&text, &body, title)
+	return emailMessage{
+		To:      to,
+		Subject: fmt.Sprintf("[roborev] %s (%d open failed reviews)", title, r.OpenReviews),
+		Text:    text.String(),
+		HTML:    body.String(),
+	}
+}
+
+// render writes the report under title as plain text and HTML.
+func (r digestReport) render(text, body *strings.Builder, title string) {
+	text.WriteString(title + "\n")
+	fmt.Fprintf(text, "Since %s\n\n", r.Since.Format("Mon Jan 2 15:04 MST"))
+	body.WriteString("<h2>" + html.EscapeString(title) + "</h2>\n")
+	fmt.Fprintf(body, "<p>Since %s</p>\n", html.EscapeString(r.Since.Format("Mon Jan 2 15:04 MST")))
+
+	rows := [][2]string{
+		{"Reviews", fmt.Sprintf("%d (%d passed, %d failed, %d addressed)", r.Reviews, r.Passed, r.Failed, r.Addressed)},
+		{"Fixes", fmt.Sprintf("%d (%d applied)", r.Fixes, r.FixesApplied)},
+		{"Open failed reviews", fmt.Sprintf("%d", r.OpenReviews)},
+	}
+	cost := fmt.Sprintf("$%.2f", r.CostUSD)
+	if r.CostPartial {
+		cost += " (partial: some jobs report no cost)"
+	}
+	rows = append(rows, [2]string{"Cost", cost})
+	body.WriteString("<table>\n")
+	for _, row := range rows {
+		fmt.Fprintf(text, "%-20s %s\n", row[0]+":", row[1])
+		fmt.Fprintf(body, "<tr><th align=\"left\">%s</th><td>%s</td></tr>\n",
+			html.EscapeString(row[0]), html.EscapeString(row[1]))
+	}
+	body.WriteString("</table>\n")
+	text.WriteString("\n")
+
+	if r.OpenReviews > 0 {
+		text.WriteString("Open findings by severity:\n")
+		body.WriteString("<h3>Open findings by severity</h3>\n<ul>\n")
+		for _, sev := range digestSeverities {
+			if n := r.Open[sev]; n > 0 {
+				fmt.Fprintf(text, "  %-9s %d\n", sev, n)
+				fmt.Fprintf(body, "<li>%s: %d</li>\n", sev, n)
+			}
+		}
+		body.WriteString("</ul>\n")
+		text.WriteString("\n")
+	}
+
+	if len(r.New) > 0 {
+		text.WriteString("New failed reviews:\n")
+		body.WriteString("<h3>New failed reviews</h3>\n<ul>\n")
+		for i, rv := range r.New {
+			if i == digestMaxListed {
+				fmt.Fprintf(text, "  and %d more\n", len(r.New)-i)
+				fmt.Fprintf(body, "<li><em>and %d more</em></li>\n", len(r.New)-i)
+				break
+			}
+			line := digestReviewLine(rv, r.Author == "", r.Repo == "")
+			text.WriteString("  - " + line + "\n")
+			body.WriteString("<li>" + html.EscapeString(line) + "</li>\n")
+		}
+		body.WriteString("</ul>\n")
+	}
+}
+
+// digestReviewLine summarizes one failed review. Repo digests name the
+// author, author digests name the repo, and all-repo summaries name both.
+func digestReviewLine(rv storage.DigestReview, withAuthor, withRepo bool) string {
+	parts := []string{fmt.Sprintf("#%d", rv.JobID)}
+	if withAuthor && rv.Author != "" {
+		parts = append(parts, rv.Author)
+	}
+	if withRepo {
+		parts = append(parts, rv.RepoName)
+	}
+	ref := rv.GitRef
+	if git.LooksLikeSHA(ref) {
+		ref = git.ShortSHA(ref)
+	}
+	parts = append(parts, ref)
+	if rv.Subject != "" {
+		parts = append(parts, rv.Subject)
+	}
+	line := strings.Join(parts, " ")
+	if findings := reviewpkg.ParseFindings(rv.Output); len(findings) > 0 {
+		line += fmt.Sprintf(" (%d findings)", len(findings))
+	}
+	if rv.Closed {
+		line += " [closed]"
+	}
+	return line
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+// newTestDigests returns a scheduler whose emails are captured instead of
+// sent.
+func newTestDigests(t *testing.T, db *storage.DB, cfg *config.Config) (*DigestScheduler, *[]emailMessage) {
+	t.Helper()
+	cfg.SMTP = config.SMTPConfig{Host: "smtp.example.com", From: "roborev@example.com"}
+	s := NewDigestScheduler(db, NewStaticConfig(cfg), log.Default())
+	var sent []emailMessage
+	s.send = func(_ config.SMTPConfig, msg emailMessage) error {
+		sent = append(sent, msg)
+		return nil
+	}
+	return s, &sent
+}
+
+func TestDigestSchedule(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+	testutil.CreateCompletedReview(t, db, repo.ID, "aaaaaaaaaaaa", "codex",
+		"- High — auth.go:10 token logged\n- Low — a.go:1 nit")
+
+	digest := config.DigestConfig{Schedule: "daily", Time: "09:00", To: []string{"team@example.com"}}
+	s, sent := newTestDigests(t, db, &config.Config{Digests: []config.DigestConfig{digest}})
+
+	loc := time.Local
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, loc)
+	s.now = func() time.Time { return now }
+
+	// First sight records a baseline without sending.
+	s.runDue()
+	assert.Empty(t, *sent)
+	last, err := db.GetDaemonState("digest:daily/repo")
+	require.NoError(t, err)
+	assert.NotEmpty(t, last)
+
+	// Not yet 09:00.
+	now = time.Date(2026, 3, 2, 8, 59, 0, 0, loc)
+	s.runDue()
+	assert.Empty(t, *sent)
+
+	// Past 09:00: one email, and only one even after a catch-up gap.
+	now = time.Date(2026, 3, 4, 12, 0, 0, 0, loc)
+	s.runDue()
+	s.runDue()
+	require.Len(t, *sent, 1)
+
+	msg := (*sent)[0]
+	assert := assert.New(t)
+	assert.Equal([]string{"team@example.com"}, msg.To)
+	assert.Contains(msg.Subject, "Daily review digest: "+repo.Name)
+	assert.Contains(msg.Subject, "(1 open failed reviews)")
+	assert.Contains(msg.Text, "high      1")
+	assert.Contains(msg.Text, "low       1")
+	assert.Contains(msg.HTML, "<h3>Open findings by severity</h3>")
+}
+
+func TestDigestGroupByAuthor(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+	testutil.CreateCompletedReview(t, db, repo.ID, "bbbbbbbbbbbb", "codex", "- Medium — a.go:3 race")
+
+	s, sent := newTestDigests(t, db, &config.Config{})
+	err := s.sendDigest(config.SMTPConfig{}, config.DigestConfig{
+		GroupBy:      "author",
+		AuthorEmails: map[string]string{testutil.GitUserName: "author@example.com"},
+	}, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	require.Len(t, *sent, 1)
+	msg := (*sent)[0]
+	assert.Equal(t, []string{"author@example.com"}, msg.To)
+	assert.Contains(t, msg.Subject, testutil.GitUserName)
+	assert.Contains(t, msg.Text, repo.Name+" bbbbbbb")
+}
+
+func TestDigestSkipsQuietRepos(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	testutil.CreateTestRepo(t, db)
+
+	s, sent := newTestDigests(t, db, &config.Config{})
+	err := s.sendDigest(config.SMTPConfig{}, config.DigestConfig{To: []string{"team@example.com"}},
+		time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, *sent)
+}
@@ -0,0 +1,235 @@
+package daemon
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"go.kenn.io/roborev/internal/config"
+)
+
+// smtpTimeout bounds one whole SMTP session.
+const smtpTimeout = 30 * time.Second
+
+// emailMessage is one outgoing email with plaintext and HTML bodies.
+type emailMessage struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// emailSender delivers an email; replaced in tests.
+type emailSender func(cfg config.SMTPConfig, msg emailMessage) error
+
+// sendEmail delivers msg through the configured SMTP server.
+func sendEmail(cfg config.SMTPConfig, msg emailMessage) error {
+	if !cfg.Enabled() {
+		return errors.New("smtp.host is not set")
+	}
+	if len(msg.To) == 0 {
+		return errors.New("no recipients")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid smtp.from %q: %w", cfg.From, err)
+	}
+	rcpts := make([]string, 0, len(msg.To))
+	for _, to := range msg.To {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		rcpts = append(rcpts, addr.Address)
+	}
+	body, err := buildEmail(cfg.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	host := strings.TrimSpace(cfg.Host)
+	tlsConfig := &tls.Config{ServerName: host}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	var conn net.Conn
+	if cfg.ResolvedTLS() == config.SMTPTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.Addr(), tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.Addr())
+	}
+	if err != nil {
+		return fmt.Errorf("connect %s: %w", cfg.Addr(), err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer c.Close()
+
+	if cfg.ResolvedTLS() == config.SMTPTLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("server does not support STARTTLS; set smtp.tls = \"none\" for a plaintext relay")
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.PasswordExpanded(), host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	for _, rcpt := range rcpts {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("rcpt to %s: %w", rcpt, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	return c.Quit()
+}
+
+// buildEmail renders msg as a multipart/alternative MIME message.
+func buildEmail(from string, msg emailMessage, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	var id [12]byte
+	_, _ = rand.Read(id[:])
+	domain := "roborev.local"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if _, d, ok := strings.Cut(addr.Address, "@"); ok {
+			domain = d
+		}
+	}
+
+	headers := []string{
+		"From: " + from,
+		"To: " + strings.Join(msg.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + now.Format(time.RFC1123Z),
+		"Message-ID: <" + hex.EncodeToString(id[:]) + "@" + domain + ">",
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + mw.Boundary(),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// runEmailHook renders event like the chat notifiers and emails it to the
+// hook's recipients.
+func (hr *HookRunner) runEmailHook(hook config.HookConfig, event Event) {
+	defer hr.wg.Done()
+	cfg := hr.cfgGetter.Config()
+	if cfg == nil || !cfg.SMTP.Enabled() {
+		hr.logger.Printf("Email hook skipped (%s, job %d): smtp.host is not set", event.Type, event.JobID)
+		return
+	}
+	msg := buildChatMessage(hook, newHookSubject(event, hr.db, hr.logger))
+	send := hr.sendEmail
+	if send == nil {
+		send = sendEmail
+	}
+	if err := send(cfg.SMTP, chatEmail(msg, hook.To)); err != nil {
+		hr.logger.Printf("Email hook error (%s, job %d): %v", event.Type, event.JobID, err)
+	}
+}
+
+// chatEmail renders a notification as an email.
+func chatEmail(msg chatMessage, to []string) emailMessage {
+	var text, body strings.Builder
+	headline := msg.headline()
+	text.WriteString(headline + "\n\n")
+	body.WriteString("<h2>" + html.EscapeString(headline) + "</h2>\n")
+
+	if facts := msg.chatFacts(); len(facts) > 0 {
+		body.WriteString("<table>\n")
+		for _, f := range facts {
+			fmt.Fprintf(&text, "%-11s %s\n", f[0]+":", f[1])
+			fmt.Fprintf(&body, "<tr><th align=\"left\">%s</th><td>%s</td></tr>\n",
+				html.EscapeString(f[0]), html.EscapeString(f[1]))
+		}
+		body.WriteString("</table>\n")
+		text.WriteString("\n")
+	}
+	if len(msg.Findings) > 0 {
+		text.WriteString("Findings:\n")
+		body.WriteString("<h3>Findings</h3>\n<ul>\n")
+		for _, f := range msg.Findings {
+			text.WriteString("  - " + f + "\n")
+			body.WriteString("<li>" + html.EscapeString(f) + "</li>\n")
+		}
+		if msg.More > 0 {
+			fmt.Fprintf(&text, "  and %d more\n", msg.More)
+			fmt.Fprintf(&body, "<li><em>and %d more</em></li>\n", msg.More)
+		}
+		body.WriteString("</ul>\n")
+		text.WriteString("\n")
+	}
+	if msg.Error != "" {
+		text.WriteString("Error: " + msg.Error + "\n\n")
+		body.WriteString("<pre>" + html.EscapeString(msg.Error) + "</pre>\n")
+	}
+	if msg.Link != "" {
+		text.WriteString(msg.Link + "\n")
+		fmt.Fprintf(&body, "<p><a href=\"%s\">View review</a></p>\n", html.EscapeString(msg.Link))
+	}
+	if msg.Show != "" {
+		text.WriteString(msg.Show + "\n")
+		body.WriteString("<p><code>" + html.EscapeString(msg.Show) + "</code></p>\n")
+	}
+	return emailMessage{
+		To:      to,
+		Subject: "[roborev] " + headline,
+		Text:    text.String(),
+		HTML:    body.String(),
+	}
+}
@@ -0,0 +1,216 @@
+package daemon
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+)
+
+// smtpCapture is one message received by newSMTPServer.
+type smtpCapture struct {
+	From string
+	To   []string
+	Data string
+}
+
+// newSMTPServer runs a minimal plaintext SMTP server on localhost and returns
+// its config and a function listing the messages it received.
+func newSMTPServer(t *testing.T) (config.SMTPConfig, func() []smtpCapture) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	var got []smtpCapture
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+				reply("220 localhost ESMTP")
+				var msg smtpCapture
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					cmd := strings.TrimRight(line, "\r\n")
+					upper := strings.ToUpper(cmd)
+					switch {
+					case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+						reply("250 localhost")
+					case strings.HasPrefix(upper, "MAIL FROM:"):
+						msg.From = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+						reply("250 OK")
+					case strings.HasPrefix(upper, "RCPT TO:"):
+						msg.To = append(msg.To, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+						reply("250 OK")
+					case upper == "DATA":
+						reply("354 go ahead")
+						var data strings.Builder
+						for {
+							l, err := r.ReadString('\n')
+							if err != nil {
+								return
+							}
+							if l == ".\r\n" {
+								break
+							}
+							data.WriteString(l)
+						}
+						msg.Data = data.String()
+						mu.Lock()
+						got = append(got, msg)
+						mu.Unlock()
+						msg = smtpCapture{}
+						reply("250 queued")
+					case upper == "QUIT":
+						reply("221 bye")
+						return
+					default:
+						reply("250 OK")
+					}
+				}
+			}()
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	cfg := config.SMTPConfig{Host: host, Port: portNum, From: "roborev <roborev@example.com>", TLS: "none"}
+	return cfg, func() []smtpCapture {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]smtpCapture(nil), got...)
+	}
+}
+
+func TestSendEmail(t *testing.T) {
+	cfg, received := newSMTPServer(t)
+	err := sendEmail(cfg, emailMessage{
+		To:      []string{"Dev <dev@example.com>", "lead@example.com"},
+		Subject: "Review found issues: api",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+	})
+	require.NoError(t, err)
+
+	msgs := received()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "roborev@example.com", msgs[0].From)
+	assert.Equal(t, []string{"dev@example.com", "lead@example.com"}, msgs[0].To)
+	assert.Contains(t, msgs[0].Data, "Subject: Review found issues: api")
+}
+
+func TestSendEmailRequiresStartTLSSupport(t *testing.T) {
+	cfg, _ := newSMTPServer(t)
+	cfg.TLS = ""
+	err := sendEmail(cfg, emailMessage{To: []string{"dev@example.com"}, Subject: "s"})
+	require.ErrorContains(t, err, "STARTTLS")
+}
+
+func TestBuildEmailMultipart(t *testing.T) {
+	raw, err := buildEmail("roborev <roborev@example.com>", emailMessage{
+		To:      []string{"dev@example.com"},
+		Subject: "Digest — api",
+		Text:    "line one\nline two",
+		HTML:    "<p>line one</p>",
+	}, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Digest — api", subject)
+	assert.Contains(t, msg.Header.Get("Message-ID"), "@example.com>")
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(p)
+		require.NoError(t, err)
+		parts = append(parts, p.Header.Get("Content-Type")+": "+string(body))
+	}
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: line one\r\nline two", // quoted-printable uses CRLF
+		"text/html; charset=utf-8: <p>line one</p>",
+	}, parts)
+}
+
+func TestEmailHook(t *testing.T) {
+	var mu sync.Mutex
+	var sent []emailMessage
+	cfg := &config.Config{
+		SMTP: config.SMTPConfig{Host: "smtp.example.com", From: "roborev@example.com"},
+		Hooks: []config.HookConfig{
+			{Event: "review.failed", Type: "email", To: []string{"dev@example.com"}},
+			{Event: "review.failed", Type: "email"}, // no recipients: skipped
+		},
+	}
+	hr, b := setupRunner(t, cfg)
+	hr.sendEmail = func(_ config.SMTPConfig, msg emailMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, msg)
+		return nil
+	}
+
+	b.Broadcast(Event{Type: "review.failed", TS: time.Now(), JobID: 9, RepoName: "api", Error: "agent <timed> out"})
+	hr.WaitUntilIdle()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, sent, 1)
+	assert := assert.New(t)
+	assert.Equal([]string{"dev@example.com"}, sent[0].To)
+	assert.Equal("[roborev] Review job failed: api", sent[0].Subject)
+	assert.Contains(sent[0].Text, "Error: agent <timed> out")
+	assert.Contains(sent[0].HTML, "agent &lt;timed&gt; out")
+	assert.Contains(sent[0].Text, "roborev show 9")
+}
+
+func TestEmailHookSkippedWithoutSMTP(t *testing.T) {
+	cfg := &config.Config{Hooks: []config.HookConfig{
+		{Event: "review.failed", Type: "email", To: []string{"dev@example.com"}},
+	}}
+	hr, b := setupRunner(t, cfg)
+	called := false
+	hr.sendEmail = func(config.SMTPConfig, emailMessage) error {
+		called = true
+		return nil
+	}
+	b.Broadcast(Event{Type: "review.failed", TS: time.Now(), JobID: 1})
+	hr.WaitUntilIdle()
+	assert.False(t, called)
+}
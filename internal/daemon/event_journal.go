@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const (
+	// eventJournalRetention is how long journaled events stay available
+	// for stream resume.
+	eventJournalRetention = 7 * 24 * time.Hour
+	// eventJournalPruneEvery is the minimum gap between prune passes.
+	eventJournalPruneEvery = time.Hour
+	// eventJournalPage caps how many events one replay query reads.
+	eventJournalPage = 500
+)
+
+// EventJournal is the durable, append-only record of broadcast events. It
+// gives each event a monotonically increasing ID so stream clients can
+// resume after a disconnect or daemon restart without missing events.
+type EventJournal struct {
+	db     *storage.DB
+	logger *log.Logger
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewEventJournal creates a journal over db.
+func NewEventJournal(db *storage.DB, logger *log.Logger) *EventJournal {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &EventJournal{db: db, logger: logger}
+}
+
+// Append records events in one write, in order, and sets their IDs. A failed
+// write is logged and leaves the IDs at 0: the events are still delivered
+// live, but cannot be replayed.
+func (j *EventJournal) Append(events ...*Event) {
+	rows := make([]storage.JournalEvent, 0, len(events))
+	stamped := make([]*Event, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			j.logger.Printf("Event journal: marshal %s: %v", event.Type, err)
+			continue
+		}
+		createdAt := event.TS
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+		rows = append(rows, storage.JournalEvent{
+			EventType: event.Type,
+			Repo:      event.Repo,
+			JobID:     event.JobID,
+			Payload:   string(payload),
+			CreatedAt: createdAt,
+		})
+		stamped = append(stamped, event)
+	}
+	if len(rows) == 0 {
+		return
+	}
+	ids, err := j.db.AppendJournalEvents(rows)
+	if err != nil {
+		j.logger.Printf("Event journal: %v", err)
+		return
+	}
+	for i, event := range stamped {
+		event.ID = ids[i]
+	}
+	j.maybePrune()
+}
+
+func (j *EventJournal) maybePrune() {
+	j.mu.Lock()
+	due := time.Since(j.lastPruned) >= eventJournalPruneEvery
+	if due {
+		j.lastPruned = time.Now()
+	}
+	j.mu.Unlock()
+	if !due {
+		return
+	}
+	if _, err := j.db.PruneJournalEvents(time.Now().Add(-eventJournalRetention)); err != nil {
+		j.logger.Printf("Event journal: %v", err)
+	}
+}
+
+// Latest returns the ID of the newest journaled event, or 0.
+func (j *EventJournal) Latest() (int64, error) {
+	return j.db.LatestJournalEventID()
+}
+
+// Replay returns up to one page of journaled events matching q, decoded and
+// stamped with their IDs, in ID order. Each row yields exactly one event.
+func (j *EventJournal) Replay(q storage.JournalQuery) ([]Event, error) {
+	if q.Limit <= 0 {
+		q.Limit = eventJournalPage
+	}
+	rows, err := j.db.ListJournalEvents(q)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(rows))
+	for _, row := range rows {
+		var event Event
+		if err := json.Unmarshal([]byte(row.Payload), &event); err != nil {
+			// Keep the row's place in the stream with what the
+			// columns still say about it.
+			j.logger.Printf("Event journal: decode event %d: %v", row.ID, err)
+			event = Event{
+				Type: row.EventType, TS: row.CreatedAt, JobID: row.JobID, Repo: row.Repo,
+			}
+		}
+		event.ID = row.ID
+		events = append(events, event)
+	}
+	return events, nil
+}
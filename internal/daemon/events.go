@@ -0,0 +1,105 @@
+package daemon
+
+import (
+	"log"
+	"time"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// Event types outside the review.* family. Every job still emits its review.*
+// lifecycle events; these accompany them (or stand alone, for CI, sync, and agent circuits)
+// and carry a typed payload for their family.
+const (
+	EventJobEnqueued    = "job.enqueued"
+	EventConfigReloaded = "config.reloaded"
+
+	EventFixCompleted = "fix.completed"
+	EventFixFailed    = "fix.failed"
+
+	EventPanelCompleted = "panel.completed"
+	EventPanelFailed    = "panel.failed"
+
+	EventCIPosted   = "ci.posted"
+	EventCIDeferred = "ci.deferred"
+	EventCIGaveUp   = "ci.gave_up"
+
+	EventSyncFailed    = "sync.failed"
+	EventSyncRecovered = "sync.recovered"
+
+	EventAgentCircuit = "agent.circuit"
+)
+
+// FixEventData is the fix.* payload.
+type FixEventData struct {
+	ParentJobID int64 `json:"parent_job_id,omitempty"` // review the fix addresses
+}
+
+// PanelEventData is the panel.* payload, sent when a panel run's synthesis
+// finishes. Member counts are as of that moment.
+type PanelEventData struct {
+	RunUUID          string `json:"run_uuid"`
+	Name             string `json:"name,omitempty"`
+	MembersTotal     int    `json:"members_total"`
+	MembersSucceeded int    `json:"members_succeeded"`
+	MembersFailed    int    `json:"members_failed"`
+}
+
+// CIEventData is the ci.* payload for a PR panel run. ErrorClass, Attempt,
+// and NextAttemptAt are set on ci.deferred; ErrorClass is also set on
+// ci.gave_up.
+type CIEventData struct {
+	GithubRepo    string     `json:"github_repo"`
+	PRNumber      int        `json:"pr_number"`
+	HeadSHA       string     `json:"head_sha"`
+	PanelRunUUID  string     `json:"panel_run_uuid,omitempty"`
+	ErrorClass    string     `json:"error_class,omitempty"` // "transient" or "genuine"
+	Attempt       int        `json:"attempt,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+}
+
+// SyncEventData is the sync.* payload. Phase is where the failure streak
+// started: "connect" or "sync".
+type SyncEventData struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+// broadcastJobFamilyEvent follows a job's terminal review event with the
+// matching fix.* or panel.* event, copying the job fields from review. Plain
+// reviews and panel members emit nothing extra.
+func (wp *WorkerPool) broadcastJobFamilyEvent(job *storage.ReviewJob, review Event) {
+	if wp.broadcaster == nil {
+		return
+	}
+	failed := review.Type == "review.failed"
+	event := review
+	event.TS = time.Now()
+	switch {
+	case job.IsFixJob():
+		event.Type = EventFixCompleted
+		if failed {
+			event.Type = EventFixFailed
+		}
+		event.Fix = &FixEventData{}
+		if job.ParentJobID != nil {
+			event.Fix.ParentJobID = *job.ParentJobID
+		}
+	case job.PanelRole == storage.PanelRoleSynthesis && job.PanelRunUUID != "":
+		event.Type = EventPanelCompleted
+		if failed {
+			event.Type = EventPanelFailed
+		}
+		event.Panel = &PanelEventData{RunUUID: job.PanelRunUUID, Name: job.PanelName}
+		summaries, err := wp.db.GetPanelSummaries([]string{job.PanelRunUUID})
+		if err != nil {
+			log.Printf("Panel event for run %s: load member counts: %v", job.PanelRunUUID, err)
+		} else if sum, ok := summaries[job.PanelRunUUID]; ok {
+			event.Panel.MembersTotal = sum.MembersTotal
+			event.Panel.MembersSucceeded = sum.MembersSucceeded
+			event.Panel.MembersFailed = sum.MembersFailed
+		}
+	default:
+		return
+	}
+	wp.broadcaster.Broadcast(event)
+}
@@ -0,0 +1,102 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// drainEventTypes collects the types of every event already buffered on ch.
+func drainEventTypes(ch <-chan Event) map[string]Event {
+	got := make(map[string]Event)
+	for {
+		select {
+		case e := <-ch:
+			got[e.Type] = e
+		case <-time.After(50 * time.Millisecond):
+			return got
+		}
+	}
+}
+
+func TestBroadcastJobFamilyEventFixJob(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	_, ch := tc.Broadcaster.Subscribe("")
+
+	parentID := int64(7)
+	fix := &storage.ReviewJob{ID: 9, JobType: storage.JobTypeFix, ParentJobID: &parentID, RepoPath: "/r"}
+	tc.Pool.broadcastFailed(fix, "codex", "agent crashed")
+
+	events := drainEventTypes(ch)
+	require.Contains(t, events, "review.failed")
+	require.Contains(t, events, EventFixFailed)
+	e := events[EventFixFailed]
+	assert.EqualValues(t, 9, e.JobID)
+	assert.Equal(t, "agent crashed", e.Error)
+	require.NotNil(t, e.Fix)
+	assert.EqualValues(t, 7, e.Fix.ParentJobID)
+
+	// A plain review emits no family event.
+	tc.Pool.broadcastFailed(&storage.ReviewJob{ID: 10, RepoPath: "/r"}, "codex", "boom")
+	events = drainEventTypes(ch)
+	assert.Len(t, events, 1)
+	assert.Contains(t, events, "review.failed")
+}
+
+func TestSynthesisCompletionBroadcastsPanelEvent(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	const memberAgent = "panel-event-member"
+	registerPassingAgent(t, memberAgent)
+
+	runUUID, members, _ := enqueuePanelRun(t, tc, "events-panel", []memberSpec{
+		{name: "m0", agent: memberAgent},
+		{name: "m1", agent: memberAgent},
+	})
+	completeMember(t, tc, members[0].ID, memberAgent, "No issues found.")
+	completeMember(t, tc, members[1].ID, memberAgent, "No issues found.")
+
+	_, ch := tc.Broadcaster.Subscribe("")
+	synth := releaseAndClaimSynthesis(t, tc, runUUID)
+	tc.Pool.processSynthesisJob(context.Background(), testWorkerID, synth)
+
+	events := drainEventTypes(ch)
+	require.Contains(t, events, "review.completed")
+	require.Contains(t, events, EventPanelCompleted)
+	e := events[EventPanelCompleted]
+	assert.Equal(t, synth.ID, e.JobID)
+	require.NotNil(t, e.Panel)
+	assert.Equal(t, runUUID, e.Panel.RunUUID)
+	assert.Equal(t, "events-panel", e.Panel.Name)
+	assert.Equal(t, 2, e.Panel.MembersTotal)
+	assert.Equal(t, 2, e.Panel.MembersSucceeded)
+}
+
+func TestEventMarshalJSONIncludesFamilyPayload(t *testing.T) {
+	next := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(Event{
+		Type: EventCIDeferred,
+		TS:   next,
+		CI: &CIEventData{
+			GithubRepo: "acme/api", PRNumber: 12, HeadSHA: "abc",
+			ErrorClass: "transient", Attempt: 2, NextAttemptAt: &next,
+		},
+	})
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "ci.deferred", got["type"])
+	assert.NotContains(t, got, "fix")
+	ci, ok := got["ci"].(map[string]any)
+	require.True(t, ok, "ci payload: %s", data)
+	assert.Equal(t, "acme/api", ci["github_repo"])
+	assert.EqualValues(t, 12, ci["pr_number"])
+	assert.Equal(t, "transient", ci["error_class"])
+	assert.Equal(t, "2026-03-01T12:00:00Z", ci["next_attempt_at"])
+}
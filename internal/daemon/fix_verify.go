@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/verify"
+)
+
+// verifyFixJob runs the repo's verify_command in a fix job's worktree after
+// the agent's edits, asking the agent to repair failures. The outcome is
+// stored on the job. repaired reports whether the agent edited the worktree
+// again. ok is false when the job must not complete: the edits still fail
+// verification (the worktree is discarded, so no patch is produced), the
+// repair agent failed, or the job was canceled.
+func (wp *WorkerPool) verifyFixJob(
+	ctx context.Context, workerID string, job *storage.ReviewJob,
+	a agent.Agent, agentName, dir string, sessions *agent.SessionCaptureWriter, out io.Writer,
+) (repaired, ok bool) {
+	settings := config.ResolveVerify(job.RepoPath)
+	if !settings.Enabled() {
+		return false, true
+	}
+	log.Printf("[%s] Fix job %d: running verify command", workerID, job.ID)
+	outcome, err := verify.Check(ctx, dir, settings, func(ctx context.Context, prompt string) error {
+		// Continue the fix session so the agent sees its own edits.
+		repairAgent := a
+		sessions.Flush()
+		if id := sessions.SessionID(); id != "" {
+			if sa, ok := a.(agent.SessionAgent); ok {
+				repairAgent = sa.WithSessionID(id)
+			}
+		}
+		_, err := repairAgent.Review(ctx, dir, job.GitRef, prompt, out)
+		return err
+	}, out)
+	if saveErr := wp.db.SaveJobVerifyResult(job.ID, storage.VerifyResult{
+		Passed:     outcome.Passed,
+		Attempts:   outcome.Attempts,
+		DurationMs: outcome.Duration.Milliseconds(),
+		Command:    outcome.Failure.Command,
+		Output:     outcome.Failure.Output,
+	}); saveErr != nil {
+		log.Printf("[%s] Error saving verify result for job %d: %v", workerID, job.ID, saveErr)
+	}
+	log.Printf("[%s] Fix job %d: %s", workerID, job.ID, outcome.Summary())
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		log.Printf("[%s] Job %d was canceled during verification", workerID, job.ID)
+		return false, false
+	}
+	if err != nil {
+		wp.failOrRetryAgent(workerID, job, agentName, err.Error())
+		return false, false
+	}
+	if !outcome.Passed {
+		// Retrying reruns the same fix against the same failing check, so
+		// a failed verification is final.
+		msg := fmt.Sprintf("%s\n\n%s", outcome.Summary(), outcome.Failure.Output)
+		if updated, failErr := wp.db.FailJob(job.ID, workerID, msg); failErr != nil {
+			log.Printf("[%s] Error failing job %d: %v", workerID, job.ID, failErr)
+		} else if updated {
+			wp.broadcastFailed(job, agentName, msg)
+			wp.logJobFailed(job.ID, workerID, agentName, msg)
+		}
+		return false, false
+	}
+	return outcome.Attempts > 0, true
+}
@@ -0,0 +1,220 @@
+package daemon
+
+import (
+	"log"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.kenn.io/roborev/internal/config"
+	reviewpkg "go.kenn.io/roborev/internal/review"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// jobSourceUser is the job_sources value for jobs enqueued explicitly (by the
+// post-commit hook, the CLI, or the TUI) rather than by daemon automation.
+const jobSourceUser = "user"
+
+// hookSubject is the event a hook's conditions are evaluated against. The
+// stored job and review are loaded lazily, at most once per event, and only
+// when a condition needs a field the event does not carry.
+type hookSubject struct {
+	event  Event
+	db     *storage.DB
+	logger *log.Logger
+
+	job       *storage.ReviewJob
+	jobLoaded bool
+	review    *storage.Review
+	revLoaded bool
+}
+
+func newHookSubject(event Event, db *storage.DB, logger *log.Logger) *hookSubject {
+	return &hookSubject{event: event, db: db, logger: logger}
+}
+
+func (s *hookSubject) storedJob() *storage.ReviewJob {
+	if !s.jobLoaded {
+		s.jobLoaded = true
+		if s.db != nil && s.event.JobID > 0 {
+			job, err := s.db.GetJobByID(s.event.JobID)
+			if err != nil {
+				s.logger.Printf("Hooks: load job %d for conditions: %v", s.event.JobID, err)
+			}
+			s.job = job
+		}
+	}
+	return s.job
+}
+
+func (s *hookSubject) storedReview() *storage.Review {
+	if !s.revLoaded {
+		s.revLoaded = true
+		if s.db != nil && s.event.JobID > 0 {
+			// A job without a review (still running, or failed) is not an
+			// error here: conditions on the review simply do not hold.
+			if rev, err := s.db.GetReviewByJobID(s.event.JobID); err == nil {
+				s.review = rev
+			}
+		}
+	}
+	return s.review
+}
+
+// output returns the review text: the findings carried on the event, else
+// the stored review.
+func (s *hookSubject) output() string {
+	if s.event.Findings != "" {
+		return s.event.Findings
+	}
+	if rev := s.storedReview(); rev != nil {
+		return rev.Output
+	}
+	return ""
+}
+
+// verdict returns "P", "F", or "" when the event has no reviewed outcome.
+func (s *hookSubject) verdict() string {
+	if s.event.Verdict != "" {
+		return s.event.Verdict
+	}
+	rev := s.storedReview()
+	if rev == nil || rev.Output == "" {
+		return ""
+	}
+	if rev.VerdictBool != nil {
+		if *rev.VerdictBool == 1 {
+			return "P"
+		}
+		return "F"
+	}
+	return storage.ParseVerdict(rev.Output)
+}
+
+func (s *hookSubject) agent() string {
+	if s.event.Agent != "" {
+		return s.event.Agent
+	}
+	if job := s.storedJob(); job != nil {
+		return job.Agent
+	}
+	return ""
+}
+
+// reviewType returns the job's review type with the default aliases folded
+// to "default", or "" when the event has no job.
+func (s *hookSubject) reviewType() string {
+	job := s.storedJob()
+	if job == nil {
+		return ""
+	}
+	return normalizeHookReviewType(job.ReviewType)
+}
+
+// jobSource returns "ci", "auto_design", "user", or "" when the event has no
+// job. CI events without a job are still from CI.
+func (s *hookSubject) jobSource() string {
+	job := s.storedJob()
+	switch {
+	case job != nil && job.IsCIReview():
+		return storage.JobSourceCI
+	case job != nil && job.Source != "":
+		return job.Source
+	case job != nil:
+		return jobSourceUser
+	case s.event.CI != nil:
+		return storage.JobSourceCI
+	default:
+		return ""
+	}
+}
+
+// repoNames returns every name a repos pattern may match for the event.
+func (s *hookSubject) repoNames() []string {
+	var names []string
+	if s.event.Repo != "" {
+		names = append(names, s.event.Repo, filepath.Base(s.event.Repo))
+	}
+	if s.event.RepoName != "" {
+		names = append(names, s.event.RepoName)
+	}
+	if s.event.CI != nil && s.event.CI.GithubRepo != "" {
+		names = append(names, s.event.CI.GithubRepo)
+	}
+	return names
+}
+
+// matchConditions reports whether every condition set on hook holds for the
+// subject. Like branch filtering, conditions fail closed: a condition whose
+// value the event cannot supply (a verdict on review.failed, a review type
+// on sync.failed) does not match. Config loading rejects invalid condition
+// values; one that still reaches here is returned as an error so the caller
+// can log it, and the hook does not fire.
+func matchConditions(hook config.HookConfig, s *hookSubject) (bool, error) {
+	// Event-only conditions first, so most mismatches skip the DB.
+	if len(hook.Repos) > 0 && !matchAnyGlob(hook.Repos, s.repoNames()...) {
+		return false, nil
+	}
+	if hook.Verdict != "" {
+		want, err := config.NormalizeHookVerdict(hook.Verdict)
+		if err != nil {
+			return false, err
+		}
+		if s.verdict() != want {
+			return false, nil
+		}
+	}
+	if len(hook.Agents) > 0 && !matchAnyGlob(hook.Agents, s.agent()) {
+		return false, nil
+	}
+	if len(hook.ReviewTypes) > 0 {
+		rt := s.reviewType()
+		if rt == "" || !matchAnyGlob(normalizeHookReviewTypes(hook.ReviewTypes), rt) {
+			return false, nil
+		}
+	}
+	if len(hook.JobSources) > 0 && !matchAnyGlob(hook.JobSources, s.jobSource()) {
+		return false, nil
+	}
+	if hook.MinSeverity != "" {
+		minSeverity, err := config.NormalizeMinSeverity(hook.MinSeverity)
+		if err != nil {
+			return false, err
+		}
+		if !reviewpkg.HasFindingAtLeast(s.output(), minSeverity) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchAnyGlob reports whether any non-empty value matches any path.Match
+// pattern. Patterns without glob characters match exactly.
+func matchAnyGlob(patterns []string, values ...string) bool {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		for _, p := range patterns {
+			if ok, err := path.Match(p, v); err == nil && ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizeHookReviewType(rt string) string {
+	if config.IsDefaultReviewType(rt) {
+		return config.ReviewTypeDefault
+	}
+	return rt
+}
+
+func normalizeHookReviewTypes(types []string) []string {
+	out := make([]string, len(types))
+	for i, rt := range types {
+		out[i] = normalizeHookReviewType(strings.TrimSpace(rt))
+	}
+	return out
+}
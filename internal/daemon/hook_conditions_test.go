@@ -0,0 +1,143 @@
+package daemon
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestMatchConditionsFromEvent(t *testing.T) {
+	failed := Event{
+		Type:     "review.completed",
+		JobID:    1,
+		Repo:     "/src/acme/api",
+		RepoName: "api",
+		Agent:    "codex",
+		Verdict:  "F",
+		Findings: "- High — auth.go:10 token logged\n- Low — a.go:1 nit",
+	}
+	tests := []struct {
+		name  string
+		hook  config.HookConfig
+		event Event
+		want  bool
+	}{
+		{"no conditions", config.HookConfig{}, failed, true},
+		{"verdict fail", config.HookConfig{Verdict: "fail"}, failed, true},
+		{"verdict pass", config.HookConfig{Verdict: "pass"}, failed, false},
+		{"verdict on job failure", config.HookConfig{Verdict: "fail"}, Event{Type: "review.failed", JobID: 1}, false},
+		{"min severity met", config.HookConfig{MinSeverity: "high"}, failed, true},
+		{"min severity case-insensitive", config.HookConfig{MinSeverity: "High"}, failed, true},
+		{"min severity not met", config.HookConfig{MinSeverity: "critical"}, failed, false},
+		{"repo by name", config.HookConfig{Repos: []string{"api"}}, failed, true},
+		{"repo by path glob", config.HookConfig{Repos: []string{"/src/acme/*"}}, failed, true},
+		{"repo mismatch", config.HookConfig{Repos: []string{"web"}}, failed, false},
+		{"repo by github name", config.HookConfig{Repos: []string{"acme/*"}}, Event{
+			Type: EventCIPosted, CI: &CIEventData{GithubRepo: "acme/api"},
+		}, true},
+		{"agent glob", config.HookConfig{Agents: []string{"claude-*", "codex"}}, failed, true},
+		{"agent mismatch", config.HookConfig{Agents: []string{"gemini"}}, failed, false},
+		{"ci source without job", config.HookConfig{JobSources: []string{"ci"}}, Event{
+			Type: EventCIDeferred, CI: &CIEventData{GithubRepo: "acme/api"},
+		}, true},
+		{"review type unknown without db", config.HookConfig{ReviewTypes: []string{"default"}}, failed, false},
+		{"all conditions", config.HookConfig{
+			Verdict: "fail", MinSeverity: "high", Repos: []string{"api"}, Agents: []string{"codex"},
+		}, failed, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchConditions(tt.hook, newHookSubject(tt.event, nil, log.Default()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchConditionsInvalidValues(t *testing.T) {
+	subject := newHookSubject(Event{Type: "review.completed", Verdict: "F"}, nil, log.Default())
+
+	_, err := matchConditions(config.HookConfig{Verdict: "maybe"}, subject)
+	require.ErrorContains(t, err, "invalid verdict")
+
+	_, err = matchConditions(config.HookConfig{MinSeverity: "urgent"}, subject)
+	require.ErrorContains(t, err, "min_severity")
+}
+
+func TestMatchConditionsFromStoredJob(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+
+	enqueue := func(opts storage.EnqueueOpts, output string) *storage.ReviewJob {
+		t.Helper()
+		commit, err := db.GetOrCreateCommit(repo.ID, "sha-"+opts.ReviewType+opts.Source, "a", "s", time.Now())
+		require.NoError(t, err)
+		opts.RepoID, opts.CommitID, opts.GitRef, opts.Agent = repo.ID, commit.ID, commit.SHA, "codex"
+		job, err := db.EnqueueJob(opts)
+		require.NoError(t, err)
+		_, err = db.ClaimJob(testWorkerID)
+		require.NoError(t, err)
+		require.NoError(t, db.CompleteJob(job.ID, "codex", "prompt", output))
+		return job
+	}
+	security := enqueue(storage.EnqueueOpts{ReviewType: "security"}, "- Critical — auth.go:3 injection")
+	ci := enqueue(storage.EnqueueOpts{Source: storage.JobSourceCI}, "No issues found.")
+
+	match := func(hook config.HookConfig, job *storage.ReviewJob) bool {
+		t.Helper()
+		// Lifecycle events such as review.closed carry no verdict or findings,
+		// so these conditions must come from the stored job and review.
+		ok, err := matchConditions(hook, newHookSubject(Event{
+			Type: "review.closed", JobID: job.ID, Repo: repo.RootPath,
+		}, db, log.Default()))
+		require.NoError(t, err)
+		return ok
+	}
+
+	assert := assert.New(t)
+	assert.True(match(config.HookConfig{ReviewTypes: []string{"security"}}, security))
+	assert.False(match(config.HookConfig{ReviewTypes: []string{"security"}}, ci))
+	assert.True(match(config.HookConfig{ReviewTypes: []string{"general"}}, ci), "default aliases are folded")
+	assert.True(match(config.HookConfig{JobSources: []string{"user"}}, security))
+	assert.True(match(config.HookConfig{JobSources: []string{"ci"}}, ci))
+	assert.False(match(config.HookConfig{JobSources: []string{"ci"}}, security))
+	assert.True(match(config.HookConfig{Verdict: "fail", MinSeverity: "critical"}, security))
+	assert.True(match(config.HookConfig{Verdict: "pass"}, ci))
+	assert.False(match(config.HookConfig{MinSeverity: "low"}, ci))
+	assert.True(match(config.HookConfig{Agents: []string{"codex"}}, ci), "agent falls back to the job")
+}
+
+func TestHookRunnerAppliesConditions(t *testing.T) {
+	tmpDir := t.TempDir()
+	critical := filepath.Join(tmpDir, "critical")
+	anyFail := filepath.Join(tmpDir, "any-fail")
+	cfg := &config.Config{
+		Hooks: []config.HookConfig{
+			{Event: "review.completed", MinSeverity: "critical", Command: touchCmd(critical)},
+			{Event: "review.completed", Verdict: "fail", Command: touchCmd(anyFail)},
+		},
+	}
+
+	hr, broadcaster := setupRunner(t, cfg)
+	broadcaster.Broadcast(Event{
+		Type:     "review.completed",
+		TS:       time.Now(),
+		JobID:    7,
+		Verdict:  "F",
+		Findings: "- Medium — a.go:1 race",
+	})
+
+	waitForFile(t, anyFail, 5*time.Second)
+	hr.WaitUntilIdle()
+	_, err := os.Stat(critical)
+	assert.True(t, os.IsNotExist(err), "critical-only hook must not fire for a medium finding")
+}
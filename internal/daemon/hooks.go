@@ -23,22 +23,34 @@ import (
 	gitpkg "go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/kata"
 	"go.kenn.io/roborev/internal/procutil"
+	"go.kenn.io/roborev/internal/storage"
 )
 
 // HookRunner listens for broadcaster events and runs configured hooks.
 type HookRunner struct {
 	cfgGetter     ConfigGetter
 	broadcaster   Broadcaster
+	db            *storage.DB // job and review lookups for hook conditions; may be nil
 	logger        *log.Logger
 	subID         int
 	stopCh        chan struct{}
 	idleCh        chan chan struct{}
 	wg            sync.WaitGroup
 	newKataClient func(workdir string) kata.Client
+	outbox        *WebhookOutbox // durable webhook delivery; nil posts once
+	chatThreads   chatThreads    // per-conversation locks for threaded slack/teams posts
+	sendEmail     emailSender    // nil = sendEmail
 }
 
-// NewHookRunner creates a new HookRunner that subscribes to events from the broadcaster.
-func NewHookRunner(cfgGetter ConfigGetter, broadcaster Broadcaster, logger *log.Logger) *HookRunner {
+// NewHookRunner creates a new HookRunner that subscribes to events from the
+// broadcaster. Hook conditions that need the stored job or review read them
+// from db; with a nil db they are evaluated from the event alone. Webhook
+// hooks are queued in outbox for retried delivery; with a nil outbox they are
+// posted once, best effort.
+func NewHookRunner(
+	cfgGetter ConfigGetter, broadcaster Broadcaster, db *storage.DB,
+	outbox *WebhookOutbox, logger *log.Logger,
+) *HookRunner {
 	if logger == nil {
 		logger = log.Default()
 	}
@@ -47,11 +59,13 @@ func NewHookRunner(cfgGetter ConfigGetter, broadcaster Broadcaster, logger *log.
 	hr := &HookRunner{
 		cfgGetter:     cfgGetter,
 		broadcaster:   broadcaster,
+		db:            db,
 		logger:        logger,
 		subID:         subID,
 		stopCh:        make(chan struct{}),
 		idleCh:        make(chan chan struct{}),
 		newKataClient: func(workdir string) kata.Client { return kata.NewCLIClient(workdir) },
+		outbox:        outbox,
 	}
 
 	go hr.listen(eventCh)
@@ -137,11 +151,6 @@ func (hr *HookRunner) Stop() {
 
 // handleEvent checks all configured hooks against the event and fires matches.
 func (hr *HookRunner) handleEvent(event Event) {
-	// Only handle review events
-	if !strings.HasPrefix(event.Type, "review.") {
-		return
-	}
-
 	cfg := hr.cfgGetter.Config()
 	if cfg == nil {
 		return
@@ -165,6 +174,7 @@ func (hr *HookRunner) handleEvent(event Event) {
 		}
 	}
 
+	subject := newHookSubject(event, hr.db, hr.logger)
 	fired := 0
 	for _, hook := range hooks {
 		if !matchEvent(hook.Event, event.Type) {
@@ -175,17 +185,50 @@ func (hr *HookRunner) handleEvent(event Event) {
 			continue
 		}
 
+		if ok, err := matchConditions(hook, subject); err != nil {
+			hr.logger.Printf("Hooks: skipping %s hook: %v", hook.Event, err)
+			continue
+		} else if !ok {
+			continue
+		}
+
 		if hook.Type == "webhook" {
 			if hook.URL == "" {
 				continue
 			}
 
 			fired++
+			if hr.outbox != nil {
+				if err := hr.outbox.Enqueue(hook, event); err != nil {
+					hr.logger.Printf("Webhook error (url=%q): %v", redactWebhookURL(hook.URL), err)
+				}
+				continue
+			}
 			hr.wg.Add(1)
 			go hr.postWebhook(hook.URL, event)
 			continue
 		}
 
+		if hook.Type == chatProviderSlack || hook.Type == chatProviderTeams {
+			if !chatHookConfigured(hook) {
+				continue
+			}
+			fired++
+			hr.wg.Add(1)
+			go hr.runChatHook(hook, event)
+			continue
+		}
+
+		if hook.Type == "email" {
+			if len(hook.To) == 0 {
+				continue
+			}
+			fired++
+			hr.wg.Add(1)
+			go hr.runEmailHook(hook, event)
+			continue
+		}
+
 		if hook.Type == "kata" {
 			fired++
 			hr.wg.Add(1)
@@ -210,9 +253,10 @@ func (hr *HookRunner) handleEvent(event Event) {
 }
 
 // matchEvent checks if an event type matches a hook's event pattern.
-// Supports exact match and "review.*" wildcard.
+// Supports exact match, family wildcards like "review.*" or "ci.*", and "*"
+// for every event.
 func matchEvent(pattern, eventType string) bool {
-	if pattern == eventType {
+	if pattern == eventType || pattern == "*" {
 		return true
 	}
 	// Support wildcard like "review.*"
@@ -231,8 +275,8 @@ func matchEvent(pattern, eventType string) bool {
 //
 // Every review event tied to a job carries that job's branch, so lifecycle
 // hooks (started/canceled/completed/failed/closed/reopened) filter correctly.
-// The few repo-level events without a single job (e.g. review.remapped) carry
-// no branch and so never match a non-empty allowlist.
+// Events without a single job (e.g. review.remapped, config.reloaded, sync.*)
+// carry no branch and so never match a non-empty allowlist.
 func matchBranch(patterns []string, branch string) bool {
 	if len(patterns) == 0 {
 		return true
@@ -288,7 +332,25 @@ func interpolate(cmd string, event Event) string {
 		return ""
 	}
 
+	var prNumber, githubRepo string
+	if event.CI != nil {
+		prNumber = fmt.Sprintf("%d", event.CI.PRNumber)
+		githubRepo = event.CI.GithubRepo
+	}
+	var panelRun string
+	switch {
+	case event.Panel != nil:
+		panelRun = event.Panel.RunUUID
+	case event.CI != nil:
+		panelRun = event.CI.PanelRunUUID
+	}
+
 	r := strings.NewReplacer(
+		"{event}", shellEscape(event.Type),
+		"{branch}", shellEscape(event.Branch),
+		"{pr_number}", shellEscape(prNumber),
+		"{github_repo}", shellEscape(githubRepo),
+		"{panel_run}", shellEscape(panelRun),
 		"{job_id}", fmt.Sprintf("%d", event.JobID),
 		"{repo}", shellEscape(event.Repo),
 		"{repo_name}", shellEscape(event.RepoName),
@@ -32,7 +32,7 @@ func quote(s string) string {
 func setupRunner(t *testing.T, cfg *config.Config) (*HookRunner, Broadcaster) {
 	t.Helper()
 	b := NewBroadcaster()
-	hr := NewHookRunner(NewStaticConfig(cfg), b, log.Default())
+	hr := NewHookRunner(NewStaticConfig(cfg), b, nil, nil, log.Default())
 	t.Cleanup(hr.Stop)
 	return hr, b
 }
@@ -123,6 +123,10 @@ func TestMatchEvent(t *testing.T) {
 		{"review.*", "review.started", true},
 		{"review.*", "other.event", false},
 		{"other.*", "review.failed", false},
+		{"ci.*", "ci.deferred", true},
+		{"ci.*", "review.failed", false},
+		{"*", "sync.failed", true},
+		{"*", "review.completed", true},
 	}
 
 	for _, tt := range tests {
@@ -209,6 +213,42 @@ func TestInterpolate(t *testing.T) {
 	}
 }
 
+func TestInterpolateFamilyFields(t *testing.T) {
+	event := Event{
+		Type:   EventCIDeferred,
+		Branch: "feature/x",
+		CI: &CIEventData{
+			GithubRepo: "acme/api", PRNumber: 12, PanelRunUUID: "run-1",
+		},
+	}
+	got := interpolate("page {event} {github_repo}#{pr_number} {panel_run} {branch}", event)
+	assert.Equal(t, "page "+quote("ci.deferred")+" "+quote("acme/api")+"#"+quote("12")+
+		" "+quote("run-1")+" "+quote("feature/x"), got)
+
+	got = interpolate("{pr_number}", Event{Type: EventSyncFailed})
+	assert.Equal(t, quote(""), got, "fields of other families interpolate empty")
+}
+
+func TestHookRunnerFiresNonReviewEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	marker := filepath.Join(tmpDir, "sync-failed")
+	cfg := &config.Config{
+		Hooks: []config.HookConfig{
+			{Event: "sync.*", Command: touchCmd(marker)},
+		},
+	}
+
+	_, broadcaster := setupRunner(t, cfg)
+	broadcaster.Broadcast(Event{
+		Type:  EventSyncFailed,
+		TS:    time.Now(),
+		Error: "connection refused",
+		Sync:  &SyncEventData{Phase: "connect"},
+	})
+
+	waitForFile(t, marker, 5*time.Second)
+}
+
 func TestInterpolateShellInjection(t *testing.T) {
 	// Test with payloads that attempt to break out of quoting and execute commands.
 	// We assert safety properties rather than exact output to avoid testing
@@ -1009,7 +1049,7 @@ func TestHookRunnerStopUnsubscribes(t *testing.T) {
 	cfg := &config.Config{}
 
 	before := broadcaster.SubscriberCount()
-	hr := NewHookRunner(NewStaticConfig(cfg), broadcaster, log.Default())
+	hr := NewHookRunner(NewStaticConfig(cfg), broadcaster, nil, nil, log.Default())
 	afterSub := broadcaster.SubscriberCount()
 	if afterSub != before+1 {
 		assert.Condition(t, func() bool {
@@ -1176,7 +1216,7 @@ func TestWaitUntilIdle_StopDoesNotDeadlock(t *testing.T) {
 		},
 	}
 	b := NewBroadcaster()
-	hr := NewHookRunner(NewStaticConfig(cfg), b, log.Default())
+	hr := NewHookRunner(NewStaticConfig(cfg), b, nil, nil, log.Default())
 
 	done := make(chan struct{})
 	go func() {
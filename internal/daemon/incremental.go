@@ -0,0 +1,182 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"go.kenn.io/roborev/internal/config"
+	gitpkg "go.kenn.io/roborev/internal/git"
+	reviewpkg "go.kenn.io/roborev/internal/review"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// incrementalReviewLookback caps how many prior reviews of a branch or PR are
+// considered when choosing the base of an incremental re-review.
+const incrementalReviewLookback = 50
+
+// resolvedReviewResponder is the responder name on the comment left on a
+// prior review that roborev closed as resolved by later commits.
+const resolvedReviewResponder = "roborev"
+
+// planIncrementalReview picks the base of an incremental re-review of
+// rangeCommits, the commits of the full branch range ending at headSHA. The
+// base is the newest prior range review whose head is one of those commits
+// other than headSHA, since it covered the branch up to that commit. Still-
+// open failing reviews of commits in the range are carried as the reviews
+// the new run may resolve. It returns nil when no prior review lies inside
+// the range, so the whole range is reviewed.
+func planIncrementalReview(
+	prior []storage.PriorReview, rangeCommits []string, headSHA string,
+) *storage.IncrementalReview {
+	inRange := make(map[string]bool, len(rangeCommits))
+	for _, c := range rangeCommits {
+		if c != headSHA {
+			inRange[c] = true
+		}
+	}
+	var inc *storage.IncrementalReview
+	for _, r := range prior {
+		if inRange[r.HeadSHA()] && strings.Contains(r.GitRef, "..") {
+			inc = &storage.IncrementalReview{BaseJobID: r.JobID, BaseSHA: r.HeadSHA()}
+			break
+		}
+	}
+	if inc == nil {
+		return nil
+	}
+	for _, r := range prior {
+		if inRange[r.HeadSHA()] && !r.Closed && !r.Passed {
+			inc.OpenJobIDs = append(inc.OpenJobIDs, r.JobID)
+		}
+	}
+	return inc
+}
+
+// narrowIncrementalRange narrows a branch range review to the commits since
+// the branch's last completed review. It returns the range unchanged, with a
+// nil link, when the branch has no usable prior review.
+func (s *Server) narrowIncrementalRange(
+	in freezeInputs, fullRef, endSHA string,
+) (string, *storage.IncrementalReview) {
+	if in.req.Branch == "" || !config.IsDefaultReviewType(in.req.ReviewType) {
+		return fullRef, nil
+	}
+	commits, err := in.metadata.RangeCommits(fullRef)
+	if err != nil {
+		log.Printf("Incremental review of %s: list range commits: %v", fullRef, err)
+		return fullRef, nil
+	}
+	prior, err := s.db.ListBranchReviews(in.repo.ID, in.req.Branch, incrementalReviewLookback)
+	if err != nil {
+		log.Printf("Incremental review of %s: %v", fullRef, err)
+		return fullRef, nil
+	}
+	inc := planIncrementalReview(prior, commits, endSHA)
+	if inc == nil {
+		return fullRef, nil
+	}
+	log.Printf("Enqueue %s: incremental review since job %d at %s (%d open prior reviews)",
+		fullRef, inc.BaseJobID, gitpkg.ShortSHA(inc.BaseSHA), len(inc.OpenJobIDs))
+	return inc.BaseSHA + ".." + endSHA, inc
+}
+
+// incrementalReviewContext renders the prompt section of an incremental
+// re-review from the prior reviews linked at enqueue. Reviews closed since
+// then are left out.
+func incrementalReviewContext(db *storage.DB, inc *storage.IncrementalReview) (string, error) {
+	ids := append([]int64{inc.BaseJobID}, inc.OpenJobIDs...)
+	jobs, err := db.GetJobsWithReviewsByIDs(ids)
+	if err != nil {
+		return "", fmt.Errorf("load prior reviews: %w", err)
+	}
+	prior := func(id int64) *storage.PriorReview {
+		jr, ok := jobs[id]
+		if !ok || jr.Review == nil {
+			return nil
+		}
+		return &storage.PriorReview{
+			JobID: id, GitRef: jr.Job.GitRef, Output: jr.Review.Output, Closed: jr.Review.Closed,
+		}
+	}
+	var open []storage.PriorReview
+	for _, id := range inc.OpenJobIDs {
+		if r := prior(id); r != nil && !r.Closed {
+			open = append(open, *r)
+		}
+	}
+	return reviewpkg.BuildIncrementalContext(inc.BaseSHA, prior(inc.BaseJobID), open), nil
+}
+
+// closeResolvedPriorReviews closes the prior reviews a completed incremental
+// re-review reports resolved, leaving a comment on each that names the
+// resolving job. Panel members report through their synthesis, which closes
+// a review only when every member reported it resolved; a chunked review
+// closes it when any file group did, since each group sees only its files.
+func (wp *WorkerPool) closeResolvedPriorReviews(workerID string, job *storage.ReviewJob, output string) {
+	inc := job.Incremental
+	if inc == nil || len(inc.OpenJobIDs) == 0 || job.PanelRole == storage.PanelRoleMember {
+		return
+	}
+	resolved, err := wp.resolvedPriorReviews(job, output)
+	if err != nil {
+		log.Printf("[%s] Warning: resolve prior reviews for job %d: %v", workerID, job.ID, err)
+		return
+	}
+	if len(resolved) == 0 {
+		return
+	}
+	comment := fmt.Sprintf("Resolved by incremental review job %d of %s.", job.ID, job.GitRef)
+	var closed []int64
+	for _, id := range resolved {
+		if err := wp.db.MarkReviewClosedByJobID(id, true); err != nil {
+			log.Printf("[%s] Warning: close resolved review %d: %v", workerID, id, err)
+			continue
+		}
+		if _, err := wp.db.AddCommentToJob(id, resolvedReviewResponder, comment); err != nil {
+			log.Printf("[%s] Warning: comment on resolved review %d: %v", workerID, id, err)
+		}
+		closed = append(closed, id)
+	}
+	updated := *inc
+	updated.ResolvedJobIDs = closed
+	if err := wp.db.SaveJobIncremental(job.ID, updated); err != nil {
+		log.Printf("[%s] Warning: record resolved reviews for job %d: %v", workerID, job.ID, err)
+	}
+	log.Printf("[%s] Job %d resolved prior reviews %v", workerID, job.ID, closed)
+}
+
+// resolvedPriorReviews returns the open prior reviews that a job's output, or
+// for a synthesis its members' outputs, report resolved.
+func (wp *WorkerPool) resolvedPriorReviews(job *storage.ReviewJob, output string) ([]int64, error) {
+	candidates := job.Incremental.OpenJobIDs
+	if !job.IsSynthesisJob() {
+		ids, _ := reviewpkg.ParseResolvedPriorReviews(output, candidates)
+		return ids, nil
+	}
+	rows, err := wp.db.GetPanelMemberReviews(job.PanelRunUUID)
+	if err != nil {
+		return nil, fmt.Errorf("load panel members: %w", err)
+	}
+	members := filterSucceeded(toReviewResults(rows))
+	if len(members) == 0 {
+		return nil, nil
+	}
+	chunked := synthesisSpecOf(job).Strategy == config.SynthesisStrategyChunks
+	votes := make(map[int64]int, len(candidates))
+	for _, m := range members {
+		ids, _ := reviewpkg.ParseResolvedPriorReviews(m.Output, candidates)
+		for _, id := range ids {
+			votes[id]++
+		}
+	}
+	var resolved []int64
+	for _, id := range candidates {
+		if (chunked && votes[id] > 0) || votes[id] == len(members) {
+			resolved = append(resolved, id)
+		}
+	}
+	slices.Sort(resolved)
+	return resolved, nil
+}
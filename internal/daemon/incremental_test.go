@@ -0,0 +1,111 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestPlanIncrementalReview(t *testing.T) {
+	rangeCommits := []string{"c1", "c2", "c3", "head"}
+	tests := []struct {
+		name  string
+		prior []storage.PriorReview
+		want  *storage.IncrementalReview
+	}{
+		{
+			name: "newest in-range review is the base",
+			prior: []storage.PriorReview{
+				{JobID: 9, GitRef: "base..c2", Passed: true},
+				{JobID: 5, GitRef: "base..c1"},
+				{JobID: 3, GitRef: "c1"},
+			},
+			want: &storage.IncrementalReview{BaseJobID: 9, BaseSHA: "c2", OpenJobIDs: []int64{5, 3}},
+		},
+		{
+			name: "closed and out-of-range reviews are not open",
+			prior: []storage.PriorReview{
+				{JobID: 8, GitRef: "base..c3"},
+				{JobID: 6, GitRef: "base..c2", Closed: true},
+				{JobID: 4, GitRef: "old..gone"},
+			},
+			want: &storage.IncrementalReview{BaseJobID: 8, BaseSHA: "c3", OpenJobIDs: []int64{8}},
+		},
+		{
+			name: "review of head itself is not a base",
+			prior: []storage.PriorReview{
+				{JobID: 7, GitRef: "base..head"},
+				{JobID: 2, GitRef: "c1"},
+			},
+		},
+		{name: "no prior reviews"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, planIncrementalReview(tt.prior, rangeCommits, "head"))
+		})
+	}
+}
+
+func TestProcessJobClosesResolvedPriorReviews(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := testutil.GetHeadSHA(t, tc.TmpDir)
+
+	completePrior := func(output string) int64 {
+		t.Helper()
+		job := tc.createAndClaimJobWithAgent(t, sha, testWorkerID, "test")
+		require.NoError(t, tc.DB.CompleteJob(job.ID, "test", "p", output))
+		return job.ID
+	}
+	fixed := completePrior("- High — a.go:1 nil dereference")
+	stillOpen := completePrior("- Medium — b.go:2 missing check")
+
+	const agentName = "incremental-resolver"
+	var captured string
+	agent.Register(&agent.FakeAgent{
+		NameStr: agentName,
+		ReviewFn: func(_ context.Context, _, _, prompt string, _ io.Writer) (string, error) {
+			captured = prompt
+			return fmt.Sprintf("No issues found.\n\nResolved prior reviews: %d", fixed), nil
+		},
+	})
+	t.Cleanup(func() { agent.Unregister(agentName) })
+
+	inc := &storage.IncrementalReview{BaseJobID: stillOpen, BaseSHA: sha, OpenJobIDs: []int64{fixed, stillOpen}}
+	job, err := tc.DB.EnqueueJob(storage.EnqueueOpts{
+		RepoID: tc.Repo.ID, GitRef: sha, Agent: agentName, Incremental: inc,
+	})
+	require.NoError(t, err)
+	claimed, err := tc.DB.ClaimJob(testWorkerID)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, claimed.ID)
+
+	tc.Pool.processJob(testWorkerID, claimed)
+
+	updated := tc.assertJobStatus(t, job.ID, storage.JobStatusDone)
+	assert.Contains(t, captured, "## Incremental Review")
+	assert.Contains(t, captured, fmt.Sprintf("### Open review %d", fixed))
+	assert.Contains(t, captured, "b.go:2 missing check")
+	require.NotNil(t, updated.Incremental)
+	assert.Equal(t, []int64{fixed}, updated.Incremental.ResolvedJobIDs)
+
+	review, err := tc.DB.GetReviewByJobID(fixed)
+	require.NoError(t, err)
+	assert.True(t, review.Closed)
+	comments, err := tc.DB.GetCommentsForJob(fixed)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, resolvedReviewResponder, comments[0].Responder)
+	assert.Contains(t, comments[0].Response, fmt.Sprintf("incremental review job %d", job.ID))
+
+	review, err = tc.DB.GetReviewByJobID(stillOpen)
+	require.NoError(t, err)
+	assert.False(t, review.Closed)
+}
@@ -47,11 +47,22 @@ func (s *Server) retireCIPanelForCanceledSynthesis(job *storage.ReviewJob) {
 // cancel of the synthesis row tear down its still-queued members, which
 // otherwise have no path to a terminal state. killWorker kills the running
 // worker process for a member (may be nil — e.g. the CI poller in tests, where
-// it is nil-guarded by the caller).
+// it is nil-guarded by the caller). A synthesis superseded by a re-synthesis
+// does not cascade.
 func cascadePanelMembers(db *storage.DB, killWorker func(int64), job *storage.ReviewJob) {
 	if job == nil || job.PanelRole != storage.PanelRoleSynthesis || job.PanelRunUUID == "" {
 		return
 	}
+	// After a re-synthesis the members belong to the newest synthesis;
+	// canceling an earlier one (e.g. a rerun of a history row) leaves them.
+	latest, err := db.GetSynthesisJob(job.PanelRunUUID)
+	if err != nil {
+		log.Printf("cancel cascade: load synthesis for %s: %v", job.PanelRunUUID, err)
+		return
+	}
+	if latest != nil && latest.ID != job.ID {
+		return
+	}
 	members, err := db.GetPanelMembers(job.PanelRunUUID)
 	if err != nil {
 		log.Printf("cancel cascade: list members for %s: %v", job.PanelRunUUID, err)
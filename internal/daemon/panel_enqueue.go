@@ -16,6 +16,7 @@ import (
 	"go.kenn.io/roborev/internal/config"
 	"go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/storage"
 )
 
@@ -45,6 +46,8 @@ type targetDescriptor struct {
 	requestedModel    string
 	requestedProvider string
 	commitSubject     string // single-commit only; set on the returned job
+	route             string // [[review.routes]] entry that picked the settings
+	incremental       *storage.IncrementalReview
 }
 
 // baseOpts returns an EnqueueOpts with every non-agent, non-panel field set.
@@ -57,6 +60,7 @@ func (d targetDescriptor) baseOpts() storage.EnqueueOpts {
 		WorktreePath: d.worktreePath, JobType: d.jobType, Prompt: d.prompt,
 		Source: d.source, PromptPrebuilt: d.promptPrebuilt, OutputPrefix: d.outputPrefix, Label: d.label,
 		Agentic: d.agentic, RequestedModel: d.requestedModel, RequestedProvider: d.requestedProvider,
+		Route: d.route, Incremental: d.incremental,
 	}
 }
 
@@ -225,6 +229,13 @@ func (s *Server) descriptorForDirty(
 			)})
 		return targetDescriptor{}, out
 	}
+	// The dirty diff is stored on the job, so mask it before it is persisted.
+	redactor, err := redact.ForRepo(in.repo.RootPath, s.configWatcher.Config())
+	if err != nil {
+		out, _ := rawJSONOutput(http.StatusInternalServerError,
+			ErrorResponse{Error: fmt.Sprintf("redaction: %v", err)})
+		return targetDescriptor{}, out
+	}
 
 	targetSHA, _ := in.metadata.Resolve("HEAD")
 	var commitID int64
@@ -243,7 +254,7 @@ func (s *Server) descriptorForDirty(
 		gitRef:            in.gitRef,
 		branch:            in.req.Branch,
 		sessionSHA:        targetSHA,
-		diffContent:       in.req.DiffContent,
+		diffContent:       redactor.String(in.req.DiffContent),
 		dirtyFiles:        slices.Clone(in.req.DirtyFiles),
 		jobType:           storage.JobTypeDirty,
 		minSeverity:       in.normalizedMinSev,
@@ -254,8 +265,9 @@ func (s *Server) descriptorForDirty(
 }
 
 // descriptorForRange freezes a commit-range target. It resolves the endpoints to
-// SHAs (supporting the "<first>^.." empty-tree fallback), applies the
-// all-commits-excluded skip, and freezes git_ref to "<startSHA>..<endSHA>".
+// SHAs (supporting the "<first>^.." empty-tree fallback), narrows an
+// incremental request to the commits since the branch's last review, applies
+// the all-commits-excluded skip, and freezes git_ref to "<startSHA>..<endSHA>".
 func (s *Server) descriptorForRange(
 	ctx context.Context, in freezeInputs,
 ) (targetDescriptor, *RawJSONOutput) {
@@ -282,6 +294,10 @@ func (s *Server) descriptorForRange(
 	}
 
 	fullRef := startSHA + ".." + endSHA
+	var incremental *storage.IncrementalReview
+	if in.req.Incremental {
+		fullRef, incremental = s.narrowIncrementalRange(in, fullRef, endSHA)
+	}
 	if skip := s.rangeExclusionSkip(in.repoRoot, in.metadata, fullRef); skip != nil {
 		return targetDescriptor{}, skip
 	}
@@ -295,6 +311,7 @@ func (s *Server) descriptorForRange(
 		worktreePath:      in.worktreePath,
 		requestedModel:    in.requestedModel,
 		requestedProvider: in.requestedProvider,
+		incremental:       incremental,
 	}, nil
 }
 
@@ -542,7 +559,9 @@ func workflowForPanelReviewType(reviewType string) string {
 
 // panelSynthesisOpts overlays the synthesis spec and panel fields onto the
 // frozen base opts. The synthesis BackupAgent/BackupModel are persisted so the
-// worker can prefer them on synthesis failover.
+// worker can prefer them on synthesis failover, and the whole spec is stored
+// as the row's config JSON so the worker applies the strategy frozen at
+// enqueue.
 // EnqueuePanelRun enforces JobTypeSynthesis/PanelRoleSynthesis/ClaimBlocked, but
 // they are set here too so the opts are self-describing.
 func panelSynthesisOpts(
@@ -550,8 +569,10 @@ func panelSynthesisOpts(
 	synth config.SynthesisSpec,
 ) storage.EnqueueOpts {
 	o := descriptor.baseOpts()
+	cfgJSON, _ := json.Marshal(synth)
 	o.JobType = storage.JobTypeSynthesis
 	o.Agent, o.Model, o.Reasoning = synth.Agent, synth.Model, synth.Reasoning
+	o.PanelMemberConfigJSON = string(cfgJSON)
 	o.BackupAgent, o.BackupModel = synth.BackupAgent, synth.BackupModel
 	o.PanelRunUUID, o.PanelRole = runUUID, storage.PanelRoleSynthesis
 	o.PanelName, o.ClaimBlocked = panelName, true
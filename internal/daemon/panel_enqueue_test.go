@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
 	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/testutil"
 )
@@ -620,6 +621,46 @@ synthesis_backup_model = "opus"
 	assert.Equal("opus", synth.BackupModel, "synthesis backup model persisted")
 }
 
+// TestEnqueuePanelFreezesSynthesisStrategy verifies the resolved synthesis
+// strategy is stored on the synthesis row, so the worker combines the run the
+// way the panel was configured at enqueue.
+func TestEnqueuePanelFreezesSynthesisStrategy(t *testing.T) {
+	server, db, _ := newTestServer(t)
+
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile(".roborev.toml", `
+[review]
+default_panel = "voting"
+
+[review.subagents.bug]
+agent = "test"
+review_type = "default"
+
+[review.subagents.security]
+agent = "test"
+review_type = "security"
+
+[review.panels.voting]
+members = ["bug", "security"]
+synthesis_strategy = "majority"
+synthesis_quorum = 2
+`)
+	repo.CommitFile("a.txt", "a", "add a")
+
+	resp := enqueuePanelViaHTTP(t, server, EnqueueRequest{
+		RepoPath: repo.Path(),
+		GitRef:   "HEAD",
+		Agent:    "test",
+	})
+
+	synth, err := db.GetSynthesisJob(resp.PanelRunUUID)
+	require.NoError(t, err)
+	require.NotNil(t, synth)
+	spec := synthesisSpecOf(synth)
+	assert.Equal(t, config.SynthesisStrategyMajority, spec.Strategy)
+	assert.Equal(t, 2, spec.Quorum)
+}
+
 // TestEnqueueSingleMemberPanelSynthesisBackupPersisted verifies the single-member
 // override block that surfaces the member's agent/model on the parent row does
 // NOT clear the synthesis backup agent/model.
@@ -169,3 +169,40 @@ func TestListJobsStatsExcludeMembers(t *testing.T) {
 	assert.Equal(1, body.Stats.Done,
 		"stats count the synthesis parent only, not the 3 members")
 }
+
+// TestListJobsShowsNewestSynthesisAfterResynthesis verifies a re-synthesized
+// run lists once, as its newest synthesis with the run's panel_summary, while
+// panel_run expansion still returns the earlier synthesis as history.
+func TestListJobsShowsNewestSynthesisAfterResynthesis(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+
+	runUUID, synthID, frozenRef := enqueueTrioPanel(t, server)
+	_, err := db.Exec(`UPDATE review_jobs SET status = 'done', claim_blocked = 0 WHERE panel_run_uuid = ?`, runUUID)
+	require.NoError(t, err)
+	synth, err := db.GetJobByID(synthID)
+	require.NoError(t, err)
+	fresh, err := db.ResynthesizePanel(storage.EnqueueOpts{
+		RepoID: synth.RepoID, GitRef: frozenRef, Agent: "test", PanelRunUUID: runUUID,
+	})
+	require.NoError(t, err)
+
+	var parents []storage.ReviewJob
+	for _, j := range listJobsViaHTTP(t, server, "") {
+		if j.PanelRunUUID == runUUID {
+			parents = append(parents, j)
+		}
+	}
+	require.Len(t, parents, 1, "the run lists once")
+	assert.Equal(fresh.ID, parents[0].ID)
+	require.NotNil(t, parents[0].PanelSummary)
+	assert.Equal(3, parents[0].PanelSummary.MembersTotal)
+
+	var syntheses []int64
+	for _, j := range listJobsViaHTTP(t, server, "?panel_run="+runUUID) {
+		if j.PanelRole == storage.PanelRoleSynthesis {
+			syntheses = append(syntheses, j.ID)
+		}
+	}
+	assert.ElementsMatch([]int64{synthID, fresh.ID}, syntheses)
+}
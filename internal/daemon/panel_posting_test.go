@@ -831,6 +831,57 @@ func TestPostPanelRunDefersTransient(t *testing.T) {
 	assert.NotNil(attempt.NextAttemptAt, "transient defer schedules a next attempt")
 }
 
+// TestPostPanelRunBroadcastsCIEvents covers the ci.* hook events: a deferral
+// carries its error class and next attempt, and a give-up is announced once.
+func TestPostPanelRunBroadcastsCIEvents(t *testing.T) {
+	assert := assert.New(t)
+	h := newCIPollerHarness(t, "https://github.com/acme/api.git")
+	h.CaptureComments()
+	h.CaptureCommitStatuses()
+	h.Poller.broadcaster = NewBroadcaster()
+	_, ch := h.Poller.broadcaster.Subscribe("")
+
+	const deferSHA = "cievent12345678"
+	_, err := h.DB.ReserveReviewAttempt("acme/api", 90, deferSHA, time.Now())
+	require.NoError(t, err)
+	outage := reviewpkg.OutageErrorPrefix + "503 Service Unavailable"
+	_, synth, _ := h.seedCIPanelRun(t, "acme/api", 90, deferSHA, "base.."+deferSHA,
+		[]jobSpec{{Agent: "test", ReviewType: "review", Status: "failed", Error: outage}})
+	h.markJobFailed(t, synth.ID, "synthesis released after all members failed")
+	h.Poller.handleReviewFailed(ciEvent(synth.ID, "review.failed"))
+
+	events := drainEventTypes(ch)
+	require.Contains(t, events, EventCIDeferred)
+	e := events[EventCIDeferred]
+	assert.Equal(synth.ID, e.JobID)
+	assert.Contains(e.Error, "503")
+	require.NotNil(t, e.CI)
+	assert.Equal("acme/api", e.CI.GithubRepo)
+	assert.Equal(90, e.CI.PRNumber)
+	assert.Equal(deferSHA, e.CI.HeadSHA)
+	assert.Equal("transient", e.CI.ErrorClass)
+	assert.NotNil(e.CI.NextAttemptAt)
+
+	const giveUpSHA = "cieventgiveup12"
+	_, err = h.DB.ReserveReviewAttempt("acme/api", 91, giveUpSHA, time.Now())
+	require.NoError(t, err)
+	_, err = h.DB.Exec(`UPDATE ci_pr_review_attempts SET consecutive_genuine_attempts = ?
+		WHERE github_repo = ? AND pr_number = ? AND head_sha = ?`,
+		reviewpkg.DefaultRetrySchedule.GenuineMax-1, "acme/api", 91, giveUpSHA)
+	require.NoError(t, err)
+	_, synth, _ = h.seedCIPanelRun(t, "acme/api", 91, giveUpSHA, "base.."+giveUpSHA,
+		[]jobSpec{{Agent: "test", ReviewType: "review", Status: "failed", Error: "still broken"}})
+	h.markJobFailed(t, synth.ID, "synthesis released after all members failed")
+	h.Poller.handleReviewFailed(ciEvent(synth.ID, "review.failed"))
+
+	events = drainEventTypes(ch)
+	require.Contains(t, events, EventCIGaveUp)
+	assert.NotContains(events, EventCIDeferred)
+	require.NotNil(t, events[EventCIGaveUp].CI)
+	assert.Equal("genuine", events[EventCIGaveUp].CI.ErrorClass)
+	assert.Equal(91, events[EventCIGaveUp].CI.PRNumber)
+}
+
 // TestPostPanelRunDefersQuotaOnly covers the agent-unavailable policy: when
 // every member is skipped because the configured agent is quota/session limited,
 // the panel is deferred for the retry sweep instead of posting an all-skipped
@@ -12,7 +12,8 @@ const panelSweepInterval = 60 * time.Second
 
 // runPanelSweep periodically releases panel synthesis jobs whose members are all
 // terminal but whose claim_blocked gate was never cleared (e.g. a missed worker
-// release after a crash). It returns when ctx is canceled.
+// release after a crash), and settles depends_on jobs the same way. It returns
+// when ctx is canceled.
 func (s *Server) runPanelSweep(ctx context.Context, interval time.Duration) {
 	t := time.NewTicker(interval)
 	defer t.Stop()
@@ -22,6 +23,7 @@ func (s *Server) runPanelSweep(ctx context.Context, interval time.Duration) {
 			return
 		case <-t.C:
 			s.sweepStuckPanels()
+			s.settleJobDependencies()
 		}
 	}
 }
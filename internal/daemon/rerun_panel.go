@@ -1,13 +1,17 @@
 package daemon
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
 
+	"go.kenn.io/roborev/internal/config"
 	"go.kenn.io/roborev/internal/storage"
 )
 
@@ -147,37 +151,195 @@ func panelRerunMemberOpts(m storage.ReviewJob, runUUID, diff string, dirtyFiles
 	}
 }
 
-// panelRerunSynthesisOpts clones the synthesis parent into fresh EnqueueOpts for
-// a new run. EnqueuePanelRun re-enforces JobType=synthesis, role=synthesis, and
+// panelRerunSynthesisOpts clones the synthesis parent, including its frozen
+// synthesis spec, into fresh EnqueueOpts for a new run. EnqueuePanelRun re-enforces JobType=synthesis, role=synthesis, and
 // ClaimBlocked, but they are set here too so the opts are self-describing.
 func panelRerunSynthesisOpts(job *storage.ReviewJob, runUUID, diff string, dirtyFiles []string, source string) storage.EnqueueOpts {
 	return storage.EnqueueOpts{
-		RepoID:            job.RepoID,
-		CommitID:          job.CommitIDValue(),
-		GitRef:            job.GitRef,
-		Branch:            job.Branch,
-		CIBaseBranch:      job.CIBaseBranch,
-		Agent:             job.Agent,
-		Model:             job.Model,
-		Provider:          job.Provider,
-		RequestedModel:    job.RequestedModel,
-		RequestedProvider: job.RequestedProvider,
-		Reasoning:         job.Reasoning,
-		ReviewType:        job.ReviewType,
-		PatchID:           job.PatchID,
-		DiffContent:       diff,
-		DirtyFiles:        dirtyFiles,
-		OutputPrefix:      job.OutputPrefix,
-		Source:            source,
-		Agentic:           job.Agentic,
-		JobType:           storage.JobTypeSynthesis,
-		WorktreePath:      job.WorktreePath,
-		MinSeverity:       job.MinSeverity,
-		BackupAgent:       job.BackupAgent,
-		BackupModel:       job.BackupModel,
-		PanelRunUUID:      runUUID,
-		PanelRole:         storage.PanelRoleSynthesis,
-		PanelName:         job.PanelName,
-		ClaimBlocked:      true,
+		RepoID:                job.RepoID,
+		CommitID:              job.CommitIDValue(),
+		GitRef:                job.GitRef,
+		Branch:                job.Branch,
+		CIBaseBranch:          job.CIBaseBranch,
+		Agent:                 job.Agent,
+		Model:                 job.Model,
+		Provider:              job.Provider,
+		RequestedModel:        job.RequestedModel,
+		RequestedProvider:     job.RequestedProvider,
+		Reasoning:             job.Reasoning,
+		ReviewType:            job.ReviewType,
+		PatchID:               job.PatchID,
+		DiffContent:           diff,
+		DirtyFiles:            dirtyFiles,
+		OutputPrefix:          job.OutputPrefix,
+		Source:                source,
+		Agentic:               job.Agentic,
+		JobType:               storage.JobTypeSynthesis,
+		WorktreePath:          job.WorktreePath,
+		MinSeverity:           job.MinSeverity,
+		BackupAgent:           job.BackupAgent,
+		BackupModel:           job.BackupModel,
+		PanelRunUUID:          runUUID,
+		PanelRole:             storage.PanelRoleSynthesis,
+		PanelName:             job.PanelName,
+		PanelMemberConfigJSON: job.PanelMemberConfigJSON,
+		ClaimBlocked:          true,
+	}
+}
+
+// humaRerunPanelMember re-queues a single panel member in place and enqueues
+// a blocked clone of the run's newest synthesis, so one flaky or outdated
+// member can be refreshed without cloning the whole run the way rerunPanelRun
+// does. The member keeps its frozen agent/model; the new synthesis runs once
+// the member finishes, and earlier syntheses stay as history.
+func (s *Server) humaRerunPanelMember(
+	ctx context.Context, input *PanelRerunMemberInput,
+) (*PanelRerunMemberOutput, error) {
+	if input.Body.JobID == 0 {
+		return nil, huma.Error400BadRequest("job_id is required")
 	}
+	job, err := s.db.GetJobByID(input.Body.JobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, huma.Error404NotFound("job not found")
+		}
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load job: %v", err))
+	}
+	if job.PanelRole != storage.PanelRoleMember || job.PanelRunUUID == "" {
+		return nil, huma.Error400BadRequest("job is not a panel member")
+	}
+	synth, err := s.db.GetSynthesisJob(job.PanelRunUUID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load synthesis job: %v", err))
+	}
+	if synth == nil {
+		return nil, huma.Error409Conflict("panel run has no synthesis to rerun")
+	}
+	source, err := s.panelRerunSource(synth)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("resolve panel source: %v", err))
+	}
+	diff, err := s.db.GetJobDiffContent(synth.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load synthesis diff: %v", err))
+	}
+	dirtyFiles, err := s.db.GetJobDirtyFiles(synth.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load synthesis dirty files: %v", err))
+	}
+	opts := panelRerunSynthesisOpts(synth, job.PanelRunUUID, diff, dirtyFiles, source)
+	fresh, err := s.db.RerunPanelMember(job.ID, opts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, huma.Error409Conflict(
+				"panel member and synthesis must both be finished to rerun a member")
+		}
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("rerun panel member: %v", err))
+	}
+
+	resp := &PanelRerunMemberOutput{}
+	resp.Body.Success = true
+	resp.Body.SynthesisJobID = fresh.ID
+	return resp, nil
+}
+
+// humaResynthesizePanel enqueues a fresh synthesis over a panel run's stored
+// member outputs without re-running any member. The previous synthesis rows
+// and reviews stay as history, so synthesis agents and models can be compared
+// on the same member reviews. The new job clones the latest synthesis, with
+// the agent and model optionally overridden; an override on a run with a
+// deterministic synthesis_strategy re-synthesizes it with the llm strategy.
+func (s *Server) humaResynthesizePanel(
+	ctx context.Context, input *PanelResynthesizeInput,
+) (*PanelResynthesizeOutput, error) {
+	runUUID := strings.TrimSpace(input.Body.PanelRunUUID)
+	if runUUID == "" {
+		return nil, huma.Error400BadRequest("panel_run_uuid is required")
+	}
+	synth, err := s.db.GetSynthesisJob(runUUID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load synthesis job: %v", err))
+	}
+	if synth == nil {
+		return nil, huma.Error404NotFound("panel run not found")
+	}
+	source, err := s.panelRerunSource(synth)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("resolve panel source: %v", err))
+	}
+	// A CI panel run is bound to its one synthesis job for PR comment
+	// posting, so a second synthesis would never be posted.
+	if source == storage.JobSourceCI {
+		return nil, huma.Error400BadRequest(
+			"CI panel runs cannot be re-synthesized; rerun the panel synthesis job instead")
+	}
+
+	diff, err := s.db.GetJobDiffContent(synth.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load synthesis diff: %v", err))
+	}
+	dirtyFiles, err := s.db.GetJobDirtyFiles(synth.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("load synthesis dirty files: %v", err))
+	}
+	opts := panelRerunSynthesisOpts(synth, runUUID, diff, dirtyFiles, source)
+	opts.ClaimBlocked = false
+	agentOverride := strings.TrimSpace(input.Body.Agent)
+	modelOverride := strings.TrimSpace(input.Body.Model)
+	spec := synthesisSpecOf(synth)
+	if (agentOverride != "" || modelOverride != "") && spec.Strategy == config.SynthesisStrategyChunks {
+		return nil, huma.Error400BadRequest(
+			"chunked reviews are combined per file group without an agent; --agent and --model do not apply")
+	}
+	if agentName := agentOverride; agentName != "" {
+		if err := validateRerunAgent(synth.RepoPath, agentName, "", s.configWatcher.Config()); err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		// The previous model and provider belong to the previous agent.
+		opts.Agent = agentName
+		opts.Model, opts.RequestedModel = "", ""
+		opts.Provider, opts.RequestedProvider = "", ""
+	}
+	if model := modelOverride; model != "" {
+		opts.Model, opts.RequestedModel = model, model
+	}
+	if agentOverride != "" || modelOverride != "" {
+		// Naming an agent or model asks for an agent synthesis, so a
+		// deterministic strategy frozen on the run gives way to llm.
+		if spec.Deterministic() {
+			spec.Strategy, spec.Quorum = config.SynthesisStrategyLLM, 0
+		}
+		spec.Agent, spec.Model = opts.Agent, opts.Model
+		specJSON, err := json.Marshal(spec)
+		if err != nil {
+			return nil, huma.Error500InternalServerError(
+				fmt.Sprintf("encode synthesis spec: %v", err))
+		}
+		opts.PanelMemberConfigJSON = string(specJSON)
+	}
+
+	job, err := s.db.ResynthesizePanel(opts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, huma.Error409Conflict(
+				"panel members and synthesis must all be finished to re-synthesize")
+		}
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("resynthesize panel: %v", err))
+	}
+
+	resp := &PanelResynthesizeOutput{}
+	resp.Body.Success = true
+	resp.Body.SynthesisJobID = job.ID
+	return resp, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.kenn.io/roborev/internal/config"
 	"go.kenn.io/roborev/internal/storage"
 )
 
@@ -443,3 +444,152 @@ func TestRerunPanelPreservesTarget(t *testing.T) {
 		}
 	})
 }
+
+func TestRerunPanelMemberEndpoint(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+	runUUID, members, synth := enqueueServerPanelRun(t, db, 2)
+
+	// In-flight run: rejected without touching anything.
+	markJobStatus(t, db, members[0].ID, storage.JobStatusDone)
+	_, err := server.humaRerunPanelMember(context.Background(), &PanelRerunMemberInput{
+		Body: PanelRerunMemberRequest{JobID: members[0].ID},
+	})
+	require.Error(t, err)
+
+	markJobStatus(t, db, members[1].ID, storage.JobStatusFailed)
+	markJobStatus(t, db, synth.ID, storage.JobStatusDone)
+	out, err := server.humaRerunPanelMember(context.Background(), &PanelRerunMemberInput{
+		Body: PanelRerunMemberRequest{JobID: members[1].ID},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(synth.ID, out.Body.SynthesisJobID, "the rerun gets a new synthesis row")
+
+	got, err := db.GetJobByID(members[1].ID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusQueued, got.Status)
+	other, err := db.GetJobByID(members[0].ID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusDone, other.Status, "other members untouched")
+	gotSynth, err := db.GetSynthesisJob(runUUID)
+	require.NoError(t, err)
+	assert.Equal(out.Body.SynthesisJobID, gotSynth.ID, "member rerun stays in the same run")
+	assert.Equal(synth.Agent, gotSynth.Agent, "the new synthesis clones the newest one")
+	assert.Equal(storage.JobStatusQueued, gotSynth.Status)
+	assert.True(gotSynth.ClaimBlocked)
+	old, err := db.GetJobByID(synth.ID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusDone, old.Status, "the earlier synthesis is kept as history")
+
+	// The synthesis is not a member.
+	_, err = server.humaRerunPanelMember(context.Background(), &PanelRerunMemberInput{
+		Body: PanelRerunMemberRequest{JobID: synth.ID},
+	})
+	require.Error(t, err)
+}
+
+func TestResynthesizePanelEndpoint(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+	runUUID, members, synth := enqueueServerPanelRun(t, db, 2)
+	resynth := func(req PanelResynthesizeRequest) (*PanelResynthesizeOutput, error) {
+		return server.humaResynthesizePanel(context.Background(), &PanelResynthesizeInput{Body: req})
+	}
+
+	_, err := resynth(PanelResynthesizeRequest{PanelRunUUID: runUUID})
+	require.Error(t, err, "members still pending")
+
+	for _, m := range members {
+		markJobStatus(t, db, m.ID, storage.JobStatusDone)
+	}
+	markJobStatus(t, db, synth.ID, storage.JobStatusFailed)
+	out, err := resynth(PanelResynthesizeRequest{PanelRunUUID: runUUID, Model: "fast-model"})
+	require.NoError(t, err)
+	assert.NotEqual(synth.ID, out.Body.SynthesisJobID, "a fresh synthesis job is enqueued")
+
+	fresh, err := db.GetJobByID(out.Body.SynthesisJobID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusQueued, fresh.Status)
+	assert.Equal(storage.PanelRoleSynthesis, fresh.PanelRole)
+	assert.Equal(runUUID, fresh.PanelRunUUID)
+	assert.Equal("test", fresh.Agent, "agent defaults to the previous synthesis agent")
+	assert.Equal("fast-model", fresh.Model)
+	old, err := db.GetJobByID(synth.ID)
+	require.NoError(t, err)
+	assert.Equal(storage.JobStatusFailed, old.Status, "the old synthesis is kept as history")
+	for _, m := range members {
+		got, err := db.GetJobByID(m.ID)
+		require.NoError(t, err)
+		assert.Equal(storage.JobStatusDone, got.Status, "members are not re-run")
+	}
+
+	_, err = resynth(PanelResynthesizeRequest{PanelRunUUID: runUUID})
+	require.ErrorContains(t, err, "must all be finished", "only one synthesis may be active")
+
+	markJobStatus(t, db, fresh.ID, storage.JobStatusDone)
+	_, err = resynth(PanelResynthesizeRequest{PanelRunUUID: runUUID, Agent: "no-such-agent"})
+	require.ErrorContains(t, err, "invalid agent")
+
+	_, err = resynth(PanelResynthesizeRequest{PanelRunUUID: "no-such-run"})
+	require.ErrorContains(t, err, "panel run not found")
+}
+
+func TestResynthesizePanelOverrideSwitchesDeterministicRunToLLM(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+	runUUID, members, synth := enqueueServerPanelRun(t, db, 2)
+	for _, m := range members {
+		markJobStatus(t, db, m.ID, storage.JobStatusDone)
+	}
+	markJobStatus(t, db, synth.ID, storage.JobStatusDone)
+	_, err := db.Exec(`UPDATE review_jobs SET panel_member_config_json = ? WHERE id = ?`,
+		`{"agent":"test","strategy":"majority","quorum":2}`, synth.ID)
+	require.NoError(t, err)
+	resynth := func(req PanelResynthesizeRequest) (*PanelResynthesizeOutput, error) {
+		req.PanelRunUUID = runUUID
+		return server.humaResynthesizePanel(context.Background(), &PanelResynthesizeInput{Body: req})
+	}
+
+	out, err := resynth(PanelResynthesizeRequest{Model: "big-model"})
+	require.NoError(t, err)
+	fresh, err := db.GetJobByID(out.Body.SynthesisJobID)
+	require.NoError(t, err)
+	spec := synthesisSpecOf(fresh)
+	assert.Equal(config.SynthesisStrategyLLM, spec.Strategy, "an override asks for an agent synthesis")
+	assert.Zero(spec.Quorum)
+	assert.Equal("test", spec.Agent)
+	assert.Equal("big-model", spec.Model)
+
+	// Without an override the frozen strategy is kept.
+	markJobStatus(t, db, fresh.ID, storage.JobStatusDone)
+	_, err = db.Exec(`UPDATE review_jobs SET panel_member_config_json = ? WHERE id = ?`,
+		`{"agent":"test","strategy":"majority","quorum":2}`, fresh.ID)
+	require.NoError(t, err)
+	out, err = resynth(PanelResynthesizeRequest{})
+	require.NoError(t, err)
+	again, err := db.GetJobByID(out.Body.SynthesisJobID)
+	require.NoError(t, err)
+	assert.Equal(config.SynthesisStrategyMajority, synthesisSpecOf(again).Strategy)
+
+	// Chunked reviews have no agent synthesis to switch to.
+	markJobStatus(t, db, again.ID, storage.JobStatusDone)
+	_, err = db.Exec(`UPDATE review_jobs SET panel_member_config_json = ? WHERE id = ?`,
+		`{"agent":"test","strategy":"chunks"}`, again.ID)
+	require.NoError(t, err)
+	_, err = resynth(PanelResynthesizeRequest{Agent: "test"})
+	require.ErrorContains(t, err, "do not apply")
+}
+
+func TestResynthesizePanelEndpointRejectsCIRuns(t *testing.T) {
+	server, db, _ := newTestServer(t)
+	runUUID, members, synth := enqueueServerPanelRun(t, db, 1)
+	_, err := db.Exec(`UPDATE review_jobs SET source = ? WHERE panel_run_uuid = ?`, storage.JobSourceCI, runUUID)
+	require.NoError(t, err)
+	markJobStatus(t, db, members[0].ID, storage.JobStatusDone)
+	markJobStatus(t, db, synth.ID, storage.JobStatusDone)
+
+	_, err = server.humaResynthesizePanel(context.Background(), &PanelResynthesizeInput{
+		Body: PanelResynthesizeRequest{PanelRunUUID: runUUID},
+	})
+	require.ErrorContains(t, err, "CI panel runs cannot be re-synthesized")
+}
@@ -0,0 +1,223 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"go.kenn.io/roborev/internal/config"
+	gitpkg "go.kenn.io/roborev/internal/git"
+	reviewpkg "go.kenn.io/roborev/internal/review"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const (
+	// resolutionCheckWorkflow names the agent/model config keys of resolution
+	// checks: resolution_check_agent and resolution_check_model.
+	resolutionCheckWorkflow = "resolution_check"
+	// resolutionCheckReasoning keeps resolution checks cheap; they answer a
+	// yes/no question per review rather than reviewing code.
+	resolutionCheckReasoning = "fast"
+	// resolutionCheckMaxReviews caps the open reviews one check covers.
+	resolutionCheckMaxReviews = 10
+	// resolutionCheckMaxCommits caps the commits listed per review.
+	resolutionCheckMaxCommits = 10
+	// resolutionCheckMaxDiff caps the diff quoted per review.
+	resolutionCheckMaxDiff = 20000
+)
+
+// maybeEnqueueResolutionCheck queues a resolution check after a branch review
+// completes when resolution_check is enabled. The completed review's head is
+// the newest commit the branch's older open reviews are checked against.
+// Opportunistic: errors are logged and never fail the completed job.
+func (wp *WorkerPool) maybeEnqueueResolutionCheck(workerID string, job *storage.ReviewJob) {
+	if job.Branch == "" || job.IsCIReview() || job.IsDirtyJob() ||
+		job.PanelRole == storage.PanelRoleMember ||
+		(!job.IsReviewJob() && !job.IsSynthesisJob()) ||
+		!config.IsDefaultReviewType(job.ReviewType) {
+		return
+	}
+	cfg := wp.cfgGetter.Config()
+	if !config.ResolveResolutionCheck(job.RepoPath, cfg) {
+		return
+	}
+	if err := wp.enqueueResolutionCheck(workerID, job, cfg); err != nil {
+		log.Printf("[%s] Warning: resolution check after job %d: %v", workerID, job.ID, err)
+	}
+}
+
+func (wp *WorkerPool) enqueueResolutionCheck(workerID string, job *storage.ReviewJob, cfg *config.Config) error {
+	pending, err := wp.db.HasPendingResolutionCheck(job.RepoID, job.Branch)
+	if err != nil || pending {
+		return err
+	}
+	ctx := context.Background()
+	head, err := gitpkg.ResolveSHACtx(ctx, job.RepoPath, storage.PriorReview{GitRef: job.GitRef}.HeadSHA())
+	if err != nil {
+		return fmt.Errorf("resolve head: %w", err)
+	}
+	prior, err := wp.db.ListBranchReviews(job.RepoID, job.Branch, incrementalReviewLookback)
+	if err != nil {
+		return err
+	}
+
+	var reviews []reviewpkg.ResolutionCheckReview
+	var candidates []storage.ResolutionCandidate
+	for _, r := range prior {
+		if r.Closed || r.Passed || r.JobID == job.ID {
+			continue
+		}
+		rv, ok := resolutionCheckCandidate(ctx, job.RepoPath, r, head)
+		if !ok {
+			continue
+		}
+		reviews = append(reviews, rv)
+		commits := make([]string, len(rv.Commits))
+		for i, c := range rv.Commits {
+			commits[i] = c.SHA
+		}
+		candidates = append(candidates, storage.ResolutionCandidate{JobID: r.JobID, Commits: commits})
+		if len(candidates) == resolutionCheckMaxReviews {
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	agentName := config.ResolveAgentForWorkflow("", job.RepoPath, cfg, resolutionCheckWorkflow, resolutionCheckReasoning)
+	model := config.ResolveModelForWorkflow("", job.RepoPath, cfg, resolutionCheckWorkflow, resolutionCheckReasoning)
+	check, err := wp.db.EnqueueJob(storage.EnqueueOpts{
+		RepoID:     job.RepoID,
+		GitRef:     "resolution-check-" + gitpkg.ShortSHA(head),
+		Branch:     job.Branch,
+		Agent:      agentName,
+		Model:      model,
+		Reasoning:  resolutionCheckReasoning,
+		JobType:    storage.JobTypeResolution,
+		Prompt:     reviewpkg.BuildResolutionCheckPrompt(job.Branch, head, reviews),
+		Resolution: &storage.ResolutionCheck{HeadSHA: head, Candidates: candidates},
+	})
+	if err != nil {
+		return fmt.Errorf("enqueue resolution check: %w", err)
+	}
+	log.Printf("[%s] Enqueued resolution check %d of %d open reviews on %s",
+		workerID, check.ID, len(candidates), job.Branch)
+	return nil
+}
+
+// resolutionCheckCandidate reports whether an open review is worth checking
+// at head: its commit must be an ancestor of head, and a later commit must
+// have touched a file its findings reference.
+func resolutionCheckCandidate(
+	ctx context.Context, repoPath string, r storage.PriorReview, head string,
+) (reviewpkg.ResolutionCheckReview, bool) {
+	base, err := gitpkg.ResolveSHACtx(ctx, repoPath, r.HeadSHA())
+	if err != nil || base == head {
+		return reviewpkg.ResolutionCheckReview{}, false
+	}
+	if ok, err := gitpkg.IsAncestor(repoPath, base, head); err != nil || !ok {
+		return reviewpkg.ResolutionCheckReview{}, false
+	}
+	rangeRef := base + ".." + head
+	changed, err := gitpkg.GetRangeFilesChangedCtx(ctx, repoPath, rangeRef)
+	if err != nil {
+		return reviewpkg.ResolutionCheckReview{}, false
+	}
+	referenced := referencedFiles(r.Output, changed)
+	if len(referenced) == 0 {
+		return reviewpkg.ResolutionCheckReview{}, false
+	}
+	shas, err := gitpkg.GetRangePathCommitsCtx(ctx, repoPath, rangeRef, referenced)
+	if err != nil || len(shas) == 0 {
+		return reviewpkg.ResolutionCheckReview{}, false
+	}
+	if len(shas) > resolutionCheckMaxCommits {
+		shas = shas[:resolutionCheckMaxCommits]
+	}
+	rv := reviewpkg.ResolutionCheckReview{JobID: r.JobID, GitRef: r.GitRef, Output: r.Output}
+	for _, sha := range shas {
+		c := reviewpkg.ResolutionCommit{SHA: sha}
+		if info, err := gitpkg.GetCommitInfoCtx(ctx, repoPath, sha); err == nil {
+			c.Subject = info.Subject
+		}
+		rv.Commits = append(rv.Commits, c)
+	}
+	diff, truncated, err := gitpkg.GetPathsDiffLimitedCtx(ctx, repoPath, rangeRef, resolutionCheckMaxDiff, referenced)
+	if err == nil {
+		if truncated {
+			diff += "\n... (truncated)"
+		}
+		rv.Diff = diff
+	}
+	return rv, true
+}
+
+// referencedFiles returns the changed files a review's output names, either
+// by path or, for a location like "file.go:12", by base name.
+func referencedFiles(output string, changed []string) []string {
+	var out []string
+	for _, f := range changed {
+		if strings.Contains(output, f) || strings.Contains(output, path.Base(f)+":") {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// applyResolutionCheck closes the reviews a completed resolution check
+// reported resolved, leaving a comment on each that cites the resolving
+// commit. A commit the agent named that is not among the review's later
+// commits is replaced by the newest of them. The check's own review is closed
+// too, since its outcome lives on the reviews it covered.
+func (wp *WorkerPool) applyResolutionCheck(workerID string, job *storage.ReviewJob, output string) {
+	rc := job.Resolution
+	if rc == nil {
+		return
+	}
+	reported := reviewpkg.ParseResolutionCheck(output)
+	updated := *rc
+	updated.Resolved = nil
+	for _, c := range rc.Candidates {
+		named, ok := reported[c.JobID]
+		if !ok || len(c.Commits) == 0 {
+			continue
+		}
+		if rv, err := wp.db.GetReviewByJobID(c.JobID); err != nil || rv.Closed {
+			continue
+		}
+		commit := resolvingCommit(c.Commits, named)
+		if err := wp.db.MarkReviewClosedByJobID(c.JobID, true); err != nil {
+			log.Printf("[%s] Warning: close resolved review %d: %v", workerID, c.JobID, err)
+			continue
+		}
+		comment := fmt.Sprintf("Resolved by commit %s (resolution check job %d).", gitpkg.ShortSHA(commit), job.ID)
+		if _, err := wp.db.AddCommentToJob(c.JobID, resolvedReviewResponder, comment); err != nil {
+			log.Printf("[%s] Warning: comment on resolved review %d: %v", workerID, c.JobID, err)
+		}
+		updated.Resolved = append(updated.Resolved, storage.ResolvedReview{JobID: c.JobID, Commit: commit})
+	}
+	if err := wp.db.SaveJobResolution(job.ID, updated); err != nil {
+		log.Printf("[%s] Warning: record resolution check %d: %v", workerID, job.ID, err)
+	}
+	if err := wp.db.MarkReviewClosedByJobID(job.ID, true); err != nil {
+		log.Printf("[%s] Warning: close resolution check %d: %v", workerID, job.ID, err)
+	}
+	log.Printf("[%s] Resolution check %d closed %d of %d reviews",
+		workerID, job.ID, len(updated.Resolved), len(rc.Candidates))
+}
+
+// resolvingCommit returns the candidate commit the agent named by SHA
+// prefix, or the newest candidate when it named none of them.
+func resolvingCommit(commits []string, named string) string {
+	if len(named) >= 4 {
+		for _, c := range commits {
+			if strings.HasPrefix(c, named) {
+				return c
+			}
+		}
+	}
+	return commits[0]
+}
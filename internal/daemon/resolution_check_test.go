@@ -0,0 +1,154 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	gitpkg "go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func TestReferencedFiles(t *testing.T) {
+	output := "- High — internal/api/handler.go:12 nil check\n- Low — util.go:4 naming"
+	changed := []string{"internal/api/handler.go", "pkg/util.go", "README.md", "internal/api/other.go"}
+	assert.Equal(t, []string{"internal/api/handler.go", "pkg/util.go"}, referencedFiles(output, changed))
+}
+
+func TestResolvingCommit(t *testing.T) {
+	commits := []string{"ccc111", "bbb222"}
+	assert.Equal(t, "bbb222", resolvingCommit(commits, "bbb2"))
+	assert.Equal(t, "ccc111", resolvingCommit(commits, ""), "no named commit credits the newest")
+	assert.Equal(t, "ccc111", resolvingCommit(commits, "fff9"), "unknown commits are not credited")
+}
+
+func TestResolutionCheckClosesFixedReviews(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	const checkAgent = "resolution-check-agent"
+	cfg := config.DefaultConfig()
+	cfg.ResolutionCheck = true
+	cfg.ResolutionCheckAgent = checkAgent
+	tc.reconfigurePool(cfg)
+	registerPassingAgent(t, "resolution-reviewer")
+
+	review := func(sha, output string) int64 {
+		t.Helper()
+		job, err := tc.DB.EnqueueJob(storage.EnqueueOpts{
+			RepoID: tc.Repo.ID, GitRef: sha, Branch: "feature", Agent: "test",
+		})
+		require.NoError(t, err)
+		claimed, err := tc.DB.ClaimJob(testWorkerID)
+		require.NoError(t, err)
+		require.Equal(t, job.ID, claimed.ID)
+		require.NoError(t, tc.DB.CompleteJob(job.ID, "test", "p", output))
+		return job.ID
+	}
+
+	first := tc.GitRepo.CommitFile("a.go", "package a\n", "add a")
+	fixable := review(first, "- High — a.go:1 nil dereference")
+	untouched := review(first, "- Medium — b.go:3 missing check")
+	fix := tc.GitRepo.CommitFile("a.go", "package a // fixed\n", "guard nil")
+
+	job, err := tc.DB.EnqueueJob(storage.EnqueueOpts{
+		RepoID: tc.Repo.ID, GitRef: fix, Branch: "feature", Agent: "resolution-reviewer",
+	})
+	require.NoError(t, err)
+	claimed, err := tc.DB.ClaimJob(testWorkerID)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, claimed.ID)
+	tc.Pool.processJob(testWorkerID, claimed)
+	tc.assertJobStatus(t, job.ID, storage.JobStatusDone)
+
+	check, err := tc.DB.ClaimJob(testWorkerID)
+	require.NoError(t, err)
+	require.NotNil(t, check, "completing the branch review queues a resolution check")
+	assert.Equal(t, storage.JobTypeResolution, check.JobType)
+	assert.Equal(t, checkAgent, check.Agent)
+	assert.Equal(t, "fast", check.Reasoning)
+	require.NotNil(t, check.Resolution)
+	assert.Equal(t, []storage.ResolutionCandidate{{JobID: fixable, Commits: []string{fix}}}, check.Resolution.Candidates,
+		"a review whose files no later commit touched is not checked")
+	assert.Contains(t, check.Prompt, fmt.Sprintf("## Review %d", fixable))
+	assert.NotContains(t, check.Prompt, fmt.Sprintf("## Review %d", untouched))
+
+	var captured string
+	agent.Register(&agent.FakeAgent{
+		NameStr: checkAgent,
+		ReviewFn: func(_ context.Context, _, _, prompt string, _ io.Writer) (string, error) {
+			captured = prompt
+			return fmt.Sprintf("Review %d: resolved by %s", fixable, gitpkg.ShortSHA(fix)), nil
+		},
+	})
+	t.Cleanup(func() { agent.Unregister(checkAgent) })
+	tc.Pool.processJob(testWorkerID, check)
+
+	done := tc.assertJobStatus(t, check.ID, storage.JobStatusDone)
+	assert.Contains(t, captured, "# Resolution Check")
+	require.NotNil(t, done.Resolution)
+	assert.Equal(t, []storage.ResolvedReview{{JobID: fixable, Commit: fix}}, done.Resolution.Resolved)
+
+	fixed, err := tc.DB.GetReviewByJobID(fixable)
+	require.NoError(t, err)
+	assert.True(t, fixed.Closed)
+	comments, err := tc.DB.GetCommentsForJob(fixable)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, fmt.Sprintf("Resolved by commit %s (resolution check job %d).", gitpkg.ShortSHA(fix), check.ID),
+		comments[0].Response)
+
+	open, err := tc.DB.GetReviewByJobID(untouched)
+	require.NoError(t, err)
+	assert.False(t, open.Closed)
+	own, err := tc.DB.GetReviewByJobID(check.ID)
+	require.NoError(t, err)
+	assert.True(t, own.Closed)
+}
+
+func TestResolutionCheckRunsAtCheckedHead(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	const checkAgent = "resolution-head-agent"
+	first := tc.GitRepo.CommitFile("a.go", "package a\n", "add a")
+	fix := tc.GitRepo.CommitFile("a.go", "package a // fixed\n", "guard nil")
+
+	// The user has since moved to an older commit and has uncommitted edits.
+	tc.GitRepo.CheckoutDetached(first)
+	tc.GitRepo.WriteFile("a.go", "package a // local edit\n")
+
+	var agentDir, seen string
+	agent.Register(&agent.FakeAgent{
+		NameStr: checkAgent,
+		ReviewFn: func(_ context.Context, repoPath, _, _ string, _ io.Writer) (string, error) {
+			agentDir = repoPath
+			data, err := os.ReadFile(filepath.Join(repoPath, "a.go"))
+			seen = string(data)
+			return "No reviews resolved.", err
+		},
+	})
+	t.Cleanup(func() { agent.Unregister(checkAgent) })
+
+	job, err := tc.DB.EnqueueJob(storage.EnqueueOpts{
+		RepoID:     tc.Repo.ID,
+		GitRef:     "resolution-check-" + gitpkg.ShortSHA(fix),
+		Agent:      checkAgent,
+		JobType:    storage.JobTypeResolution,
+		Prompt:     "# Resolution Check",
+		Resolution: &storage.ResolutionCheck{HeadSHA: fix},
+	})
+	require.NoError(t, err)
+	claimed, err := tc.DB.ClaimJob(testWorkerID)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, claimed.ID)
+	tc.Pool.processJob(testWorkerID, claimed)
+
+	tc.assertJobStatus(t, job.ID, storage.JobStatusDone)
+	assert.NotEqual(t, tc.TmpDir, agentDir, "the agent must not run in the live checkout")
+	assert.Equal(t, "package a // fixed\n", seen)
+	assert.NoDirExists(t, agentDir, "the worktree is removed after the check")
+}
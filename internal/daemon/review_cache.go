@@ -0,0 +1,175 @@
+package daemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// reviewCacheMaxAge bounds how old a completed review may be and still
+// satisfy a new job with the same cache key.
+const reviewCacheMaxAge = 30 * 24 * time.Hour
+
+// reviewCacheable reports whether a job's result is determined by its patch
+// and settings alone: a single-commit review outside CI, panels, chunking
+// and incremental re-reviews, whose prompts carry extra context.
+func reviewCacheable(job *storage.ReviewJob) bool {
+	return job.JobType == storage.JobTypeReview &&
+		job.PatchID != "" &&
+		job.PanelRole == "" &&
+		job.Incremental == nil &&
+		!job.PromptPrebuilt &&
+		!job.IsCIReview()
+}
+
+// reviewCacheInputs are the resolved settings, beyond the job's own fields,
+// that shape a review's prompt and agent run.
+type reviewCacheInputs struct {
+	model       string   // the model the agent runs; see reviewCacheModel
+	minSeverity string   // severity floor
+	guidelines  string   // review guidelines
+	excludes    []string // exclude_patterns filtering the diff
+}
+
+// reviewCacheKey identifies the result of reviewing one patch: the same
+// patch-id reviewed with the same review type, agent, model, reasoning,
+// severity floor, guidelines, exclude patterns and prompt templates is
+// expected to produce the same review.
+func reviewCacheKey(job *storage.ReviewJob, in reviewCacheInputs) string {
+	reasoning := strings.ToLower(strings.TrimSpace(job.Reasoning))
+	if reasoning == "" {
+		reasoning = "thorough"
+	}
+	excludes := slices.Clone(in.excludes)
+	slices.Sort(excludes)
+	h := sha256.New()
+	for _, part := range []string{
+		"v2", job.PatchID, job.ReviewType, agent.CanonicalName(job.Agent),
+		in.model, reasoning, in.minSeverity, in.guidelines,
+		strings.Join(excludes, "\n"), prompt.TemplatesDigest(),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// reviewCacheModel returns the model a job runs with, for its cache key. A
+// job without one runs the agent CLI's own default, which can change when
+// the CLI is upgraded, so the CLI version stands in for it.
+func reviewCacheModel(ctx context.Context, job *storage.ReviewJob) string {
+	if job.Model != "" {
+		return job.Model
+	}
+	a, err := agent.Get(job.Agent)
+	if err != nil {
+		return ""
+	}
+	ca, ok := a.(agent.CommandAgent)
+	if !ok {
+		return ""
+	}
+	if v := agent.CommandVersion(ctx, ca); v != "" {
+		return "default@" + v
+	}
+	return ""
+}
+
+// completeFromReviewCache records the cache key of a cacheable review and,
+// unless the job or repo opted out, completes it with a recent review of an
+// identical patch — possibly one run on another machine and pulled through
+// sync. It returns true when the job was handled without running an agent.
+// Lookup failures are logged and fall back to a normal run.
+func (wp *WorkerPool) completeFromReviewCache(ctx context.Context, workerID string, job *storage.ReviewJob, cfg *config.Config) bool {
+	if !reviewCacheable(job) {
+		return false
+	}
+	minSev := job.MinSeverity
+	if minSev == "" {
+		resolved, err := config.ResolveReviewMinSeverity("", job.RepoPath, cfg)
+		if err != nil {
+			return false
+		}
+		minSev = resolved
+	}
+	key := reviewCacheKey(job, reviewCacheInputs{
+		model:       reviewCacheModel(ctx, job),
+		minSeverity: minSev,
+		guidelines:  prompt.LoadGuidelinesWithConfig(ctx, job.RepoPath, cfg),
+		excludes:    config.ResolveExcludePatterns(ctx, job.RepoPath, cfg, job.ReviewType),
+	})
+	if err := wp.db.SetJobCacheKey(job.ID, key); err != nil {
+		log.Printf("[%s] Warning: save cache key for job %d: %v", workerID, job.ID, err)
+		return false
+	}
+	if job.NoCache || !config.ResolveReviewCache(job.RepoPath, cfg) {
+		return false
+	}
+	cached, err := wp.db.FindCachedReview(key, job.ID, time.Now().Add(-reviewCacheMaxAge))
+	if err != nil {
+		log.Printf("[%s] Warning: review cache lookup for job %d: %v", workerID, job.ID, err)
+		return false
+	}
+	if cached == nil {
+		return false
+	}
+
+	note := fmt.Sprintf("Cached review: reused the result of job %d (%s), which reviewed an identical patch with the same settings.",
+		cached.JobID, cached.JobUUID)
+	if err := wp.db.CompleteJobFromCache(job.ID, cached.Agent, note, cached.Output, cached.JobUUID); err != nil {
+		log.Printf("[%s] Error storing cached review for job %d: %v", workerID, job.ID, err)
+		return false
+	}
+	if j, err := wp.db.GetJobByID(job.ID); err != nil {
+		log.Printf("[%s] Job %d: failed to verify status: %v", workerID, job.ID, err)
+	} else if j.Status != storage.JobStatusDone {
+		log.Printf("[%s] Job %d not completed (status=%s), skipping broadcast", workerID, job.ID, j.Status)
+		return true
+	}
+
+	wp.autoClosePassingReview(workerID, job, cached.Output)
+	wp.maybeEnqueueResolutionCheck(workerID, job)
+	wp.settleJobDependencies()
+
+	log.Printf("[%s] Completed job %d %s from cached review of job %d",
+		workerID, job.ID, job.RepoName, cached.JobID)
+	if wp.activityLog != nil {
+		wp.activityLog.Log(
+			"job.completed", "worker",
+			fmt.Sprintf("job %d completed from cache by %s", job.ID, workerID),
+			map[string]string{
+				"job_id":      fmt.Sprintf("%d", job.ID),
+				"worker":      workerID,
+				"agent":       cached.Agent,
+				"cached_from": cached.JobUUID,
+			},
+		)
+	}
+
+	completed := Event{
+		Type:     "review.completed",
+		TS:       time.Now(),
+		JobID:    job.ID,
+		JobUUID:  job.UUID,
+		Repo:     job.RepoPath,
+		RepoName: job.RepoName,
+		SHA:      job.GitRef,
+		Branch:   job.HookBranch(),
+		Agent:    cached.Agent,
+		Verdict:  storage.ParseVerdict(cached.Output),
+		Findings: cached.Output,
+	}
+	wp.broadcaster.Broadcast(completed)
+	wp.broadcastJobFamilyEvent(job, completed)
+	return true
+}
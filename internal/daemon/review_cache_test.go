@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestProcessJobReusesCachedReview(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	sha := testutil.GetHeadSHA(t, tc.TmpDir)
+	commit, err := tc.DB.GetOrCreateCommit(tc.Repo.ID, sha, "Author", "Subject", time.Now())
+	require.NoError(t, err)
+
+	const agentName = "cache-reviewer"
+	calls := 0
+	agent.Register(&agent.FakeAgent{
+		NameStr: agentName,
+		ReviewFn: func(context.Context, string, string, string, io.Writer) (string, error) {
+			calls++
+			return "- High — a.go:1 nil dereference", nil
+		},
+	})
+	t.Cleanup(func() { agent.Unregister(agentName) })
+
+	// GetJobByID does not load UUIDs; keep the ones EnqueueJob assigned.
+	uuids := map[int64]string{}
+	run := func(opts storage.EnqueueOpts) *storage.ReviewJob {
+		t.Helper()
+		opts.RepoID = tc.Repo.ID
+		opts.CommitID = commit.ID
+		opts.GitRef = sha
+		opts.Agent = agentName
+		opts.PatchID = "patch-1"
+		job, err := tc.DB.EnqueueJob(opts)
+		require.NoError(t, err)
+		uuids[job.ID] = job.UUID
+		claimed, err := tc.DB.ClaimJob(testWorkerID)
+		require.NoError(t, err)
+		require.Equal(t, job.ID, claimed.ID)
+		tc.Pool.processJob(testWorkerID, claimed)
+		return tc.assertJobStatus(t, job.ID, storage.JobStatusDone)
+	}
+
+	source := run(storage.EnqueueOpts{})
+	assert.Equal(t, 1, calls)
+	assert.NotEmpty(t, source.CacheKey)
+	assert.Empty(t, source.CachedFrom)
+
+	// Same patch and settings: the agent is not run again.
+	cached := run(storage.EnqueueOpts{})
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, uuids[source.ID], cached.CachedFrom)
+	assert.Equal(t, source.CacheKey, cached.CacheKey)
+	review, err := tc.DB.GetReviewByJobID(cached.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "- High — a.go:1 nil dereference", review.Output)
+	assert.Contains(t, review.Prompt, uuids[source.ID])
+
+	// Opting out or changing a key input runs the agent.
+	optOut := run(storage.EnqueueOpts{NoCache: true})
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, optOut.CachedFrom)
+	security := run(storage.EnqueueOpts{ReviewType: "security"})
+	assert.Equal(t, 3, calls)
+	assert.NotEqual(t, source.CacheKey, security.CacheKey)
+}
+
+func TestReviewCacheKeyInputs(t *testing.T) {
+	job := &storage.ReviewJob{PatchID: "patch-1", Agent: "codex"}
+	base := reviewCacheInputs{minSeverity: "low", guidelines: "Be terse.", excludes: []string{"*.pb.go", "vendor/"}}
+	key := reviewCacheKey(job, base)
+
+	reordered := base
+	reordered.excludes = []string{"vendor/", "*.pb.go"}
+	assert.Equal(t, key, reviewCacheKey(job, reordered), "exclude order does not matter")
+
+	excludes := base
+	excludes.excludes = []string{"vendor/"}
+	assert.NotEqual(t, key, reviewCacheKey(job, excludes), "exclude patterns change the diff")
+
+	model := base
+	model.model = "default@codex 1.2.3"
+	assert.NotEqual(t, key, reviewCacheKey(job, model), "the resolved model changes the run")
+}
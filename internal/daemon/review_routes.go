@@ -0,0 +1,157 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// routeTargetInputs groups what selectReviewRoute needs to describe an
+// enqueue target without freezing it.
+type routeTargetInputs struct {
+	req            EnqueueRequest
+	gitRef         string
+	checkoutRoot   string
+	resolutionPath string
+	metadata       git.EnqueueMetadataReader
+	cfg            *config.Config
+}
+
+// selectReviewRoute matches a review enqueue against [[review.routes]] and
+// returns the first matching route, or nil when none matches or the target is
+// not a code review. A non-nil *RawJSONOutput is a 400 for an invalid route.
+func (s *Server) selectReviewRoute(
+	ctx context.Context, in routeTargetInputs,
+) (*config.SelectedRoute, *RawJSONOutput) {
+	merged := config.MergedReviewConfig(in.resolutionPath, in.cfg)
+	if len(merged.Routes) == 0 ||
+		in.req.JobType == storage.JobTypeInsights ||
+		classifyTarget(in.req.CustomPrompt, in.gitRef) == kindPrompt {
+		return nil, nil
+	}
+	target := describeRouteTarget(ctx, in)
+	route, err := merged.MatchRoute(target)
+	if err != nil {
+		out, _ := rawJSONOutput(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return nil, out
+	}
+	return route, nil
+}
+
+// describeRouteTarget lists the files, authors and commit messages of an
+// enqueue target. Lookups that fail leave their part empty, so routes that
+// need it do not match and the review proceeds on the normal settings.
+func describeRouteTarget(ctx context.Context, in routeTargetInputs) config.RouteTarget {
+	var target config.RouteTarget
+	addCommit := func(ref string) {
+		if info, err := in.metadata.CommitInfo(ref); err == nil {
+			target.Authors = append(target.Authors, info.Author)
+			target.Messages = append(target.Messages, info.Subject+"\n"+info.Body)
+		}
+	}
+	var err error
+	switch classifyTarget(in.req.CustomPrompt, in.gitRef) {
+	case kindDirty:
+		target.Files = in.req.DirtyFiles
+	case kindRange:
+		target.Files, err = git.GetRangeFilesChangedCtx(ctx, in.checkoutRoot, in.gitRef)
+		if commits, rcErr := in.metadata.RangeCommits(in.gitRef); rcErr == nil {
+			for _, sha := range commits {
+				addCommit(sha)
+			}
+		}
+	default:
+		sha, resolveErr := in.metadata.Resolve(in.gitRef)
+		if resolveErr != nil {
+			return target
+		}
+		target.Files, err = git.GetFilesChangedCtx(ctx, in.checkoutRoot, sha)
+		addCommit(sha)
+	}
+	if err != nil {
+		log.Printf("review routes: list changed files for %s: %v", in.gitRef, err)
+	}
+	return target
+}
+
+// selectCIRoute matches a PR against [[review.routes]] from the default-branch
+// repo config and the global config (F1: never the working tree). Authors are
+// the commit authors plus the PR author's login; messages are the commit
+// messages plus the PR title.
+func (p *CIPoller) selectCIRoute(
+	ctx context.Context, repo *storage.Repo, repoCfg *config.RepoConfig, cfg *config.Config,
+	pr ghPR, gitRef string,
+) (*config.SelectedRoute, error) {
+	var repoReview config.ReviewConfig
+	if repoCfg != nil {
+		repoReview = repoCfg.Review
+	}
+	merged := config.MergeReviewConfig(repoReview, cfg.Review)
+	if len(merged.Routes) == 0 {
+		return nil, nil
+	}
+	target := config.RouteTarget{
+		Authors:  []string{pr.Author.Login},
+		Messages: []string{pr.Title},
+	}
+	files, err := git.GetRangeFilesChangedCtx(ctx, repo.RootPath, gitRef)
+	if err != nil {
+		log.Printf("CI poller: review routes: list changed files for %s: %v", gitRef, err)
+	}
+	target.Files = files
+	if base, head, ok := strings.Cut(gitRef, ".."); ok {
+		commits, _ := listCommitsInRange(repo.RootPath, base, head)
+		for _, sha := range commits {
+			if info, err := git.GetCommitInfoCtx(ctx, repo.RootPath, sha); err == nil {
+				target.Authors = append(target.Authors, info.Author)
+				target.Messages = append(target.Messages, info.Subject+"\n"+info.Body)
+			}
+		}
+	}
+	route, err := merged.MatchRoute(target)
+	if err != nil {
+		return nil, err
+	}
+	if route != nil {
+		log.Printf("CI poller: %s#%d: review route %s", repo.Name, pr.Number, routeSummary(route))
+	}
+	return route, nil
+}
+
+// applyReviewRoute fills the settings the request left unset from route.
+// Explicit flags still win: a route only decides what the caller did not.
+func applyReviewRoute(req EnqueueRequest, route *config.SelectedRoute) EnqueueRequest {
+	if req.Panel == "" {
+		req.Panel = route.Panel
+	}
+	if route.ReviewType != "" && config.IsDefaultReviewType(req.ReviewType) {
+		req.ReviewType = route.ReviewType
+	}
+	if strings.TrimSpace(req.Reasoning) == "" {
+		req.Reasoning = route.Reasoning
+	}
+	if strings.TrimSpace(req.MinSeverity) == "" {
+		req.MinSeverity = route.MinSeverity
+	}
+	return req
+}
+
+// routeSummary describes a route's effect for logs.
+func routeSummary(route *config.SelectedRoute) string {
+	var parts []string
+	for _, kv := range [][2]string{
+		{"panel", route.Panel}, {"type", route.ReviewType},
+		{"reasoning", route.Reasoning}, {"min_severity", route.MinSeverity},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	return fmt.Sprintf("%q (%s)", route.Label, strings.Join(parts, ", "))
+}
@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+const routesTOML = `
+[[review.routes]]
+name = "crypto"
+paths = ["crypto/**"]
+review_type = "security"
+reasoning = "maximum"
+min_severity = "high"
+
+[[review.routes]]
+name = "docs"
+paths = ["docs/**"]
+reasoning = "fast"
+`
+
+func TestEnqueueAppliesReviewRoute(t *testing.T) {
+	assert := assert.New(t)
+	server, db, _ := newTestServer(t)
+
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile(".roborev.toml", routesTOML)
+	repo.CommitFile("README.md", "readme", "initial")
+	repo.CommitFile("crypto/aes.go", "package aes", "add aes")
+
+	job := enqueueViaHTTP(t, server, EnqueueRequest{RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test"})
+	stored, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal("crypto", stored.Route)
+	assert.Equal("security", stored.ReviewType)
+	assert.Equal("maximum", stored.Reasoning)
+	assert.Equal("high", stored.MinSeverity)
+
+	// Explicit request settings win over the route.
+	job = enqueueViaHTTP(t, server, EnqueueRequest{
+		RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test", Reasoning: "fast",
+	})
+	stored, err = db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal("crypto", stored.Route)
+	assert.Equal("fast", stored.Reasoning)
+
+	// Unrouted changes keep the normal settings.
+	repo.CommitFile("main.go", "package main", "add main")
+	job = enqueueViaHTTP(t, server, EnqueueRequest{RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test"})
+	stored, err = db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Empty(stored.Route)
+	assert.Equal("default", stored.ReviewType)
+}
+
+func TestEnqueueReviewRoutePanel(t *testing.T) {
+	server, db, _ := newTestServer(t)
+
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile(".roborev.toml", panelTOML+`
+[[review.routes]]
+name = "docs"
+paths = ["docs/**"]
+panel = "none"
+`)
+	repo.CommitFile("README.md", "readme", "initial")
+	repo.CommitFile("docs/intro.md", "hi", "add docs")
+
+	// The route's panel = "none" overrides default_panel.
+	job := enqueueViaHTTP(t, server, EnqueueRequest{RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test"})
+	stored, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.PanelRunUUID)
+	assert.Equal(t, "docs", stored.Route)
+}
+
+func TestEnqueueRejectsInvalidReviewRoute(t *testing.T) {
+	server, _, _ := newTestServer(t)
+
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile(".roborev.toml", `
+[[review.routes]]
+message_patterns = ["("]
+`)
+	repo.CommitFile("a.txt", "a", "add a")
+
+	req := testutil.MakeJSONRequest(t, http.MethodPost, "/api/enqueue",
+		EnqueueRequest{RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test"})
+	w := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid message pattern")
+}
@@ -148,6 +148,27 @@ func (s *Server) registerHumaAPI(mux *http.ServeMux) huma.API {
 			o.Tags = []string{"daemon"}
 		})
 
+	huma.Get(api, "/api/agents", s.humaListAgents,
+		func(o *huma.Operation) {
+			o.OperationID = "list-agents"
+			o.Summary = "List the latest health probe of each agent"
+			o.Tags = []string{"daemon"}
+		})
+
+	huma.Post(api, "/api/agents/probe", s.humaProbeAgents,
+		func(o *huma.Operation) {
+			o.OperationID = "probe-agents"
+			o.Summary = "Probe configured agents with a canary prompt now"
+			o.Tags = []string{"daemon"}
+		})
+
+	huma.Get(api, "/api/agents/circuits", s.humaListAgentCircuits,
+		func(o *huma.Operation) {
+			o.OperationID = "list-agent-circuits"
+			o.Summary = "List agent circuit breaker states"
+			o.Tags = []string{"daemon"}
+		})
+
 	huma.Get(api, "/api/ping", s.humaPing,
 		func(o *huma.Operation) {
 			o.OperationID = "ping"
@@ -194,6 +215,23 @@ func (s *Server) registerHumaAPI(mux *http.ServeMux) huma.API {
 			})
 		})
 
+	huma.Post(api, "/api/workflows", s.humaSubmitWorkflow,
+		func(o *huma.Operation) {
+			o.OperationID = "submit-workflow"
+			o.Summary = "Enqueue a DAG of dependent jobs as one workflow"
+			o.Tags = []string{"jobs"}
+			o.DefaultStatus = 201
+			o.SkipValidateBody = true
+			o.MaxBodyBytes = -1
+		})
+
+	huma.Get(api, "/api/workflows", s.humaListWorkflows,
+		func(o *huma.Operation) {
+			o.OperationID = "list-workflows"
+			o.Summary = "List workflows or get one with its step jobs"
+			o.Tags = []string{"jobs"}
+		})
+
 	huma.Post(api, "/api/jobs/batch", s.humaBatchJobs,
 		func(o *huma.Operation) {
 			o.OperationID = "batch-jobs"
@@ -261,6 +299,34 @@ func (s *Server) registerHumaAPI(mux *http.ServeMux) huma.API {
 			o.Tags = []string{"jobs"}
 		})
 
+	huma.Post(api, "/api/panel/rerun-member", s.humaRerunPanelMember,
+		func(o *huma.Operation) {
+			o.OperationID = "rerun-panel-member"
+			o.Summary = "Re-run one panel member and re-synthesize its run"
+			o.Tags = []string{"jobs"}
+		})
+
+	huma.Post(api, "/api/panel/resynthesize", s.humaResynthesizePanel,
+		func(o *huma.Operation) {
+			o.OperationID = "resynthesize-panel"
+			o.Summary = "Re-run a panel synthesis over the existing member outputs"
+			o.Tags = []string{"jobs"}
+		})
+
+	huma.Get(api, "/api/hooks/deliveries", s.humaListWebhookDeliveries,
+		func(o *huma.Operation) {
+			o.OperationID = "list-webhook-deliveries"
+			o.Summary = "List webhook deliveries from the outbox"
+			o.Tags = []string{"hooks"}
+		})
+
+	huma.Post(api, "/api/hooks/deliveries/redeliver", s.humaRedeliverWebhook,
+		func(o *huma.Operation) {
+			o.OperationID = "redeliver-webhook"
+			o.Summary = "Requeue a webhook delivery to be sent again"
+			o.Tags = []string{"hooks"}
+		})
+
 	huma.Post(api, "/api/review/close", s.humaCloseReview,
 		func(o *huma.Operation) {
 			o.OperationID = "close-review"
@@ -269,6 +335,14 @@ func (s *Server) registerHumaAPI(mux *http.ServeMux) huma.API {
 			o.SkipValidateBody = true
 		})
 
+	huma.Post(api, "/api/job/verify", s.humaSaveJobVerify,
+		func(o *huma.Operation) {
+			o.OperationID = "save-job-verify"
+			o.Summary = "Record the verify_command outcome of a job's edits"
+			o.Tags = []string{"jobs"}
+			o.SkipValidateBody = true
+		})
+
 	huma.Post(api, "/api/comment", s.humaAddComment,
 		func(o *huma.Operation) {
 			o.OperationID = "add-comment"
@@ -332,6 +406,13 @@ func (s *Server) registerHumaAPI(mux *http.ServeMux) huma.API {
 			o.Tags = []string{"jobs"}
 		})
 
+	huma.Get(api, "/api/job/attempts", s.humaJobAttempts,
+		func(o *huma.Operation) {
+			o.OperationID = "list-job-attempts"
+			o.Summary = "List a job's agent attempts"
+			o.Tags = []string{"jobs"}
+		})
+
 	huma.Get(api, "/api/stream/events", s.humaStreamEvents,
 		func(o *huma.Operation) {
 			o.OperationID = "stream-events"
@@ -211,6 +211,38 @@ func TestHumaGetReview_Found(t *testing.T) {
 	assert.Equal(t, job.ID, review.JobID)
 }
 
+func TestHumaJobAttempts(t *testing.T) {
+	srv, db, _ := newTestServer(t)
+	repo := testutil.CreateTestRepo(t, db)
+	job := testutil.CreateCompletedReview(
+		t, db, repo.ID, "abc123", "claude-code", "LGTM",
+	)
+	require.NoError(t, db.RecordJobAttempt(storage.JobAttempt{
+		JobID: job.ID, Agent: "codex", Outcome: storage.AttemptFailed,
+		FailureClass: storage.AttemptFailureQuota, Error: "usage limit reached",
+	}))
+	require.NoError(t, db.RecordJobAttempt(storage.JobAttempt{
+		JobID: job.ID, Agent: "claude-code", Outcome: storage.AttemptSucceeded,
+	}))
+
+	rr := serveHuma(t, srv, http.MethodGet,
+		fmt.Sprintf("/api/job/attempts?job_id=%d", job.ID), nil,
+	)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var out struct {
+		Attempts []storage.JobAttempt `json:"attempts"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+	require.Len(t, out.Attempts, 2)
+	assert.Equal(t, storage.AttemptFailureQuota, out.Attempts[0].FailureClass)
+	assert.Equal(t, "claude-code", out.Attempts[1].Agent)
+
+	rr = serveHuma(t, srv, http.MethodGet, "/api/job/attempts?job_id=99999", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveHuma(t, srv, http.MethodGet, "/api/job/attempts", nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
 func TestHumaExportReviews(t *testing.T) {
 	srv, db, _ := newTestServer(t)
 	repo := testutil.CreateTestRepo(t, db)
@@ -282,6 +314,27 @@ func TestHumaExportReviews(t *testing.T) {
 	assert.Equal(t, "- Medium — issue", *page2.Reviews[0].Content)
 }
 
+func TestHumaExportReviewsAppliesRepoRedactPatterns(t *testing.T) {
+	srv, db, _ := newTestServer(t)
+	repo := testutil.CreateTestRepo(t, db)
+	require.NoError(t, os.WriteFile(filepath.Join(repo.RootPath, ".roborev.toml"),
+		[]byte("redact_patterns = ['acme-token-[a-z0-9]+']\n"), 0o644))
+	testutil.CreateCompletedReview(
+		t, db, repo.ID, "export-secret", "test-agent", "- High — leaked acme-token-abc123",
+	)
+
+	rr := serveHuma(t, srv, http.MethodGet, "/api/export/reviews?profile=content", nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var body struct {
+		Reviews []storage.ExportReview `json:"reviews"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Len(t, body.Reviews, 1)
+	require.NotNil(t, body.Reviews[0].Content)
+	assert.NotContains(t, *body.Reviews[0].Content, "acme-token-abc123")
+	assert.Contains(t, *body.Reviews[0].Content, "[REDACTED:custom]")
+}
+
 func TestHumaExportReviewsRejectsDifferentDatabaseCursorWithConflict(t *testing.T) {
 	srv, db, _ := newTestServer(t)
 	repo := testutil.CreateTestRepo(t, db)
@@ -551,6 +604,43 @@ func TestHumaCloseReview(t *testing.T) {
 	})
 }
 
+func TestHumaSaveJobVerify(t *testing.T) {
+	srv, db, _ := newTestServer(t)
+	repo := testutil.CreateTestRepo(t, db)
+	job := testutil.CreateCompletedReview(
+		t, db, repo.ID, "verifyme", "test-agent", "done",
+	)
+
+	t.Run("records the outcome on the job", func(t *testing.T) {
+		body, _ := json.Marshal(JobVerifyRequest{
+			JobID: job.ID,
+			Verify: storage.VerifyResult{
+				Passed: false, Attempts: 2, DurationMs: 1500, Command: "go test ./...",
+			},
+		})
+		rr := serveHuma(
+			t, srv, http.MethodPost, "/api/job/verify", body,
+		)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		got, err := db.GetJobByID(job.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.Verify)
+		assert.False(t, got.Verify.Passed)
+		assert.Equal(t, 2, got.Verify.Attempts)
+		assert.Equal(t, int64(1500), got.Verify.DurationMs)
+		assert.Equal(t, "go test ./...", got.Verify.Command)
+	})
+
+	t.Run("nonexistent job returns 404", func(t *testing.T) {
+		body, _ := json.Marshal(JobVerifyRequest{JobID: 99999})
+		rr := serveHuma(
+			t, srv, http.MethodPost, "/api/job/verify", body,
+		)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
 func TestHumaBackfillTokensUpdatesMatchingEligibleJob(t *testing.T) {
 	srv, db, _ := newTestServer(t)
 	repo := testutil.CreateTestRepo(t, db)
@@ -692,37 +782,46 @@ func TestHumaOpenAPISpec(t *testing.T) {
 	require.True(t, ok, "spec must have paths object")
 
 	wantPaths := map[string]string{
-		"/api/jobs":              "get",
-		"/api/review":            "get",
-		"/api/export/reviews":    "get",
-		"/api/comments":          "get",
-		"/api/repos":             "get",
-		"/api/repos/resolve":     "get",
-		"/api/branches":          "get",
-		"/api/status":            "get",
-		"/api/summary":           "get",
-		"/api/health":            "get",
-		"/api/ping":              "get",
-		"/api/sync/status":       "get",
-		"/api/activity":          "get",
-		"/api/job/output":        "get",
-		"/api/job/log":           "get",
-		"/api/job/patch":         "get",
-		"/api/stream/events":     "get",
-		"/api/job/cancel":        "post",
-		"/api/job/rerun":         "post",
-		"/api/review/close":      "post",
-		"/api/comment":           "post",
-		"/api/enqueue":           "post",
-		"/api/jobs/batch":        "post",
-		"/api/repos/register":    "post",
-		"/api/job/update-branch": "post",
-		"/api/remap":             "post",
-		"/api/sync/now":          "post",
-		"/api/job/fix":           "post",
-		"/api/job/applied":       "post",
-		"/api/job/rebased":       "post",
-		"/api/tokens/backfill":   "post",
+		"/api/jobs":                       "get",
+		"/api/review":                     "get",
+		"/api/export/reviews":             "get",
+		"/api/comments":                   "get",
+		"/api/repos":                      "get",
+		"/api/repos/resolve":              "get",
+		"/api/branches":                   "get",
+		"/api/status":                     "get",
+		"/api/summary":                    "get",
+		"/api/health":                     "get",
+		"/api/agents":                     "get",
+		"/api/agents/probe":               "post",
+		"/api/agents/circuits":            "get",
+		"/api/ping":                       "get",
+		"/api/sync/status":                "get",
+		"/api/activity":                   "get",
+		"/api/job/output":                 "get",
+		"/api/job/log":                    "get",
+		"/api/job/patch":                  "get",
+		"/api/job/attempts":               "get",
+		"/api/stream/events":              "get",
+		"/api/job/cancel":                 "post",
+		"/api/job/rerun":                  "post",
+		"/api/panel/rerun-member":         "post",
+		"/api/panel/resynthesize":         "post",
+		"/api/hooks/deliveries":           "get",
+		"/api/hooks/deliveries/redeliver": "post",
+		"/api/review/close":               "post",
+		"/api/comment":                    "post",
+		"/api/enqueue":                    "post",
+		"/api/jobs/batch":                 "post",
+		"/api/repos/register":             "post",
+		"/api/job/update-branch":          "post",
+		"/api/remap":                      "post",
+		"/api/sync/now":                   "post",
+		"/api/job/fix":                    "post",
+		"/api/job/applied":                "post",
+		"/api/job/rebased":                "post",
+		"/api/tokens/backfill":            "post",
+		"/api/workflows":                  "post",
 	}
 	for p, method := range wantPaths {
 		pathObj, exists := paths[p]
@@ -823,6 +922,28 @@ func TestHumaGetSummary(t *testing.T) {
 	assert.GreaterOrEqual(t, summary.Overview.Total, 1)
 }
 
+func TestHumaGetSummaryAgentScores(t *testing.T) {
+	srv, db, _ := newTestServer(t)
+	repo := testutil.CreateTestRepo(t, db)
+	job := testutil.CreateCompletedReview(
+		t, db, repo.ID, "scoresha", "test-agent", "- High — a.go:1 bug",
+	)
+	require.NoError(t, db.MarkReviewClosedByJobID(job.ID, true))
+
+	rr := serveHuma(t, srv, http.MethodGet, "/api/summary", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var summary storage.Summary
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &summary))
+	assert.Empty(t, summary.AgentScores, "scoreboard is opt-in")
+
+	rr = serveHuma(t, srv, http.MethodGet, "/api/summary?agents=true", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &summary))
+	require.Len(t, summary.AgentScores, 1)
+	assert.Equal(t, "test-agent", summary.AgentScores[0].Agent)
+	assert.Equal(t, 1, summary.AgentScores[0].Accepted)
+}
+
 func TestHumaListComments(t *testing.T) {
 	srv, db, _ := newTestServer(t)
 	repo := testutil.CreateTestRepo(t, db)
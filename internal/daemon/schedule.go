@@ -0,0 +1,481 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/git"
+	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/prompt/analyze"
+	reviewpkg "go.kenn.io/roborev/internal/review"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const (
+	// scheduleStatePrefix prefixes the daemon_state key holding a
+	// schedule's last run time.
+	scheduleStatePrefix = "schedule:"
+	// scheduleJobsPrefix prefixes the daemon_state key holding the job each
+	// repo got from a schedule's last run, as a JSON map of repo path to
+	// job ID.
+	scheduleJobsPrefix = "schedule-jobs:"
+
+	defaultScheduleCompactLimit  = 20
+	defaultScheduleInsightsSince = "30d"
+	defaultScheduleSummarySince  = "7d"
+)
+
+// scheduleEnqueuer enqueues a job the way /api/enqueue does and returns its
+// ID; the server supplies it.
+type scheduleEnqueuer func(ctx context.Context, req EnqueueRequest) (int64, error)
+
+// JobScheduler runs the [[schedule]] entries on their cron schedules:
+// analyze, insights, and compact entries enqueue one job per matching repo,
+// summary entries email or post a summary. A repo whose job from the
+// previous run is still queued is skipped rather than queued twice.
+type JobScheduler struct {
+	*cronLoop
+	enqueue  scheduleEnqueuer
+	send     emailSender
+	webhooks *WebhookOutbox
+}
+
+// NewJobScheduler creates a scheduler over db that enqueues through enqueue
+// and posts summary webhooks through webhooks. Call Start to begin.
+func NewJobScheduler(
+	db *storage.DB, cfgGetter ConfigGetter, enqueue scheduleEnqueuer,
+	webhooks *WebhookOutbox, logger *log.Logger,
+) *JobScheduler {
+	s := &JobScheduler{enqueue: enqueue, send: sendEmail, webhooks: webhooks}
+	s.cronLoop = newCronLoop(db, cfgGetter, logger, "Schedule", scheduleStatePrefix, s.scheduleEntries)
+	return s
+}
+
+// scheduleEntries returns the configured schedules as cron entries.
+func (s *JobScheduler) scheduleEntries(cfg *config.Config) []cronEntry {
+	entries := make([]cronEntry, 0, len(cfg.Schedules))
+	for _, sc := range cfg.Schedules {
+		entries = append(entries, cronEntry{
+			key:  sc.Key(),
+			err:  validateSchedule(sc),
+			next: sc.NextRun,
+			run:  func(time.Time) error { return s.runSchedule(cfg, sc) },
+		})
+	}
+	return entries
+}
+
+// enqueueScheduled is the JobScheduler's enqueue path: the /api/enqueue
+// handler, so scheduled jobs get the same validation, routing, and agent
+// resolution as jobs submitted by the CLI.
+func (s *Server) enqueueScheduled(ctx context.Context, req EnqueueRequest) (int64, error) {
+	out, err := s.humaEnqueue(ctx, &EnqueueInput{Body: req})
+	if err != nil {
+		return 0, err
+	}
+	id, _, err := enqueuedJobID(out)
+	return id, err
+}
+
+// validateSchedule extends ScheduleConfig.Validate with the checks that
+// need daemon-side knowledge: the analysis type and the since window.
+func validateSchedule(sc config.ScheduleConfig) error {
+	if err := sc.Validate(); err != nil {
+		return err
+	}
+	if sc.ResolvedKind() == config.ScheduleAnalyze && analyze.GetType(sc.Analysis) == nil {
+		return fmt.Errorf("unknown analysis type %q", sc.Analysis)
+	}
+	if sc.Since != "" {
+		if _, err := parseDuration(sc.Since); err != nil {
+			return fmt.Errorf("since: %w", err)
+		}
+	}
+	return nil
+}
+
+// scheduleWindow returns the since window of an insights or summary run.
+func scheduleWindow(sc config.ScheduleConfig, fallback string) time.Duration {
+	since := sc.Since
+	if since == "" {
+		since = fallback
+	}
+	d, _ := parseDuration(since)
+	return d
+}
+
+// runSchedule performs one run of a due schedule.
+func (s *JobScheduler) runSchedule(cfg *config.Config, sc config.ScheduleConfig) error {
+	repos, err := s.matchingRepos(sc.Repos)
+	if err != nil {
+		return err
+	}
+	if sc.ResolvedKind() == config.ScheduleSummary {
+		return s.sendSummary(cfg, sc, repos)
+	}
+
+	jobsKey := scheduleJobsPrefix + sc.Key()
+	previous := s.lastJobs(jobsKey)
+	jobs := make(map[string]int64, len(repos))
+	var errs []string
+	for _, repo := range repos {
+		if id, ok := previous[repo.RootPath]; ok && s.stillQueued(id) {
+			s.logger.Printf("Schedule %q: %s skipped, job %d from the previous run is still queued",
+				sc.Key(), repo.Name, id)
+			jobs[repo.RootPath] = id
+			continue
+		}
+		id, err := s.enqueueFor(cfg, sc, repo)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", repo.Name, err))
+			continue
+		}
+		if id != 0 {
+			s.logger.Printf("Schedule %q: enqueued %s job %d for %s", sc.Key(), sc.ResolvedKind(), id, repo.Name)
+			jobs[repo.RootPath] = id
+		}
+	}
+	if data, err := json.Marshal(jobs); err == nil {
+		if err := s.db.SetDaemonState(jobsKey, string(data)); err != nil {
+			errs = append(errs, fmt.Sprintf("record jobs: %v", err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("enqueue failed for %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// lastJobs returns the jobs the schedule's previous run enqueued, by repo
+// path.
+func (s *JobScheduler) lastJobs(key string) map[string]int64 {
+	jobs := map[string]int64{}
+	raw, err := s.db.GetDaemonState(key)
+	if err != nil || raw == "" {
+		return jobs
+	}
+	if err := json.Unmarshal([]byte(raw), &jobs); err != nil {
+		s.logger.Printf("Schedule state %q: invalid job map, ignoring: %v", key, err)
+	}
+	return jobs
+}
+
+func (s *JobScheduler) stillQueued(jobID int64) bool {
+	job, err := s.db.GetJobByID(jobID)
+	return err == nil && job.Status == storage.JobStatusQueued
+}
+
+// enqueueFor enqueues the schedule's job for one repo. It returns 0 when the
+// repo has nothing to run, such as no source files or no open reviews.
+func (s *JobScheduler) enqueueFor(cfg *config.Config, sc config.ScheduleConfig, repo storage.Repo) (int64, error) {
+	ctx := context.Background()
+	req := EnqueueRequest{
+		RepoPath:  repo.RootPath,
+		Branch:    git.GetCurrentBranch(repo.RootPath),
+		Agent:     sc.Agent,
+		Model:     sc.Model,
+		Reasoning: sc.Reasoning,
+	}
+	var sources []int64
+	switch sc.ResolvedKind() {
+	case config.ScheduleInsights:
+		req.GitRef = "insights"
+		req.JobType = storage.JobTypeInsights
+		req.Since = s.now().Add(-scheduleWindow(sc, defaultScheduleInsightsSince)).Format(time.RFC3339)
+	case config.ScheduleAnalyze:
+		ok, err := s.buildAnalyzeRequest(cfg, sc, repo, &req)
+		if err != nil || !ok {
+			return 0, err
+		}
+	case config.ScheduleCompact:
+		var err error
+		sources, err = s.buildCompactRequest(ctx, cfg, sc, repo, &req)
+		if err != nil || len(sources) == 0 {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("kind %q does not enqueue jobs", sc.Kind)
+	}
+
+	id, err := s.enqueue(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	if len(sources) > 0 {
+		// Without the metadata the worker could never close the sources,
+		// so a compact job that cannot record them is not left to run.
+		if err := WriteCompactMetadata(id, sources); err != nil {
+			_ = s.db.CancelJob(id)
+			return 0, fmt.Errorf("compact job %d: %w", id, err)
+		}
+	}
+	return id, nil
+}
+
+// buildAnalyzeRequest fills req with an analysis of the repo's tracked
+// source files, embedding their contents when they fit the prompt size
+// limit and listing their paths otherwise, as `roborev analyze` does. It
+// reports false when no file matches.
+func (s *JobScheduler) buildAnalyzeRequest(
+	cfg *config.Config, sc config.ScheduleConfig, repo storage.Repo, req *EnqueueRequest,
+) (bool, error) {
+	analysisType := analyze.GetType(sc.Analysis)
+	tracked, err := git.ListTrackedFiles(repo.RootPath)
+	if err != nil {
+		return false, err
+	}
+	var relPaths []string
+	for _, file := range tracked {
+		if analyze.IsSourceFile(file) && (len(sc.Files) == 0 || matchPathOrParent(sc.Files, file)) {
+			relPaths = append(relPaths, file)
+		}
+	}
+	if len(relPaths) == 0 {
+		return false, nil
+	}
+	sort.Strings(relPaths)
+
+	maxSize := config.ResolveMaxPromptSize(repo.RootPath, cfg)
+	files := make(map[string]string, len(relPaths))
+	total := 0
+	for _, rel := range relPaths {
+		data, err := os.ReadFile(filepath.Join(repo.RootPath, filepath.FromSlash(rel)))
+		if err != nil {
+			return false, fmt.Errorf("read %s: %w", rel, err)
+		}
+		total += len(data)
+		if total > maxSize {
+			files = nil
+			break
+		}
+		files[rel] = string(data)
+	}
+	var analysisPrompt string
+	if files != nil {
+		analysisPrompt, err = analysisType.BuildPrompt(files)
+	}
+	if err == nil && (files == nil || len(analysisPrompt) > maxSize) {
+		absPaths := make([]string, len(relPaths))
+		for i, rel := range relPaths {
+			absPaths[i] = filepath.Join(repo.RootPath, filepath.FromSlash(rel))
+		}
+		analysisPrompt, err = analysisType.BuildPromptWithPaths(repo.RootPath, absPaths)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	req.GitRef = analysisType.Name
+	req.CustomPrompt = analysisPrompt
+	req.OutputPrefix = analyze.OutputPrefix(analysisType.Name, relPaths)
+	req.Agentic = true
+	if analysisType.Name == config.ReviewTypeSecurity {
+		req.ReviewType = config.ReviewTypeSecurity
+	}
+	return true, nil
+}
+
+// buildCompactRequest fills req with a consolidation of the repo's open
+// reviews, newest first up to the schedule's limit, as `roborev compact`
+// does. It returns the consolidated review jobs; none means nothing to do.
+func (s *JobScheduler) buildCompactRequest(
+	ctx context.Context, cfg *config.Config, sc config.ScheduleConfig, repo storage.Repo, req *EnqueueRequest,
+) ([]int64, error) {
+	opts := []storage.ListJobsOption{storage.WithClosed(false), storage.WithoutPrompt()}
+	if sc.Branch != "" {
+		opts = append(opts, storage.WithBranchOrEmpty(sc.Branch))
+	}
+	jobs, err := s.db.ListJobs(string(storage.JobStatusDone), repo.RootPath, 0, 0, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list open reviews: %w", err)
+	}
+	limit := sc.Limit
+	if limit == 0 {
+		limit = defaultScheduleCompactLimit
+	}
+	var reviews []reviewpkg.CompactReview
+	var sources []int64
+	for _, job := range jobs {
+		// Compact and task output is not a review; consolidating it would
+		// compact earlier compactions.
+		if job.JobType == storage.JobTypeCompact || job.JobType == storage.JobTypeTask {
+			continue
+		}
+		rv, err := s.db.GetReviewByJobID(job.ID)
+		if err != nil {
+			continue
+		}
+		reviews = append(reviews, reviewpkg.CompactReview{JobID: job.ID, GitRef: job.GitRef, Output: rv.Output})
+		sources = append(sources, job.ID)
+		if len(sources) == limit {
+			break
+		}
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	// Compact runs under the fix workflow; resolve its agent only to pick
+	// the matching review output contract. The daemon resolves the job's
+	// agent again from the same overrides.
+	reasoning, err := config.ResolveFixReasoning(sc.Reasoning, repo.RootPath, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("resolve reasoning: %w", err)
+	}
+	agentName := config.ResolveAgentForWorkflow(sc.Agent, repo.RootPath, cfg, "fix", reasoning)
+	guidelines := prompt.LoadGuidelinesWithConfig(ctx, repo.RootPath, cfg)
+
+	label := "compact-all-" + s.now().Format("20060102-150405")
+	if sc.Branch != "" {
+		label = fmt.Sprintf("compact-%s-%s", sc.Branch, s.now().Format("20060102-150405"))
+		req.Branch = sc.Branch
+	}
+	req.GitRef = label
+	req.JobType = storage.JobTypeCompact
+	req.CustomPrompt = reviewpkg.BuildCompactPrompt(
+		prompt.GetSystemPrompt(agentName, "review"), guidelines, sc.Branch, reviews)
+	req.OutputPrefix = reviewpkg.CompactOutputPrefix(sc.Branch, sources)
+	req.Agentic = true
+	return sources, nil
+}
+
+// matchPathOrParent reports whether a pattern matches the slash-separated
+// path or one of its parent directories, so "internal" selects everything
+// under internal/.
+func matchPathOrParent(patterns []string, file string) bool {
+	for p := file; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		if matchAnyGlob(patterns, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleSummaryPayload is the JSON posted to a summary schedule's webhook.
+type scheduleSummaryPayload struct {
+	Type      string             `json:"type"`
+	Schedule  string             `json:"schedule"`
+	TS        time.Time          `json:"ts"`
+	Summaries []*storage.Summary `json:"summaries"`
+}
+
+// sendSummary reports the schedule's window, for all repos together or per
+// matching repo, to the configured recipients and webhook.
+func (s *JobScheduler) sendSummary(cfg *config.Config, sc config.ScheduleConfig, repos []storage.Repo) error {
+	since := s.now().Add(-scheduleWindow(sc, defaultScheduleSummarySince))
+	var errs []string
+	if len(sc.To) > 0 {
+		if !cfg.SMTP.Enabled() {
+			errs = append(errs, "email: smtp.host is not set")
+		} else if err := s.emailSummary(cfg.SMTP, sc, repos, since); err != nil {
+			errs = append(errs, fmt.Sprintf("email: %v", err))
+		}
+	}
+	if sc.Webhook != "" {
+		if err := s.postSummary(sc, repos, since); err != nil {
+			errs = append(errs, fmt.Sprintf("webhook: %v", err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("summary %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// emailSummary mails the summary as digest reports: one covering every
+// repo, or one section per matching repo when the schedule names repos.
+func (s *JobScheduler) emailSummary(
+	smtpCfg config.SMTPConfig, sc config.ScheduleConfig, repos []storage.Repo, since time.Time,
+) error {
+	var reports []digestReport
+	if len(sc.Repos) == 0 {
+		all := digestReport{AllRepos: true, Since: since}
+		for _, repo := range repos {
+			if err := all.collect(s.db, repo.RootPath, "", since); err != nil {
+				return err
+			}
+		}
+		reports = append(reports, all)
+	} else {
+		var err error
+		if reports, err = repoDigestReports(s.db, repos, since); err != nil {
+			return err
+		}
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+	return s.send(smtpCfg, summaryEmail(sc, reports))
+}
+
+// summaryEmail renders the reports as one email, a section per report.
+func summaryEmail(sc config.ScheduleConfig, reports []digestReport) emailMessage {
+	var text, body strings.Builder
+	open := 0
+	for i, r := range reports {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		r.render(&text, &body, "Summary: "+r.name())
+		open += r.OpenReviews
+	}
+	return emailMessage{
+		To:      sc.To,
+		Subject: fmt.Sprintf("[roborev] Summary: %s (%d open failed reviews)", sc.Key(), open),
+		Text:    text.String(),
+		HTML:    body.String(),
+	}
+}
+
+// postSummary queues the schedule's summary in the webhook outbox, so it is
+// retried like any other webhook.
+func (s *JobScheduler) postSummary(sc config.ScheduleConfig, repos []storage.Repo, since time.Time) error {
+	var summaries []*storage.Summary
+	if len(sc.Repos) == 0 {
+		sum, err := s.db.GetSummary(storage.SummaryOptions{Since: since, AllRepos: true})
+		if err != nil {
+			return fmt.Errorf("get summary: %w", err)
+		}
+		summaries = append(summaries, sum)
+	} else {
+		for _, repo := range repos {
+			sum, err := s.db.GetSummary(storage.SummaryOptions{Since: since, RepoPath: repo.RootPath})
+			if err != nil {
+				return fmt.Errorf("get summary for %s: %w", repo.Name, err)
+			}
+			summaries = append(summaries, sum)
+		}
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(scheduleSummaryPayload{
+		Type:      "schedule.summary",
+		Schedule:  sc.Key(),
+		TS:        s.now(),
+		Summaries: summaries,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal summary: %w", err)
+	}
+	if _, err := s.db.EnqueueWebhookDelivery(storage.WebhookDelivery{
+		URL:       sc.Webhook,
+		EventType: "schedule.summary",
+		Payload:   string(payload),
+	}); err != nil {
+		return err
+	}
+	if s.webhooks != nil {
+		s.webhooks.Wake()
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+// newTestJobScheduler returns a scheduler whose enqueues are recorded and
+// stored as prompt jobs instead of going through the HTTP handler.
+func newTestJobScheduler(t *testing.T, db *storage.DB, cfg *config.Config) (*JobScheduler, *[]EnqueueRequest) {
+	t.Helper()
+	var reqs []EnqueueRequest
+	enqueue := func(_ context.Context, req EnqueueRequest) (int64, error) {
+		reqs = append(reqs, req)
+		repo, err := db.GetOrCreateRepo(req.RepoPath)
+		if err != nil {
+			return 0, err
+		}
+		job, err := db.EnqueueJob(storage.EnqueueOpts{
+			RepoID: repo.ID, Agent: "codex", GitRef: req.GitRef, JobType: req.JobType,
+			Prompt: "scheduled",
+		})
+		if err != nil {
+			return 0, err
+		}
+		return job.ID, nil
+	}
+	return NewJobScheduler(db, NewStaticConfig(cfg), enqueue, nil, log.Default()), &reqs
+}
+
+func TestJobScheduleSkipsQueuedRun(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+
+	sc := config.ScheduleConfig{Name: "monthly-insights", Cron: "0 9 1 * *", Kind: "insights", Since: "4w"}
+	s, reqs := newTestJobScheduler(t, db, &config.Config{Schedules: []config.ScheduleConfig{sc}})
+
+	loc := time.Local
+	now := time.Date(2026, 3, 15, 8, 0, 0, 0, loc)
+	s.now = func() time.Time { return now }
+
+	// First sight records a baseline without enqueuing.
+	s.runDue()
+	assert.Empty(t, *reqs)
+
+	// April 1st: one insights job, even after a catch-up gap.
+	now = time.Date(2026, 4, 2, 12, 0, 0, 0, loc)
+	s.runDue()
+	s.runDue()
+	require.Len(t, *reqs, 1)
+	req := (*reqs)[0]
+	assert.Equal(t, repo.RootPath, req.RepoPath)
+	assert.Equal(t, storage.JobTypeInsights, req.JobType)
+	assert.Equal(t, now.AddDate(0, 0, -28).Format(time.RFC3339), req.Since)
+
+	// May 1st with the April job still queued: skipped.
+	now = time.Date(2026, 5, 1, 9, 0, 0, 0, loc)
+	s.runDue()
+	require.Len(t, *reqs, 1)
+
+	// June 1st after the job ran: enqueued again.
+	claimed, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.NoError(t, db.CompleteJob(claimed.ID, "codex", "p", "insights"))
+	now = time.Date(2026, 6, 1, 9, 0, 0, 0, loc)
+	s.runDue()
+	assert.Len(t, *reqs, 2)
+}
+
+func TestJobScheduleCompact(t *testing.T) {
+	t.Setenv("ROBOREV_DATA_DIR", t.TempDir())
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+	first := testutil.CreateCompletedReview(t, db, repo.ID, "aaaaaaaaaaaa", "codex", "- High — auth.go:10 token logged")
+	second := testutil.CreateCompletedReview(t, db, repo.ID, "bbbbbbbbbbbb", "codex", "- Low — a.go:1 nit")
+
+	sc := config.ScheduleConfig{Cron: "@weekly", Kind: "compact"}
+	s, reqs := newTestJobScheduler(t, db, &config.Config{})
+	id, err := s.enqueueFor(&config.Config{}, sc, *repo)
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+
+	req := (*reqs)[0]
+	assert.Equal(t, storage.JobTypeCompact, req.JobType)
+	assert.True(t, req.Agentic)
+	assert.Contains(t, req.CustomPrompt, "auth.go:10 token logged")
+	assert.Contains(t, req.CustomPrompt, "a.go:1 nit")
+	assert.Contains(t, req.OutputPrefix, "Verified and consolidated 2 open reviews")
+
+	metadata, err := ReadCompactMetadata(id)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{first.ID, second.ID}, metadata.SourceJobIDs)
+
+	// The compact job itself is never consolidated by a later run.
+	claimed, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.NoError(t, db.CompleteJob(claimed.ID, "codex", "p", "compacted-output-marker"))
+	_, err = s.enqueueFor(&config.Config{}, config.ScheduleConfig{Cron: "@weekly", Kind: "compact", Limit: 5}, *repo)
+	require.NoError(t, err)
+	require.Len(t, *reqs, 2)
+	assert.NotContains(t, (*reqs)[1].CustomPrompt, "compacted-output-marker")
+}
+
+func TestJobScheduleAnalyzeFiles(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	gitRepo := testutil.InitTestGitRepo(t, t.TempDir())
+	gitRepo.CommitFiles(map[string]string{
+		"internal/auth/token.go": "package auth\n",
+		"main.go":                "package main\n",
+	}, "add sources")
+	repo, err := db.GetOrCreateRepo(gitRepo.Root)
+	require.NoError(t, err)
+
+	sc := config.ScheduleConfig{Cron: "@weekly", Kind: "analyze", Analysis: "security", Files: []string{"internal"}}
+	s, reqs := newTestJobScheduler(t, db, &config.Config{})
+	_, err = s.enqueueFor(&config.Config{}, sc, *repo)
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+
+	req := (*reqs)[0]
+	assert.Equal(t, "security", req.GitRef)
+	assert.Equal(t, config.ReviewTypeSecurity, req.ReviewType)
+	assert.Contains(t, req.CustomPrompt, "package auth")
+	assert.NotContains(t, req.CustomPrompt, "package main")
+	assert.Contains(t, req.OutputPrefix, "- internal/auth/token.go\n")
+}
+
+func TestJobScheduleSummaryWebhook(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+	testutil.CreateCompletedReview(t, db, repo.ID, "cccccccccccc", "codex", "No issues found.")
+
+	sc := config.ScheduleConfig{Name: "weekly", Cron: "@weekly", Kind: "summary", Webhook: "https://example.com/hook"}
+	s, reqs := newTestJobScheduler(t, db, &config.Config{})
+	require.NoError(t, s.runSchedule(&config.Config{}, sc))
+	assert.Empty(t, *reqs)
+
+	deliveries, err := db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "https://example.com/hook", deliveries[0].URL)
+	var payload scheduleSummaryPayload
+	require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+	assert.Equal(t, "weekly", payload.Schedule)
+	require.Len(t, payload.Summaries, 1)
+	assert.Equal(t, 1, payload.Summaries[0].Overview.Done)
+}
+
+func TestJobScheduleSummaryEmail(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	repo := testutil.CreateTestRepo(t, db)
+	testutil.CreateCompletedReview(t, db, repo.ID, "dddddddddddd", "codex", "- High — auth.go:10 token logged")
+
+	cfg := &config.Config{SMTP: config.SMTPConfig{Host: "smtp.example.com", From: "roborev@example.com"}}
+	sc := config.ScheduleConfig{Name: "weekly", Cron: "@weekly", Kind: "summary", To: []string{"team@example.com"}}
+	s, _ := newTestJobScheduler(t, db, cfg)
+	var sent []emailMessage
+	s.send = func(_ config.SMTPConfig, msg emailMessage) error {
+		sent = append(sent, msg)
+		return nil
+	}
+	require.NoError(t, s.runSchedule(cfg, sc))
+
+	require.Len(t, sent, 1)
+	msg := sent[0]
+	assert := assert.New(t)
+	assert.Equal([]string{"team@example.com"}, msg.To)
+	assert.Equal("[roborev] Summary: weekly (1 open failed reviews)", msg.Subject)
+	assert.Contains(msg.Text, "Summary: all repos")
+	assert.Contains(msg.Text, "high      1")
+	assert.Contains(msg.Text, repo.Name+" ddddddd")
+	assert.Contains(msg.HTML, "<h3>Open findings by severity</h3>")
+}
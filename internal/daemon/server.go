@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,6 +29,8 @@ import (
 	"go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/githook"
 	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/redact"
+	reviewpkg "go.kenn.io/roborev/internal/review"
 	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/telemetry"
 	"go.kenn.io/roborev/internal/tokens"
@@ -39,11 +42,16 @@ type Server struct {
 	db              *storage.DB
 	configWatcher   *ConfigWatcher
 	broadcaster     Broadcaster
+	eventJournal    *EventJournal
 	workerPool      *WorkerPool
 	httpServer      *http.Server
 	syncWorker      *storage.SyncWorker
 	ciPoller        *CIPoller
 	hookRunner      *HookRunner
+	webhookOutbox   *WebhookOutbox
+	digests         *DigestScheduler
+	schedules       *JobScheduler
+	agentProber     *AgentProber
 	errorLog        *ErrorLog
 	activityLog     *ActivityLog
 	telemetry       telemetry.Client
@@ -95,26 +103,37 @@ func newServerWithLogs(
 	agent.SetAllowUnsafeAgents(cfg.AllowUnsafeAgents != nil && *cfg.AllowUnsafeAgents)
 	agent.SetCodexSandboxDisabled(cfg.DisableCodexSandbox)
 	agent.SetAnthropicAPIKey(cfg.AnthropicAPIKey)
-	broadcaster := NewBroadcaster()
+	if err := agent.ConfigureSandbox(cfg.Sandbox); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	eventJournal := NewEventJournal(db, log.Default())
+	broadcaster := NewJournaledBroadcaster(eventJournal)
 
 	// Create config watcher for hot-reloading
 	configWatcher := NewConfigWatcher(configPath, cfg, broadcaster, activityLog)
 
-	// Create hook runner to fire hooks on review events
-	hookRunner := NewHookRunner(configWatcher, broadcaster, log.Default())
+	// Create hook runner to fire hooks on review events. Webhooks go through
+	// the persistent outbox so receiver outages are retried, not dropped.
+	webhookOutbox := NewWebhookOutbox(db, log.Default())
+	hookRunner := NewHookRunner(configWatcher, broadcaster, db, webhookOutbox, log.Default())
 
 	s := &Server{
 		db:            db,
 		configWatcher: configWatcher,
 		broadcaster:   broadcaster,
+		eventJournal:  eventJournal,
 		workerPool:    NewWorkerPool(db, configWatcher, cfg.MaxWorkers, broadcaster, errorLog, activityLog),
 		hookRunner:    hookRunner,
+		webhookOutbox: webhookOutbox,
+		digests:       NewDigestScheduler(db, configWatcher, log.Default()),
+		agentProber:   NewAgentProber(db, configWatcher, log.Default()),
 		errorLog:      errorLog,
 		activityLog:   activityLog,
 		telemetryStop: make(chan struct{}),
 		startTime:     time.Now(),
 		shutdownCh:    make(chan struct{}),
 	}
+	s.schedules = NewJobScheduler(db, configWatcher, s.enqueueScheduled, webhookOutbox, log.Default())
 
 	mux := http.NewServeMux()
 	s.registerHumaAPI(mux)
@@ -265,6 +284,10 @@ func (s *Server) Start(ctx context.Context) error {
 
 	// Start worker pool before advertising availability.
 	s.workerPool.Start()
+	s.webhookOutbox.Start()
+	s.digests.Start()
+	s.schedules.Start()
+	s.agentProber.Start()
 
 	ready, serveExited, err := waitForServerReady(ctx, ep, 2*time.Second, serveErrCh)
 	if err != nil {
@@ -544,6 +567,27 @@ func (s *Server) stopOnce0() error {
 		s.hookRunner.Stop()
 	}
 
+	// Stop digest and job scheduling
+	if s.digests != nil {
+		s.digests.Stop()
+	}
+	if s.schedules != nil {
+		s.schedules.Stop()
+	}
+	if s.agentProber != nil {
+		s.agentProber.Stop()
+	}
+
+	// Stop webhook delivery; undelivered webhooks stay queued for next start
+	if s.webhookOutbox != nil {
+		s.webhookOutbox.Stop()
+	}
+
+	// Journal and deliver events still queued by the stopped components
+	if b, ok := s.broadcaster.(*EventBroadcaster); ok {
+		b.Close()
+	}
+
 	// Close error log
 	if s.errorLog != nil {
 		s.errorLog.Close()
@@ -652,9 +696,19 @@ func (s *Server) telemetryProperties(cfg *config.Config) map[string]any {
 	return props
 }
 
-// SetSyncWorker sets the sync worker for triggering manual syncs
+// SetSyncWorker sets the sync worker for triggering manual syncs and
+// broadcasts its health transitions as sync.failed / sync.recovered.
 func (s *Server) SetSyncWorker(sw *storage.SyncWorker) {
 	s.syncWorker = sw
+	sw.SetHealthHandler(func(phase string, err error) {
+		event := Event{Type: EventSyncRecovered, TS: time.Now(), Sync: &SyncEventData{}}
+		if err != nil {
+			event.Type = EventSyncFailed
+			event.Error = err.Error()
+			event.Sync.Phase = phase
+		}
+		s.broadcaster.Broadcast(event)
+	})
 }
 
 // SetCIPoller sets the CI poller for status reporting and wires up
@@ -1109,16 +1163,17 @@ func (s *Server) humaListJobs(
 	}
 
 	// Panels: panel_run returns a full run (members + synthesis) for
-	// expansion. Otherwise the listing is parent-only — member rows are
-	// excluded so list/wait/fix-discovery resolve to the synthesis parent,
-	// never an individual reviewer — the same caller-driven exclusion
-	// mechanism that fix jobs use via exclude_job_type.
+	// expansion. Otherwise the listing is parent-only — member rows and
+	// re-synthesized history are excluded so list/wait/fix-discovery resolve
+	// to the newest synthesis parent, never an individual reviewer — the same
+	// caller-driven exclusion mechanism that fix jobs use via exclude_job_type.
 	if input.PanelRun != "" {
 		listOpts = append(listOpts, storage.WithPanelRun(input.PanelRun))
 	} else {
 		listOpts = append(
 			listOpts,
 			storage.WithExcludePanelRole(storage.PanelRoleMember),
+			storage.WithLatestSynthesisOnly(),
 		)
 	}
 
@@ -1187,6 +1242,7 @@ func (s *Server) humaListJobs(
 	statsOpts = append(
 		statsOpts,
 		storage.WithExcludePanelRole(storage.PanelRoleMember),
+		storage.WithLatestSynthesisOnly(),
 	)
 	stats, statsErr := s.db.CountJobStats(repo, statsOpts...)
 	if statsErr != nil {
@@ -1290,6 +1346,9 @@ func (s *Server) humaExportReviews(
 	if err != nil {
 		return nil, fmt.Errorf("get database ID: %w", err)
 	}
+	if err := redactExport(s.configWatcher.Config(), page.Reviews); err != nil {
+		return nil, fmt.Errorf("redaction: %w", err)
+	}
 
 	var nextCursor *string
 	if page.NextCursor != nil {
@@ -1315,6 +1374,33 @@ func (s *Server) humaExportReviews(
 	return resp, nil
 }
 
+// redactExport masks secrets in exported review content in place, using
+// each review's repo redaction settings. Exports leave the machine, so
+// stored output quoting a secret is masked on the way out.
+func redactExport(cfg *config.Config, reviews []storage.ExportReview) error {
+	redactors := make(map[string]*redact.Redactor)
+	for i := range reviews {
+		redactor, ok := redactors[reviews[i].RepoPath]
+		if !ok {
+			var err error
+			if redactor, err = redact.ForRepo(reviews[i].RepoPath, cfg); err != nil {
+				return err
+			}
+			redactors[reviews[i].RepoPath] = redactor
+		}
+		mask := func(content *string) {
+			if content != nil {
+				*content = redactor.String(*content)
+			}
+		}
+		mask(reviews[i].Content)
+		for j := range reviews[i].Subagents {
+			mask(reviews[i].Subagents[j].Content)
+		}
+	}
+	return nil
+}
+
 func parseExportTimeBound(raw string, upper bool) (time.Time, *string, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -1518,6 +1604,9 @@ func (s *Server) humaGetStatus(
 		ConfigReloadedAt:    configReloadedAt,
 		ConfigReloadCounter: configReloadCounter,
 	}
+	if health, err := s.db.ListAgentHealth(); err == nil {
+		resp.Body.UnhealthyAgents = unhealthyAgentLabels(health)
+	}
 	return resp, nil
 }
 
@@ -1575,6 +1664,16 @@ func (s *Server) humaGetSummary(
 		)
 	}
 
+	if input.Agents == "true" {
+		samples, err := s.db.GetAgentScoreSamples(opts)
+		if err != nil {
+			return nil, huma.Error500InternalServerError(
+				fmt.Sprintf("get agent scores: %v", err),
+			)
+		}
+		summary.AgentScores = reviewpkg.ScoreAgents(samples)
+	}
+
 	return &GetSummaryOutput{Body: summary}, nil
 }
 
@@ -1658,6 +1757,7 @@ func (s *Server) humaCancelJob(
 	// already-terminal row.
 	s.cascadeCancelPanelMembers(job)
 	s.releaseSynthesisIfCanceledMember(job)
+	s.settleJobDependencies()
 
 	resp := &CancelJobOutput{}
 	resp.Body.Success = true
@@ -1694,7 +1794,7 @@ func (s *Server) humaRerunJob(
 	}
 	if job.PanelRole == storage.PanelRoleMember {
 		return nil, huma.Error400BadRequest(
-			"panel members cannot be rerun directly; rerun the panel synthesis job",
+			"panel members cannot be rerun directly; use /api/panel/rerun-member or rerun the panel synthesis job",
 		)
 	}
 
@@ -1722,6 +1822,8 @@ func (s *Server) humaRerunJob(
 			fmt.Sprintf("rerun job: %v", err),
 		)
 	}
+	// A rerun dependent is still gated; release or cancel it now.
+	s.settleJobDependencies()
 
 	resp := &RerunJobOutput{}
 	resp.Body.Success = true
@@ -1774,6 +1876,30 @@ func (s *Server) humaCloseReview(
 	return resp, nil
 }
 
+// humaSaveJobVerify records the verify_command outcome of edits made for a
+// job outside the worker, such as a `roborev refine` attempt on its review.
+func (s *Server) humaSaveJobVerify(
+	ctx context.Context, input *JobVerifyInput,
+) (*JobVerifyOutput, error) {
+	if input.Body.JobID == 0 {
+		return nil, huma.Error400BadRequest("job_id is required")
+	}
+	if _, err := s.db.GetJobByID(input.Body.JobID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, huma.Error404NotFound("job not found")
+		}
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("get job: %v", err))
+	}
+	if err := s.db.SaveJobVerifyResult(input.Body.JobID, input.Body.Verify); err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("save verify result: %v", err))
+	}
+	resp := &JobVerifyOutput{}
+	resp.Body.Success = true
+	return resp, nil
+}
+
 func (s *Server) humaAddComment(
 	ctx context.Context, input *AddCommentInput,
 ) (*AddCommentOutput, error) {
@@ -1881,6 +2007,18 @@ func (s *Server) humaEnqueue(
 		)
 	}
 
+	if early := s.validateDependsOn(req.DependsOn); early != nil {
+		return early, nil
+	}
+	// A dependent job is gated by claim_blocked, which a panel run already
+	// uses for its synthesis, so dependents always run single-agent.
+	if len(req.DependsOn) > 0 && req.Panel != "" && req.Panel != "none" {
+		return rawJSONOutput(
+			http.StatusBadRequest,
+			ErrorResponse{Error: "depends_on cannot be combined with a panel"},
+		)
+	}
+
 	if req.ReviewType == "" {
 		req.ReviewType = config.ReviewTypeDefault
 	}
@@ -1954,13 +2092,31 @@ func (s *Server) humaEnqueue(
 		)
 	}
 
-	workflow := workflowForJob(req.JobType, req.ReviewType)
 	cfg := s.configWatcher.Config()
 	resolutionPath := repoRoot
 	if worktreePath != "" {
 		resolutionPath = worktreePath
 	}
 
+	// A matching [[review.routes]] entry fills in the panel, review type,
+	// reasoning and min severity the request left unset.
+	route, early := s.selectReviewRoute(ctx, routeTargetInputs{
+		req:            req,
+		gitRef:         gitRef,
+		checkoutRoot:   checkoutRoot,
+		resolutionPath: resolutionPath,
+		metadata:       metadata,
+		cfg:            cfg,
+	})
+	if early != nil {
+		return early, nil
+	}
+	if route != nil {
+		req = applyReviewRoute(req, route)
+		log.Printf("Enqueue %s: review route %s", gitRef, routeSummary(route))
+	}
+	workflow := workflowForJob(req.JobType, req.ReviewType)
+
 	var reasoning string
 	if workflow == "fix" {
 		reasoning, err = config.ResolveFixReasoning(
@@ -2019,9 +2175,23 @@ func (s *Server) humaEnqueue(
 	if early != nil {
 		return early, nil
 	}
+	if route != nil {
+		descriptor.route = route.Label
+	}
 
 	merged := config.MergedReviewConfig(resolutionPath, cfg)
 	panelName := selectPanelForTarget(descriptor, req, merged)
+	if panelName != "" && len(req.DependsOn) > 0 {
+		// The same rule as an explicit panel: a configured one is rejected
+		// rather than silently dropped.
+		return rawJSONOutput(
+			http.StatusBadRequest,
+			ErrorResponse{Error: fmt.Sprintf(
+				"depends_on cannot be combined with panel %q selected by the review config; set \"panel\": \"none\" to run single-agent",
+				panelName,
+			)},
+		)
+	}
 	if panelName != "" {
 		return s.enqueuePanelRun(ctx, panelRunInputs{
 			descriptor:     descriptor,
@@ -2111,7 +2281,8 @@ func (s *Server) resolveSingleAgent(
 // enqueueSingleAgent resolves the single-review agent, enqueues one job from the
 // frozen descriptor, and runs the shared tail (auto-design dispatch, activity
 // log, broadcast). This is the no-panel path; it must stay behaviorally
-// identical to the pre-panel handler.
+// identical to the pre-panel handler unless [review.chunking] splits the
+// target into file groups.
 func (s *Server) enqueueSingleAgent(
 	ctx context.Context, in singleAgentInputs,
 ) (*RawJSONOutput, error) {
@@ -2119,6 +2290,11 @@ func (s *Server) enqueueSingleAgent(
 	if early != nil {
 		return early, nil
 	}
+	// With [review.chunking] enabled, a diff over the chunk size is reviewed
+	// as file groups merged into one parent review.
+	if chunks := s.planChunksForTarget(ctx, in); len(chunks) > 1 {
+		return s.enqueueChunkedRun(ctx, in, agentName, model, chunks)
+	}
 
 	o := in.descriptor.baseOpts()
 	o.Agent = agentName
@@ -2126,6 +2302,8 @@ func (s *Server) enqueueSingleAgent(
 	o.Provider = in.descriptor.requestedProvider
 	o.Reasoning = in.reasoning
 	o.ReviewType = in.req.ReviewType
+	o.DependsOn = in.req.DependsOn
+	o.NoCache = in.req.NoCache
 	if in.descriptor.sessionSHA != "" {
 		o.SessionID = s.findReusableSessionID(ctx,
 			in.checkoutRoot, in.repo.ID, in.req.Branch, agentName,
@@ -2145,6 +2323,10 @@ func (s *Server) enqueueSingleAgent(
 	}
 	job.RepoPath = in.repo.RootPath
 	job.RepoName = in.repo.Name
+	if len(o.DependsOn) > 0 {
+		// Release the job now if its prerequisites already succeeded.
+		s.settleJobDependencies()
+	}
 
 	s.finishSingleEnqueue(ctx, job, agentName, in)
 	return rawJSONOutput(http.StatusCreated, job)
@@ -2196,7 +2378,7 @@ func (s *Server) logEnqueueSideEffects(
 	}
 
 	s.broadcaster.Broadcast(Event{
-		Type:     "job.enqueued",
+		Type:     EventJobEnqueued,
 		TS:       time.Now(),
 		JobID:    job.ID,
 		Repo:     in.repo.RootPath,
@@ -2395,6 +2577,19 @@ func (s *Server) humaFixJob(
 			ErrorResponse{Error: "parent job must be a review, not a fix job"},
 		)
 	}
+	if early := s.validateDependsOn(req.DependsOn); early != nil {
+		return early, nil
+	}
+	// A fix that waits for its still-running parent builds its prompt from
+	// the parent's review once the worker claims it.
+	deferPrompt := slices.Contains(req.DependsOn, req.ParentJobID) &&
+		(parentJob.Status == storage.JobStatusQueued || parentJob.Status == storage.JobStatusRunning)
+	if deferPrompt && (req.Prompt != "" || req.StaleJobID > 0) {
+		return rawJSONOutput(
+			http.StatusBadRequest,
+			ErrorResponse{Error: "prompt and stale_job_id require a finished parent job"},
+		)
+	}
 
 	fixPrompt := ""
 	if req.StaleJobID > 0 {
@@ -2466,27 +2661,15 @@ func (s *Server) humaFixJob(
 			fixMinSev = resolved
 		}
 
-		review, err := s.db.GetReviewByJobID(req.ParentJobID)
-		if err != nil || review == nil {
-			return rawJSONOutput(
-				http.StatusBadRequest,
-				ErrorResponse{Error: "parent job has no review to fix"},
-			)
-		}
-
-		commitID, fallbackSHA := parentJob.LegacyCommentLookupTarget()
-		comments, commentsErr := s.db.GetAllCommentsForJob(
-			req.ParentJobID, commitID, fallbackSHA,
-		)
-		if commentsErr != nil {
-			log.Printf(
-				"fix job for parent %d: failed to fetch comments: %v",
-				req.ParentJobID, commentsErr,
-			)
+		if !deferPrompt {
+			fixPrompt, err = buildParentFixPrompt(s.db, parentJob, req.Prompt, fixMinSev)
+			if err != nil {
+				return rawJSONOutput(
+					http.StatusBadRequest,
+					ErrorResponse{Error: err.Error()},
+				)
+			}
 		}
-		fixPrompt = buildFixPromptWithInstructions(
-			review.Output, req.Prompt, fixMinSev, comments,
-		)
 	}
 
 	cfg := s.configWatcher.Config()
@@ -2591,6 +2774,7 @@ func (s *Server) humaFixJob(
 		ParentJobID:  req.ParentJobID,
 		WorktreePath: worktreePath,
 		MinSeverity:  fixMinSev,
+		DependsOn:    req.DependsOn,
 	})
 	if err != nil {
 		if s.errorLog != nil {
@@ -2608,10 +2792,29 @@ func (s *Server) humaFixJob(
 	if commitID > 0 {
 		job.CommitSubject = parentJob.CommitSubject
 	}
+	if len(req.DependsOn) > 0 {
+		// Release the job now if its prerequisites already succeeded.
+		s.settleJobDependencies()
+	}
 
 	return rawJSONOutput(http.StatusCreated, job)
 }
 
+// buildParentFixPrompt builds a fix job's prompt from its parent's review and
+// the comments on it.
+func buildParentFixPrompt(db *storage.DB, parent *storage.ReviewJob, instructions, minSeverity string) (string, error) {
+	review, err := db.GetReviewByJobID(parent.ID)
+	if err != nil || review == nil {
+		return "", errors.New("parent job has no review to fix")
+	}
+	commitID, fallbackSHA := parent.LegacyCommentLookupTarget()
+	comments, err := db.GetAllCommentsForJob(parent.ID, commitID, fallbackSHA)
+	if err != nil {
+		log.Printf("fix job for parent %d: failed to fetch comments: %v", parent.ID, err)
+	}
+	return buildFixPromptWithInstructions(review.Output, instructions, minSeverity, comments), nil
+}
+
 func (s *Server) humaMarkJobApplied(
 	ctx context.Context, input *JobIDInput,
 ) (*JobStatusOutput, error) {
@@ -3043,6 +3246,31 @@ func (s *Server) humaJobPatch(
 	}}, nil
 }
 
+// humaJobAttempts returns a job's agent attempts in order, with the
+// failure class of each failed or skipped attempt.
+func (s *Server) humaJobAttempts(
+	_ context.Context, input *JobAttemptsInput,
+) (*JobAttemptsOutput, error) {
+	if input.JobID <= 0 {
+		return nil, huma.Error400BadRequest("job_id parameter required")
+	}
+	if _, err := s.db.GetJobByID(input.JobID); err != nil {
+		return nil, huma.Error404NotFound("job not found")
+	}
+	attempts, err := s.db.ListJobAttempts(input.JobID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("list job attempts: %v", err),
+		)
+	}
+	out := &JobAttemptsOutput{}
+	out.Body.Attempts = attempts
+	if out.Body.Attempts == nil {
+		out.Body.Attempts = []storage.JobAttempt{}
+	}
+	return out, nil
+}
+
 func (s *Server) humaSyncNow(
 	ctx context.Context, input *SyncNowInput,
 ) (*huma.StreamResponse, error) {
@@ -3111,6 +3339,14 @@ func (s *Server) humaSyncNow(
 func (s *Server) humaStreamEvents(
 	ctx context.Context, input *StreamEventsInput,
 ) (*huma.StreamResponse, error) {
+	resume, err := parseStreamResume(input)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+	if resume != nil && s.eventJournal == nil {
+		return nil, huma.Error503ServiceUnavailable("event journal unavailable")
+	}
+
 	return &huma.StreamResponse{Body: func(hctx huma.Context) {
 		hctx.SetHeader("Content-Type", "application/x-ndjson")
 		hctx.SetHeader("Cache-Control", "no-cache")
@@ -3126,10 +3362,69 @@ func (s *Server) humaStreamEvents(
 			return
 		}
 
+		// Subscribe before reading the journal so nothing broadcast in
+		// between is lost.
 		subID, eventCh := s.broadcaster.Subscribe(input.Repo)
 		defer s.broadcaster.Unsubscribe(subID)
 
 		encoder := json.NewEncoder(writer)
+		send := func(event Event) bool {
+			return encoder.Encode(event) == nil
+		}
+
+		if s.eventJournal == nil {
+			for {
+				select {
+				case <-hctx.Context().Done():
+					return
+				case event, ok := <-eventCh:
+					if !ok || !send(event) {
+						return
+					}
+					flusher.Flush()
+				}
+			}
+		}
+
+		// Journal mode: the journal is the source of truth and the live
+		// channel only signals that there is something new. A subscriber
+		// that falls behind and drops live events still gets every
+		// journaled event, in order.
+		query := storage.JournalQuery{Repo: input.Repo}
+		if resume != nil {
+			query.AfterID, query.Since = resume.afterID, resume.since
+		} else {
+			latest, err := s.eventJournal.Latest()
+			if err != nil {
+				// Start from the first live event instead.
+				log.Printf("Event stream: %v", err)
+				latest = -1
+			}
+			query.AfterID = latest
+		}
+		catchUp := func() bool {
+			for {
+				events, err := s.eventJournal.Replay(query)
+				if err != nil {
+					log.Printf("Event stream: %v", err)
+					return true
+				}
+				for _, event := range events {
+					if !send(event) {
+						return false
+					}
+					query.AfterID = event.ID
+				}
+				flusher.Flush()
+				if len(events) < eventJournalPage {
+					return true
+				}
+			}
+		}
+		if resume != nil && !catchUp() {
+			return
+		}
+
 		for {
 			select {
 			case <-hctx.Context().Done():
@@ -3138,15 +3433,56 @@ func (s *Server) humaStreamEvents(
 				if !ok {
 					return
 				}
-				if err := encoder.Encode(event); err != nil {
-					return
+				if query.AfterID < 0 && event.ID > 0 {
+					query.AfterID = event.ID - 1
+				}
+				switch {
+				case event.ID == 0:
+					// Not journaled (the write failed): deliver it live.
+					if !send(event) {
+						return
+					}
+					flusher.Flush()
+				case event.ID > query.AfterID:
+					if !catchUp() {
+						return
+					}
 				}
-				flusher.Flush()
 			}
 		}
 	}}, nil
 }
 
+// streamResume is where a resumed event stream starts.
+type streamResume struct {
+	afterID int64
+	since   time.Time
+}
+
+// parseStreamResume reads the resume point from the Last-Event-ID header or
+// the since parameter. Both accept an event ID; since also accepts an RFC 3339
+// timestamp. Returns nil when the client asked for live events only.
+func parseStreamResume(input *StreamEventsInput) (*streamResume, error) {
+	if v := strings.TrimSpace(input.LastEventID); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("invalid Last-Event-ID %q: must be an event ID", v)
+		}
+		return &streamResume{afterID: id}, nil
+	}
+	v := strings.TrimSpace(input.Since)
+	if v == "" {
+		return nil, nil
+	}
+	if id, err := strconv.ParseInt(v, 10, 64); err == nil && id >= 0 {
+		return &streamResume{afterID: id}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &streamResume{since: t}, nil
+	}
+	return nil, fmt.Errorf("invalid since %q: must be an event ID or RFC 3339 timestamp", v)
+}
+
 func rawJSONOutput(status int, body any) (*RawJSONOutput, error) {
 	return &RawJSONOutput{
 		Status: status,
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/storage"
 )
 
 // startStreamHandler starts the stream handler in a goroutine and waits for subscription.
@@ -483,3 +486,87 @@ func TestHandleStreamEvents(t *testing.T) {
 		}
 	})
 }
+
+// streamedEvents parses the JSONL body written by the stream handler.
+func streamedEvents(t *testing.T, body string) []Event {
+	t.Helper()
+	var events []Event
+	for line := range strings.SplitSeq(strings.TrimSpace(body), "\n") {
+		if line == "" {
+			continue
+		}
+		var ev Event
+		require.NoError(t, json.Unmarshal([]byte(line), &ev), "line: %s", line)
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestStreamEventsResume(t *testing.T) {
+	server, _, _ := newTestServer(t)
+
+	// Events broadcast with no subscribers are still journaled.
+	for i, repo := range []string{"/r1", "/r2", "/r1"} {
+		server.broadcaster.Broadcast(Event{Type: "review.completed", TS: time.Now(), JobID: int64(i + 1), Repo: repo})
+	}
+	server.broadcaster.(*EventBroadcaster).Flush()
+	history, err := server.eventJournal.Replay(storage.JournalQuery{})
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	first := history[0].ID
+
+	t.Run("since replays then streams live", func(t *testing.T) {
+		cancel, w, done := startStreamHandler(t, server, fmt.Sprintf("/api/stream/events?since=%d", first))
+		require.True(t, waitForEvents(w, 2, time.Second), "replayed events")
+		server.broadcaster.Broadcast(Event{Type: "review.started", TS: time.Now(), JobID: 9, Repo: "/r2"})
+		require.True(t, waitForEvents(w, 3, time.Second), "live event")
+		cancel()
+		<-done
+
+		events := streamedEvents(t, w.bodyString())
+		require.Len(t, events, 3)
+		assert.Equal(t, []int64{2, 3, 9}, []int64{events[0].JobID, events[1].JobID, events[2].JobID})
+		assert.Less(t, events[0].ID, events[1].ID)
+		assert.Less(t, events[1].ID, events[2].ID)
+	})
+
+	t.Run("Last-Event-ID header with repo filter", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodGet, "/api/stream/events?since=999999&repo=%2Fr1", nil).WithContext(ctx)
+		req.Header.Set("Last-Event-ID", "0")
+		w := newSafeRecorder()
+		done := make(chan struct{})
+		go func() {
+			server.httpServer.Handler.ServeHTTP(w, req)
+			close(done)
+		}()
+		require.True(t, waitForEvents(w, 2, time.Second))
+		cancel()
+		<-done
+
+		events := streamedEvents(t, w.bodyString())
+		require.Len(t, events, 2, "header takes precedence over since")
+		for _, ev := range events {
+			assert.Equal(t, "/r1", ev.Repo)
+		}
+	})
+
+	t.Run("live-only stream skips history", func(t *testing.T) {
+		cancel, w, done := startStreamHandler(t, server, "/api/stream/events")
+		server.broadcaster.Broadcast(Event{Type: "review.started", TS: time.Now(), JobID: 10, Repo: "/r1"})
+		require.True(t, waitForEvents(w, 1, time.Second))
+		cancel()
+		<-done
+		events := streamedEvents(t, w.bodyString())
+		require.Len(t, events, 1)
+		assert.EqualValues(t, 10, events[0].JobID)
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/stream/events?since=yesterday", nil)
+		w := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "event ID or RFC 3339 timestamp")
+	})
+}
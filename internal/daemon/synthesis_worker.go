@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 
 	"go.kenn.io/roborev/internal/agent"
 	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/redact"
 	reviewpkg "go.kenn.io/roborev/internal/review"
 	"go.kenn.io/roborev/internal/storage"
 )
@@ -23,7 +25,9 @@ var errSynthesisCanceled = errors.New("synthesis canceled")
 // reviews. It picks one of three branches: all members failed -> durable fail
 // review (no agent); exactly one member succeeded -> passthrough that member's
 // output unless min-severity filtering requires a synthesis pass; two or more
-// succeeded -> a single verify+dedupe agent call.
+// succeeded -> a single verify+dedupe agent call. Panels configured with a
+// deterministic synthesis_strategy, and chunked reviews, skip the agent call
+// whenever at least one member succeeded.
 func (wp *WorkerPool) processSynthesisJob(
 	ctx context.Context, workerID string, job *storage.ReviewJob,
 ) {
@@ -39,6 +43,27 @@ func (wp *WorkerPool) processSynthesisJob(
 	results := toReviewResults(rows)
 	succeeded := filterSucceeded(results)
 
+	spec := synthesisSpecOf(job)
+	if spec.Strategy == config.SynthesisStrategyChunks && len(succeeded) > 0 {
+		// Chunked reviews cover disjoint file groups, so the parent verdict
+		// must account for every group, including the ones that failed.
+		wp.completeSynthesis(workerID, job, job.Agent, "",
+			reviewpkg.CombineChunks(results, job.MinSeverity))
+		return
+	}
+	if spec.Deterministic() && len(succeeded) > 0 {
+		// Deterministic strategies combine member reviews without an agent
+		// call, so there is no quota gate and no failover. Like chunks, they
+		// see every member so a failed member can fail a strictest panel.
+		output, err := reviewpkg.CombineDeterministic(spec.Strategy, spec.Quorum, results, job.MinSeverity)
+		if err != nil {
+			wp.failSynthesisWithoutReview(workerID, job, err.Error())
+			return
+		}
+		wp.completeSynthesis(workerID, job, job.Agent, "", output)
+		return
+	}
+
 	switch len(succeeded) {
 	case 0:
 		if errMsg, ok := allAvailabilitySkippedFailure(results); ok {
@@ -75,6 +100,17 @@ func (wp *WorkerPool) processSynthesisJob(
 	}
 }
 
+// synthesisSpecOf decodes the synthesis spec frozen on the job at enqueue.
+// Rows enqueued before strategies existed carry no spec and use the LLM
+// strategy.
+func synthesisSpecOf(job *storage.ReviewJob) config.SynthesisSpec {
+	var spec config.SynthesisSpec
+	if job.PanelMemberConfigJSON != "" {
+		_ = json.Unmarshal([]byte(job.PanelMemberConfigJSON), &spec)
+	}
+	return spec
+}
+
 func allAvailabilitySkippedFailure(results []reviewpkg.ReviewResult) (string, bool) {
 	if len(results) == 0 {
 		return "", false
@@ -110,16 +146,25 @@ func (wp *WorkerPool) synthesizeSucceededResults(
 	job *storage.ReviewJob,
 	succeeded []reviewpkg.ReviewResult,
 ) {
-	// Mirror processJob's quota gate: an agent already in cooldown must fail
-	// over instead of burning another quota-exhausted call. The no-agent
-	// branches skip this check because they never invoke an agent.
-	canonicalAgent := agent.CanonicalName(job.Agent)
-	if wp.isAgentCoolingDown(canonicalAgent) {
-		wp.failCooldownOrFailover(workerID, job, canonicalAgent,
-			fmt.Sprintf("agent %s quota cooldown active", canonicalAgent))
+	// Member findings may quote secrets an agent read from the repo. A
+	// broken custom pattern fails the job rather than sending them unmasked.
+	redactor, err := redact.ForRepo(job.RepoPath, wp.cfgGetter.Config())
+	if err != nil {
+		log.Printf("[%s] Error configuring redaction: %v", workerID, err)
+		wp.failOrRetry(workerID, job, job.Agent, fmt.Sprintf("redaction: %v", err))
+		return
+	}
+
+	// Mirror processJob's circuit gate: an agent whose circuit is open must
+	// fail over instead of burning another call. The no-agent branches skip
+	// this check because they never invoke an agent.
+	circuit := jobCircuitKey(job, job.Agent, job.Model)
+	if !wp.circuits.allow(circuit, job.ID) {
+		wp.rerouteOpenCircuit(workerID, job, circuit)
 		return
 	}
-	prompt := reviewpkg.BuildSynthesisPrompt(succeeded, job.MinSeverity)
+	defer wp.circuits.release(circuit, job.ID)
+	prompt := redactor.String(reviewpkg.BuildSynthesisPrompt(succeeded, job.MinSeverity))
 	out, resolvedAgent, runErr := wp.runSynthesisAgent(ctx, workerID, job, prompt)
 	if runErr != nil {
 		// runSynthesisAgent already handled the failure/cancel.
@@ -176,14 +221,14 @@ func allMembersPassed(
 
 func (wp *WorkerPool) failSynthesisWithoutReview(workerID string, job *storage.ReviewJob, errorMsg string) {
 	if updated, err := wp.db.FailJob(job.ID, workerID, errorMsg); err != nil {
-		log.Printf("[%s] Error failing skipped synthesis job %d: %v", workerID, job.ID, err)
+		log.Printf("[%s] Error failing synthesis job %d: %v", workerID, job.ID, err)
 	} else if updated {
-		log.Printf("[%s] Synthesis job %d skipped because all panel members were unavailable",
-			workerID, job.ID)
+		log.Printf("[%s] Synthesis job %d failed without a review: %s",
+			workerID, job.ID, errorMsg)
 		wp.broadcastFailed(job, job.Agent, errorMsg)
 		if wp.errorLog != nil {
 			wp.errorLog.LogError("worker",
-				fmt.Sprintf("synthesis job %d skipped: %s", job.ID, errorMsg),
+				fmt.Sprintf("synthesis job %d failed: %s", job.ID, errorMsg),
 				job.ID)
 		}
 		wp.logJobFailed(job.ID, workerID, job.Agent, errorMsg)
@@ -191,7 +236,8 @@ func (wp *WorkerPool) failSynthesisWithoutReview(workerID string, job *storage.R
 }
 
 // completeSynthesis stores the synthesis review, guards against the cancel race,
-// and broadcasts review.completed. The done-path mirrors processJob's tail.
+// and broadcasts review.completed plus panel.completed. The done-path mirrors
+// processJob's tail.
 func (wp *WorkerPool) completeSynthesis(
 	workerID string, job *storage.ReviewJob, agentName, prompt, output string,
 ) {
@@ -213,11 +259,14 @@ func (wp *WorkerPool) completeSynthesis(
 		return
 	}
 	wp.autoClosePassingReview(workerID, job, output)
+	wp.closeResolvedPriorReviews(workerID, job, output)
+	wp.maybeEnqueueResolutionCheck(workerID, job)
+	wp.settleJobDependencies()
 
 	log.Printf("[%s] Completed synthesis job %d %s panel=%s",
 		workerID, job.ID, job.RepoName, job.PanelName)
 
-	wp.broadcaster.Broadcast(Event{
+	completed := Event{
 		Type:     "review.completed",
 		TS:       time.Now(),
 		JobID:    job.ID,
@@ -229,7 +278,9 @@ func (wp *WorkerPool) completeSynthesis(
 		Agent:    agentName,
 		Verdict:  storage.ParseVerdict(output),
 		Findings: output,
-	})
+	}
+	wp.broadcaster.Broadcast(completed)
+	wp.broadcastJobFamilyEvent(job, completed)
 }
 
 // runSynthesisAgent invokes the configured agent read-only (non-agentic) to
@@ -322,6 +373,7 @@ func (wp *WorkerPool) runSynthesisAgent(
 		wp.failOrRetryAgent(workerID, job, agentName, fmt.Sprintf("agent: %v", err))
 		return "", agentName, err
 	}
+	wp.recordAgentSuccess(job, agentName, job.Model)
 	wp.captureTokenUsageForSession(context.Background(), workerID, job, sessionWriter.SessionID())
 	return output, agentName, nil
 }
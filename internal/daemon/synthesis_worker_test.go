@@ -646,6 +646,150 @@ func TestSynthesisAllPassingSkipsAgent(t *testing.T) {
 	assert.False(synthCalled, "clean panels must not invoke an extra synthesis agent")
 }
 
+func TestSynthesisDeterministicStrategySkipsAgent(t *testing.T) {
+	assert := assert.New(t)
+	tc := newWorkerTestContext(t, 1)
+
+	const memberAgent = "panel-majority-member"
+	registerPassingAgent(t, memberAgent)
+
+	var synthCalled bool
+	const synthAgent = "synth-majority"
+	registerNeverCalledAgent(t, synthAgent, &synthCalled)
+	// Deterministic strategies make no agent call, so a cooled-down synthesis
+	// agent must not divert the job.
+	tc.Pool.circuits.trip(circuitKeyFor(synthAgent, "", ""), time.Now().Add(time.Hour), "quota limit")
+
+	runUUID, members, _ := enqueuePanelRun(t, tc, "majority-panel", []memberSpec{
+		{name: "m0", agent: memberAgent},
+		{name: "m1", agent: memberAgent},
+		{name: "m2", agent: memberAgent},
+	})
+	setSynthesisAgent(t, tc, runUUID, synthAgent)
+	_, err := tc.DB.Exec(
+		"UPDATE review_jobs SET panel_member_config_json = ? WHERE panel_run_uuid = ? AND panel_role = 'synthesis'",
+		`{"agent":"synth-majority","strategy":"majority"}`, runUUID,
+	)
+	require.NoError(t, err)
+	completeMember(t, tc, members[0].ID, memberAgent, "- High — a.go:10 shared finding")
+	completeMember(t, tc, members[1].ID, memberAgent, "- Medium — a.go:11 shared finding\n---\n- Low — b.go:2 lone finding")
+	completeMember(t, tc, members[2].ID, memberAgent, "No issues found.")
+
+	synth := releaseAndClaimSynthesis(t, tc, runUUID)
+	tc.Pool.processSynthesisJob(context.Background(), testWorkerID, synth)
+
+	tc.assertJobStatus(t, synth.ID, storage.JobStatusDone)
+	review, err := tc.DB.GetReviewByJobID(synth.ID)
+	require.NoError(t, err)
+	assert.False(synthCalled, "deterministic strategies must not invoke the synthesis agent")
+	assert.Contains(review.Output, "Reported by: m0, m1")
+	assert.Contains(review.Output, "- Reported by m1: `b.go:2` lone finding")
+	assert.Equal("F", storage.ParseVerdict(review.Output))
+}
+
+func TestSynthesisStrictestFailsOnFailedMember(t *testing.T) {
+	assert := assert.New(t)
+	tc := newWorkerTestContext(t, 1)
+
+	const memberAgent = "panel-strictest-member"
+	registerPassingAgent(t, memberAgent)
+	var synthCalled bool
+	const synthAgent = "synth-strictest"
+	registerNeverCalledAgent(t, synthAgent, &synthCalled)
+
+	runUUID, members, _ := enqueuePanelRun(t, tc, "strictest-panel", []memberSpec{
+		{name: "m0", agent: memberAgent},
+		{name: "m1", agent: memberAgent},
+	})
+	setSynthesisAgent(t, tc, runUUID, synthAgent)
+	_, err := tc.DB.Exec(
+		"UPDATE review_jobs SET panel_member_config_json = ? WHERE panel_run_uuid = ? AND panel_role = 'synthesis'",
+		`{"agent":"synth-strictest","strategy":"strictest"}`, runUUID,
+	)
+	require.NoError(t, err)
+	completeMember(t, tc, members[0].ID, memberAgent, "No issues found.")
+	failMemberWithError(t, tc, members[1].ID, "agent timed out")
+
+	synth := releaseAndClaimSynthesis(t, tc, runUUID)
+	tc.Pool.processSynthesisJob(context.Background(), testWorkerID, synth)
+
+	tc.assertJobStatus(t, synth.ID, storage.JobStatusDone)
+	review, err := tc.DB.GetReviewByJobID(synth.ID)
+	require.NoError(t, err)
+	assert.False(synthCalled)
+	assert.Contains(review.Output, "m1 did not complete")
+	assert.Equal("F", storage.ParseVerdict(review.Output), "a failed member must fail a strictest panel")
+}
+
+func TestSynthesisMajorityFailsWhenQuorumUnreachable(t *testing.T) {
+	assert := assert.New(t)
+	tc := newWorkerTestContext(t, 1)
+
+	const memberAgent = "panel-quorum-member"
+	registerPassingAgent(t, memberAgent)
+	var synthCalled bool
+	const synthAgent = "synth-quorum"
+	registerNeverCalledAgent(t, synthAgent, &synthCalled)
+
+	runUUID, members, _ := enqueuePanelRun(t, tc, "quorum-panel", []memberSpec{
+		{name: "m0", agent: memberAgent},
+		{name: "m1", agent: memberAgent},
+		{name: "m2", agent: memberAgent},
+	})
+	setSynthesisAgent(t, tc, runUUID, synthAgent)
+	_, err := tc.DB.Exec(
+		"UPDATE review_jobs SET panel_member_config_json = ? WHERE panel_run_uuid = ? AND panel_role = 'synthesis'",
+		`{"agent":"synth-quorum","strategy":"majority","quorum":3}`, runUUID,
+	)
+	require.NoError(t, err)
+	completeMember(t, tc, members[0].ID, memberAgent, "- High — a.go:10 finding")
+	completeMember(t, tc, members[1].ID, memberAgent, "- High — a.go:10 finding")
+	failMemberWithError(t, tc, members[2].ID, "agent timed out")
+
+	synth := releaseAndClaimSynthesis(t, tc, runUUID)
+	tc.Pool.processSynthesisJob(context.Background(), testWorkerID, synth)
+
+	got := tc.assertJobStatus(t, synth.ID, storage.JobStatusFailed)
+	assert.Contains(got.Error, "synthesis_quorum 3 cannot be reached")
+	_, err = tc.DB.GetReviewByJobID(synth.ID)
+	require.Error(t, err, "an unreachable quorum must not store a passing review")
+	assert.False(synthCalled)
+}
+
+func TestSynthesisChunksStrategyAccountsForFailedGroups(t *testing.T) {
+	assert := assert.New(t)
+	tc := newWorkerTestContext(t, 1)
+
+	const memberAgent = "chunk-member"
+	registerPassingAgent(t, memberAgent)
+	var synthCalled bool
+	const synthAgent = "synth-chunks"
+	registerNeverCalledAgent(t, synthAgent, &synthCalled)
+
+	runUUID, members, _ := enqueuePanelRun(t, tc, chunkPanelName, []memberSpec{
+		{name: "chunk 1/2", agent: memberAgent},
+		{name: "chunk 2/2", agent: memberAgent},
+	})
+	setSynthesisAgent(t, tc, runUUID, synthAgent)
+	_, err := tc.DB.Exec(
+		"UPDATE review_jobs SET panel_member_config_json = ? WHERE panel_run_uuid = ? AND panel_role = 'synthesis'",
+		`{"agent":"synth-chunks","strategy":"chunks"}`, runUUID,
+	)
+	require.NoError(t, err)
+	completeMember(t, tc, members[0].ID, memberAgent, "No issues found.")
+	failMemberWithError(t, tc, members[1].ID, "agent timed out")
+
+	synth := releaseAndClaimSynthesis(t, tc, runUUID)
+	tc.Pool.processSynthesisJob(context.Background(), testWorkerID, synth)
+
+	tc.assertJobStatus(t, synth.ID, storage.JobStatusDone)
+	review, err := tc.DB.GetReviewByJobID(synth.ID)
+	require.NoError(t, err)
+	assert.False(synthCalled, "chunked reviews must not invoke the synthesis agent")
+	assert.Contains(review.Output, "chunk 2/2 was not reviewed")
+	assert.Equal("F", storage.ParseVerdict(review.Output), "an unreviewed group must not pass the parent")
+}
+
 func TestSynthesisUsesSynthesisEntrypoint(t *testing.T) {
 	assert := assert.New(t)
 	tc := newWorkerTestContext(t, 1)
@@ -677,6 +821,35 @@ func TestSynthesisUsesSynthesisEntrypoint(t *testing.T) {
 	assert.NotContains(synthAgent.synthPrompt, "Review the code changes in commit")
 }
 
+func TestSynthesisFailsClosedOnBrokenRedactionPattern(t *testing.T) {
+	tc := newWorkerTestContext(t, 1)
+	require.NoError(t, os.WriteFile(filepath.Join(tc.TmpDir, ".roborev.toml"),
+		[]byte("redact_patterns = [\"(\"]\n"), 0o644))
+
+	const memberAgent = "panel-synthesis-badredact-member"
+	registerPassingAgent(t, memberAgent)
+
+	synthAgent := &synthesisEntrypointTestAgent{name: "panel-synthesis-badredact"}
+	agent.Register(synthAgent)
+	t.Cleanup(func() { agent.Unregister(synthAgent.name) })
+
+	runUUID, members, synth := enqueuePanelRun(t, tc, "badredact-panel", []memberSpec{
+		{name: "m0", agent: memberAgent},
+		{name: "m1", agent: memberAgent},
+	})
+	setSynthesisAgent(t, tc, runUUID, synthAgent.name)
+	completeMember(t, tc, members[0].ID, memberAgent, "## Review Findings\n\n- Severity: High\n- Location: a.go:1\n- Problem: A\n- Fix: Fix A")
+	completeMember(t, tc, members[1].ID, memberAgent, "## Review Findings\n\n- Severity: Medium\n- Location: b.go:2\n- Problem: B\n- Fix: Fix B")
+
+	claimed := releaseAndClaimSynthesis(t, tc, runUUID)
+	tc.Pool.processSynthesisJob(context.Background(), testWorkerID, claimed)
+
+	assert.Empty(t, synthAgent.synthPrompt, "findings must not reach the agent unmasked")
+	updated, err := tc.DB.GetJobByID(synth.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, storage.JobStatusDone, updated.Status)
+}
+
 func TestSynthesisCapturesTokenUsage(t *testing.T) {
 	assert := assert.New(t)
 	tc := newWorkerTestContext(t, 1)
@@ -826,7 +999,7 @@ func TestSynthesisPassthroughIgnoresAgentCooldown(t *testing.T) {
 
 	// Put the synthesis agent in cooldown — the passthrough branch must ignore
 	// it because it never invokes the agent.
-	tc.Pool.cooldownAgent(synthAgent, time.Now().Add(time.Hour))
+	tc.Pool.circuits.trip(circuitKeyFor(synthAgent, "", ""), time.Now().Add(time.Hour), "quota limit")
 
 	runUUID, members, _ := enqueuePanelRun(t, tc, "cooldown-panel", []memberSpec{
 		{name: "m0", agent: memberAgent},
@@ -890,7 +1063,7 @@ func TestSynthesisMultiSuccessRespectsCooldown(t *testing.T) {
 
 	// Cool the synthesis agent: the multi-success branch must divert instead of
 	// invoking a quota-exhausted agent.
-	tc.Pool.cooldownAgent(synthAgent, time.Now().Add(time.Hour))
+	tc.Pool.circuits.trip(circuitKeyFor(synthAgent, "", ""), time.Now().Add(time.Hour), "quota limit")
 
 	runUUID, members, _ := enqueuePanelRun(t, tc, "cd-multi-panel", []memberSpec{
 		{name: "m0", agent: memberAgent},
@@ -920,7 +1093,7 @@ func TestSynthesisCIReviewCooldownDoesNotFailOverToBackup(t *testing.T) {
 	const synthAgent = "synth-ci-cd"
 	registerNeverCalledAgent(t, synthAgent, &called)
 
-	tc.Pool.cooldownAgent(synthAgent, time.Now().Add(time.Hour))
+	tc.Pool.circuits.trip(circuitKeyFor(synthAgent, "", ""), time.Now().Add(time.Hour), "quota limit")
 
 	runUUID, members, _ := enqueuePanelRun(t, tc, "ci-cd-panel", []memberSpec{
 		{name: "m0", agent: memberAgent},
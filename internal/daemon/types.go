@@ -26,6 +26,9 @@ type EnqueueRequest struct {
 	MinSeverity  string   `json:"min_severity,omitempty"`  // Minimum severity filter: critical, high, medium, low
 	Panel        string   `json:"panel,omitempty"`         // Panel name; "none" forces single-agent
 	Source       string   `json:"source,omitempty"`        // Provenance, e.g. "post_commit" (empty = foreground)
+	Incremental  bool     `json:"incremental,omitempty"`   // Narrow a branch range to the commits since its last completed review
+	DependsOn    []int64  `json:"depends_on,omitempty"`    // Prerequisite job IDs; the job waits for them and is canceled if one fails
+	NoCache      bool     `json:"no_cache,omitempty"`      // Run the agent even when a cached review of the same patch matches
 }
 
 // PanelEnqueueResponse is returned when an enqueue fans out into a panel run.
@@ -181,6 +184,12 @@ type CloseReviewRequest struct {
 	Closed bool  `json:"closed"`
 }
 
+// JobVerifyRequest is the JSON body for POST /api/job/verify.
+type JobVerifyRequest struct {
+	JobID  int64                `json:"job_id"`
+	Verify storage.VerifyResult `json:"verify"`
+}
+
 // JobOutputResponse is the response for GET /api/job/output.
 type JobOutputResponse struct {
 	JobID   int64        `json:"job_id"`
@@ -217,6 +226,48 @@ type RerunJobOutput struct {
 	}
 }
 
+// -- POST /api/panel/rerun-member --
+
+// PanelRerunMemberRequest is the JSON body for POST /api/panel/rerun-member.
+type PanelRerunMemberRequest struct {
+	JobID int64 `json:"job_id" doc:"Panel member job ID"`
+}
+
+// PanelRerunMemberInput is the request body for rerunning one panel member.
+type PanelRerunMemberInput struct {
+	Body PanelRerunMemberRequest
+}
+
+// PanelRerunMemberOutput is the response for POST /api/panel/rerun-member.
+type PanelRerunMemberOutput struct {
+	Body struct {
+		Success        bool  `json:"success"`
+		SynthesisJobID int64 `json:"synthesis_job_id"`
+	}
+}
+
+// -- POST /api/panel/resynthesize --
+
+// PanelResynthesizeRequest is the JSON body for POST /api/panel/resynthesize.
+type PanelResynthesizeRequest struct {
+	PanelRunUUID string `json:"panel_run_uuid" doc:"Panel run UUID"`
+	Agent        string `json:"agent,omitempty" doc:"Synthesis agent override (defaults to the previous synthesis agent)"`
+	Model        string `json:"model,omitempty" doc:"Synthesis model override"`
+}
+
+// PanelResynthesizeInput is the request body for re-synthesizing a panel run.
+type PanelResynthesizeInput struct {
+	Body PanelResynthesizeRequest
+}
+
+// PanelResynthesizeOutput is the response for POST /api/panel/resynthesize.
+type PanelResynthesizeOutput struct {
+	Body struct {
+		Success        bool  `json:"success"`
+		SynthesisJobID int64 `json:"synthesis_job_id"`
+	}
+}
+
 // -- POST /api/review/close --
 
 // CloseReviewInput is the request body for closing/reopening a review.
@@ -231,6 +282,20 @@ type CloseReviewOutput struct {
 	}
 }
 
+// -- POST /api/job/verify --
+
+// JobVerifyInput is the request body for recording a verify_command outcome.
+type JobVerifyInput struct {
+	Body JobVerifyRequest
+}
+
+// JobVerifyOutput is the response for POST /api/job/verify.
+type JobVerifyOutput struct {
+	Body struct {
+		Success bool `json:"success"`
+	}
+}
+
 // -- POST /api/comment --
 
 // AddCommentInput is the request body for adding a comment.
@@ -341,6 +406,7 @@ type GetSummaryInput struct {
 	Repo   string `query:"repo" doc:"Filter by repo root path"`
 	Branch string `query:"branch" doc:"Filter by branch name"`
 	All    string `query:"all" doc:"Include per-repo breakdown" enum:"true,false"`
+	Agents string `query:"agents" doc:"Include the agent quality scoreboard" enum:"true,false"`
 }
 
 // GetSummaryOutput is the response for GET /api/summary.
@@ -363,6 +429,40 @@ type GetCostOutput struct {
 	Body storage.CostAggregate
 }
 
+// -- GET /api/hooks/deliveries --
+
+// ListWebhookDeliveriesInput holds query parameters for the webhook delivery log.
+type ListWebhookDeliveriesInput struct {
+	Status string `query:"status" doc:"Filter by delivery status" enum:"pending,delivered,failed"`
+	JobID  int64  `query:"job_id" doc:"Filter by job ID"`
+	Limit  int    `query:"limit" doc:"Maximum deliveries to return (default 50)"`
+}
+
+// ListWebhookDeliveriesOutput is the response for GET /api/hooks/deliveries.
+// Delivery URLs are redacted to scheme and host.
+type ListWebhookDeliveriesOutput struct {
+	Body struct {
+		Deliveries []storage.WebhookDelivery `json:"deliveries"`
+	}
+}
+
+// RedeliverWebhookRequest is the request body for POST /api/hooks/deliveries/redeliver.
+type RedeliverWebhookRequest struct {
+	ID int64 `json:"id" doc:"Webhook delivery ID"`
+}
+
+// RedeliverWebhookInput is the request body for requeueing a webhook delivery.
+type RedeliverWebhookInput struct {
+	Body RedeliverWebhookRequest
+}
+
+// RedeliverWebhookOutput is the response for POST /api/hooks/deliveries/redeliver.
+type RedeliverWebhookOutput struct {
+	Body struct {
+		Success bool `json:"success"`
+	}
+}
+
 // RawJSONOutput is used by endpoints with union response shapes while their
 // core behavior is represented by Huma request types.
 type RawJSONOutput struct {
@@ -370,6 +470,68 @@ type RawJSONOutput struct {
 	Body   any
 }
 
+// -- /api/workflows --
+
+// WorkflowRequest is the request body for POST /api/workflows: a DAG of
+// enqueue requests submitted as a unit.
+type WorkflowRequest struct {
+	Name  string                `json:"name,omitempty" doc:"Display name of the workflow"`
+	Steps []WorkflowStepRequest `json:"steps" doc:"Steps to enqueue; each may depend on earlier or later steps by name"`
+}
+
+// WorkflowStepRequest is one step of a workflow. Request is an ordinary
+// /api/enqueue body; DependsOn names the steps it waits for. A step with
+// ParentStep instead enqueues a fix job for that step's review.
+type WorkflowStepRequest struct {
+	Name       string         `json:"name" doc:"Step name, unique within the workflow"`
+	DependsOn  []string       `json:"depends_on,omitempty" doc:"Names of the steps this step waits for"`
+	ParentStep string         `json:"parent_step,omitempty" doc:"Review step to fix; the step waits for it and runs a fix job of its findings instead of request"`
+	Request    EnqueueRequest `json:"request,omitempty" doc:"Enqueue request for the step's job; omitted for a parent_step fix"`
+}
+
+// SubmitWorkflowInput is the request body for POST /api/workflows.
+type SubmitWorkflowInput struct {
+	Body WorkflowRequest
+}
+
+// SubmitWorkflowOutput is the response for POST /api/workflows.
+type SubmitWorkflowOutput struct {
+	Body *storage.Workflow
+}
+
+// ListWorkflowsInput holds query parameters for GET /api/workflows.
+type ListWorkflowsInput struct {
+	UUID  string `query:"uuid" doc:"Return a single workflow with its step jobs"`
+	Limit int    `query:"limit" doc:"Maximum workflows to return (default 50)"`
+}
+
+// ListWorkflowsOutput is the response for GET /api/workflows.
+type ListWorkflowsOutput struct {
+	Body struct {
+		Workflows []storage.Workflow `json:"workflows"`
+	}
+}
+
+// ListAgentsOutput is the response for GET /api/agents and
+// POST /api/agents/probe.
+type ListAgentsOutput struct {
+	Body struct {
+		Agents []storage.AgentHealth `json:"agents"`
+	}
+}
+
+// ListAgentCircuitsOutput is the response for GET /api/agents/circuits.
+type ListAgentCircuitsOutput struct {
+	Body struct {
+		Circuits []AgentCircuit `json:"circuits"`
+	}
+}
+
+// ProbeAgentsInput holds query parameters for POST /api/agents/probe.
+type ProbeAgentsInput struct {
+	Agent string `query:"agent" doc:"Probe only this agent (default: every configured agent)"`
+}
+
 // EnqueueInput is the request body for POST /api/enqueue.
 type EnqueueInput struct {
 	Body EnqueueRequest
@@ -442,6 +604,9 @@ type FixJobRequest struct {
 	Prompt      string `json:"prompt,omitempty"`
 	GitRef      string `json:"git_ref,omitempty"`
 	StaleJobID  int64  `json:"stale_job_id,omitempty"`
+	// DependsOn lists prerequisite job IDs. When it includes an unfinished
+	// parent, the fix prompt is built from the parent's review at run time.
+	DependsOn []int64 `json:"depends_on,omitempty"`
 }
 
 // FixJobInput is the request body for POST /api/job/fix.
@@ -521,6 +686,18 @@ type JobPatchInput struct {
 	JobID string `query:"job_id" doc:"Job ID"`
 }
 
+// JobAttemptsInput holds query parameters for GET /api/job/attempts.
+type JobAttemptsInput struct {
+	JobID int64 `query:"job_id" doc:"Job ID"`
+}
+
+// JobAttemptsOutput is the response for GET /api/job/attempts.
+type JobAttemptsOutput struct {
+	Body struct {
+		Attempts []storage.JobAttempt `json:"attempts"`
+	}
+}
+
 // SyncNowInput holds query parameters for POST /api/sync/now.
 type SyncNowInput struct {
 	Stream string `query:"stream" doc:"Stream sync progress as NDJSON when set to 1"`
@@ -544,5 +721,7 @@ type BackfillTokensOutput struct {
 
 // StreamEventsInput holds query parameters for GET /api/stream/events.
 type StreamEventsInput struct {
-	Repo string `query:"repo" doc:"Filter events by repo root path"`
+	Repo        string `query:"repo" doc:"Filter events by repo root path"`
+	Since       string `query:"since" doc:"Replay journaled events after this event ID, or since this RFC 3339 timestamp, before streaming live events"`
+	LastEventID string `header:"Last-Event-ID" doc:"Resume after this event ID; takes precedence over since"`
 }
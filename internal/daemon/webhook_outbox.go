@@ -0,0 +1,363 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+const (
+	// webhookTimeout bounds one delivery attempt.
+	webhookTimeout = 10 * time.Second
+	// webhookMaxAttempts is the retry budget before a delivery is marked
+	// failed. With exponential backoff from webhookBaseBackoff capped at
+	// webhookMaxBackoff, the last attempt lands roughly four hours after the
+	// first.
+	webhookMaxAttempts = 10
+	webhookBaseBackoff = 15 * time.Second
+	webhookMaxBackoff  = time.Hour
+	// webhookBatchSize caps how many due deliveries one pass sends.
+	webhookBatchSize = 20
+	// webhookIdlePoll is the longest the dispatcher sleeps when nothing is
+	// due, so rows enqueued by another process are still picked up.
+	webhookIdlePoll = time.Minute
+	// webhookRetention is how long finished deliveries are kept for the
+	// delivery log.
+	webhookRetention = 30 * 24 * time.Hour
+
+	// webhookSignatureHeader carries "sha256=<hex HMAC of timestamp.body>"
+	// when the hook has a secret; webhookTimestampHeader carries the signed
+	// Unix timestamp.
+	webhookSignatureHeader = "X-Roborev-Signature"
+	webhookTimestampHeader = "X-Roborev-Timestamp"
+)
+
+// WebhookOutbox delivers webhook hooks from the persistent webhook_deliveries
+// table. Events are written to the outbox before any network call, so a
+// receiver outage or daemon restart delays a delivery instead of dropping it.
+// Failed attempts are retried with exponential backoff until
+// webhookMaxAttempts is reached.
+type WebhookOutbox struct {
+	db     *storage.DB
+	logger *log.Logger
+	client *http.Client
+
+	wakeCh    chan struct{}
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   bool
+	mu        sync.Mutex
+}
+
+// NewWebhookOutbox creates an outbox over db. Call Start to begin delivering.
+func NewWebhookOutbox(db *storage.DB, logger *log.Logger) *WebhookOutbox {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &WebhookOutbox{
+		db:     db,
+		logger: logger,
+		client: &http.Client{Timeout: webhookTimeout},
+		wakeCh: make(chan struct{}, 1),
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+}
+
+// Start launches the delivery loop. Deliveries left pending by a previous
+// daemon run are sent first. Safe to call more than once.
+func (o *WebhookOutbox) Start() {
+	o.startOnce.Do(func() {
+		o.mu.Lock()
+		o.started = true
+		o.mu.Unlock()
+		go o.run()
+	})
+}
+
+// Stop halts the delivery loop and waits for an in-flight pass to finish.
+// Pending deliveries stay in the outbox for the next start.
+func (o *WebhookOutbox) Stop() {
+	o.stopOnce.Do(func() {
+		close(o.stopCh)
+		o.mu.Lock()
+		started := o.started
+		o.mu.Unlock()
+		if started {
+			<-o.doneCh
+		}
+	})
+}
+
+// Wake asks the delivery loop to check for due deliveries now.
+func (o *WebhookOutbox) Wake() {
+	select {
+	case o.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Enqueue stores a delivery of event to the hook's URL and wakes the loop.
+func (o *WebhookOutbox) Enqueue(hook config.HookConfig, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	if _, err := o.db.EnqueueWebhookDelivery(storage.WebhookDelivery{
+		URL:       hook.URL,
+		Secret:    hook.Secret,
+		EventType: event.Type,
+		JobID:     event.JobID,
+		Repo:      event.Repo,
+		Payload:   string(payload),
+	}); err != nil {
+		return err
+	}
+	o.Wake()
+	return nil
+}
+
+func (o *WebhookOutbox) run() {
+	defer close(o.doneCh)
+	lastPrune := time.Time{}
+	for {
+		o.deliverDue()
+		if time.Since(lastPrune) > time.Hour {
+			if _, err := o.db.PruneWebhookDeliveries(time.Now().Add(-webhookRetention)); err != nil {
+				o.logger.Printf("Webhook outbox: %v", err)
+			}
+			lastPrune = time.Now()
+		}
+
+		timer := time.NewTimer(o.nextWait())
+		select {
+		case <-o.stopCh:
+			timer.Stop()
+			return
+		case <-o.wakeCh:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// nextWait returns how long to sleep until the earliest pending delivery is
+// due, capped at webhookIdlePoll.
+func (o *WebhookOutbox) nextWait() time.Duration {
+	next, err := o.db.NextWebhookDeliveryDue()
+	if err != nil {
+		o.logger.Printf("Webhook outbox: %v", err)
+		return webhookIdlePoll
+	}
+	if next == nil {
+		return webhookIdlePoll
+	}
+	return min(max(time.Until(*next), 0), webhookIdlePoll)
+}
+
+// deliverDue sends every due delivery, one batch at a time, until none are
+// due or the outbox is stopping. Each URL's deliveries go out in order on
+// their own goroutine, so a slow receiver does not hold up the others. Once
+// an attempt to a URL fails, the rest of that URL's queue is deferred to the
+// same retry time instead of each waiting out webhookTimeout against a
+// receiver that is already down.
+func (o *WebhookOutbox) deliverDue() {
+	for {
+		due, err := o.db.ListDueWebhookDeliveries(time.Now(), webhookBatchSize)
+		if err != nil {
+			o.logger.Printf("Webhook outbox: %v", err)
+			return
+		}
+		var urls []string
+		byURL := make(map[string][]storage.WebhookDelivery)
+		for _, d := range due {
+			if _, ok := byURL[d.URL]; !ok {
+				urls = append(urls, d.URL)
+			}
+			byURL[d.URL] = append(byURL[d.URL], d)
+		}
+		var wg sync.WaitGroup
+		for _, url := range urls {
+			wg.Add(1)
+			go func(ds []storage.WebhookDelivery) {
+				defer wg.Done()
+				o.deliverInOrder(ds)
+			}(byURL[url])
+		}
+		wg.Wait()
+
+		select {
+		case <-o.stopCh:
+			return
+		default:
+		}
+		if len(due) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// deliverInOrder sends deliveries to a single URL one after another and
+// stops at the first failure, deferring the URL's remaining queue until the
+// failed delivery's retry.
+func (o *WebhookOutbox) deliverInOrder(ds []storage.WebhookDelivery) {
+	for _, d := range ds {
+		select {
+		case <-o.stopCh:
+			return
+		default:
+		}
+		attempt := o.deliver(d)
+		if attempt.Delivered {
+			continue
+		}
+		until := time.Now().Add(webhookBaseBackoff)
+		if attempt.NextAttemptAt != nil {
+			until = *attempt.NextAttemptAt
+		}
+		if err := o.db.DeferWebhookDeliveries(d.URL, until); err != nil {
+			o.logger.Printf("Webhook outbox: %v", err)
+		}
+		return
+	}
+}
+
+// deliver makes one attempt, records its outcome, and returns it.
+func (o *WebhookOutbox) deliver(d storage.WebhookDelivery) storage.WebhookAttempt {
+	attempt := o.send(d)
+	now := time.Now()
+	safeURL := redactWebhookURL(d.URL)
+	if !attempt.Delivered {
+		if d.Attempts+1 < webhookMaxAttempts {
+			next := now.Add(webhookBackoff(d.Attempts + 1))
+			attempt.NextAttemptAt = &next
+			o.logger.Printf("Webhook error (url=%q, delivery %d, attempt %d): %s; retrying at %s",
+				safeURL, d.ID, d.Attempts+1, attempt.Error, next.Format(time.RFC3339))
+		} else {
+			o.logger.Printf("Webhook error (url=%q, delivery %d): %s; giving up after %d attempts",
+				safeURL, d.ID, attempt.Error, d.Attempts+1)
+		}
+	}
+	if err := o.db.RecordWebhookAttempt(d.ID, now, attempt); err != nil {
+		o.logger.Printf("Webhook outbox: %v", err)
+	}
+	return attempt
+}
+
+// send POSTs the frozen payload once. Errors are redacted so the URL (which
+// often embeds a token) never reaches the delivery log.
+func (o *WebhookOutbox) send(d storage.WebhookDelivery) storage.WebhookAttempt {
+	payload := []byte(d.Payload)
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(payload))
+	if err != nil {
+		return storage.WebhookAttempt{Error: fmt.Sprintf("build request: %v", redactURLError(err))}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Roborev-Event", d.EventType)
+	req.Header.Set("X-Roborev-Delivery", strconv.FormatInt(d.ID, 10))
+	if d.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(webhookTimestampHeader, timestamp)
+		req.Header.Set(webhookSignatureHeader, signWebhookPayload(d.Secret, timestamp, payload))
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return storage.WebhookAttempt{Error: redactURLError(err).Error()}
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return storage.WebhookAttempt{Delivered: true, StatusCode: resp.StatusCode}
+	}
+	msg := "status " + resp.Status
+	if text := strings.TrimSpace(string(body)); text != "" {
+		msg += ": " + text
+	}
+	return storage.WebhookAttempt{StatusCode: resp.StatusCode, Error: msg}
+}
+
+// webhookBackoff returns the delay before retry number attempt (1-based):
+// webhookBaseBackoff doubled per attempt, capped at webhookMaxBackoff.
+func webhookBackoff(attempt int) time.Duration {
+	d := webhookBaseBackoff
+	for i := 1; i < attempt && d < webhookMaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, webhookMaxBackoff)
+}
+
+// signWebhookPayload returns the X-Roborev-Signature value for a payload:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>". Covering
+// the timestamp lets receivers reject replays of an old signed request.
+func signWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) humaListWebhookDeliveries(
+	ctx context.Context, input *ListWebhookDeliveriesInput,
+) (*ListWebhookDeliveriesOutput, error) {
+	deliveries, err := s.db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{
+		Status: input.Status,
+		JobID:  input.JobID,
+		Limit:  input.Limit,
+	})
+	if err != nil {
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("list webhook deliveries: %v", err))
+	}
+	for i := range deliveries {
+		deliveries[i].URL = redactWebhookURL(deliveries[i].URL)
+	}
+	out := &ListWebhookDeliveriesOutput{}
+	out.Body.Deliveries = deliveries
+	if out.Body.Deliveries == nil {
+		out.Body.Deliveries = []storage.WebhookDelivery{}
+	}
+	return out, nil
+}
+
+func (s *Server) humaRedeliverWebhook(
+	ctx context.Context, input *RedeliverWebhookInput,
+) (*RedeliverWebhookOutput, error) {
+	if input.Body.ID <= 0 {
+		return nil, huma.Error400BadRequest("id is required")
+	}
+	if err := s.db.RedeliverWebhook(input.Body.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, huma.Error404NotFound("webhook delivery not found")
+		}
+		return nil, huma.Error500InternalServerError(
+			fmt.Sprintf("redeliver webhook: %v", err))
+	}
+	if s.webhookOutbox != nil {
+		s.webhookOutbox.Wake()
+	}
+	out := &RedeliverWebhookOutput{}
+	out.Body.Success = true
+	return out, nil
+}
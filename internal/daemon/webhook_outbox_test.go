@@ -0,0 +1,241 @@
+package daemon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestWebhookOutboxDeliversSignedPayload(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	reqCh := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		reqCh <- received{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	db := testutil.OpenTestDB(t)
+	outbox := NewWebhookOutbox(db, log.New(io.Discard, "", 0))
+	outbox.Start()
+	defer outbox.Stop()
+
+	require.NoError(t, outbox.Enqueue(
+		config.HookConfig{Type: "webhook", URL: server.URL + "/hook", Secret: "topsecret"},
+		Event{Type: "review.failed", JobID: 9, Repo: "/repo", Verdict: "F"},
+	))
+
+	var req received
+	select {
+	case req = <-reqCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "webhook was not delivered")
+	}
+
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "review.failed", req.header.Get("X-Roborev-Event"))
+	assert.NotEmpty(t, req.header.Get("X-Roborev-Delivery"))
+	timestamp := req.header.Get(webhookTimestampHeader)
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(sent, 0), time.Minute)
+	mac := hmac.New(sha256.New, []byte("topsecret"))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(req.body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.header.Get(webhookSignatureHeader))
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(req.body, &event))
+	assert.Equal(t, "review.failed", event["type"])
+	assert.EqualValues(t, 9, event["job_id"])
+
+	assert.Eventually(t, func() bool {
+		ds, err := db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{Status: storage.WebhookDelivered})
+		return err == nil && len(ds) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebhookOutboxRetriesThenGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Empty(t, r.Header.Get(webhookSignatureHeader), "no secret, no signature")
+		http.Error(w, "receiver down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	db := testutil.OpenTestDB(t)
+	var logs strings.Builder
+	outbox := NewWebhookOutbox(db, log.New(&logs, "", 0))
+	require.NoError(t, outbox.Enqueue(
+		config.HookConfig{Type: "webhook", URL: server.URL + "/secret-token"},
+		Event{Type: "review.failed", JobID: 1},
+	))
+
+	outbox.deliverDue()
+	ds, err := db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	d := ds[0]
+	assert.Equal(t, storage.WebhookPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, d.LastStatusCode)
+	assert.Contains(t, d.LastError, "receiver down")
+	require.NotNil(t, d.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(webhookBaseBackoff), *d.NextAttemptAt, 5*time.Second)
+
+	// The retry is not due yet.
+	outbox.deliverDue()
+	assert.EqualValues(t, 1, calls.Load())
+
+	// The last allowed attempt marks the delivery failed.
+	d.Attempts = webhookMaxAttempts - 1
+	outbox.deliver(d)
+	got, err := db.GetWebhookDelivery(d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.WebhookFailed, got.Status)
+	assert.Nil(t, got.NextAttemptAt)
+	assert.Contains(t, logs.String(), "giving up")
+	assert.NotContains(t, logs.String(), "secret-token")
+}
+
+func TestWebhookOutboxDefersReceiverInBackoff(t *testing.T) {
+	var downCalls atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downCalls.Add(1)
+		http.Error(w, "receiver down", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer slow.Close()
+	var upCalls atomic.Int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upCalls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer up.Close()
+
+	db := testutil.OpenTestDB(t)
+	outbox := NewWebhookOutbox(db, log.New(io.Discard, "", 0))
+	for _, url := range []string{down.URL, down.URL, slow.URL, up.URL, up.URL} {
+		require.NoError(t, outbox.Enqueue(
+			config.HookConfig{Type: "webhook", URL: url},
+			Event{Type: "review.failed", JobID: 1},
+		))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		outbox.deliverDue()
+	}()
+	// The slow receiver does not hold up the others.
+	assert.Eventually(t, func() bool { return upCalls.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "deliverDue did not return")
+	}
+
+	// The failing receiver is tried once; its second delivery waits for the
+	// first one's retry without spending an attempt.
+	assert.EqualValues(t, 1, downCalls.Load())
+	ds, err := db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{Status: storage.WebhookPending})
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+	newer, older := ds[0], ds[1]
+	assert.Equal(t, 1, older.Attempts)
+	assert.Equal(t, 0, newer.Attempts)
+	require.NotNil(t, older.NextAttemptAt)
+	require.NotNil(t, newer.NextAttemptAt)
+	assert.Equal(t, *older.NextAttemptAt, *newer.NextAttemptAt)
+	delivered, err := db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{Status: storage.WebhookDelivered})
+	require.NoError(t, err)
+	assert.Len(t, delivered, 3)
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, webhookBaseBackoff, webhookBackoff(1))
+	assert.Equal(t, 2*webhookBaseBackoff, webhookBackoff(2))
+	assert.Equal(t, 8*webhookBaseBackoff, webhookBackoff(4))
+	assert.Equal(t, webhookMaxBackoff, webhookBackoff(webhookMaxAttempts))
+}
+
+func TestHookRunnerQueuesWebhooksInOutbox(t *testing.T) {
+	db := testutil.OpenTestDB(t)
+	outbox := NewWebhookOutbox(db, log.New(io.Discard, "", 0))
+	cfg := &config.Config{Hooks: []config.HookConfig{
+		{Event: "review.failed", Type: "webhook", URL: "http://127.0.0.1:1/hook", Secret: "k"},
+	}}
+	hr := &HookRunner{cfgGetter: NewStaticConfig(cfg), logger: log.New(io.Discard, "", 0), outbox: outbox}
+
+	hr.handleEvent(Event{Type: "review.failed", JobID: 3, Repo: "/repo"})
+	hr.handleEvent(Event{Type: "review.completed", JobID: 3, Repo: "/repo"})
+
+	ds, err := db.ListWebhookDeliveries(storage.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	assert.Equal(t, "review.failed", ds[0].EventType)
+	assert.EqualValues(t, 3, ds[0].JobID)
+	assert.Equal(t, "k", ds[0].Secret)
+	assert.Equal(t, storage.WebhookPending, ds[0].Status)
+}
+
+func TestHumaWebhookDeliveries(t *testing.T) {
+	srv, db, _ := newTestServer(t)
+	id, err := db.EnqueueWebhookDelivery(storage.WebhookDelivery{
+		URL: "https://hooks.example.com/services/T000/B000/token", Secret: "k",
+		EventType: "review.failed", JobID: 5, Payload: "{}",
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.RecordWebhookAttempt(id, time.Now(), storage.WebhookAttempt{Error: "timeout"}))
+
+	rr := serveHuma(t, srv, http.MethodGet, "/api/hooks/deliveries?status=failed", nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.NotContains(t, rr.Body.String(), "token")
+	assert.NotContains(t, rr.Body.String(), `"secret"`)
+	var list struct {
+		Deliveries []storage.WebhookDelivery `json:"deliveries"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	require.Len(t, list.Deliveries, 1)
+	assert.Equal(t, "https://hooks.example.com/...", list.Deliveries[0].URL)
+	assert.Equal(t, "timeout", list.Deliveries[0].LastError)
+
+	rr = serveHuma(t, srv, http.MethodPost, "/api/hooks/deliveries/redeliver",
+		fmt.Appendf(nil, `{"id":%d}`, id))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	d, err := db.GetWebhookDelivery(id)
+	require.NoError(t, err)
+	assert.Equal(t, storage.WebhookPending, d.Status)
+	assert.Zero(t, d.Attempts)
+
+	rr = serveHuma(t, srv, http.MethodPost, "/api/hooks/deliveries/redeliver", []byte(`{"id":999}`))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
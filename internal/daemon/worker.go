@@ -9,6 +9,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,6 +23,7 @@ import (
 	gitpkg "go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/kata"
 	"go.kenn.io/roborev/internal/prompt"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/review"
 	"go.kenn.io/roborev/internal/storage"
 	"go.kenn.io/roborev/internal/tokens"
@@ -50,9 +52,8 @@ type WorkerPool struct {
 	pendingCancels map[int64]bool // Jobs canceled before registered
 	runningJobsMu  sync.Mutex
 
-	// Agent cooldowns for quota exhaustion
-	agentCooldowns   map[string]time.Time // agent name -> expiry
-	agentCooldownsMu sync.RWMutex
+	// Per-agent circuit breakers, fed by every job type
+	circuits *circuitBreakers
 
 	// classify is the rate-limit/quota classifier. Defaults to
 	// agent.ClassifyLimit; tests substitute a stub by setting this
@@ -76,13 +77,12 @@ type WorkerPool struct {
 	retryBackoff time.Duration
 
 	// Test hooks for deterministic synchronization (nil in production)
-	testHookAfterSecondCheck    func() // Called after second runningJobs check, before second DB lookup
-	testHookCooldownLockUpgrade func() // Called between RUnlock and Lock in isAgentCoolingDown
+	testHookAfterSecondCheck func() // Called after second runningJobs check, before second DB lookup
 }
 
 // NewWorkerPool creates a new worker pool
 func NewWorkerPool(db *storage.DB, cfgGetter ConfigGetter, numWorkers int, broadcaster Broadcaster, errorLog *ErrorLog, activityLog *ActivityLog) *WorkerPool {
-	return &WorkerPool{
+	wp := &WorkerPool{
 		db:             db,
 		cfgGetter:      cfgGetter,
 		broadcaster:    broadcaster,
@@ -93,11 +93,12 @@ func NewWorkerPool(db *storage.DB, cfgGetter ConfigGetter, numWorkers int, broad
 		readyCh:        make(chan struct{}),
 		runningJobs:    make(map[int64]context.CancelFunc),
 		pendingCancels: make(map[int64]bool),
-		agentCooldowns: make(map[string]time.Time),
 		outputBuffers:  NewOutputBuffer(512*1024, 4*1024*1024), // 512KB/job, 4MB total
 		classify:       agent.ClassifyLimit,
 		retryBackoff:   2 * time.Second,
 	}
+	wp.circuits = newCircuitBreakers(wp.agentQuotaCooldown, wp.broadcastCircuit)
+	return wp
 }
 
 // Start begins the worker pool. Safe to call multiple times;
@@ -316,6 +317,9 @@ type preparedJobCheckout struct {
 func (wp *WorkerPool) prepareJobCheckout(
 	ctx context.Context, workerID string, job *storage.ReviewJob,
 ) (preparedJobCheckout, error) {
+	if job.JobType == storage.JobTypeResolution && job.Resolution != nil && job.Resolution.HeadSHA != "" {
+		return wp.createResolutionCheckout(ctx, workerID, job)
+	}
 	requiresCIWorktree, err := wp.jobRequiresCIExactCheckout(job)
 	if err != nil {
 		return preparedJobCheckout{}, err
@@ -419,6 +423,45 @@ func (wp *WorkerPool) createCIExactCheckout(
 	return wt.Dir, cleanup, nil
 }
 
+// createResolutionCheckout runs a resolution check in a detached worktree at
+// the head it checks, so the agent judges findings against that commit rather
+// than whatever the user's checkout holds now.
+func (wp *WorkerPool) createResolutionCheckout(
+	ctx context.Context, workerID string, job *storage.ReviewJob,
+) (preparedJobCheckout, error) {
+	head := job.Resolution.HeadSHA
+	unlock := lockGitMetadata(job.RepoPath)
+	// The check reads source files named in findings, so LFS assets are
+	// not pulled.
+	wt, err := createWorkerWorktree(ctx, job.RepoPath, head, gitworktree.Options{
+		Prefix:         fmt.Sprintf("roborev-resolution-%d-", job.ID),
+		InitSubmodules: true,
+	})
+	unlock()
+	if err != nil {
+		return preparedJobCheckout{}, fmt.Errorf("create resolution worktree: %w", err)
+	}
+	log.Printf("[%s] Resolution check %d: running agent in worktree %s (%s)",
+		workerID, job.ID, wt.Dir, gitpkg.ShortSHA(head))
+	cleanup := func() {
+		unlock := lockGitMetadata(job.RepoPath)
+		defer unlock()
+		if err := wt.Close(context.Background()); err != nil {
+			log.Printf("[%s] Warning: remove resolution worktree for job %d: %v",
+				workerID, job.ID, err)
+		}
+	}
+	return preparedJobCheckout{
+		promptRepoPath: job.RepoPath,
+		agentRepoPath:  wt.Dir,
+		snapshotTarget: prompt.SnapshotTarget{
+			RepoPath:       wt.Dir,
+			ConfigRepoPath: job.RepoPath,
+		},
+		cleanup: cleanup,
+	}, nil
+}
+
 func (wp *WorkerPool) worker(id int) {
 	defer wp.wg.Done()
 	workerID := fmt.Sprintf("worker-%d", id)
@@ -541,25 +584,29 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 	wp.registerRunningJob(job.ID, cancel)
 	defer wp.unregisterRunningJob(job.ID)
 
-	// Synthesis jobs route to their own handler before the cooldown gate: the
-	// all-failed and passthrough branches call no agent, so a synthesis-agent
-	// quota cooldown must not skip or fail them. Placing this after
+	// Synthesis jobs route to their own handler before the circuit gate: the
+	// all-failed and passthrough branches call no agent, so an open
+	// synthesis-agent circuit must not skip or fail them. Placing this after
 	// registerRunningJob keeps synthesis jobs cancellable.
 	if job.IsSynthesisJob() {
 		wp.processSynthesisJob(ctx, workerID, job)
 		return
 	}
 
-	// Skip immediately if the agent is in quota cooldown.
-	// Resolve alias so "claude" checks cooldown for "claude-code".
-	canonicalAgent := agent.CanonicalName(job.Agent)
-	if wp.isAgentCoolingDown(canonicalAgent) {
-		log.Printf("[%s] Agent %s in cooldown, skipping job %d",
-			workerID, canonicalAgent, job.ID)
-		wp.failCooldownOrFailover(workerID, job, canonicalAgent,
-			fmt.Sprintf("agent %s quota cooldown active", canonicalAgent))
+	// A review of a patch already reviewed with the same settings reuses
+	// that result. It runs before the circuit gate since no agent is called.
+	if wp.completeFromReviewCache(ctx, workerID, job, cfg) {
+		return
+	}
+
+	// Reroute before running when the agent's circuit is open. The key
+	// resolves aliases, so "claude" checks the circuit for "claude-code".
+	circuit := jobCircuitKey(job, job.Agent, job.Model)
+	if !wp.circuits.allow(circuit, job.ID) {
+		wp.rerouteOpenCircuit(workerID, job, circuit)
 		return
 	}
+	defer wp.circuits.release(circuit, job.ID)
 
 	// Classify jobs route through their own handler — no prompt building,
 	// no review path; the row gets converted in place.
@@ -582,10 +629,19 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 		return
 	}
 
+	// Secrets are masked from everything the agent is given. A broken
+	// custom pattern fails the job rather than sending content unmasked.
+	redactor, err := redact.ForRepo(checkout.promptRepoPath, cfg)
+	if err != nil {
+		log.Printf("[%s] Error configuring redaction: %v", workerID, err)
+		wp.failOrRetry(workerID, job, job.Agent, fmt.Sprintf("redaction: %v", err))
+		return
+	}
+
 	// Build the prompt (or use pre-stored prompt for task/compact jobs).
 	// Create a per-job builder with the snapshotted config so exclude
 	// patterns are resolved consistently.
-	pb := prompt.NewBuilderWithConfig(wp.db, cfg).WithContext(ctx).ForRepo(checkout.promptRepoPath, job.RepoID)
+	pb := prompt.NewBuilderWithConfig(wp.db, cfg).WithContext(ctx).ForRepo(checkout.promptRepoPath, job.RepoID).WithRedactor(redactor)
 	// CI jobs normally carry a prebuilt prompt whose kata context was gated
 	// on PR author trust by the poller. This rebuild fallback has no author
 	// information, so it must not resolve kata refs from fork-controlled
@@ -593,9 +649,29 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 	if !job.IsCIReview() {
 		pb = pb.WithKataClient(kata.NewCLIClient(checkout.promptRepoPath))
 	}
+	// A chunked review member covers only its file group.
+	pb = pb.WithPaths(memberPaths(job))
+	// An incremental re-review sees the branch's last review and its
+	// still-open reviews so it can report which ones the new commits resolve.
+	if job.Incremental != nil && !job.UsesStoredPrompt() {
+		incrementalContext, incErr := incrementalReviewContext(wp.db, job.Incremental)
+		if incErr != nil {
+			log.Printf("[%s] Warning: incremental context for job %d: %v", workerID, job.ID, incErr)
+		}
+		pb = pb.WithAdditionalContext(incrementalContext)
+	}
 	if err := pb.CleanupStaleSnapshots(prompt.DefaultStaleSnapshotAge); err != nil {
 		log.Printf("[%s] Warning: cleanup stale snapshots for job %d: %v", workerID, job.ID, err)
 	}
+	if job.IsFixJob() && job.Prompt == "" && job.ParentJobID != nil {
+		// A fix enqueued behind its unfinished parent builds its prompt from
+		// the parent's review now that the parent is done.
+		if err := wp.buildDeferredFixPrompt(job); err != nil {
+			log.Printf("[%s] Error building fix prompt for job %d: %v", workerID, job.ID, err)
+			wp.failOrRetry(workerID, job, job.Agent, fmt.Sprintf("build fix prompt: %v", err))
+			return
+		}
+	}
 	var reviewPrompt string
 	var promptToPersist string
 	storedPromptValue := job.Prompt
@@ -610,7 +686,7 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 			ctx, checkout.promptRepoPath, cfg, job.ReviewType,
 		)
 		reviewPrompt, cleanup, err = preparePrebuiltPrompt(
-			ctx, checkout.promptRepoPath, checkout.snapshotTarget, job, reviewPrompt, excludes,
+			ctx, checkout.promptRepoPath, checkout.snapshotTarget, job, reviewPrompt, excludes, redactor,
 		)
 		if cleanup != nil {
 			defer cleanup()
@@ -691,6 +767,16 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 	if promptToPersist == "" {
 		promptToPersist = reviewPrompt
 	}
+	reviewPrompt = redactor.String(reviewPrompt)
+	// Counted before promptToPersist, which repeats what the agent sees.
+	redactions := redactor.Count()
+	promptToPersist = redactor.String(promptToPersist)
+	if redactions > 0 {
+		log.Printf("[%s] Masked %d secret(s) in job %d prompt", workerID, redactions, job.ID)
+	}
+	if err := wp.db.SaveJobRedactions(job.ID, redactions); err != nil {
+		log.Printf("[%s] Error saving redaction count: %v", workerID, err)
+	}
 
 	// Save the prompt so it can be viewed while job is running
 	if err := wp.db.SaveJobPrompt(job.ID, promptToPersist); err != nil {
@@ -746,6 +832,13 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 	if agentName != job.Agent {
 		log.Printf("[%s] Agent %s not available, using %s", workerID, job.Agent, agentName)
 	}
+	// A substitute agent's review must not be cached under the requested
+	// agent's key.
+	if reviewCacheable(job) && agentName != agent.CanonicalName(job.Agent) {
+		if err := wp.db.SetJobCacheKey(job.ID, ""); err != nil {
+			log.Printf("[%s] Warning: clear cache key for job %d: %v", workerID, job.ID, err)
+		}
+	}
 
 	// Enforce the final submission size after all prompt transformations.
 	// Oversized prompts are deterministic and should never be sent to any
@@ -861,6 +954,7 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 			})
 			// Member canceled is terminal — release the panel synthesis.
 			wp.releaseIfPanelMember(job)
+			wp.settleJobDependencies()
 			return // Job already marked as canceled in DB, nothing more to do
 		}
 		if errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded {
@@ -878,9 +972,11 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 		wp.failOrRetryAgent(workerID, job, agentName, fmt.Sprintf("agent: %v", err))
 		return
 	}
+	wp.recordAgentSuccess(job, agentName, job.Model)
 
 	// For fix jobs, capture the patch from the worktree. Patch capture
-	// failures are fatal — a fix job without a patch is useless.
+	// failures are fatal — a fix job without a patch is useless. Edits that
+	// fail the repo's verify_command never become a patch.
 	var fixPatch string
 	if job.IsFixJob() {
 		var patchErr error
@@ -895,6 +991,18 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 			wp.failOrRetry(workerID, job, agentName, "agent produced no file changes")
 			return
 		}
+		repaired, ok := wp.verifyFixJob(ctx, workerID, job, a, agentName, fixWorktree.Dir, sessionWriter, agentOutput)
+		if !ok {
+			return
+		}
+		if repaired {
+			fixPatch, patchErr = fixWorktree.CapturePatch(ctx)
+			if patchErr != nil {
+				log.Printf("[%s] Fix job %d: patch capture failed: %v", workerID, job.ID, patchErr)
+				wp.failOrRetry(workerID, job, agentName, fmt.Sprintf("patch capture: %v", patchErr))
+				return
+			}
+		}
 		log.Printf("[%s] Fix job %d: captured patch (%d bytes)", workerID, job.ID, len(fixPatch))
 	}
 
@@ -943,12 +1051,20 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 		}
 	}
 
+	// For resolution checks, close the reviews the agent reported fixed.
+	if job.JobType == storage.JobTypeResolution {
+		wp.applyResolutionCheck(workerID, job, output)
+	}
+
 	wp.autoClosePassingReview(workerID, job, output)
+	wp.closeResolvedPriorReviews(workerID, job, output)
+	wp.maybeEnqueueResolutionCheck(workerID, job)
 
 	wp.captureTokenUsageForSession(context.Background(), workerID, job, sessionWriter.SessionID())
 
 	// Member done — release the panel synthesis once all members are terminal.
 	wp.releaseIfPanelMember(job)
+	wp.settleJobDependencies()
 
 	log.Printf("[%s] Completed job %d %s %sreview/%s",
 		workerID, job.ID, job.RepoName, rtTag, agentName)
@@ -968,7 +1084,7 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 
 	// Broadcast completion event
 	verdict := storage.ParseVerdict(output)
-	wp.broadcaster.Broadcast(Event{
+	completed := Event{
 		Type:         "review.completed",
 		TS:           time.Now(),
 		JobID:        job.ID,
@@ -981,7 +1097,9 @@ func (wp *WorkerPool) processJob(workerID string, job *storage.ReviewJob) {
 		Verdict:      verdict,
 		Findings:     output,
 		WorktreePath: eventWorktreePath,
-	})
+	}
+	wp.broadcaster.Broadcast(completed)
+	wp.broadcastJobFamilyEvent(job, completed)
 }
 
 func shouldAppendReviewJobLog(job *storage.ReviewJob) bool {
@@ -1022,6 +1140,21 @@ func applyCodexReviewSettings(a agent.Agent, job *storage.ReviewJob, cfg *config
 	return a
 }
 
+// buildDeferredFixPrompt fills in the prompt of a fix job that was enqueued
+// before its parent review finished.
+func (wp *WorkerPool) buildDeferredFixPrompt(job *storage.ReviewJob) error {
+	parent, err := wp.db.GetJobByID(*job.ParentJobID)
+	if err != nil {
+		return fmt.Errorf("get parent job %d: %w", *job.ParentJobID, err)
+	}
+	fixPrompt, err := buildParentFixPrompt(wp.db, parent, "", job.MinSeverity)
+	if err != nil {
+		return err
+	}
+	job.Prompt = fixPrompt
+	return nil
+}
+
 // failOrRetry attempts to retry the job, or marks it as failed if max retries reached.
 // This is used for non-agent errors (e.g., prompt build failures) where switching agents won't help.
 func (wp *WorkerPool) failOrRetry(workerID string, job *storage.ReviewJob, agentName string, errorMsg string) {
@@ -1052,26 +1185,14 @@ func (wp *WorkerPool) finalErrorMsg(agentName, errorMsg string, agentError bool)
 }
 
 func (wp *WorkerPool) failOrRetryInner(workerID string, job *storage.ReviewJob, agentName string, errorMsg string, agentError bool) {
-	// Quota and session-limit errors skip retries entirely — cool down
-	// the agent and attempt failover or fail. Behavior matches the
-	// original isQuotaError branch; classification now lives in
-	// internal/agent (ClassifyLimit) so the CLI fix loop can share it.
+	// Quota and session-limit errors skip retries entirely — the agent's
+	// circuit opens and the job fails over or fails. Classification lives
+	// in internal/agent (ClassifyLimit) so the CLI fix loop can share it.
 	if agentError {
-		cls := wp.classify(agent.CanonicalName(agentName), errorMsg)
+		cls := wp.recordAgentFailure(job, agentName, job.Model, errorMsg)
 		switch cls.Kind {
 		case agent.LimitKindQuota, agent.LimitKindSession:
-			dur := wp.agentQuotaCooldown()
-			if cls.CooldownFor > 0 && cls.CooldownFor < dur {
-				dur = cls.CooldownFor
-			}
-			if !cls.ResetAt.IsZero() {
-				if until := time.Until(cls.ResetAt); until > 0 && until < dur {
-					dur = until
-				}
-			}
-			wp.cooldownAgent(agentName, time.Now().Add(dur))
-			log.Printf("[%s] Agent %s limit exhausted, cooldown %v",
-				workerID, agentName, dur)
+			log.Printf("[%s] Agent %s limit exhausted", workerID, agentName)
 			prefix := review.QuotaErrorPrefix
 			label := "quota"
 			if cls.Kind == agent.LimitKindSession {
@@ -1115,21 +1236,10 @@ func (wp *WorkerPool) failOrRetryInner(workerID string, job *storage.ReviewJob,
 		log.Printf("[%s] Job %d %s queued for retry (%d/%d)",
 			workerID, job.ID, job.RepoName, retryCount, maxRetries)
 	} else {
-		// Retries exhausted -- attempt failover to backup agent if this is an agent error
-		if agentError {
-			backupAgent := wp.resolveBackupAgent(job)
-			if backupAgent != "" && !wp.isAgentCoolingDown(backupAgent) {
-				backupModel := wp.resolveBackupModel(job)
-				failedOver, foErr := wp.db.FailoverJob(job.ID, workerID, backupAgent, backupModel)
-				if foErr != nil {
-					log.Printf("[%s] Error attempting failover for job %d: %v", workerID, job.ID, foErr)
-				}
-				if failedOver {
-					log.Printf("[%s] Job %d failing over from %s to %s after %d retries: %s",
-						workerID, job.ID, agentName, backupAgent, maxRetries, errorMsg)
-					return
-				}
-			}
+		// Retries exhausted -- attempt failover down the fallback chain if this is an agent error
+		if agentError && wp.failoverToFallback(workerID, job, agentName,
+			fmt.Sprintf("after %d retries", maxRetries), errorMsg) {
+			return
 		}
 
 		// No backup or failover failed -- mark as failed
@@ -1153,19 +1263,8 @@ func (wp *WorkerPool) failoverOrFailNonRetryableAgent(
 	workerID string, job *storage.ReviewJob,
 	agentName, errorMsg string,
 ) {
-	backupAgent := wp.resolveBackupAgent(job)
-	if backupAgent != "" && !wp.isAgentCoolingDown(backupAgent) {
-		backupModel := wp.resolveBackupModel(job)
-		failedOver, err := wp.db.FailoverJob(job.ID, workerID, backupAgent, backupModel)
-		if err != nil {
-			log.Printf("[%s] Error attempting failover for job %d: %v",
-				workerID, job.ID, err)
-		}
-		if failedOver {
-			log.Printf("[%s] Job %d failing over from %s to %s (non-retryable): %s",
-				workerID, job.ID, agentName, backupAgent, errorMsg)
-			return
-		}
+	if wp.failoverToFallback(workerID, job, agentName, "non-retryable", errorMsg) {
+		return
 	}
 
 	if updated, err := wp.db.FailJob(job.ID, workerID, errorMsg); err != nil {
@@ -1192,76 +1291,137 @@ func failoverWorkflow(job *storage.ReviewJob) string {
 	return config.WorkflowForReviewType(job.ReviewType)
 }
 
-// resolveBackupAgent determines the backup agent for a job. An explicit
-// per-job backup (job.BackupAgent) is preferred and returned canonicalized.
-// Otherwise it falls back to the workflow config, returning the canonicalized
-// backup agent name, or "" if none is available or it's the same as the job's
-// current agent.
-func (wp *WorkerPool) resolveBackupAgent(job *storage.ReviewJob) string {
-	// An explicit per-job backup wins, for reliability: the enqueuer (e.g. a CI
-	// panel synthesis) chose this failover deliberately. Canonicalize the alias
+// fallbackTarget is one agent and model a job can fail over to.
+type fallbackTarget struct {
+	Agent string
+	Model string
+}
+
+// fallbackChain returns the ordered agents a job may move to when its
+// current agent fails or its circuit is open. An explicit per-job chain wins:
+// a panel or CI member's fallbacks, else the job's stored backup agent.
+// Otherwise the chain is every distinct installed fallback configured for the
+// job's workflow, in backup priority order. Entries are distinct by agent and
+// model, so one agent may appear with several models. A job already running
+// on a chain entry only moves further down, so failover never cycles.
+func (wp *WorkerPool) fallbackChain(job *storage.ReviewJob) []fallbackTarget {
+	// An explicit per-job chain wins, for reliability: the enqueuer (e.g. a CI
+	// panel synthesis) chose this failover deliberately. Canonicalize aliases
 	// (e.g. "claude" -> "claude-code") via the registry; fall back to the raw
 	// value if the name is unknown so the override is never silently dropped.
-	// Gate on PRESENCE only (job.BackupAgent != "") so resolveBackupModel agrees
-	// on whether the stored backup is in play. Note: agent.Get resolves from the
-	// registry, not PATH, so this does not require local installation.
-	if job.BackupAgent != "" {
-		if resolved, err := agent.Get(job.BackupAgent); err == nil {
-			return resolved.Name()
+	// Stored models are used even if empty: never fall through to the
+	// workflow backup model, which is resolved for a different agent. Note:
+	// agent.Get resolves from the registry, not PATH, so this does not require
+	// local installation.
+	explicit := config.ParseFallbacks(memberFallbacks(job))
+	if len(explicit) == 0 && job.BackupAgent != "" {
+		explicit = []config.FallbackTarget{{Agent: job.BackupAgent, Model: job.BackupModel}}
+	}
+	if len(explicit) > 0 {
+		var chain []fallbackTarget
+		for _, t := range explicit {
+			name := t.Agent
+			if resolved, err := agent.Get(t.Agent); err == nil {
+				name = resolved.Name()
+			}
+			if !slices.ContainsFunc(chain, func(c fallbackTarget) bool { return c.Agent == name && c.Model == t.Model }) {
+				chain = append(chain, fallbackTarget{Agent: name, Model: t.Model})
+			}
 		}
-		return job.BackupAgent
+		for i, t := range chain {
+			if agent.CanonicalName(t.Agent) == agent.CanonicalName(job.Agent) && t.Model == job.Model {
+				return chain[i+1:]
+			}
+		}
+		return chain
 	}
 	if job.JobType == storage.JobTypeSynthesis {
-		return ""
+		return nil
 	}
 	cfg := wp.cfgGetter.Config()
 	resolution, err := agent.ResolveWorkflowConfig(
 		"", job.RepoPath, cfg, failoverWorkflow(job), "",
 	)
 	if err != nil {
-		return ""
+		return nil
 	}
-	backup := resolution.BackupAgent
-	if backup == "" {
-		return ""
+	var chain []fallbackTarget
+	for _, t := range config.ResolveBackupChainForWorkflowFromConfig(
+		resolution.RepoConfig, cfg, resolution.Workflow,
+	) {
+		// Resolve exactly the configured backup using the config-aware path so
+		// command overrides and configured ACP aliases participate in failover
+		// without falling through to unrelated agents.
+		resolved, err := agent.GetAvailableExactWithConfig(job.RepoPath, t.Agent, cfg)
+		if err != nil {
+			continue
+		}
+		if !slices.ContainsFunc(chain, func(c fallbackTarget) bool {
+			return resolution.AgentMatches(c.Agent, resolved.Name()) && c.Model == t.Model
+		}) {
+			chain = append(chain, fallbackTarget{Agent: resolved.Name(), Model: t.Model})
+		}
 	}
-	// Resolve exactly the configured backup using the config-aware path so
-	// command overrides and configured ACP aliases participate in failover
-	// without falling through to unrelated agents.
-	resolved, err := agent.GetAvailableExactWithConfig(job.RepoPath, backup, cfg)
-	if err != nil {
-		return ""
+	for i, t := range chain {
+		if resolution.AgentMatches(t.Agent, job.Agent) && t.Model == job.Model {
+			return chain[i+1:]
+		}
 	}
-	if resolution.AgentMatches(resolved.Name(), job.Agent) {
-		return ""
+	return chain
+}
+
+// mayFailover reports whether a job may move to another agent. CI reviews
+// keep their configured panel member unless the member carries an explicit
+// fallbacks chain.
+func mayFailover(job *storage.ReviewJob) bool {
+	return !job.IsCIReview() || len(memberFallbacks(job)) > 0
+}
+
+// resolveBackupAgent returns the first agent in the job's fallback chain,
+// canonicalized, or "" if the chain is empty.
+func (wp *WorkerPool) resolveBackupAgent(job *storage.ReviewJob) string {
+	if chain := wp.fallbackChain(job); len(chain) > 0 {
+		return chain[0].Agent
 	}
-	return resolved.Name()
+	return ""
 }
 
-// resolveBackupModel determines the backup model for a job from config.
-// Returns the configured backup model, or "" if none is set.
+// resolveBackupModel returns the model for the first agent in the job's
+// fallback chain, or "" if none is set.
 func (wp *WorkerPool) resolveBackupModel(job *storage.ReviewJob) string {
-	// Stored backup agent present => use the stored model, even if empty. Never
-	// fall through to the workflow backup model, which is resolved for a
-	// different agent (the user's F7 guardrail). Same presence gate as
-	// resolveBackupAgent so the two never disagree.
-	if job.BackupAgent != "" {
-		return job.BackupModel
-	}
-	if job.JobType == storage.JobTypeSynthesis {
-		return ""
+	if chain := wp.fallbackChain(job); len(chain) > 0 {
+		return chain[0].Model
 	}
-	cfg := wp.cfgGetter.Config()
-	resolution, err := agent.ResolveWorkflowConfig(
-		"", job.RepoPath, cfg, failoverWorkflow(job), "",
-	)
-	if err != nil {
-		return ""
+	return ""
+}
+
+// failoverToFallback requeues the job on the first agent in its fallback
+// chain whose circuit is not blocked. It returns false when no agent is
+// available or the job could not be switched.
+func (wp *WorkerPool) failoverToFallback(
+	workerID string, job *storage.ReviewJob,
+	agentName, label, errorMsg string,
+) bool {
+	for _, t := range wp.fallbackChain(job) {
+		if wp.circuits.blocked(jobCircuitKey(job, t.Agent, t.Model)) {
+			continue
+		}
+		failedOver, err := wp.db.FailoverJob(job.ID, workerID, t.Agent, t.Model)
+		if err != nil {
+			log.Printf("[%s] Error attempting failover for job %d: %v",
+				workerID, job.ID, err)
+		}
+		if failedOver {
+			log.Printf("[%s] Job %d failing over from %s to %s (%s): %s",
+				workerID, job.ID, agentName, t.Agent, label, errorMsg)
+		}
+		return failedOver
 	}
-	return resolution.BackupModel()
+	return false
 }
 
-// broadcastFailed sends a review.failed event for a job
+// broadcastFailed sends a review.failed event for a job, followed by
+// fix.failed or panel.failed for fix and synthesis jobs.
 func (wp *WorkerPool) broadcastFailed(job *storage.ReviewJob, agentName, errorMsg string) {
 	wtPath := ""
 	if job.WorktreePath != "" {
@@ -1269,7 +1429,7 @@ func (wp *WorkerPool) broadcastFailed(job *storage.ReviewJob, agentName, errorMs
 			wtPath = job.WorktreePath
 		}
 	}
-	wp.broadcaster.Broadcast(Event{
+	failed := Event{
 		Type:         "review.failed",
 		TS:           time.Now(),
 		JobID:        job.ID,
@@ -1281,11 +1441,15 @@ func (wp *WorkerPool) broadcastFailed(job *storage.ReviewJob, agentName, errorMs
 		Agent:        agentName,
 		Error:        errorMsg,
 		WorktreePath: wtPath,
-	})
+	}
+	wp.broadcaster.Broadcast(failed)
+	wp.broadcastJobFamilyEvent(job, failed)
 	// broadcastFailed is the terminal-failure chokepoint (never reached on
 	// retry/failover), so a member that finally fails releases its panel's
 	// synthesis here. No-op for non-member and synthesis jobs (role gate).
 	wp.releaseIfPanelMember(job)
+	// It is the same chokepoint for canceling the failed job's dependents.
+	wp.settleJobDependencies()
 }
 
 // memberInstructionSuffix returns the trusted reviewer-instruction block to
@@ -1305,6 +1469,32 @@ func memberInstructionSuffix(job *storage.ReviewJob) string {
 	)
 }
 
+// memberFallbacks returns a panel or CI member's explicit fallbacks chain,
+// or nil for other jobs.
+func memberFallbacks(job *storage.ReviewJob) []string {
+	if job.PanelRole != storage.PanelRoleMember || job.PanelMemberConfigJSON == "" {
+		return nil
+	}
+	var m config.ResolvedMember
+	if json.Unmarshal([]byte(job.PanelMemberConfigJSON), &m) != nil {
+		return nil
+	}
+	return m.Fallbacks
+}
+
+// memberPaths returns the file group a chunked review member covers, or nil
+// for every other job.
+func memberPaths(job *storage.ReviewJob) []string {
+	if job.PanelRole != storage.PanelRoleMember || job.PanelMemberConfigJSON == "" {
+		return nil
+	}
+	var m config.ResolvedMember
+	if json.Unmarshal([]byte(job.PanelMemberConfigJSON), &m) != nil {
+		return nil
+	}
+	return m.Paths
+}
+
 func resolveJobTimeoutDuration(job *storage.ReviewJob, defaultMinutes int) time.Duration {
 	defaultDuration := time.Duration(defaultMinutes) * time.Minute
 	if job.PanelRole != storage.PanelRoleMember || job.PanelMemberConfigJSON == "" {
@@ -1338,23 +1528,25 @@ func (wp *WorkerPool) captureTokenUsageForSession(
 ) {
 	// Only fetch agentsview usage for fresh sessions (where we captured a new
 	// session ID). Resumed-session agentsview totals are cumulative across
-	// turns, but Codex job-log turn.completed usage belongs to this job's
-	// stream and is safe to parse below.
+	// turns, but usage parsed from the job log belongs to this job's stream
+	// and is safe to use. Job-log usage without a cost is priced from the
+	// configured price list; an agentsview cost still takes precedence.
 	wasResumed := job.SessionID != "" && capturedSession == job.SessionID
 
+	cfg := wp.cfgGetter.Config()
 	var usage *tokens.Usage
-	logUsage, logErr := tokens.ParseCodexUsageFile(JobLogPath(job.ID))
+	logUsage, logErr := tokens.ParseUsageFile(JobLogPath(job.ID))
 	if logErr != nil {
 		log.Printf("[%s] Warning: parse token usage from job log for job %d: %v",
 			workerID, job.ID, logErr)
 	} else if logUsage != nil {
+		cfg.Cost.ResolvedPricing().EstimateCost(logUsage, backfill.UsageModel(logUsage, *job))
 		usage = logUsage
 	}
 
 	if capturedSession != "" && !wasResumed {
 		fetcher := wp.tokenUsageFetcher
 		if fetcher == nil {
-			cfg := wp.cfgGetter.Config()
 			fetcher = func(ctx context.Context, sessionID string) (*tokens.Usage, error) {
 				return tokens.FetchForSessionWithConfig(
 					ctx, sessionID,
@@ -1424,86 +1616,118 @@ func isContextWindowError(errMsg string) bool {
 	return false
 }
 
-// cooldownAgent records the cooldown expiry for an agent, bounded by the
-// current configured maximum. Later provider hints may shorten an existing
-// cooldown; a subsequent quota error can extend it again, but never past config.
-func (wp *WorkerPool) cooldownAgent(name string, until time.Time) {
-	name = agent.CanonicalName(name)
-	until = wp.clampAgentCooldownExpiry(until, time.Now())
-	wp.agentCooldownsMu.Lock()
-	defer wp.agentCooldownsMu.Unlock()
-	wp.agentCooldowns[name] = until
+// recordAgentFailure feeds an agent error into the agent's circuit, records
+// the failed job attempt, and returns the error's limit classification.
+// Quota and session limits open the circuit for the provider's reset time,
+// capped by agent_quota_cooldown; other failures count toward the circuit's
+// failure rate. Context-window errors say nothing about the agent's health
+// and do not count.
+func (wp *WorkerPool) recordAgentFailure(
+	job *storage.ReviewJob, agentName, model, errorMsg string,
+) agent.LimitClassification {
+	key := jobCircuitKey(job, agentName, model)
+	cls := wp.classify(key.Agent, errorMsg)
+	wp.recordAttempt(job, agentName, model, storage.AttemptFailed,
+		attemptFailureClass(cls, errorMsg), errorMsg)
+	switch cls.Kind {
+	case agent.LimitKindQuota, agent.LimitKindSession:
+		dur := wp.agentQuotaCooldown()
+		if cls.CooldownFor > 0 && cls.CooldownFor < dur {
+			dur = cls.CooldownFor
+		}
+		if !cls.ResetAt.IsZero() {
+			if until := time.Until(cls.ResetAt); until > 0 && until < dur {
+				dur = until
+			}
+		}
+		label := "quota limit"
+		if cls.Kind == agent.LimitKindSession {
+			label = "session limit"
+		}
+		wp.circuits.trip(key, time.Now().Add(dur), label+": "+logExcerpt(errorMsg))
+	default:
+		if !isContextWindowError(errorMsg) {
+			wp.circuits.record(key, true, logExcerpt(errorMsg))
+		}
+	}
+	return cls
 }
 
-// isAgentCoolingDown returns true if the agent is currently in a
-// quota cooldown period. Expired entries are cleaned up eagerly.
-func (wp *WorkerPool) isAgentCoolingDown(name string) bool {
-	name = agent.CanonicalName(name)
-	now := time.Now()
-	wp.agentCooldownsMu.RLock()
-	expiry, ok := wp.agentCooldowns[name]
-	if !ok {
-		wp.agentCooldownsMu.RUnlock()
-		return false
-	}
-	if now.After(expiry) {
-		wp.agentCooldownsMu.RUnlock()
-		if wp.testHookCooldownLockUpgrade != nil {
-			wp.testHookCooldownLockUpgrade()
-		}
-		wp.agentCooldownsMu.Lock()
-		cooling := wp.clampActiveCooldownLocked(name)
-		wp.agentCooldownsMu.Unlock()
-		return cooling
-	}
-	clampedExpiry := wp.clampAgentCooldownExpiry(expiry, now)
-	if clampedExpiry.Before(expiry) {
-		wp.agentCooldownsMu.RUnlock()
-		wp.agentCooldownsMu.Lock()
-		cooling := wp.clampActiveCooldownLocked(name)
-		wp.agentCooldownsMu.Unlock()
-		return cooling
-	}
-	wp.agentCooldownsMu.RUnlock()
-	return true
+// recordAgentSuccess feeds a successful agent run into the agent's circuit
+// and records the job attempt.
+func (wp *WorkerPool) recordAgentSuccess(job *storage.ReviewJob, agentName, model string) {
+	wp.circuits.record(jobCircuitKey(job, agentName, model), false, "")
+	wp.recordAttempt(job, agentName, model, storage.AttemptSucceeded, "", "")
 }
 
-// clampActiveCooldownLocked rechecks and clamps a cooldown under the write lock.
-// The caller must hold agentCooldownsMu for writing.
-func (wp *WorkerPool) clampActiveCooldownLocked(name string) bool {
-	expiry, ok := wp.agentCooldowns[name]
-	if !ok {
-		return false
-	}
-	now := time.Now()
-	if now.After(expiry) {
-		delete(wp.agentCooldowns, name)
-		return false
-	}
-	clampedExpiry := wp.clampAgentCooldownExpiry(expiry, now)
-	if clampedExpiry.Before(expiry) {
-		wp.agentCooldowns[name] = clampedExpiry
+// recordAttempt appends an agent attempt to the job's history. Recording is
+// best-effort: a failure is logged and never affects the job.
+func (wp *WorkerPool) recordAttempt(
+	job *storage.ReviewJob, agentName, model, outcome, class, errorMsg string,
+) {
+	err := wp.db.RecordJobAttempt(storage.JobAttempt{
+		JobID:        job.ID,
+		Agent:        agent.CanonicalName(agentName),
+		Model:        model,
+		Outcome:      outcome,
+		FailureClass: class,
+		Error:        logExcerpt(errorMsg),
+		StartedAt:    job.StartedAt,
+	})
+	if err != nil {
+		log.Printf("Error recording attempt for job %d: %v", job.ID, err)
 	}
-	return true
 }
 
-func (wp *WorkerPool) clampAgentCooldownExpiry(expiry, now time.Time) time.Time {
-	maxExpiry := now.Add(wp.agentQuotaCooldown())
-	if expiry.After(maxExpiry) {
-		return maxExpiry
+// attemptFailureClass names the failure class recorded for a failed agent
+// attempt.
+func attemptFailureClass(cls agent.LimitClassification, errorMsg string) string {
+	switch cls.Kind {
+	case agent.LimitKindQuota:
+		return storage.AttemptFailureQuota
+	case agent.LimitKindSession:
+		return storage.AttemptFailureSessionLimit
+	case agent.LimitKindTransient:
+		return storage.AttemptFailureOutage
+	}
+	switch {
+	case isContextWindowError(errorMsg):
+		return storage.AttemptFailureContextWindow
+	case strings.HasPrefix(errorMsg, agentTimeoutErrorPrefix):
+		return storage.AttemptFailureTimeout
+	default:
+		return storage.AttemptFailureError
 	}
-	return expiry
 }
 
-func (wp *WorkerPool) failCooldownOrFailover(
-	workerID string, job *storage.ReviewJob,
-	agentName, errorMsg string,
-) {
-	wp.failoverOrFailWithPrefix(workerID, job, agentName, errorMsg, review.QuotaErrorPrefix, "quota")
+// rerouteOpenCircuit handles a job whose agent circuit is blocked before it
+// runs: it moves the job down its fallback chain, waits out a half-open probe
+// run by another job, or fails the job as a quota skip. CI reviews never fail
+// over, preserving their configured panel member.
+func (wp *WorkerPool) rerouteOpenCircuit(workerID string, job *storage.ReviewJob, key circuitKey) {
+	errorMsg := fmt.Sprintf("agent %s circuit open", key.Agent)
+	if key.Provider != "" {
+		errorMsg += fmt.Sprintf(" (provider %s)", key.Provider)
+	}
+	log.Printf("[%s] Agent %s circuit open, rerouting job %d", workerID, key, job.ID)
+	wp.recordAttempt(job, key.Agent, job.Model, storage.AttemptSkipped, storage.AttemptFailureCircuitOpen, errorMsg)
+	if mayFailover(job) && wp.failoverToFallback(workerID, job, key.Agent, "circuit open", errorMsg) {
+		return
+	}
+	if wp.circuits.state(key) == CircuitHalfOpen {
+		deferred, err := wp.db.DeferJob(job.ID, workerID, time.Now().Add(circuitProbeDefer))
+		if err != nil {
+			log.Printf("[%s] Error deferring job %d: %v", workerID, job.ID, err)
+		}
+		if deferred {
+			return
+		}
+	}
+	wp.failJobWithPrefix(workerID, job, key.Agent, errorMsg, review.QuotaErrorPrefix, "quota")
 }
 
-// failoverOrFail attempts failover to a backup agent for non-CI jobs. CI
-// reviews must preserve their configured panel member and let the CI retry
+// failoverOrFail attempts failover down the fallback chain for non-CI jobs.
+// CI reviews must preserve their configured panel member and let the CI retry
 // schedule handle quota/session availability failures.
 func (wp *WorkerPool) failoverOrFail(
 	workerID string, job *storage.ReviewJob,
@@ -1516,26 +1740,9 @@ func (wp *WorkerPool) failoverOrFailWithPrefix(
 	workerID string, job *storage.ReviewJob,
 	agentName, errorMsg, prefix, label string,
 ) {
-	backupAgent := ""
-	if !job.IsCIReview() {
-		backupAgent = wp.resolveBackupAgent(job)
-	}
-	if backupAgent != "" && !wp.isAgentCoolingDown(backupAgent) {
-		backupModel := wp.resolveBackupModel(job)
-		failedOver, err := wp.db.FailoverJob(
-			job.ID, workerID, backupAgent, backupModel,
-		)
-		if err != nil {
-			log.Printf("[%s] Error attempting failover for job %d: %v",
-				workerID, job.ID, err)
-		}
-		if failedOver {
-			log.Printf("[%s] Job %d failing over from %s to %s (%s): %s",
-				workerID, job.ID, agentName, backupAgent, label, errorMsg)
-			return
-		}
+	if mayFailover(job) && wp.failoverToFallback(workerID, job, agentName, label, errorMsg) {
+		return
 	}
-
 	wp.failJobWithPrefix(workerID, job, agentName, errorMsg, prefix, label)
 }
 
@@ -1572,11 +1779,12 @@ func prefixedFailure(prefix, msg string) string {
 func preparePrebuiltPrompt(
 	ctx context.Context, repoPath string, snapshotTarget prompt.SnapshotTarget,
 	job *storage.ReviewJob, reviewPrompt string, excludes []string,
+	redactor *redact.Redactor,
 ) (string, func(), error) {
 	if !strings.Contains(reviewPrompt, prompt.DiffFilePathPlaceholder) {
 		return reviewPrompt, nil, nil
 	}
-	builder := prompt.NewBuilder(nil).WithContext(ctx).ForRepo(repoPath, job.RepoID)
+	builder := prompt.NewBuilder(nil).WithContext(ctx).ForRepo(repoPath, job.RepoID).WithRedactor(redactor)
 	diffFile, cleanup, err := builder.WriteDiffSnapshotTarget(job.GitRef, excludes, snapshotTarget)
 	if err != nil {
 		return "", nil, fmt.Errorf("prepare diff snapshot for prebuilt prompt: %w", err)
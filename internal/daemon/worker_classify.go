@@ -166,8 +166,21 @@ func (wp *WorkerPool) processClassifyJob(ctx context.Context, workerID string, j
 		if !ok {
 			return false, "", selectedName, fmt.Errorf("classify_agent %q lost SchemaAgent capability after WithReasoning/WithModel", name)
 		}
+		// An open circuit sends the classifier straight to its backup.
+		circuit := jobCircuitKey(job, selectedName, model)
+		if wp.circuits.blocked(circuit) {
+			err := fmt.Errorf("agent %s circuit open", circuit)
+			wp.recordAttempt(job, selectedName, model, storage.AttemptSkipped,
+				storage.AttemptFailureCircuitOpen, err.Error())
+			return false, "", selectedName, err
+		}
 		wp.markAgentInvoked(workerID, job, sa)
 		yes, reason, err := newClassifierAdapter(sa, maxBytes, jobLog).Decide(classifyCtx, in)
+		if err != nil {
+			wp.recordAgentFailure(job, selectedName, model, err.Error())
+		} else {
+			wp.recordAgentSuccess(job, selectedName, model)
+		}
 		return yes, reason, selectedName, err
 	}
 
@@ -0,0 +1,279 @@
+package daemon
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// maxWorkflowSteps caps the steps one workflow submission may enqueue.
+const maxWorkflowSteps = 50
+
+// settleJobDependencies releases dependents whose prerequisites all succeeded
+// and cancels those with a failed or canceled prerequisite, broadcasting
+// review.canceled for each. Called on every terminal transition; idempotent.
+func (wp *WorkerPool) settleJobDependencies() {
+	canceled, err := wp.db.SettleJobDependencies()
+	if err != nil {
+		log.Printf("settle job dependencies: %v", err)
+	}
+	for _, id := range canceled {
+		job, err := wp.db.GetJobByID(id)
+		if err != nil {
+			continue
+		}
+		log.Printf("Job %d canceled: %s", id, job.Error)
+		wp.broadcaster.Broadcast(Event{
+			Type:     "review.canceled",
+			TS:       time.Now(),
+			JobID:    job.ID,
+			JobUUID:  job.UUID,
+			Repo:     job.RepoPath,
+			RepoName: job.RepoName,
+			SHA:      job.GitRef,
+			Branch:   job.HookBranch(),
+			Agent:    job.Agent,
+			Error:    job.Error,
+		})
+	}
+}
+
+// settleJobDependencies is the server-side entry point for prerequisite state
+// changes made outside the worker pool (enqueue, cancel, rerun, sweep).
+func (s *Server) settleJobDependencies() {
+	if s.workerPool != nil {
+		s.workerPool.settleJobDependencies()
+	}
+}
+
+// validateDependsOn checks that every prerequisite of an enqueue exists and
+// can still succeed. It returns a 400 response otherwise.
+func (s *Server) validateDependsOn(dependsOn []int64) *RawJSONOutput {
+	seen := make(map[int64]bool, len(dependsOn))
+	for _, id := range dependsOn {
+		if id <= 0 || seen[id] {
+			out, _ := rawJSONOutput(http.StatusBadRequest,
+				ErrorResponse{Error: fmt.Sprintf("invalid depends_on job %d", id)})
+			return out
+		}
+		seen[id] = true
+		job, err := s.db.GetJobByID(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			out, _ := rawJSONOutput(http.StatusBadRequest,
+				ErrorResponse{Error: fmt.Sprintf("depends_on job %d not found", id)})
+			return out
+		}
+		if err != nil {
+			out, _ := rawJSONOutput(http.StatusInternalServerError,
+				ErrorResponse{Error: fmt.Sprintf("get depends_on job %d: %v", id, err)})
+			return out
+		}
+		if job.Status == storage.JobStatusFailed || job.Status == storage.JobStatusCanceled {
+			out, _ := rawJSONOutput(http.StatusBadRequest,
+				ErrorResponse{Error: fmt.Sprintf("depends_on job %d is %s", id, job.Status)})
+			return out
+		}
+	}
+	return nil
+}
+
+// dependencies returns the steps this step waits for, including its parent.
+func (r WorkflowStepRequest) dependencies() []string {
+	if r.ParentStep == "" || slices.Contains(r.DependsOn, r.ParentStep) {
+		return r.DependsOn
+	}
+	return append(slices.Clone(r.DependsOn), r.ParentStep)
+}
+
+// orderWorkflowSteps validates a workflow's steps and returns their indexes
+// in dependency order, so every step is enqueued after the steps it names.
+func orderWorkflowSteps(steps []WorkflowStepRequest) ([]int, error) {
+	if len(steps) == 0 {
+		return nil, errors.New("steps are required")
+	}
+	if len(steps) > maxWorkflowSteps {
+		return nil, fmt.Errorf("too many steps (max %d)", maxWorkflowSteps)
+	}
+	index := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if step.Name == "" {
+			return nil, fmt.Errorf("step %d has no name", i+1)
+		}
+		if _, dup := index[step.Name]; dup {
+			return nil, fmt.Errorf("duplicate step %q", step.Name)
+		}
+		index[step.Name] = i
+	}
+	for _, step := range steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.Name, dep)
+			}
+		}
+		if step.ParentStep == "" {
+			continue
+		}
+		if _, ok := index[step.ParentStep]; !ok {
+			return nil, fmt.Errorf("step %q has unknown parent_step %q", step.Name, step.ParentStep)
+		}
+		if !reflect.ValueOf(step.Request).IsZero() {
+			return nil, fmt.Errorf("step %q cannot set both parent_step and request", step.Name)
+		}
+	}
+
+	// Depth-first topological sort; a step seen again while still on the
+	// stack closes a cycle.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(steps))
+	order := make([]int, 0, len(steps))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle at step %q", steps[i].Name)
+		}
+		state[i] = visiting
+		for _, dep := range steps[i].dependencies() {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		order = append(order, i)
+		return nil
+	}
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// enqueuedJobID returns the job an /api/enqueue response created: the job
+// itself, or the synthesis handle of a panel run. A non-created response is
+// returned as an error with its HTTP status.
+func enqueuedJobID(out *RawJSONOutput) (int64, int, error) {
+	switch body := out.Body.(type) {
+	case *storage.ReviewJob:
+		return body.ID, 0, nil
+	case PanelEnqueueResponse:
+		return body.ID, 0, nil
+	case EnqueueSkippedResponse:
+		return 0, http.StatusConflict, fmt.Errorf("skipped: %s", body.Reason)
+	case ErrorResponse:
+		return 0, out.Status, errors.New(body.Error)
+	default:
+		return 0, http.StatusInternalServerError, fmt.Errorf("unexpected enqueue response %T", body)
+	}
+}
+
+func (s *Server) humaSubmitWorkflow(
+	ctx context.Context, input *SubmitWorkflowInput,
+) (*SubmitWorkflowOutput, error) {
+	steps := input.Body.Steps
+	order, err := orderWorkflowSteps(steps)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+
+	jobIDs := make(map[string]int64, len(steps))
+	// abort cancels the steps already enqueued so a half-submitted workflow
+	// never runs.
+	abort := func() {
+		for _, id := range jobIDs {
+			_, _ = s.humaCancelJob(ctx, &CancelJobInput{Body: CancelJobRequest{JobID: id}})
+		}
+	}
+	for _, i := range order {
+		step := steps[i]
+		var dependsOn []int64
+		for _, dep := range step.dependencies() {
+			dependsOn = append(dependsOn, jobIDs[dep])
+		}
+		var out *RawJSONOutput
+		if step.ParentStep != "" {
+			out, err = s.humaFixJob(ctx, &FixJobInput{Body: FixJobRequest{
+				ParentJobID: jobIDs[step.ParentStep],
+				DependsOn:   dependsOn,
+			}})
+		} else {
+			req := step.Request
+			req.DependsOn = append(req.DependsOn, dependsOn...)
+			out, err = s.humaEnqueue(ctx, &EnqueueInput{Body: req})
+		}
+		if err != nil {
+			abort()
+			return nil, err
+		}
+		id, status, err := enqueuedJobID(out)
+		if err != nil {
+			abort()
+			return nil, huma.NewError(status, fmt.Sprintf("step %q: %v", step.Name, err))
+		}
+		jobIDs[step.Name] = id
+	}
+
+	recorded := make([]storage.WorkflowStep, len(steps))
+	for i, step := range steps {
+		recorded[i] = storage.WorkflowStep{Name: step.Name, JobID: jobIDs[step.Name]}
+	}
+	uuid, err := s.db.CreateWorkflow(input.Body.Name, recorded)
+	if err != nil {
+		abort()
+		return nil, huma.Error500InternalServerError(fmt.Sprintf("create workflow: %v", err))
+	}
+	wf, err := s.db.GetWorkflow(uuid)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(fmt.Sprintf("get workflow: %v", err))
+	}
+	log.Printf("Workflow %s: enqueued %d steps", uuid, len(steps))
+	return &SubmitWorkflowOutput{Body: wf}, nil
+}
+
+func (s *Server) humaListWorkflows(
+	_ context.Context, input *ListWorkflowsInput,
+) (*ListWorkflowsOutput, error) {
+	out := &ListWorkflowsOutput{}
+	if input.UUID != "" {
+		wf, err := s.db.GetWorkflow(input.UUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, huma.Error404NotFound("workflow not found")
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError(fmt.Sprintf("get workflow: %v", err))
+		}
+		out.Body.Workflows = []storage.Workflow{*wf}
+		return out, nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	workflows, err := s.db.ListWorkflows(limit)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(fmt.Sprintf("list workflows: %v", err))
+	}
+	out.Body.Workflows = workflows
+	if out.Body.Workflows == nil {
+		out.Body.Workflows = []storage.Workflow{}
+	}
+	return out, nil
+}
@@ -0,0 +1,251 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.kenn.io/roborev/internal/storage"
+	"go.kenn.io/roborev/internal/testutil"
+)
+
+func TestOrderWorkflowSteps(t *testing.T) {
+	step := func(name string, deps ...string) WorkflowStepRequest {
+		return WorkflowStepRequest{Name: name, DependsOn: deps}
+	}
+	tests := []struct {
+		name    string
+		steps   []WorkflowStepRequest
+		want    []int
+		wantErr string
+	}{
+		{
+			name:  "dependencies enqueue first",
+			steps: []WorkflowStepRequest{step("rereview", "fix"), step("fix", "analyze"), step("analyze")},
+			want:  []int{2, 1, 0},
+		},
+		{
+			name:  "independent steps keep their order",
+			steps: []WorkflowStepRequest{step("a"), step("b"), step("c", "a", "b")},
+			want:  []int{0, 1, 2},
+		},
+		{name: "no steps", wantErr: "steps are required"},
+		{name: "unnamed step", steps: []WorkflowStepRequest{step("")}, wantErr: "step 1 has no name"},
+		{name: "duplicate step", steps: []WorkflowStepRequest{step("a"), step("a")}, wantErr: `duplicate step "a"`},
+		{name: "unknown step", steps: []WorkflowStepRequest{step("a", "b")}, wantErr: `step "a" depends on unknown step "b"`},
+		{name: "cycle", steps: []WorkflowStepRequest{step("a", "b"), step("b", "a")}, wantErr: "dependency cycle"},
+		{
+			name:  "parent step enqueues first",
+			steps: []WorkflowStepRequest{{Name: "fix", ParentStep: "analyze"}, step("analyze")},
+			want:  []int{1, 0},
+		},
+		{
+			name:    "unknown parent step",
+			steps:   []WorkflowStepRequest{{Name: "fix", ParentStep: "analyze"}},
+			wantErr: `step "fix" has unknown parent_step "analyze"`,
+		},
+		{
+			name: "parent step with request",
+			steps: []WorkflowStepRequest{
+				step("analyze"),
+				{Name: "fix", ParentStep: "analyze", Request: EnqueueRequest{CustomPrompt: "Fix"}},
+			},
+			wantErr: `step "fix" cannot set both parent_step and request`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orderWorkflowSteps(tt.steps)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubmitWorkflow(t *testing.T) {
+	repoDir := t.TempDir()
+	testutil.InitTestGitRepo(t, repoDir)
+	server, db, _ := newTestServer(t)
+
+	prompt := func(text string) EnqueueRequest {
+		return EnqueueRequest{RepoPath: repoDir, GitRef: "prompt", Agent: "test", CustomPrompt: text}
+	}
+	req := testutil.MakeJSONRequest(t, http.MethodPost, "/api/workflows", WorkflowRequest{
+		Name: "analyze-fix-rereview",
+		Steps: []WorkflowStepRequest{
+			{Name: "analyze", Request: prompt("Analyze")},
+			{Name: "fix", DependsOn: []string{"analyze"}, Request: prompt("Fix")},
+			{Name: "rereview", DependsOn: []string{"fix"}, Request: prompt("Re-review")},
+		},
+	})
+	w := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var wf storage.Workflow
+	testutil.DecodeJSON(t, w, &wf)
+	assert.Equal(t, "analyze-fix-rereview", wf.Name)
+	assert.Equal(t, storage.WorkflowStatusQueued, wf.Status)
+	require.Len(t, wf.Steps, 3)
+	assert.Equal(t, []string{"fix"}, wf.Steps[2].DependsOn)
+	analyze, fix, rereview := wf.Steps[0].JobID, wf.Steps[1].JobID, wf.Steps[2].JobID
+	require.NotNil(t, wf.Steps[1].Job)
+	assert.True(t, wf.Steps[1].Job.ClaimBlocked, "a dependent waits for its prerequisite")
+
+	claimed, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.Equal(t, analyze, claimed.ID)
+	next, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	assert.Nil(t, next, "dependents are not claimable while analyze runs")
+
+	_, err = db.FailJob(analyze, "w1", "agent error")
+	require.NoError(t, err)
+	server.settleJobDependencies()
+
+	req = httptest.NewRequest(http.MethodGet, "/api/workflows?uuid="+wf.UUID, nil)
+	w = httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var listed ListWorkflowsOutput
+	testutil.DecodeJSON(t, w, &listed.Body)
+	require.Len(t, listed.Body.Workflows, 1)
+	got := listed.Body.Workflows[0]
+	assert.Equal(t, storage.WorkflowStatusFailed, got.Status)
+	for _, step := range got.Steps[1:] {
+		require.NotNil(t, step.Job)
+		assert.Equal(t, storage.JobStatusCanceled, step.Job.Status, "step %s", step.Name)
+	}
+	assert.Equal(t, []int64{fix, rereview}, []int64{got.Steps[1].Job.ID, got.Steps[2].Job.ID})
+}
+
+func TestSubmitWorkflowFixesParentStep(t *testing.T) {
+	server, db, _ := newTestServer(t)
+	server.configWatcher.Config().FixAgent = "test"
+	repo := testutil.NewGitRepo(t)
+	repo.CommitFile("a.txt", "a", "add a")
+
+	review := EnqueueRequest{RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test"}
+	req := testutil.MakeJSONRequest(t, http.MethodPost, "/api/workflows", WorkflowRequest{
+		Steps: []WorkflowStepRequest{
+			{Name: "analyze", Request: review},
+			{Name: "fix", ParentStep: "analyze"},
+			{Name: "rereview", DependsOn: []string{"fix"}, Request: review},
+		},
+	})
+	w := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var wf storage.Workflow
+	testutil.DecodeJSON(t, w, &wf)
+	require.Len(t, wf.Steps, 3)
+	analyze := wf.Steps[0].JobID
+	fix, err := db.GetJobByID(wf.Steps[1].JobID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.JobTypeFix, fix.JobType)
+	require.NotNil(t, fix.ParentJobID)
+	assert.Equal(t, analyze, *fix.ParentJobID)
+	assert.True(t, fix.ClaimBlocked, "the fix waits for its parent")
+	assert.Empty(t, fix.Prompt, "the prompt is built once the parent's review exists")
+
+	claimed, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.Equal(t, analyze, claimed.ID)
+	require.NoError(t, db.CompleteJob(analyze, "test", "prompt", "- Medium: unchecked error in a.go"))
+	server.settleJobDependencies()
+
+	claimed, err = db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.Equal(t, fix.ID, claimed.ID)
+	require.NoError(t, server.workerPool.buildDeferredFixPrompt(claimed))
+	assert.Contains(t, claimed.Prompt, "unchecked error in a.go")
+}
+
+func TestEnqueueDependsOnRejectsConfiguredPanel(t *testing.T) {
+	server, db, _ := newTestServer(t)
+	repo := testutil.NewGitRepo(t)
+	repo.WriteFile(".roborev.toml", panelTOML)
+	repo.CommitFile("a.txt", "a", "add a")
+	repoRow, err := db.GetOrCreateRepo(repo.Path())
+	require.NoError(t, err)
+	prereq, err := db.EnqueueJob(storage.EnqueueOpts{
+		RepoID: repoRow.ID, Agent: "test", JobType: storage.JobTypeTask, GitRef: "prompt", Prompt: "Analyze",
+	})
+	require.NoError(t, err)
+
+	enqueue := func(panel string) *httptest.ResponseRecorder {
+		req := testutil.MakeJSONRequest(t, http.MethodPost, "/api/enqueue", EnqueueRequest{
+			RepoPath: repo.Path(), GitRef: "HEAD", Agent: "test", Panel: panel, DependsOn: []int64{prereq.ID},
+		})
+		w := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := enqueue("")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `depends_on cannot be combined with panel \"trio\"`)
+
+	w = enqueue("none")
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func TestSubmitWorkflowRejectsCycle(t *testing.T) {
+	server, _, _ := newTestServer(t)
+	req := testutil.MakeJSONRequest(t, http.MethodPost, "/api/workflows", WorkflowRequest{
+		Steps: []WorkflowStepRequest{
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		},
+	})
+	w := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "dependency cycle")
+}
+
+func TestEnqueueDependsOn(t *testing.T) {
+	repoDir := t.TempDir()
+	testutil.InitTestGitRepo(t, repoDir)
+	server, db, _ := newTestServer(t)
+
+	enqueue := func(dependsOn ...int64) *httptest.ResponseRecorder {
+		req := testutil.MakeJSONRequest(t, http.MethodPost, "/api/enqueue", EnqueueRequest{
+			RepoPath: repoDir, GitRef: "prompt", Agent: "test", CustomPrompt: "Analyze", DependsOn: dependsOn,
+		})
+		w := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := enqueue(999)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "depends_on job 999 not found")
+
+	w = enqueue()
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var prereq storage.ReviewJob
+	testutil.DecodeJSON(t, w, &prereq)
+	claimed, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.Equal(t, prereq.ID, claimed.ID)
+	require.NoError(t, db.CompleteJob(prereq.ID, "test", "p", "done"))
+
+	w = enqueue(prereq.ID)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var dependent storage.ReviewJob
+	testutil.DecodeJSON(t, w, &dependent)
+	claimed, err = db.ClaimJob("w1")
+	require.NoError(t, err)
+	require.NotNil(t, claimed, "a job whose prerequisites already succeeded is released at enqueue")
+	assert.Equal(t, dependent.ID, claimed.ID)
+}
@@ -426,6 +426,23 @@ func HasTrackedFilesUnder(repoPath, path string) (bool, error) {
 	return strings.TrimSpace(string(out)) != "", nil
 }
 
+// ListTrackedFiles returns the repo-relative, slash-separated paths of every
+// file in the repo's index.
+func ListTrackedFiles(repoPath string) ([]string, error) {
+	cmd := newGitCmd("-C", repoPath, "ls-files", "-z")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files: %w", err)
+	}
+	var files []string
+	for file := range strings.SplitSeq(string(out), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
 // EnsureNoTrackedFilesUnder rejects paths that already contain tracked files.
 func EnsureNoTrackedFilesUnder(repoPath, path string) error {
 	hasTrackedFiles, err := HasTrackedFilesUnder(repoPath, path)
@@ -908,6 +925,20 @@ func isFullObjectID(ref string) bool {
 	return true
 }
 
+// InitRepo creates an empty repository at dir, creating dir if needed.
+// Running it on an existing repository is harmless.
+func InitRepo(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	cmd := newGitCmdContext(ctx, "init", "-q")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git init: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // GetRepoRoot returns the root directory of the git repository
 func GetRepoRoot(path string) (string, error) {
 	cmd := newGitCmd("rev-parse", "--show-toplevel")
@@ -1140,6 +1171,27 @@ func GetRangeCommitsCtx(ctx context.Context, repoPath, rangeRef string) ([]strin
 	return commits, nil
 }
 
+// GetRangePathCommitsCtx returns the commits in a range that touch any of
+// paths, newest first.
+func GetRangePathCommitsCtx(ctx context.Context, repoPath, rangeRef string, paths []string) ([]string, error) {
+	args := append([]string{"log", "--format=%H", rangeRef, "--"}, paths...)
+	cmd := newGitCmdContext(ctx, args...)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log range paths: %w", err)
+	}
+
+	var commits []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
+
 // GetRangeDiff returns the combined diff for a range, excluding
 // generated files like lock files. Extra exclude patterns (filenames
 // or globs) are appended to the built-in exclusion list.
@@ -1470,6 +1522,109 @@ func ReviewPathspecArgs(extraExcludes ...string) []string {
 	return args
 }
 
+// DiffPathspecArgs is ReviewPathspecArgs limited to paths, which are literal
+// repo-root-relative file names. No paths means the whole repository.
+func DiffPathspecArgs(paths []string, extraExcludes ...string) []string {
+	if len(paths) == 0 {
+		return ReviewPathspecArgs(extraExcludes...)
+	}
+	args := append(PathArgs(paths), excludedPathPatterns...)
+	args = append(args, FormatExcludeArgs(extraExcludes)...)
+	return args
+}
+
+// PathArgs converts repo-root-relative file names into literal git pathspec
+// arguments.
+func PathArgs(paths []string) []string {
+	args := make([]string, len(paths))
+	for i, p := range paths {
+		args[i] = ":(top,literal)" + p
+	}
+	return args
+}
+
+// reviewDiffArgs returns the git arguments that print the diff of a commit
+// or of a range, ready for pathspec arguments.
+func reviewDiffArgs(ref string) []string {
+	if IsRange(ref) {
+		return []string{"diff", ref, "--"}
+	}
+	return []string{"show", ref, "--format=", "--"}
+}
+
+// GetPathsDiffCtx returns the diff of a commit or range limited to paths,
+// with the same exclusions as GetDiff and GetRangeDiff.
+func GetPathsDiffCtx(
+	ctx context.Context, repoPath, ref string, paths []string, extraExcludes ...string,
+) (string, error) {
+	args := append(reviewDiffArgs(ref), DiffPathspecArgs(paths, extraExcludes...)...)
+	cmd := newGitCmdContext(ctx, args...)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// GetPathsDiffLimitedCtx is GetPathsDiffCtx reading at most maxBytes, and
+// reports whether the output was truncated.
+func GetPathsDiffLimitedCtx(
+	ctx context.Context, repoPath, ref string, maxBytes int, paths []string, extraExcludes ...string,
+) (string, bool, error) {
+	args := append(reviewDiffArgs(ref), DiffPathspecArgs(paths, extraExcludes...)...)
+	return captureGitOutputLimited(ctx, repoPath, maxBytes, args...)
+}
+
+// FileDiffSize is the size of one file's section of a review diff.
+type FileDiffSize struct {
+	Path  string
+	Bytes int
+}
+
+// GetDiffFileSizesCtx returns the size of each file's section of the review
+// diff of a commit or range, in diff order. Renames are listed as a deletion
+// and an addition so every entry names a single path.
+func GetDiffFileSizesCtx(
+	ctx context.Context, repoPath, ref string, extraExcludes ...string,
+) ([]FileDiffSize, error) {
+	args := reviewDiffArgs(ref)
+	args = append(args[:len(args)-1], "--no-renames", "--src-prefix=a/", "--dst-prefix=b/", "--")
+	args = append(args, ReviewPathspecArgs(extraExcludes...)...)
+	cmd := newGitCmdContext(ctx, args...)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	var sizes []FileDiffSize
+	for section := range strings.SplitAfterSeq(string(out), "\n") {
+		if header, ok := strings.CutPrefix(section, "diff --git "); ok {
+			sizes = append(sizes, FileDiffSize{Path: diffHeaderPath(strings.TrimSuffix(header, "\n"))})
+		}
+		if len(sizes) > 0 {
+			sizes[len(sizes)-1].Bytes += len(section)
+		}
+	}
+	return sizes, nil
+}
+
+// diffHeaderPath extracts the path from the "a/<path> b/<path>" part of a
+// diff header. Without renames both sides name the same path, so the old side
+// is the first half of the header, C-quoted when the path needs it.
+func diffHeaderPath(header string) string {
+	side := header[:(len(header)-1)/2]
+	if strings.HasPrefix(side, `"`) {
+		if unquoted, err := strconv.Unquote(side); err == nil {
+			side = unquoted
+		}
+	}
+	return strings.TrimPrefix(side, "a/")
+}
+
 func captureGitOutputLimited(ctx context.Context, repoPath string, maxBytes int, args ...string) (string, bool, error) {
 	if maxBytes <= 0 {
 		return "", true, nil
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -924,6 +926,25 @@ func TestOpenEnqueueMetadataReaderGoGitMatchesGit(t *testing.T) {
 	assert.Equal(wantRange, gotRange)
 }
 
+func TestGetRangePathCommitsCtx(t *testing.T) {
+	repo := NewTestRepo(t)
+	repo.CommitFile("a.go", "a1", "base")
+	base := repo.Run("rev-parse", "HEAD")
+	repo.CommitFile("a.go", "a2", "touch a")
+	touchA := repo.Run("rev-parse", "HEAD")
+	repo.CommitFile("b.go", "b1", "touch b")
+	repo.CommitFile("a.go", "a3", "touch a again")
+	touchAgain := repo.Run("rev-parse", "HEAD")
+
+	got, err := GetRangePathCommitsCtx(t.Context(), repo.Dir, base+"..HEAD", []string{"a.go"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{touchAgain, touchA}, got)
+
+	got, err = GetRangePathCommitsCtx(t.Context(), repo.Dir, base+"..HEAD", []string{"c.go"})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 func TestOpenEnqueueMetadataReaderRangeCommitsUsesCancellableGitLog(t *testing.T) {
 	repo := NewTestRepo(t)
 	repo.CommitFile("base.txt", "base", "base")
@@ -1626,6 +1647,58 @@ func TestGetDiffExcludesSlashedDirectory(t *testing.T) {
 	})
 }
 
+func TestGetPathsDiff(t *testing.T) {
+	repo := NewTestRepoWithCommit(t)
+	repo.WriteFile("api/a.go", "package api\n")
+	repo.WriteFile("api/b[1].go", "package api\n")
+	repo.WriteFile("web/c.ts", "export {}\n")
+	repo.WriteFile("api/custom.lock", "lock\n")
+	repo.CommitAll("add files")
+
+	paths := []string{"api/b[1].go", "api/custom.lock", "api/missing.go"}
+	for _, ref := range []string{repo.HeadSHA(), "HEAD~1..HEAD"} {
+		diff, err := GetPathsDiffCtx(context.Background(), repo.Dir, ref, paths, "custom.lock")
+		require.NoError(t, err)
+		assert.Contains(t, diff, "api/b[1].go", "paths are literal, not globs")
+		assert.NotContains(t, diff, "api/a.go")
+		assert.NotContains(t, diff, "web/c.ts")
+		assert.NotContains(t, diff, "custom.lock", "excludes still apply")
+
+		limited, truncated, err := GetPathsDiffLimitedCtx(context.Background(), repo.Dir, ref, 1<<20, paths)
+		require.NoError(t, err)
+		assert.False(t, truncated)
+		assert.NotContains(t, limited, "web/c.ts")
+	}
+}
+
+func TestGetDiffFileSizes(t *testing.T) {
+	repo := NewTestRepoWithCommit(t)
+	repo.WriteFile("old.txt", "old\n")
+	repo.CommitAll("add old")
+	repo.Run("mv", "old.txt", "new.txt")
+	repo.WriteFile("big.txt", strings.Repeat("line\n", 100))
+	repo.WriteFile("with space/é.txt", "x\n")
+	repo.WriteFile("go.sum", "sum\n")
+	repo.CommitAll("change files")
+
+	sizes, err := GetDiffFileSizesCtx(context.Background(), repo.Dir, repo.HeadSHA())
+	require.NoError(t, err)
+	byPath := map[string]int{}
+	for _, s := range sizes {
+		byPath[s.Path] = s.Bytes
+	}
+	assert.ElementsMatch(t, []string{"big.txt", "new.txt", "old.txt", "with space/é.txt"},
+		slices.Collect(maps.Keys(byPath)), "renames split, lock files excluded")
+	assert.Greater(t, byPath["big.txt"], 500)
+	assert.Less(t, byPath["new.txt"], 200)
+
+	// Every byte of the diff belongs to exactly one file.
+	whole, err := GetPathsDiffCtx(context.Background(), repo.Dir, "HEAD~1..HEAD",
+		[]string{"with space/é.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, len(whole), byPath["with space/é.txt"])
+}
+
 func TestGetDiffLimited(t *testing.T) {
 	repo := NewTestRepoWithCommit(t)
 	repo.WriteFile("large.txt", strings.Repeat("line\n", 20000))
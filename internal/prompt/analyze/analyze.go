@@ -4,6 +4,7 @@ package analyze
 import (
 	"embed"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -170,3 +171,33 @@ func (t AnalysisType) BuildPromptWithPaths(repoRoot string, filePaths []string)
 
 	return sb.String(), nil
 }
+
+// OutputPrefix creates a prefix showing which files were analyzed.
+// This is prepended to the agent's output for reliable file identification.
+func OutputPrefix(analysisType string, filePaths []string) string {
+	sort.Strings(filePaths)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s Analysis\n\n", analysisType)
+	sb.WriteString("**Files:**\n")
+	for _, path := range filePaths {
+		fmt.Fprintf(&sb, "- %s\n", path)
+	}
+	sb.WriteString("\n---\n\n")
+	return sb.String()
+}
+
+// IsSourceFile returns true if the file looks like source code
+func IsSourceFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	sourceExts := map[string]bool{
+		".go": true, ".py": true, ".js": true, ".ts": true, ".tsx": true, ".jsx": true,
+		".rs": true, ".c": true, ".h": true, ".cpp": true, ".hpp": true, ".cc": true,
+		".java": true, ".kt": true, ".scala": true, ".rb": true, ".php": true,
+		".swift": true, ".m": true, ".cs": true, ".fs": true, ".vb": true,
+		".sh": true, ".bash": true, ".zsh": true, ".fish": true,
+		".sql": true, ".graphql": true, ".proto": true,
+		".yaml": true, ".yml": true, ".toml": true, ".json": true,
+		".md": true, ".txt": true, ".html": true, ".css": true, ".scss": true,
+	}
+	return sourceExts[ext]
+}
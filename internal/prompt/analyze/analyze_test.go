@@ -166,3 +166,33 @@ func TestBuildPromptWithPaths(t *testing.T) {
 	// Check instructions section
 	assert.Contains(t, prompt, "## Instructions", "prompt missing '## Instructions' header")
 }
+
+func TestIsSourceFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"main.go", true},
+		{"script.py", true},
+		{"app.js", true},
+		{"app.ts", true},
+		{"app.tsx", true},
+		{"lib.rs", true},
+		{"config.yaml", true},
+		{"config.yml", true},
+		{"data.json", true},
+		{"readme.md", true},
+		{"binary.exe", false},
+		{"image.png", false},
+		{"archive.tar.gz", false},
+		{".gitignore", false},
+		{"Makefile", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := IsSourceFile(tt.path)
+			assert.Equal(t, tt.want, got, "IsSourceFile(%q)", tt.path)
+		})
+	}
+}
@@ -307,6 +307,18 @@ func TestGoldenPrompt_SingleWithAdditionalContext(t *testing.T) {
 	assertGolden(t, scrubDynamic(prompt), "single_with_additional_context.golden")
 }
 
+func TestGoldenPrompt_SingleWithBuilderAdditionalContext(t *testing.T) {
+	r := newGoldenTestRepo(t)
+	sha := r.commitFile("hello.txt", "hello world\n", "add greeting")
+
+	additional := "## Pull Request Discussion\n\nReviewer noted the greeting should support i18n in a later PR.\n"
+	b := NewBuilder(nil).WithAdditionalContext(additional)
+	prompt, err := b.ForRepo(r.dir, 0).Build(sha, 0, "test", "", "")
+	require.NoError(t, err)
+
+	assertGolden(t, scrubDynamic(prompt), "single_with_additional_context.golden")
+}
+
 func TestGoldenPrompt_SingleWithSeverityFilter(t *testing.T) {
 	r := newGoldenTestRepo(t)
 	sha := r.commitFile("hello.txt", "hello world\n", "add greeting")
@@ -20,6 +20,7 @@ import (
 	"go.kenn.io/roborev/internal/config"
 	"go.kenn.io/roborev/internal/git"
 	"go.kenn.io/roborev/internal/kata"
+	"go.kenn.io/roborev/internal/redact"
 	"go.kenn.io/roborev/internal/storage"
 )
 
@@ -88,6 +89,11 @@ type Builder struct {
 	repoPath   string
 	repoID     int64
 	kataClient kata.Client
+	redactor   *redact.Redactor
+	paths      []string // limits commit and range diffs; nil reviews every file
+	// additionalContext is markdown placed ahead of the diff of every commit
+	// and range prompt, before any per-call additional context.
+	additionalContext string
 }
 
 // DiffFilePathPlaceholder is a sentinel path embedded in prebuilt
@@ -122,6 +128,14 @@ func NewBuilderWithConfig(
 	return &Builder{db: db, globalCfg: globalCfg}
 }
 
+// WithRedactor returns a builder that masks secrets in the diff snapshots it
+// writes for agents to read.
+func (b *Builder) WithRedactor(r *redact.Redactor) *Builder {
+	next := *b
+	next.redactor = r
+	return &next
+}
+
 // WithContext returns a builder that uses ctx for git operations.
 func (b *Builder) WithContext(ctx context.Context) *Builder {
 	next := *b
@@ -129,6 +143,22 @@ func (b *Builder) WithContext(ctx context.Context) *Builder {
 	return &next
 }
 
+// WithPaths returns a builder whose commit and range prompts cover only
+// paths, for chunked reviews of one file group. Nil covers every file.
+func (b *Builder) WithPaths(paths []string) *Builder {
+	next := *b
+	next.paths = paths
+	return &next
+}
+
+// WithAdditionalContext returns a builder that adds a markdown context block
+// ahead of the diff of every commit and range prompt it builds.
+func (b *Builder) WithAdditionalContext(additionalContext string) *Builder {
+	next := *b
+	next.additionalContext = additionalContext
+	return &next
+}
+
 // ForRepo returns a builder scoped to a repository.
 func (b *Builder) ForRepo(repoPath string, repoID int64) *Builder {
 	next := *b
@@ -161,6 +191,35 @@ func (b *Builder) resolveExcludes(
 	)
 }
 
+// diffLimited reads up to limit bytes of the diff of a commit or range,
+// limited to the builder's paths when set.
+func (b *Builder) diffLimited(gitRef string, limit int, excludes []string) (string, bool, error) {
+	switch {
+	case len(b.paths) > 0:
+		return git.GetPathsDiffLimitedCtx(b.context(), b.repoPath, gitRef, limit, b.paths, excludes...)
+	case git.IsRange(gitRef):
+		return git.GetRangeDiffLimitedCtx(b.context(), b.repoPath, gitRef, limit, excludes...)
+	default:
+		return git.GetDiffLimitedCtx(b.context(), b.repoPath, gitRef, limit, excludes...)
+	}
+}
+
+// fallbackPathspecArgs returns the pathspec the agent should pass to git when
+// the diff is too large to inline.
+func (b *Builder) fallbackPathspecArgs(excludes []string) []string {
+	return append(git.PathArgs(b.paths), git.FormatExcludeArgs(excludes)...)
+}
+
+// limitFiles keeps the files the builder's paths cover.
+func (b *Builder) limitFiles(files []string) []string {
+	if len(b.paths) == 0 {
+		return files
+	}
+	return slices.DeleteFunc(slices.Clone(files), func(f string) bool {
+		return !slices.Contains(b.paths, f)
+	})
+}
+
 func (b *Builder) context() context.Context {
 	if b.ctx != nil {
 		return b.ctx
@@ -205,6 +264,12 @@ func (b *Builder) BuildWithDiffFile(gitRef string, contextCount int, agentName,
 }
 
 func (b *Builder) buildWithOpts(gitRef string, contextCount int, agentName, reviewType string, opts buildOpts) (string, error) {
+	if extra := strings.TrimSpace(b.additionalContext); extra != "" {
+		if existing := strings.TrimSpace(opts.additionalContext); existing != "" {
+			extra += "\n\n" + existing
+		}
+		opts.additionalContext = extra
+	}
 	if git.IsRange(gitRef) {
 		return b.buildRangePrompt(gitRef, contextCount, agentName, reviewType, opts)
 	}
@@ -268,9 +333,12 @@ func (b *Builder) WriteDiffSnapshotTarget(gitRef string, excludes []string, targ
 		fullDiff string
 		err      error
 	)
-	if git.IsRange(gitRef) {
+	switch {
+	case len(b.paths) > 0:
+		fullDiff, err = git.GetPathsDiffCtx(b.context(), b.repoPath, gitRef, b.paths, excludes...)
+	case git.IsRange(gitRef):
 		fullDiff, err = git.GetRangeDiffCtx(b.context(), b.repoPath, gitRef, excludes...)
-	} else {
+	default:
 		fullDiff, err = git.GetDiffCtx(b.context(), b.repoPath, gitRef, excludes...)
 	}
 	if err != nil {
@@ -287,6 +355,7 @@ func (b *Builder) writeExternalDiffSnapshotTarget(diff string, target SnapshotTa
 	if err != nil {
 		return "", nil, err
 	}
+	diff, _ = b.redactor.Redact(diff)
 	return writeExternalDiffSnapshot(repoPath, snapshotRoot, diff)
 }
 
@@ -1104,7 +1173,7 @@ func (b *Builder) buildSinglePrompt(sha string, contextCount int, agentName, rev
 	// Include previous review attempts for this same commit (for re-reviews)
 	ctx.optional.PreviousAttempts = previousAttemptViewsFromContexts(b.previousAttemptContexts(sha))
 	if files, err := git.GetFilesChangedCtx(b.context(), b.repoPath, sha); err == nil {
-		ctx.optional.DependencyMetadata = buildDependencyMetadataSection(files)
+		ctx.optional.DependencyMetadata = buildDependencyMetadataSection(b.limitFiles(files))
 	}
 
 	// Current commit section
@@ -1148,7 +1217,7 @@ func (b *Builder) buildSinglePrompt(sha string, contextCount int, agentName, rev
 	excludes := b.resolveExcludes(reviewType)
 	bodyLimit := max(0, ctx.promptCap-len(ctx.requiredPrefix))
 	diffLimit := max(0, bodyLimit-len(currentRequired)-len(currentOverflow)-len(emptyDiffBlock))
-	diff, truncated, err := git.GetDiffLimitedCtx(b.context(), b.repoPath, sha, diffLimit, excludes...)
+	diff, truncated, err := b.diffLimited(sha, diffLimit, excludes)
 	if err != nil {
 		return "", fmt.Errorf("get diff: %w", err)
 	}
@@ -1165,7 +1234,7 @@ func (b *Builder) buildSinglePrompt(sha string, contextCount int, agentName, rev
 			}
 			return buildPromptPreservingCurrentSection(ctx.requiredPrefix, optionalPrefix, currentRequired, currentOverflow, ctx.promptCap, diffFileFallbackVariants("### Diff", opts.diffFilePath)...), nil
 		}
-		pathspecArgs := safeForMarkdown(git.FormatExcludeArgs(excludes))
+		pathspecArgs := safeForMarkdown(b.fallbackPathspecArgs(excludes))
 		if isCodexReviewAgent(agentName) {
 			variants := codexCommitInspectionFallbackVariants(sha, pathspecArgs)
 			shortestBlock, err := renderDiffBlock(variants[len(variants)-1])
@@ -1237,7 +1306,7 @@ func (b *Builder) buildRangePrompt(rangeRef string, contextCount int, agentName,
 		return "", fmt.Errorf("get range commits: %w", err)
 	}
 	if files, err := git.GetRangeFilesChangedCtx(b.context(), b.repoPath, rangeRef); err == nil {
-		ctx.optional.DependencyMetadata = buildDependencyMetadataSection(files)
+		ctx.optional.DependencyMetadata = buildDependencyMetadataSection(b.limitFiles(files))
 	}
 
 	// Include per-commit reviews for commits inside the range so the agent
@@ -1282,7 +1351,7 @@ func (b *Builder) buildRangePrompt(rangeRef string, contextCount int, agentName,
 	excludes := b.resolveExcludes(reviewType)
 	bodyLimit := max(0, ctx.promptCap-len(ctx.requiredPrefix))
 	diffLimit := max(0, bodyLimit-len(currentRequiredText)-len(currentOverflowText)-len(emptyDiffBlock))
-	diff, truncated, err := git.GetRangeDiffLimitedCtx(b.context(), b.repoPath, rangeRef, diffLimit, excludes...)
+	diff, truncated, err := b.diffLimited(rangeRef, diffLimit, excludes)
 	if err != nil {
 		return "", fmt.Errorf("get range diff: %w", err)
 	}
@@ -1299,7 +1368,7 @@ func (b *Builder) buildRangePrompt(rangeRef string, contextCount int, agentName,
 			}
 			return buildPromptPreservingCurrentSection(ctx.requiredPrefix, optionalPrefix, currentRequiredText, currentOverflowText, ctx.promptCap, diffFileFallbackVariants("### Combined Diff", opts.diffFilePath)...), nil
 		}
-		pathspecArgs := safeForMarkdown(git.FormatExcludeArgs(excludes))
+		pathspecArgs := safeForMarkdown(b.fallbackPathspecArgs(excludes))
 		if isCodexReviewAgent(agentName) {
 			variants := codexRangeInspectionFallbackVariants(rangeRef, pathspecArgs)
 			selectedCtx, err := selectRichestRangePromptView(bodyLimit, templateContextFromRangeView(rangePromptView{
@@ -1,15 +1,39 @@
 package prompt
 
 import (
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"fmt"
+	"io/fs"
 	"strings"
+	"sync"
 	"time"
 )
 
 //go:embed templates/*.md.gotmpl
 var templateFS embed.FS
 
+// TemplatesDigest returns a hash of the embedded prompt templates, so
+// callers can tell when prompts built by this binary may have changed.
+var TemplatesDigest = sync.OnceValue(func() string {
+	h := sha256.New()
+	_ = fs.WalkDir(templateFS, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := templateFS.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(path))
+		h.Write([]byte{0})
+		h.Write(data)
+		return nil
+	})
+	return hex.EncodeToString(h.Sum(nil))
+})
+
 // GetSystemPrompt returns the system prompt for the specified agent and type.
 // If a specific template exists for the agent, it uses that.
 // Otherwise, it falls back to the default templates.
@@ -0,0 +1,230 @@
+// Package redact masks secrets in text before it leaves the machine: review
+// prompts, stored diffs, exported reviews and PR comments.
+package redact
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"go.kenn.io/roborev/internal/config"
+)
+
+// Mask returns the placeholder that replaces a secret found by detector.
+func Mask(detector string) string {
+	return "[REDACTED:" + detector + "]"
+}
+
+// rule is one detector. When group is > 0 only that capture group is
+// masked, so surrounding context such as the variable name stays readable.
+type rule struct {
+	name  string
+	re    *regexp.Regexp
+	group int
+	// accept, when set, filters matches (e.g. by entropy).
+	accept func(string) bool
+	// skipPaths drops matches that are a segment of a file path.
+	skipPaths bool
+}
+
+// secretName matches identifiers that usually hold credentials.
+const secretName = `[A-Za-z0-9_.-]*(?i:secret|token|passw(?:or)?d|pwd|credential|api_?key|access_?key|private_?key)[A-Za-z0-9_.-]*`
+
+var builtinRules = []rule{
+	{name: "private-key", re: regexp.MustCompile(
+		`(?s)-----BEGIN [A-Z0-9 ]*PRIVATE KEY(?: BLOCK)?-----.*?(?:-----END [A-Z0-9 ]*PRIVATE KEY(?: BLOCK)?-----|\z)`)},
+	{name: "aws-access-key", re: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+	{name: "aws-secret-key", re: regexp.MustCompile(
+		`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`), group: 1},
+	{name: "github-token", re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{name: "slack-token", re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{name: "stripe-key", re: regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{20,}\b`)},
+	{name: "google-api-key", re: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{name: "model-api-key", re: regexp.MustCompile(`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{32,}`)},
+	// Values assigned to secret-looking names, as in .env files and
+	// config: PASSWORD=hunter2hunter2, "apiToken": "...".
+	{name: "high-entropy", re: regexp.MustCompile(
+		secretName + `["']?\s*(?::=|=|:)\s*["']?([^\s"'` + "`" + `,;]{8,})`), group: 1,
+		accept: func(v string) bool { return hasLetterAndDigit(v) && !isPlaceholder(v) && entropy(v) >= 3.0 }},
+	// Long random-looking tokens anywhere. File paths mix cases and digits
+	// too (temp directories especially), so "/" is not part of a token and
+	// path segments are skipped; masking one would break the path.
+	{name: "high-entropy", re: regexp.MustCompile(`[A-Za-z0-9+_-]{32,}={0,2}`),
+		accept:    func(v string) bool { return mixedClasses(v) && entropy(v) >= 4.3 },
+		skipPaths: true},
+}
+
+// Detectors lists the built-in detector names.
+func Detectors() []string {
+	var names []string
+	for _, r := range builtinRules {
+		if !slices.Contains(names, r.name) {
+			names = append(names, r.name)
+		}
+	}
+	return names
+}
+
+// Redactor masks secrets and counts how many it masked. A nil *Redactor
+// masks nothing. Build one per job so Count covers that job alone.
+type Redactor struct {
+	rules []rule
+	count atomic.Int64
+}
+
+// ForRepo builds a redactor from the global settings plus repoPath's
+// redact_patterns.
+func ForRepo(repoPath string, globalCfg *config.Config) (*Redactor, error) {
+	return New(config.ResolveRedaction(repoPath, globalCfg))
+}
+
+// New builds a redactor from cfg. It returns nil when redaction is
+// disabled, and an error when a custom pattern does not compile.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+	var r Redactor
+	for _, b := range builtinRules {
+		if !slices.ContainsFunc(cfg.Disable, func(d string) bool {
+			return strings.EqualFold(strings.TrimSpace(d), b.name)
+		}) {
+			r.rules = append(r.rules, b)
+		}
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("redaction pattern %q: %w", p, err)
+		}
+		group := 0
+		if re.NumSubexp() > 0 {
+			group = 1
+		}
+		r.rules = append(r.rules, rule{name: "custom", re: re, group: group})
+	}
+	return &r, nil
+}
+
+// Redact returns s with every detected secret masked, and the number of
+// secrets masked.
+func (r *Redactor) Redact(s string) (string, int) {
+	if r == nil || s == "" {
+		return s, 0
+	}
+	total := 0
+	for _, rl := range r.rules {
+		var n int
+		s, n = rl.apply(s)
+		total += n
+	}
+	r.count.Add(int64(total))
+	return s, total
+}
+
+// Count returns how many secrets this redactor has masked.
+func (r *Redactor) Count() int {
+	if r == nil {
+		return 0
+	}
+	return int(r.count.Load())
+}
+
+// String is Redact without the count.
+func (r *Redactor) String(s string) string {
+	out, _ := r.Redact(s)
+	return out
+}
+
+func (rl rule) apply(s string) (string, int) {
+	matches := rl.re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, 0
+	}
+	var b strings.Builder
+	last, n := 0, 0
+	mask := Mask(rl.name)
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if rl.group > 0 && len(m) > 2*rl.group+1 {
+			start, end = m[2*rl.group], m[2*rl.group+1]
+		}
+		if start < 0 || start < last {
+			continue
+		}
+		secret := s[start:end]
+		if secret == "" || strings.HasPrefix(secret, "[REDACTED:") ||
+			(rl.accept != nil && !rl.accept(secret)) ||
+			(rl.skipPaths && inPath(s, start, end)) {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(mask)
+		last = end
+		n++
+	}
+	if n == 0 {
+		return s, 0
+	}
+	b.WriteString(s[last:])
+	return b.String(), n
+}
+
+// inPath reports whether s[start:end] sits between path separators.
+func inPath(s string, start, end int) bool {
+	isSep := func(c byte) bool { return c == '/' || c == '\\' }
+	return (start > 0 && isSep(s[start-1])) || (end < len(s) && isSep(s[end]))
+}
+
+// entropy is the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, c := range s {
+		counts[c]++
+		total++
+	}
+	var h float64
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// mixedClasses reports whether s has upper- and lower-case letters and
+// digits, which rules out words, hex digests and most identifiers.
+func mixedClasses(s string) bool {
+	var upper, lower, digit bool
+	for _, c := range s {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+// hasLetterAndDigit separates literal secrets from code references like
+// cfg.APIKey and from plain numbers.
+func hasLetterAndDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsLetter) >= 0 && strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
+
+// isPlaceholder reports values that are references or obvious stand-ins
+// rather than secrets.
+func isPlaceholder(v string) bool {
+	lower := strings.ToLower(v)
+	return strings.HasPrefix(v, "$") || strings.HasPrefix(v, "{{") || strings.HasPrefix(v, "<") ||
+		strings.Contains(lower, "example") || strings.Contains(lower, "placeholder") ||
+		strings.Contains(lower, "changeme") || strings.Contains(lower, "redacted") ||
+		strings.Trim(lower, "x*.-_") == "" || strings.ContainsAny(v, "()[]")
+}
@@ -0,0 +1,45 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CombineChunks merges the reviews of a chunked run, one per file group,
+// into the parent review. Every group covers different files, so nothing is
+// deduplicated: each failing group's review is reproduced verbatim. The
+// combined review passes only when every group was reviewed and passed at
+// minSeverity; a group that produced no review fails the parent, since its
+// files were never looked at.
+func CombineChunks(results []ReviewResult, minSeverity string) string {
+	var failing, unreviewed []ReviewResult
+	for _, r := range results {
+		switch {
+		case r.Status != ResultDone || strings.TrimSpace(r.Output) == "":
+			unreviewed = append(unreviewed, r)
+		case reviewFails(r.Output, minSeverity):
+			failing = append(failing, r)
+		}
+	}
+	if len(failing) == 0 && len(unreviewed) == 0 {
+		return fmt.Sprintf("No issues found.\n\nReviewed in %d file groups.\n", len(results))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Failed: %d of %d file groups reported issues", len(failing), len(results))
+	if len(unreviewed) > 0 {
+		fmt.Fprintf(&b, " and %d could not be reviewed", len(unreviewed))
+	}
+	b.WriteString(".\n")
+	for _, r := range failing {
+		fmt.Fprintf(&b, "\n---\n### Review of %s\n%s\n", memberLabel(r), strings.TrimSpace(r.Output))
+	}
+	for _, r := range unreviewed {
+		reason := strings.TrimSpace(r.Error)
+		if reason == "" {
+			reason = "no review output"
+		}
+		fmt.Fprintf(&b, "\n---\n### %s was not reviewed\n%s\n", memberLabel(r), reason)
+	}
+	return b.String()
+}
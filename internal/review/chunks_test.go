@@ -0,0 +1,42 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func TestCombineChunks(t *testing.T) {
+	passing := ReviewResult{Name: "chunk 1/3", Agent: "codex", Status: ResultDone, Output: "No issues found."}
+	failing := ReviewResult{Name: "chunk 2/3", Agent: "codex", Status: ResultDone,
+		Output: "- Low — web/app.ts:4 unused import"}
+	failed := ReviewResult{Name: "chunk 3/3", Agent: "codex", Status: ResultFailed, Error: "agent timed out"}
+
+	t.Run("all groups pass", func(t *testing.T) {
+		out := CombineChunks([]ReviewResult{passing, passing}, "")
+		assert.Equal(t, "P", storage.ParseVerdict(out))
+		assert.Contains(t, out, "Reviewed in 2 file groups.")
+	})
+
+	t.Run("failing group is reproduced", func(t *testing.T) {
+		out := CombineChunks([]ReviewResult{passing, failing}, "")
+		assert.Equal(t, "F", storage.ParseVerdict(out))
+		assert.Contains(t, out, "Failed: 1 of 2 file groups reported issues.")
+		assert.Contains(t, out, "### Review of chunk 2/3\n- Low — web/app.ts:4 unused import")
+		assert.NotContains(t, out, "chunk 1/3")
+	})
+
+	t.Run("min severity filters group findings", func(t *testing.T) {
+		out := CombineChunks([]ReviewResult{passing, failing}, "high")
+		assert.Equal(t, "P", storage.ParseVerdict(out))
+	})
+
+	t.Run("unreviewed group fails the parent", func(t *testing.T) {
+		out := CombineChunks([]ReviewResult{passing, failed}, "")
+		assert.Equal(t, "F", storage.ParseVerdict(out))
+		assert.Contains(t, out, "and 1 could not be reviewed")
+		assert.Contains(t, out, "### chunk 3/3 was not reviewed\nagent timed out")
+	})
+}
@@ -0,0 +1,92 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+
+	gitrepo "go.kenn.io/kit/git/repo"
+)
+
+// CompactReview is an open review a compact job verifies and consolidates.
+type CompactReview struct {
+	JobID  int64
+	GitRef string
+	Output string
+}
+
+// BuildCompactPrompt creates the prompt for a compact job: the agent's review
+// system prompt and project guidelines, when set, followed by the open
+// reviews to verify against the current codebase and consolidate.
+func BuildCompactPrompt(systemPrompt, guidelines, branch string, reviews []CompactReview) string {
+	var sb strings.Builder
+
+	if systemPrompt = strings.TrimSpace(systemPrompt); systemPrompt != "" {
+		sb.WriteString(systemPrompt)
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("# Verification and Consolidation Request\n\n")
+	sb.WriteString("You are a code reviewer tasked with verifying and consolidating previous review findings.\n\n")
+
+	// Project guidelines keep the compact agent on the same rules as
+	// regular reviews.
+	if guidelines != "" {
+		sb.WriteString("## Project Guidelines\n\n")
+		sb.WriteString(strings.TrimSpace(guidelines))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(VerifyDedupePreamble())
+
+	sb.WriteString("## Open Review Findings\n\n")
+	fmt.Fprintf(&sb, "Below are %d open %s", len(reviews), reviewNoun(len(reviews)))
+	if branch != "" {
+		fmt.Fprintf(&sb, " from branch %s", branch)
+	}
+	sb.WriteString(":\n\n")
+
+	for i, r := range reviews {
+		fmt.Fprintf(&sb, "--- Review %d (Job %d", i+1, r.JobID)
+		if r.GitRef != "" {
+			fmt.Fprintf(&sb, " — %s", gitrepo.ShortSHA(r.GitRef))
+		}
+		sb.WriteString(") ---\n")
+		sb.WriteString(r.Output)
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("## Expected Output\n\n")
+	sb.WriteString("Provide a consolidated review output containing only verified findings.\n")
+	sb.WriteString("Use the same general structure as regular reviews so verdict parsing and downstream fix flows continue to work.\n")
+	sb.WriteString("Do not include false positives or already-fixed issues as findings.\n")
+	sb.WriteString("If any verified findings remain, repeat each remaining finding in the final review output; do not output only counts, totals, or a summary.\n")
+	sb.WriteString("If NO findings remain after verification, use the no-issues form from the regular review format.\n")
+
+	return sb.String()
+}
+
+// CompactOutputPrefix returns the header stored ahead of a compact job's
+// output, naming the reviews it consolidated.
+func CompactOutputPrefix(branch string, jobIDs []int64) string {
+	var sb strings.Builder
+	sb.WriteString("## Compact Analysis\n\n")
+	fmt.Fprintf(&sb, "Verified and consolidated %d open %s", len(jobIDs), reviewNoun(len(jobIDs)))
+	if branch != "" {
+		fmt.Fprintf(&sb, " from branch %s", branch)
+	}
+	sb.WriteString("\n\n")
+	ids := make([]string, len(jobIDs))
+	for i, id := range jobIDs {
+		ids[i] = fmt.Sprint(id)
+	}
+	fmt.Fprintf(&sb, "Original jobs: %s\n\n", strings.Join(ids, ", "))
+	sb.WriteString("---\n\n")
+	return sb.String()
+}
+
+func reviewNoun(n int) string {
+	if n == 1 {
+		return "review"
+	}
+	return "reviews"
+}
@@ -0,0 +1,54 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactOutputPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		branch       string
+		jobIDs       []int64
+		wantContains []string
+	}{
+		{
+			name:   "with_branch",
+			branch: "main",
+			jobIDs: []int64{123, 124, 125},
+			wantContains: []string{
+				"Verified and consolidated 3 open reviews from branch main",
+				"Original jobs: 123, 124, 125",
+			},
+		},
+		{
+			name:   "without_branch",
+			branch: "",
+			jobIDs: []int64{100, 200},
+			wantContains: []string{
+				"Verified and consolidated 2 open reviews",
+				"Original jobs: 100, 200",
+			},
+		},
+		{
+			name:   "single_job",
+			branch: "feature",
+			jobIDs: []int64{999},
+			wantContains: []string{
+				"Verified and consolidated 1 open review from branch feature",
+				"Original jobs: 999",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompactOutputPrefix(tt.branch, tt.jobIDs)
+
+			for _, want := range tt.wantContains {
+				assert.Contains(t, got, want, "CompactOutputPrefix() missing")
+			}
+		})
+	}
+}
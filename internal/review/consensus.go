@@ -0,0 +1,257 @@
+package review
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// consensusLineWindow is how far apart two findings in the same file may be
+// and still count as the same issue. Agents often cite the first line of a
+// block or the line of the offending call, so exact matches are too strict.
+const consensusLineWindow = 3
+
+// consensusGroup is one deduplicated finding and the members that reported it.
+type consensusGroup struct {
+	finding   Finding
+	reporters []string
+}
+
+// CombineDeterministic merges panel member reviews without an agent call,
+// using a deterministic synthesis strategy (union, majority, or strictest).
+// results holds every member, including those that failed: union and
+// majority combine the members that produced a review, while strictest also
+// fails the panel when a member did not finish. quorum applies to the
+// majority strategy; zero means a strict majority of the members that
+// produced a review, and a configured quorum more members than that is an
+// error. Findings below minSeverity are dropped. The output keeps the regular
+// review format so verdict parsing and fix flows work unchanged: it contains
+// severity-labeled findings when the panel fails and a "No issues found."
+// style line when it passes.
+func CombineDeterministic(
+	strategy string, quorum int, results []ReviewResult, minSeverity string,
+) (string, error) {
+	var reviews []ReviewResult
+	for _, r := range results {
+		if r.Status == ResultDone && strings.TrimSpace(r.Output) != "" {
+			reviews = append(reviews, r)
+		}
+	}
+	switch strategy {
+	case config.SynthesisStrategyStrictest:
+		return combineStrictest(results, minSeverity), nil
+	case config.SynthesisStrategyMajority:
+		if quorum <= 0 {
+			quorum = len(reviews)/2 + 1
+		}
+		if quorum > len(reviews) {
+			return "", fmt.Errorf(
+				"synthesis_quorum %d cannot be reached: only %d of %d panel members produced a review",
+				quorum, len(reviews), len(results))
+		}
+		return combineVoted(reviews, minSeverity, quorum), nil
+	default:
+		return combineVoted(reviews, minSeverity, 1), nil
+	}
+}
+
+// combineVoted dedupes findings across members and keeps those reported by at
+// least quorum distinct members. A quorum of 1 is the union strategy.
+func combineVoted(reviews []ReviewResult, minSeverity string, quorum int) string {
+	var (
+		groups       []*consensusGroup
+		unstructured []ReviewResult
+	)
+	for _, r := range reviews {
+		findings := ParseFindings(r.Output)
+		if len(findings) == 0 {
+			// A failing review whose findings could not be parsed must not be
+			// silently dropped; it is reported verbatim (union) or listed as
+			// unconfirmed (majority), since it cannot be matched to others.
+			if storage.ParseVerdict(r.Output) != "P" {
+				unstructured = append(unstructured, r)
+			}
+			continue
+		}
+		for _, f := range findings {
+			if !meetsMinSeverity(f.Severity, minSeverity) {
+				continue
+			}
+			groups = addToGroup(groups, f, memberLabel(r))
+		}
+	}
+
+	var kept, dropped []*consensusGroup
+	for _, g := range groups {
+		if len(g.reporters) >= quorum {
+			kept = append(kept, g)
+		} else {
+			dropped = append(dropped, g)
+		}
+	}
+	slices.SortStableFunc(kept, func(a, b *consensusGroup) int {
+		return severityRank(a.finding.Severity) - severityRank(b.finding.Severity)
+	})
+	keepUnstructured := quorum <= 1 && len(unstructured) > 0
+
+	var b strings.Builder
+	switch {
+	case len(kept) == 0 && !keepUnstructured && quorum <= 1:
+		b.WriteString("No issues found.\n")
+	case len(kept) == 0 && !keepUnstructured:
+		fmt.Fprintf(&b, "No findings reached the majority threshold (%d of %d reviewers).\n",
+			quorum, len(reviews))
+	case quorum <= 1:
+		fmt.Fprintf(&b, "Combined %d panel reviews: %d unique findings.\n",
+			len(reviews), len(kept))
+	default:
+		fmt.Fprintf(&b, "Majority of %d panel reviews (threshold %d): %d findings.\n",
+			len(reviews), quorum, len(kept))
+	}
+	for _, g := range kept {
+		b.WriteString("\n---\n")
+		b.WriteString(g.finding.Text)
+		fmt.Fprintf(&b, "\nReported by: %s\n", strings.Join(g.reporters, ", "))
+	}
+	if keepUnstructured {
+		for _, r := range unstructured {
+			fmt.Fprintf(&b, "\n---\n### Review from %s\n%s\n", memberLabel(r), strings.TrimSpace(r.Output))
+		}
+	}
+	if quorum > 1 && (len(dropped) > 0 || len(unstructured) > 0) {
+		b.WriteString("\nBelow threshold:\n")
+		for _, g := range dropped {
+			title := g.finding.Title
+			if loc := g.finding.Location(); loc != "" {
+				title = "`" + loc + "` " + strings.TrimSpace(strings.TrimPrefix(title, loc))
+			}
+			fmt.Fprintf(&b, "- Reported by %s: %s\n", strings.Join(g.reporters, ", "), title)
+		}
+		for _, r := range unstructured {
+			fmt.Fprintf(&b, "- Reported by %s: unstructured review output\n", memberLabel(r))
+		}
+	}
+	return b.String()
+}
+
+// combineStrictest fails the panel when any member's review fails or any
+// member did not produce a review, and reproduces every failing member's
+// review verbatim. Skipped members and members allowed to fail do not count.
+func combineStrictest(results []ReviewResult, minSeverity string) string {
+	var failing, unreviewed []ReviewResult
+	for _, r := range results {
+		switch {
+		case r.Status == ResultSkipped || r.Skipped:
+		case r.Status != ResultDone || strings.TrimSpace(r.Output) == "":
+			if !r.AllowFailure {
+				unreviewed = append(unreviewed, r)
+			}
+		case reviewFails(r.Output, minSeverity):
+			failing = append(failing, r)
+		}
+	}
+	if len(failing) == 0 && len(unreviewed) == 0 {
+		return "No issues found.\n"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Failed: %d of %d panel reviews reported issues", len(failing), len(results))
+	if len(unreviewed) > 0 {
+		fmt.Fprintf(&b, " and %d did not complete", len(unreviewed))
+	}
+	b.WriteString(".\n")
+	for _, r := range failing {
+		fmt.Fprintf(&b, "\n---\n### Review from %s\n%s\n", memberLabel(r), strings.TrimSpace(r.Output))
+	}
+	for _, r := range unreviewed {
+		reason := strings.TrimSpace(r.Error)
+		if reason == "" {
+			reason = "no review output"
+		}
+		fmt.Fprintf(&b, "\n---\n### %s did not complete\n%s\n", memberLabel(r), reason)
+	}
+	return b.String()
+}
+
+// reviewFails reports whether a member review fails at minSeverity. Reviews
+// whose findings cannot be parsed fall back to the stored verdict rules.
+func reviewFails(output, minSeverity string) bool {
+	if storage.ParseVerdict(output) == "P" {
+		return false
+	}
+	findings := ParseFindings(output)
+	if len(findings) == 0 {
+		return true
+	}
+	for _, f := range findings {
+		if meetsMinSeverity(f.Severity, minSeverity) {
+			return true
+		}
+	}
+	return false
+}
+
+// addToGroup merges f into the group describing the same issue, keeping the
+// most severe wording, or starts a new group.
+func addToGroup(groups []*consensusGroup, f Finding, reporter string) []*consensusGroup {
+	for _, g := range groups {
+		if !sameIssue(g.finding, f) {
+			continue
+		}
+		if severityRank(f.Severity) < severityRank(g.finding.Severity) {
+			g.finding = f
+		}
+		if !slices.Contains(g.reporters, reporter) {
+			g.reporters = append(g.reporters, reporter)
+		}
+		return groups
+	}
+	return append(groups, &consensusGroup{finding: f, reporters: []string{reporter}})
+}
+
+// sameIssue reports whether two findings describe the same issue: the same
+// file with nearby lines, or the same title when no line (or file) is given.
+func sameIssue(a, b Finding) bool {
+	if a.File != b.File {
+		return false
+	}
+	if a.File != "" && a.Line > 0 && b.Line > 0 {
+		d := a.Line - b.Line
+		return d >= -consensusLineWindow && d <= consensusLineWindow
+	}
+	return normalizeTitle(a.Title) == normalizeTitle(b.Title)
+}
+
+func normalizeTitle(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.Trim(s, " .`*_"))), " ")
+}
+
+// severityRank orders severities most severe first; unknown sorts last.
+func severityRank(sev string) int {
+	if i := slices.Index(findingSeverities, sev); i >= 0 {
+		return i
+	}
+	return len(findingSeverities)
+}
+
+// meetsMinSeverity reports whether sev is at or above minSeverity. An empty
+// or "low" threshold keeps everything.
+func meetsMinSeverity(sev, minSeverity string) bool {
+	minRank := slices.Index(findingSeverities, strings.ToLower(strings.TrimSpace(minSeverity)))
+	if minRank < 0 {
+		return true
+	}
+	return severityRank(sev) <= minRank
+}
+
+// memberLabel names a member review for attribution: the panel member name
+// when known, else the agent.
+func memberLabel(r ReviewResult) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Agent
+}
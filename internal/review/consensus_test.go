@@ -0,0 +1,131 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func consensusReviews() []ReviewResult {
+	return []ReviewResult{
+		{Name: "security", Agent: "codex", Status: ResultDone, Output: `- High — internal/auth/token.go:40 token is logged in plaintext
+---
+- Low — README.md:3 typo in heading`},
+		{Name: "correctness", Agent: "gemini", Status: ResultDone, Output: `- **Severity**: Critical
+- **Location**: internal/auth/token.go:42
+- **Problem**: Token written to logs.`},
+		{Name: "style", Agent: "claude-code", Status: ResultDone, Output: "No issues found."},
+	}
+}
+
+func combineForTest(t *testing.T, strategy string, quorum int, results []ReviewResult, minSeverity string) string {
+	t.Helper()
+	out, err := CombineDeterministic(strategy, quorum, results, minSeverity)
+	require.NoError(t, err)
+	return out
+}
+
+func TestCombineDeterministicUnion(t *testing.T) {
+	assert := assert.New(t)
+	out := combineForTest(t, config.SynthesisStrategyUnion, 0, consensusReviews(), "")
+
+	findings := ParseFindings(out)
+	require.Len(t, findings, 2, out)
+	assert.Equal("critical", findings[0].Severity, "merged duplicates keep the most severe wording, sorted first")
+	assert.Equal("low", findings[1].Severity)
+	assert.Contains(out, "Reported by: security, correctness")
+	assert.Equal("F", storage.ParseVerdict(out))
+
+	filtered := combineForTest(t, config.SynthesisStrategyUnion, 0, consensusReviews(), "high")
+	assert.Len(ParseFindings(filtered), 1)
+	assert.NotContains(filtered, "README.md")
+
+	clean := combineForTest(t, config.SynthesisStrategyUnion, 0, consensusReviews()[2:], "")
+	assert.Equal("P", storage.ParseVerdict(clean))
+}
+
+func TestCombineDeterministicUnionKeepsUnstructuredFailures(t *testing.T) {
+	reviews := []ReviewResult{
+		{Agent: "codex", Status: ResultDone, Output: "The auth module leaks tokens into the request log."},
+		{Agent: "gemini", Status: ResultDone, Output: "No issues found."},
+	}
+	out := combineForTest(t, config.SynthesisStrategyUnion, 0, reviews, "")
+	assert.Contains(t, out, "leaks tokens")
+	assert.Equal(t, "F", storage.ParseVerdict(out))
+}
+
+func TestCombineDeterministicMajority(t *testing.T) {
+	assert := assert.New(t)
+
+	// Default quorum for three members is two: only the token finding counts.
+	out := combineForTest(t, config.SynthesisStrategyMajority, 0, consensusReviews(), "")
+	findings := ParseFindings(out)
+	require.Len(t, findings, 1, out)
+	assert.Equal("internal/auth/token.go", findings[0].File)
+	assert.Contains(out, "- Reported by security: `README.md:3` typo in heading")
+	assert.Equal("F", storage.ParseVerdict(out))
+
+	// With a quorum of three nothing qualifies and the panel passes, but the
+	// dropped findings are still listed.
+	out = combineForTest(t, config.SynthesisStrategyMajority, 3, consensusReviews(), "")
+	assert.Empty(ParseFindings(out))
+	assert.Contains(out, "No findings reached the majority threshold (3 of 3 reviewers).")
+	assert.Contains(out, "- Reported by security, correctness: `internal/auth/token.go:42` Token written to logs.")
+	assert.Equal("P", storage.ParseVerdict(out))
+}
+
+func TestCombineDeterministicStrictest(t *testing.T) {
+	assert := assert.New(t)
+
+	out := combineForTest(t, config.SynthesisStrategyStrictest, 0, consensusReviews(), "")
+	assert.Contains(out, "Failed: 2 of 3 panel reviews reported issues.")
+	assert.Contains(out, "### Review from security")
+	assert.Contains(out, "### Review from correctness")
+	assert.NotContains(out, "### Review from style")
+	assert.Equal("F", storage.ParseVerdict(out))
+
+	// A review whose findings are all below min_severity does not fail.
+	reviews := consensusReviews()
+	reviews[0].Output = "- Low — README.md:3 typo in heading"
+	out = combineForTest(t, config.SynthesisStrategyStrictest, 0, reviews, "medium")
+	assert.Contains(out, "Failed: 1 of 3")
+	assert.NotContains(out, "README.md")
+
+	out = combineForTest(t, config.SynthesisStrategyStrictest, 0, consensusReviews()[2:], "")
+	assert.Equal("P", storage.ParseVerdict(out))
+}
+
+func TestCombineDeterministicStrictestFailsOnFailedMember(t *testing.T) {
+	assert := assert.New(t)
+	results := []ReviewResult{
+		{Name: "style", Agent: "claude-code", Status: ResultDone, Output: "No issues found."},
+		{Name: "security", Agent: "codex", Status: ResultFailed, Error: "agent timed out"},
+	}
+
+	out := combineForTest(t, config.SynthesisStrategyStrictest, 0, results, "")
+	assert.Contains(out, "Failed: 0 of 2 panel reviews reported issues and 1 did not complete.")
+	assert.Contains(out, "### security did not complete\nagent timed out")
+	assert.Equal("F", storage.ParseVerdict(out))
+
+	// Members allowed to fail, and skipped members, do not fail the panel.
+	results[1].AllowFailure = true
+	results = append(results, ReviewResult{Name: "design", Status: ResultSkipped, Skipped: true})
+	out = combineForTest(t, config.SynthesisStrategyStrictest, 0, results, "")
+	assert.Equal("P", storage.ParseVerdict(out))
+}
+
+func TestCombineDeterministicMajorityUnreachableQuorum(t *testing.T) {
+	results := consensusReviews()
+	results[2] = ReviewResult{Name: "style", Agent: "claude-code", Status: ResultFailed, Error: "quota"}
+
+	_, err := CombineDeterministic(config.SynthesisStrategyMajority, 3, results, "")
+	require.ErrorContains(t, err, "synthesis_quorum 3 cannot be reached: only 2 of 3 panel members produced a review")
+
+	// The default quorum follows the members that produced a review.
+	out := combineForTest(t, config.SynthesisStrategyMajority, 0, results, "")
+	assert.Len(t, ParseFindings(out), 1)
+}
@@ -0,0 +1,270 @@
+package review
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Finding is a single issue extracted from review output. The review
+// prompts ask agents for Severity/Location/Problem/Fix blocks separated
+// by "---" lines; compact "- High — file.go:12 problem" bullets are
+// also recognized.
+type Finding struct {
+	Severity string // "critical", "high", "medium", or "low"
+	File     string // referenced path, as written by the agent
+	Line     int    // first referenced line; 0 when none was given
+	Title    string // one-line summary
+	Text     string // full finding text
+}
+
+// Location formats the finding's file and line as "file:line", "file",
+// or "" when the finding references no file.
+func (f Finding) Location() string {
+	if f.File == "" {
+		return ""
+	}
+	if f.Line > 0 {
+		return f.File + ":" + strconv.Itoa(f.Line)
+	}
+	return f.File
+}
+
+var findingSeverities = []string{"critical", "high", "medium", "low"}
+
+// locationRe matches a path with an extension and an optional line
+// reference: "a/b.go:12", "a/b.go:12-20", "`b.go` line 12",
+// "b.go (lines 3-5)".
+var locationRe = regexp.MustCompile(
+	"`?([A-Za-z0-9_@+~-]*(?:[./][A-Za-z0-9_@+~-]+)*\\.[A-Za-z0-9]+)`?" +
+		`(?::(\d+)|,?\s*\(?lines?\s+(\d+))?`,
+)
+
+// HasFindingAtLeast reports whether review output contains a finding at or
+// above minSeverity. An empty or "low" threshold matches any finding.
+func HasFindingAtLeast(output, minSeverity string) bool {
+	for _, f := range ParseFindings(output) {
+		if meetsMinSeverity(f.Severity, minSeverity) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFindings extracts the findings from review output, in order.
+// Blocks without a severity label are not findings and are skipped, so
+// "No issues found." output yields an empty slice.
+func ParseFindings(output string) []Finding {
+	var (
+		findings []Finding
+		current  []string
+		inside   bool
+	)
+	flush := func() {
+		if inside {
+			if f, ok := buildFinding(current); ok {
+				findings = append(findings, f)
+			}
+		}
+		current = nil
+		inside = false
+	}
+	for line := range strings.SplitSeq(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "---" || trimmed == "***":
+			flush()
+			continue
+		case isSummaryHeading(trimmed):
+			flush()
+			continue
+		case findingSeverity(trimmed) != "":
+			// Bulleted findings are often listed without "---"
+			// separators, so every severity line starts a new finding.
+			flush()
+			inside = true
+		}
+		if inside {
+			current = append(current, line)
+		}
+	}
+	flush()
+	return findings
+}
+
+func buildFinding(lines []string) (Finding, bool) {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return Finding{}, false
+	}
+	f := Finding{
+		Severity: findingSeverity(strings.TrimSpace(lines[0])),
+		Text:     strings.TrimSpace(strings.Join(lines, "\n")),
+	}
+	if f.Severity == "" {
+		return Finding{}, false
+	}
+
+	var problem, inline string
+	for i, line := range lines {
+		text := stripFindingMarkup(line)
+		if text == "" {
+			continue
+		}
+		label, value, hasLabel := fieldLabel(text)
+		switch {
+		case hasLabel && label == "location":
+			if f.File == "" {
+				f.File, f.Line = matchLocation(value, false)
+			}
+		case hasLabel && (label == "problem" || label == "issue" || label == "description"):
+			if problem == "" {
+				problem = value
+			}
+		case i == 0 && !hasLabel:
+			// "- High — a.go:12 leaks the handle": the text after the
+			// severity word and separator is the title.
+			inline = strings.TrimLeft(strings.TrimSpace(text[len(f.Severity):]), "—–:|- ")
+		}
+	}
+	if f.File == "" {
+		// No Location field; fall back to the first path:line anywhere
+		// in the finding. Requiring a line number here avoids treating
+		// prose like "e.g." or "config.toml" mentions as locations.
+		f.File, f.Line = matchLocation(f.Text, true)
+	}
+
+	switch {
+	case problem != "":
+		f.Title = problem
+	case inline != "":
+		f.Title = inline
+	default:
+		f.Title = firstProseLine(lines)
+	}
+	return f, true
+}
+
+// findingSeverity returns the severity a line opens a finding with, or "".
+// Accepts "Severity: High" fields and lines that begin with a severity
+// word followed by a separator, after stripping bullets and markdown.
+func findingSeverity(line string) string {
+	text := strings.ToLower(stripFindingMarkup(line))
+	if text == "" {
+		return ""
+	}
+	if label, value, ok := fieldLabel(text); ok && label == "severity" {
+		value = strings.TrimSpace(strings.Trim(value, "*_`"))
+		for _, sev := range findingSeverities {
+			if strings.HasPrefix(value, sev) {
+				return sev
+			}
+		}
+		return ""
+	}
+	for _, sev := range findingSeverities {
+		rest, ok := strings.CutPrefix(text, sev)
+		if !ok {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, "—") || strings.HasPrefix(rest, "–") ||
+			strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "|") ||
+			strings.HasPrefix(rest, "- ") {
+			return sev
+		}
+	}
+	return ""
+}
+
+// stripFindingMarkup removes list markers, headings, and bold markers.
+func stripFindingMarkup(line string) string {
+	s := strings.TrimSpace(line)
+	s = strings.TrimLeft(s, "#")
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "- ") || strings.HasPrefix(s, "* ") || strings.HasPrefix(s, "• ") {
+		_, s, _ = strings.Cut(s, " ")
+	} else if i := strings.IndexAny(s, ".)"); i > 0 && i < 4 && isDigits(s[:i]) {
+		s = s[i+1:]
+	}
+	s = strings.ReplaceAll(s, "**", "")
+	s = strings.ReplaceAll(s, "__", "")
+	return strings.TrimSpace(s)
+}
+
+// fieldLabel splits "Label: value" into a lowercase label and value.
+// Labels are short single words so prose with colons is not mistaken
+// for a field.
+func fieldLabel(text string) (string, string, bool) {
+	label, value, ok := strings.Cut(text, ":")
+	if !ok || label == "" || len(label) > 12 || strings.ContainsAny(label, " \t`") {
+		return "", "", false
+	}
+	return strings.ToLower(label), strings.TrimSpace(value), true
+}
+
+func matchLocation(text string, requireLine bool) (string, int) {
+	for _, m := range locationRe.FindAllStringSubmatch(text, -1) {
+		path := m[1]
+		lineStr := m[2]
+		if lineStr == "" {
+			lineStr = m[3]
+		}
+		if lineStr == "" && requireLine {
+			continue
+		}
+		if lineStr == "" && !strings.Contains(path, "/") && !isLikelyFileName(path) {
+			continue
+		}
+		line, _ := strconv.Atoi(lineStr)
+		return path, line
+	}
+	return "", 0
+}
+
+// isLikelyFileName rejects abbreviations like "e.g" and version numbers
+// like "1.2" that the location pattern would otherwise accept when no
+// line number anchors the match.
+func isLikelyFileName(path string) bool {
+	dot := strings.LastIndex(path, ".")
+	if dot <= 0 || dot == len(path)-1 {
+		return false
+	}
+	ext := path[dot+1:]
+	return len(path[:dot]) > 1 && !isDigits(ext)
+}
+
+func isSummaryHeading(line string) bool {
+	if !strings.HasPrefix(line, "#") {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(strings.TrimLeft(line, "#")), "summary")
+}
+
+func firstProseLine(lines []string) string {
+	for _, line := range lines {
+		text := stripFindingMarkup(line)
+		if text == "" {
+			continue
+		}
+		if _, _, ok := fieldLabel(text); ok {
+			continue
+		}
+		return text
+	}
+	return stripFindingMarkup(lines[0])
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFindingsFieldBlocks(t *testing.T) {
+	output := `## Review Findings
+
+- **Severity**: High
+- **Location**: internal/daemon/server.go:142
+- **Problem**: Handler leaks the response body on error.
+- **Fix**: Close the body before returning.
+
+---
+
+- **Severity**: Low
+- **Location**: ` + "`cmd/roborev/main.go`" + ` line 9
+- **Problem**: Typo in the usage string.
+
+---
+
+- **Severity**: Medium
+- **Location**: README
+- **Problem**: Docs no longer match the flag names.
+
+## Summary
+Adds a new handler.`
+
+	findings := ParseFindings(output)
+	require.Len(t, findings, 3)
+
+	assert := assert.New(t)
+	assert.Equal("high", findings[0].Severity)
+	assert.Equal("internal/daemon/server.go", findings[0].File)
+	assert.Equal(142, findings[0].Line)
+	assert.Equal("Handler leaks the response body on error.", findings[0].Title)
+	assert.Contains(findings[0].Text, "Close the body")
+	assert.Equal("internal/daemon/server.go:142", findings[0].Location())
+
+	assert.Equal("low", findings[1].Severity)
+	assert.Equal("cmd/roborev/main.go", findings[1].File)
+	assert.Equal(9, findings[1].Line)
+
+	assert.Equal("medium", findings[2].Severity)
+	assert.Empty(findings[2].File)
+	assert.Empty(findings[2].Location())
+	assert.NotContains(findings[2].Text, "Summary")
+}
+
+func TestParseFindingsInlineBullets(t *testing.T) {
+	output := `Findings:
+1. **High** — pkg/a.go:10-14 nil map write in init
+2. Medium: pkg/b.go (line 3) missing error check
+- Low | style nit, see e.g. the docs`
+
+	findings := ParseFindings(output)
+	require.Len(t, findings, 3)
+
+	assert := assert.New(t)
+	assert.Equal("high", findings[0].Severity)
+	assert.Equal("pkg/a.go", findings[0].File)
+	assert.Equal(10, findings[0].Line)
+	assert.Equal("pkg/a.go:10-14 nil map write in init", findings[0].Title)
+
+	assert.Equal("medium", findings[1].Severity)
+	assert.Equal("pkg/b.go", findings[1].File)
+	assert.Equal(3, findings[1].Line)
+
+	assert.Equal("low", findings[2].Severity)
+	assert.Empty(findings[2].File, "prose abbreviations are not locations")
+}
+
+func TestParseFindingsNoIssues(t *testing.T) {
+	assert.Empty(t, ParseFindings("No issues found.\n\nSummary: refactors the cache."))
+	assert.Empty(t, ParseFindings("High-level overview: the change is fine."))
+	assert.Empty(t, ParseFindings(""))
+}
+
+func TestHasFindingAtLeast(t *testing.T) {
+	output := "- Medium — a.go:1 race\n- Low — b.go:2 nit"
+
+	assert := assert.New(t)
+	assert.True(HasFindingAtLeast(output, "medium"))
+	assert.True(HasFindingAtLeast(output, "low"))
+	assert.True(HasFindingAtLeast(output, ""))
+	assert.False(HasFindingAtLeast(output, "high"))
+	assert.False(HasFindingAtLeast("No issues found.", ""), "no findings never matches")
+}
@@ -0,0 +1,99 @@
+package review
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	gitrepo "go.kenn.io/kit/git/repo"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// ResolvedPriorReviewsLabel starts the line an incremental re-review ends
+// with to report which prior reviews the new commits resolve.
+const ResolvedPriorReviewsLabel = "Resolved prior reviews:"
+
+// maxPriorReviewOutput caps each prior review quoted into an incremental
+// review prompt.
+const maxPriorReviewOutput = 8000
+
+// BuildIncrementalContext renders the prompt section for an incremental
+// re-review: the review of baseSHA the new range continues from, then every
+// still-open review whose findings the new commits may have fixed. Open
+// reviews are listed by job ID, and the agent is asked to end its output
+// with a ResolvedPriorReviewsLabel line naming the ones now resolved.
+func BuildIncrementalContext(baseSHA string, base *storage.PriorReview, open []storage.PriorReview) string {
+	var b strings.Builder
+	b.WriteString("## Incremental Review\n\n")
+	fmt.Fprintf(&b,
+		"This branch was last reviewed at commit %s. The diff below contains only the commits made since then. "+
+			"Review those commits. Do not repeat findings from the earlier reviews below unless the new commits "+
+			"make them worse; unresolved findings stay open on their own reviews.\n\n",
+		gitrepo.ShortSHA(baseSHA))
+	if base != nil && !slices.ContainsFunc(open, func(r storage.PriorReview) bool { return r.JobID == base.JobID }) {
+		b.WriteString("### Last review\n\n")
+		b.WriteString(truncatePriorOutput(base.Output))
+		b.WriteString("\n\n")
+	}
+	if len(open) == 0 {
+		return b.String()
+	}
+	for _, r := range open {
+		fmt.Fprintf(&b, "### Open review %d (%s)\n\n", r.JobID, r.GitRef)
+		b.WriteString(truncatePriorOutput(r.Output))
+		b.WriteString("\n\n")
+	}
+	b.WriteString("### Resolved Reviews\n\n")
+	b.WriteString("Check each open review above against the new commits. A review is resolved only when every " +
+		"finding in it is fixed. After your review, end your output with exactly one line listing the resolved " +
+		"review numbers, or none:\n\n")
+	fmt.Fprintf(&b, "%s <comma-separated review numbers>\n", ResolvedPriorReviewsLabel)
+	b.WriteString("\nor:\n\n")
+	fmt.Fprintf(&b, "%s none\n", ResolvedPriorReviewsLabel)
+	return b.String()
+}
+
+func truncatePriorOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxPriorReviewOutput {
+		end := maxPriorReviewOutput
+		for end > 0 && !utf8.RuneStart(output[end]) {
+			end--
+		}
+		output = output[:end] + "\n\n...(truncated)"
+	}
+	return output
+}
+
+var priorReviewIDPattern = regexp.MustCompile(`\d+`)
+
+// ParseResolvedPriorReviews returns the job IDs an incremental re-review's
+// output reports resolved, limited to candidates and in ascending order. The
+// last ResolvedPriorReviewsLabel line wins. ok is false when the output has
+// no such line.
+func ParseResolvedPriorReviews(output string, candidates []int64) (resolved []int64, ok bool) {
+	var list string
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimSpace(strings.NewReplacer("*", "", "`", "", "_", "").Replace(line))
+		line = strings.TrimSpace(strings.TrimLeft(line, "-#>"))
+		if len(line) < len(ResolvedPriorReviewsLabel) ||
+			!strings.EqualFold(line[:len(ResolvedPriorReviewsLabel)], ResolvedPriorReviewsLabel) {
+			continue
+		}
+		list, ok = line[len(ResolvedPriorReviewsLabel):], true
+	}
+	if !ok {
+		return nil, false
+	}
+	for _, m := range priorReviewIDPattern.FindAllString(list, -1) {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err == nil && slices.Contains(candidates, id) && !slices.Contains(resolved, id) {
+			resolved = append(resolved, id)
+		}
+	}
+	slices.Sort(resolved)
+	return resolved, true
+}
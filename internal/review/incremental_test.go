@@ -0,0 +1,72 @@
+package review
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func TestBuildIncrementalContext(t *testing.T) {
+	base := storage.PriorReview{JobID: 9, GitRef: "aaa..bbb", Output: "No issues found."}
+	open := []storage.PriorReview{
+		{JobID: 4, GitRef: "base..aaa", Output: "- High — a.go:1 nil dereference"},
+	}
+
+	got := BuildIncrementalContext("bbbbbbbbbbbbbbbb", &base, open)
+	assert.Contains(t, got, "## Incremental Review")
+	assert.Contains(t, got, "last reviewed at commit bbbbbbb")
+	assert.Contains(t, got, "### Last review\n\nNo issues found.")
+	assert.Contains(t, got, "### Open review 4 (base..aaa)\n\n- High — a.go:1 nil dereference")
+	assert.Contains(t, got, "Resolved prior reviews: none")
+
+	t.Run("open base is listed once", func(t *testing.T) {
+		got := BuildIncrementalContext("aaa", &open[0], open)
+		assert.NotContains(t, got, "### Last review")
+		assert.Contains(t, got, "### Open review 4")
+	})
+
+	t.Run("no open reviews asks for no resolution line", func(t *testing.T) {
+		got := BuildIncrementalContext("bbb", &base, nil)
+		assert.Contains(t, got, "### Last review")
+		assert.NotContains(t, got, ResolvedPriorReviewsLabel)
+	})
+}
+
+func TestTruncatePriorOutputKeepsRunesWhole(t *testing.T) {
+	// The two-byte "é" straddles the byte cap.
+	output := strings.Repeat("a", maxPriorReviewOutput-1) + strings.Repeat("é", 10)
+
+	got := truncatePriorOutput(output)
+	assert.True(t, utf8.ValidString(got), "truncated output must stay valid UTF-8")
+	assert.Equal(t, strings.Repeat("a", maxPriorReviewOutput-1)+"\n\n...(truncated)", got)
+
+	short := "café"
+	assert.Equal(t, short, truncatePriorOutput(short))
+}
+
+func TestParseResolvedPriorReviews(t *testing.T) {
+	candidates := []int64{4, 7, 12}
+	tests := []struct {
+		name   string
+		output string
+		want   []int64
+		ok     bool
+	}{
+		{name: "ids", output: "No issues found.\n\nResolved prior reviews: 12, 4", want: []int64{4, 12}, ok: true},
+		{name: "none", output: "- Low — x.go:1 nit\nResolved prior reviews: none", ok: true},
+		{name: "markdown and hashes", output: "**Resolved prior reviews:** #7", want: []int64{7}, ok: true},
+		{name: "unknown ids dropped", output: "resolved prior reviews: 5, 7, 7", want: []int64{7}, ok: true},
+		{name: "last line wins", output: "Resolved prior reviews: 4\nResolved prior reviews: 12", want: []int64{12}, ok: true},
+		{name: "missing", output: "No issues found."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseResolvedPriorReviews(tt.output, candidates)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package review
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gitrepo "go.kenn.io/kit/git/repo"
+)
+
+// ResolutionCheckReview is an open review a resolution check asks about,
+// with the later commits that touched the files its findings reference.
+type ResolutionCheckReview struct {
+	JobID   int64
+	GitRef  string
+	Output  string
+	Commits []ResolutionCommit // Newest first
+	Diff    string             // Changes to the referenced files since the review
+}
+
+// ResolutionCommit is a commit shown to a resolution check.
+type ResolutionCommit struct {
+	SHA     string
+	Subject string
+}
+
+// BuildResolutionCheckPrompt creates the prompt for a resolution check job:
+// each open review with the commits made since it to the files it names, and
+// instructions to report one verdict line per review.
+func BuildResolutionCheckPrompt(branch, headSHA string, reviews []ResolutionCheckReview) string {
+	var b strings.Builder
+	b.WriteString("# Resolution Check\n\n")
+	fmt.Fprintf(&b,
+		"Later commits on branch %s touched files referenced by the open reviews below. "+
+			"Decide for each review whether every one of its findings is fixed at commit %s.\n\n",
+		branch, gitrepo.ShortSHA(headSHA))
+	b.WriteString("## Instructions\n\n")
+	b.WriteString("1. **Verify each finding against the current codebase:**\n" +
+		"   - Read the referenced code as it is now, not only the diff below\n" +
+		"   - A finding is fixed only when the problem it describes no longer exists\n" +
+		"   - A review is resolved only when every finding in it is fixed\n\n")
+	b.WriteString("2. **Credit the fix:**\n" +
+		"   - For a resolved review, name the listed commit that fixed it; if several commits " +
+		"together fixed it, name the newest of them\n\n")
+	b.WriteString("3. **Do not edit files or report new findings.**\n\n")
+
+	for _, r := range reviews {
+		fmt.Fprintf(&b, "## Review %d (%s)\n\n", r.JobID, r.GitRef)
+		b.WriteString(truncatePriorOutput(r.Output))
+		b.WriteString("\n\n### Commits since the review\n\n")
+		for _, c := range r.Commits {
+			fmt.Fprintf(&b, "- %s %s\n", gitrepo.ShortSHA(c.SHA), c.Subject)
+		}
+		if diff := strings.TrimSpace(r.Diff); diff != "" {
+			b.WriteString("\n### Changes to the referenced files\n\n```diff\n")
+			b.WriteString(diff)
+			b.WriteString("\n```\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Expected Output\n\n")
+	b.WriteString("Output exactly one line per review and nothing else:\n\n")
+	b.WriteString("Review <number>: resolved by <commit>\n")
+	b.WriteString("Review <number>: open\n")
+	return b.String()
+}
+
+var resolutionLinePattern = regexp.MustCompile(
+	`(?i)^review\s+#?(\d+)\s*:\s*(resolved|open)\b(?:\s+by\s+(?:commit\s+)?([0-9a-f]{4,40}))?`)
+
+// ParseResolutionCheck returns the reviews a resolution check reported
+// resolved, mapped to the commit it credited, or "" when it named none.
+// When a review appears more than once, the last line wins.
+func ParseResolutionCheck(output string) map[int64]string {
+	resolved := make(map[int64]string)
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimSpace(strings.NewReplacer("*", "", "`", "").Replace(line))
+		line = strings.TrimSpace(strings.TrimLeft(line, "-#>"))
+		m := resolutionLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		id, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(m[2], "open") {
+			delete(resolved, id)
+			continue
+		}
+		resolved[id] = strings.ToLower(m[3])
+	}
+	return resolved
+}
@@ -0,0 +1,57 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildResolutionCheckPrompt(t *testing.T) {
+	got := BuildResolutionCheckPrompt("feature", "cccccccccccc", []ResolutionCheckReview{{
+		JobID:   4,
+		GitRef:  "base..aaa",
+		Output:  "- High — a.go:1 nil dereference",
+		Commits: []ResolutionCommit{{SHA: "bbbbbbbbbbbb", Subject: "Guard nil config"}},
+		Diff:    "-cfg.Name\n+if cfg != nil {",
+	}})
+	assert.Contains(t, got, "branch feature")
+	assert.Contains(t, got, "fixed at commit ccccccc")
+	assert.Contains(t, got, "## Review 4 (base..aaa)\n\n- High — a.go:1 nil dereference")
+	assert.Contains(t, got, "- bbbbbbb Guard nil config")
+	assert.Contains(t, got, "```diff\n-cfg.Name\n+if cfg != nil {\n```")
+	assert.Contains(t, got, "Review <number>: resolved by <commit>")
+}
+
+func TestParseResolutionCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[int64]string
+	}{
+		{
+			name:   "resolved and open",
+			output: "Review 4: resolved by abc1234\nReview 7: open",
+			want:   map[int64]string{4: "abc1234"},
+		},
+		{
+			name:   "markdown and no commit",
+			output: "- **Review #12:** Resolved\n`Review 9: resolved by commit DEF5678`",
+			want:   map[int64]string{12: "", 9: "def5678"},
+		},
+		{
+			name:   "last line wins",
+			output: "Review 4: resolved by abc1234\nReview 4: open\nReview 5: open\nReview 5: resolved by 1234abc",
+			want:   map[int64]string{5: "1234abc"},
+		},
+		{
+			name:   "prose is ignored",
+			output: "I reviewed 4 files.\nThe review 4 finding looks fixed.",
+			want:   map[int64]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseResolutionCheck(tt.output))
+		})
+	}
+}
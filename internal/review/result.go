@@ -10,6 +10,7 @@ import (
 // ReviewResult holds the outcome of a single review in a batch.
 // Decoupled from storage.BatchReviewResult for daemon-free use.
 type ReviewResult struct {
+	Name       string // panel member name; empty outside panels
 	Agent      string
 	ReviewType string
 	Output     string
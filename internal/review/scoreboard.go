@@ -0,0 +1,185 @@
+package review
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+// dismissalPhrases mark a human comment as rejecting a review's findings
+// wherever they appear as whole words, unless a negation shortly before them
+// in the same clause turns them around ("this is not intentional").
+var dismissalPhrases = []string{
+	"false positive",
+	"false positives",
+	"false-positive",
+	"false-positives",
+	"invalid finding",
+	"won't fix",
+	"wont fix",
+	"wontfix",
+	"by design",
+	"intended behavior",
+	"intentional",
+}
+
+// dismissalOpeners reject a review's findings only when they open a comment:
+// inside a sentence they are as likely to agree with it ("this is not an
+// issue we can ignore").
+var dismissalOpeners = []string{
+	"not an issue",
+	"not a bug",
+	"not valid",
+}
+
+var (
+	dismissalPattern       = regexp.MustCompile(`\b(?:` + quotePhrases(dismissalPhrases) + `)\b`)
+	dismissalOpenerPattern = regexp.MustCompile(`(?m)^\s*(?:` + quotePhrases(dismissalOpeners) + `)\b`)
+	// dismissalNegation matches a negation within the last three words of
+	// the text before a dismissal phrase.
+	dismissalNegation = regexp.MustCompile(`(?:\b(?:not|never|no)|n't)\s+(?:[\w'-]+\s+){0,2}$`)
+)
+
+func quotePhrases(phrases []string) string {
+	quoted := make([]string, len(phrases))
+	for i, p := range phrases {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// IsDismissalComment reports whether a human comment rejects a review's
+// findings rather than acknowledging them. Matching is case-insensitive;
+// comments are newline separated, and each line counts as its own comment
+// for dismissalOpeners.
+func IsDismissalComment(comment string) bool {
+	lower := strings.ToLower(strings.ReplaceAll(comment, "’", "'"))
+	if dismissalOpenerPattern.MatchString(lower) {
+		return true
+	}
+	for _, loc := range dismissalPattern.FindAllStringIndex(lower, -1) {
+		if !negatedBefore(lower[:loc[0]]) {
+			return true
+		}
+	}
+	return false
+}
+
+// negatedBefore reports whether the clause ending at the end of before
+// negates what follows it.
+func negatedBefore(before string) bool {
+	if i := strings.LastIndexAny(before, ".,;:!?\n"); i >= 0 {
+		before = before[i+1:]
+	}
+	return dismissalNegation.MatchString(before)
+}
+
+// ScoreAgents builds the agent quality scoreboard from review samples,
+// one row per agent/model, most-used first. A failing review is accepted
+// when a fix for it was applied, or when it was closed without a dismissal
+// comment; it is dismissed when a human comment calls it a false positive
+// and no fix was applied. Panel agreement counts member findings that
+// another member of the same run also reported, using the same matching as
+// the deterministic synthesis strategies.
+func ScoreAgents(samples []storage.AgentScoreSample) []storage.AgentScore {
+	type key struct{ agent, model string }
+	scores := make(map[key]*storage.AgentScore)
+	findingsByJob := make(map[int64][]Finding, len(samples))
+	runs := make(map[string][]int64)
+
+	for _, s := range samples {
+		k := key{s.Agent, s.Model}
+		sc := scores[k]
+		if sc == nil {
+			sc = &storage.AgentScore{Agent: s.Agent, Model: s.Model}
+			scores[k] = sc
+		}
+		sc.Reviews++
+		sc.CostUSD += s.CostUSD
+		if s.Passed {
+			continue
+		}
+
+		findings := ParseFindings(s.Output)
+		findingsByJob[s.JobID] = findings
+		if s.PanelRunUUID != "" {
+			runs[s.PanelRunUUID] = append(runs[s.PanelRunUUID], s.JobID)
+		}
+		// A failing review without parseable findings still reported
+		// something; count it as one finding.
+		count := max(len(findings), 1)
+		sc.FailedReviews++
+		sc.Findings += count
+
+		switch {
+		case s.FixApplied:
+			sc.Accepted++
+			sc.FixesApplied++
+			sc.AcceptedFindings += count
+		case IsDismissalComment(s.Comments):
+			sc.Dismissed++
+		case s.Closed:
+			sc.Accepted++
+			sc.AcceptedFindings += count
+		default:
+			sc.Open++
+		}
+	}
+
+	for _, s := range samples {
+		if s.Passed || s.PanelRunUUID == "" {
+			continue
+		}
+		sc := scores[key{s.Agent, s.Model}]
+		for _, f := range findingsByJob[s.JobID] {
+			sc.PanelFindings++
+			if reportedByOther(f, s.JobID, runs[s.PanelRunUUID], findingsByJob) {
+				sc.AgreedFindings++
+			}
+		}
+	}
+
+	out := make([]storage.AgentScore, 0, len(scores))
+	for _, sc := range scores {
+		if resolved := sc.Accepted + sc.Dismissed; resolved > 0 {
+			sc.Precision = float64(sc.Accepted) / float64(resolved)
+			sc.FalsePositiveRate = float64(sc.Dismissed) / float64(resolved)
+		}
+		if sc.PanelFindings > 0 {
+			sc.AgreementRate = float64(sc.AgreedFindings) / float64(sc.PanelFindings)
+		}
+		if sc.AcceptedFindings > 0 {
+			sc.CostPerAcceptedFinding = sc.CostUSD / float64(sc.AcceptedFindings)
+		}
+		out = append(out, *sc)
+	}
+	slices.SortFunc(out, func(a, b storage.AgentScore) int {
+		return cmp.Or(
+			cmp.Compare(b.Reviews, a.Reviews),
+			cmp.Compare(a.Agent, b.Agent),
+			cmp.Compare(a.Model, b.Model),
+		)
+	})
+	return out
+}
+
+// reportedByOther reports whether another member review of the same panel run
+// contains a finding describing the same issue as f.
+func reportedByOther(
+	f Finding, jobID int64, runJobs []int64, findingsByJob map[int64][]Finding,
+) bool {
+	for _, other := range runJobs {
+		if other == jobID {
+			continue
+		}
+		for _, g := range findingsByJob[other] {
+			if sameIssue(f, g) {
+				return true
+			}
+		}
+	}
+	return false
+}
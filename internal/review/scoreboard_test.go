@@ -0,0 +1,72 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/storage"
+)
+
+func TestIsDismissalComment(t *testing.T) {
+	assert.True(t, IsDismissalComment("False positive: the lock is held by the caller"))
+	assert.True(t, IsDismissalComment("Won’t fix, by design"))
+	assert.True(t, IsDismissalComment("Thanks.\nNot valid: the input is sanitized upstream"))
+	assert.True(t, IsDismissalComment("This is intentional"))
+	assert.True(t, IsDismissalComment("These are all false positives"))
+	assert.False(t, IsDismissalComment("Fixed in abc123"))
+	assert.False(t, IsDismissalComment(""))
+	assert.False(t, IsDismissalComment("The nil write was unintentional, fixed"))
+	assert.False(t, IsDismissalComment("this is not an issue we can ignore"))
+	assert.False(t, IsDismissalComment("The old check was not valid, replaced it"))
+	assert.False(t, IsDismissalComment("this is not intentional"))
+	assert.False(t, IsDismissalComment("That wasn’t by design, fixing"))
+	assert.False(t, IsDismissalComment("Never intended behavior; patched"))
+	assert.False(t, IsDismissalComment("not a false positive, good catch"))
+	assert.True(t, IsDismissalComment("No, this is intentional"))
+	assert.True(t, IsDismissalComment("Not a bug. The retry is by design"))
+}
+
+func TestScoreAgents(t *testing.T) {
+	samples := []storage.AgentScoreSample{
+		{JobID: 1, Agent: "codex", Model: "gpt-5", Passed: true, Output: "No issues found.", CostUSD: 0.25},
+		{JobID: 2, Agent: "codex", Model: "gpt-5", Output: "- High — a.go:10 bug\n---\n- Low — a.go:90 nit",
+			FixApplied: true, CostUSD: 0.75},
+		{JobID: 3, Agent: "codex", Model: "gpt-5", Output: "- Medium — b.go:1 race", Closed: true,
+			Comments: "not a bug, guarded by the caller"},
+		{JobID: 4, Agent: "codex", Model: "gpt-5", Output: "Something looks off in the parser."},
+		{JobID: 5, Agent: "gemini", PanelRunUUID: "run", Output: "- High — c.go:5 leak\n---\n- Low — d.go:1 typo",
+			Closed: true},
+		{JobID: 6, Agent: "codex", Model: "gpt-5", PanelRunUUID: "run", Output: "- Medium — c.go:7 leaked handle"},
+	}
+
+	scores := ScoreAgents(samples)
+	require.Len(t, scores, 2)
+	assert := assert.New(t)
+
+	codex := scores[0]
+	assert.Equal("codex", codex.Agent)
+	assert.Equal("gpt-5", codex.Model)
+	assert.Equal(5, codex.Reviews)
+	assert.Equal(4, codex.FailedReviews)
+	assert.Equal(5, codex.Findings, "unparseable failing reviews count as one finding")
+	assert.Equal(1, codex.Accepted)
+	assert.Equal(1, codex.FixesApplied)
+	assert.Equal(1, codex.Dismissed)
+	assert.Equal(2, codex.Open)
+	assert.Equal(2, codex.AcceptedFindings)
+	assert.InDelta(0.5, codex.Precision, 1e-9)
+	assert.InDelta(0.5, codex.FalsePositiveRate, 1e-9)
+	assert.InDelta(0.5, codex.CostPerAcceptedFinding, 1e-9)
+	assert.Equal(1, codex.PanelFindings)
+	assert.Equal(1, codex.AgreedFindings)
+
+	gemini := scores[1]
+	assert.Equal(1, gemini.Accepted)
+	assert.InDelta(1.0, gemini.Precision, 1e-9)
+	assert.Equal(2, gemini.PanelFindings)
+	assert.Equal(1, gemini.AgreedFindings)
+	assert.InDelta(0.5, gemini.AgreementRate, 1e-9)
+	assert.Zero(gemini.CostPerAcceptedFinding, "no reported cost")
+}
@@ -0,0 +1,104 @@
+package sandbox
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// pathEntry is a host path and whether it is a directory.
+type pathEntry struct {
+	Path  string
+	IsDir bool
+}
+
+// bwrapSpec describes one sandboxed invocation.
+type bwrapSpec struct {
+	Home        string
+	Overlay     bool // mount a throwaway overlay over Home
+	HidePaths   []pathEntry
+	Writable    []pathEntry
+	Dir         string   // work tree
+	Chdir       string   // working directory; defaults to Dir, then /tmp
+	GitDirs     []string // worktree git dirs outside Dir
+	DirRW       bool
+	Share       []string // bound read-write after Dir
+	Helper      string   // roborev executable; empty when there is no network
+	ProxySocket string
+	Command     []string
+}
+
+// bwrapArgs builds the bubblewrap arguments for spec. Later mounts shadow
+// earlier ones, so the order is: read-only host, scratch /tmp, home overlay,
+// hidden paths, writable paths, and finally the work tree and proxy socket.
+func bwrapArgs(spec bwrapSpec) []string {
+	args := []string{
+		"--die-with-parent",
+		"--new-session",
+		"--unshare-all",
+		"--ro-bind", "/", "/",
+		"--dev", "/dev",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+	}
+	if spec.Overlay && spec.Home != "" {
+		args = append(args, "--overlay-src", spec.Home, "--tmp-overlay", spec.Home)
+	}
+	for _, p := range spec.HidePaths {
+		if p.IsDir {
+			args = append(args, "--tmpfs", p.Path)
+		} else {
+			args = append(args, "--ro-bind", "/dev/null", p.Path)
+		}
+	}
+	for _, p := range spec.Writable {
+		args = append(args, "--bind", p.Path, p.Path)
+	}
+
+	bind := "--ro-bind"
+	if spec.DirRW {
+		bind = "--bind"
+	}
+	// Parents before children, so a worktree's gitdir is not shadowed by
+	// its common dir.
+	gitDirs := slices.Clone(spec.GitDirs)
+	slices.SortFunc(gitDirs, func(a, b string) int { return len(a) - len(b) })
+	for _, d := range gitDirs {
+		args = append(args, bind, d, d)
+	}
+	chdir := spec.Chdir
+	if spec.Dir != "" {
+		args = append(args, bind, spec.Dir, spec.Dir)
+		if chdir == "" {
+			chdir = spec.Dir
+		}
+	}
+	if chdir == "" {
+		chdir = "/tmp"
+	}
+	for _, p := range spec.Share {
+		args = append(args, "--bind", p, p)
+	}
+
+	if spec.Helper != "" {
+		sockDir := filepath.Dir(spec.ProxySocket)
+		args = append(args, "--ro-bind", sockDir, sockDir)
+	}
+	args = append(args, "--chdir", chdir, "--")
+	if spec.Helper != "" {
+		args = append(args, spec.Helper, HelperCommand, "--proxy", spec.ProxySocket, "--")
+	}
+	return append(args, spec.Command...)
+}
+
+// bwrapSupportsTmpOverlay reports whether bwrap has --tmp-overlay
+// (bubblewrap 0.10+). Older versions fall back to a read-only home.
+func bwrapSupportsTmpOverlay(bwrap string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, bwrap, "--help").CombinedOutput()
+	return err == nil && strings.Contains(string(out), "--tmp-overlay")
+}
@@ -0,0 +1,127 @@
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBwrapArgs(t *testing.T) {
+	args := bwrapArgs(bwrapSpec{
+		Home:    "/home/dev",
+		Overlay: true,
+		HidePaths: []pathEntry{
+			{Path: "/home/dev/.ssh", IsDir: true},
+			{Path: "/home/dev/.netrc"},
+		},
+		Writable:    []pathEntry{{Path: "/home/dev/.claude", IsDir: true}},
+		Dir:         "/src/wt",
+		GitDirs:     []string{"/src/repo/.git/worktrees/wt", "/src/repo/.git"},
+		Helper:      "/usr/bin/roborev",
+		ProxySocket: "/tmp/roborev-sandbox-1/proxy.sock",
+		Command:     []string{"/usr/bin/claude", "-p"},
+	})
+	got := strings.Join(args, " ")
+
+	assert := assert.New(t)
+	assert.True(strings.HasPrefix(got, "--die-with-parent --new-session --unshare-all --ro-bind / / "))
+	assert.NotContains(got, "--share-net")
+	assert.Contains(got, "--overlay-src /home/dev --tmp-overlay /home/dev")
+	assert.Contains(got, "--tmpfs /home/dev/.ssh --ro-bind /dev/null /home/dev/.netrc")
+	assert.Contains(got, "--bind /home/dev/.claude /home/dev/.claude")
+	// Common dir before the worktree gitdir, then the read-only work tree.
+	assert.Contains(got, "--ro-bind /src/repo/.git /src/repo/.git "+
+		"--ro-bind /src/repo/.git/worktrees/wt /src/repo/.git/worktrees/wt "+
+		"--ro-bind /src/wt /src/wt")
+	assert.True(strings.HasSuffix(got, "--ro-bind /tmp/roborev-sandbox-1 /tmp/roborev-sandbox-1 "+
+		"--chdir /src/wt -- /usr/bin/roborev sandbox-exec --proxy /tmp/roborev-sandbox-1/proxy.sock -- /usr/bin/claude -p"))
+	// The home overlay must not shadow the hidden paths.
+	assert.Less(strings.Index(got, "--tmp-overlay"), strings.Index(got, "--tmpfs /home/dev/.ssh"))
+}
+
+func TestBwrapArgsWritableNoNetwork(t *testing.T) {
+	args := bwrapArgs(bwrapSpec{
+		Dir:     "/src/repo",
+		DirRW:   true,
+		Share:   []string{"/tmp/prompt.md"},
+		Command: []string{"/usr/bin/codex", "exec"},
+	})
+	got := strings.Join(args, " ")
+	assert.Contains(t, got, "--bind /src/repo /src/repo --bind /tmp/prompt.md /tmp/prompt.md")
+	assert.NotContains(t, got, "--overlay-src")
+	assert.True(t, strings.HasSuffix(got, "--chdir /src/repo -- /usr/bin/codex exec"))
+}
+
+func TestBwrapArgsWithoutWorkTree(t *testing.T) {
+	got := strings.Join(bwrapArgs(bwrapSpec{Command: []string{"/bin/agent"}}), " ")
+	assert.True(t, strings.HasSuffix(got, "--chdir /tmp -- /bin/agent"))
+}
+
+func TestGitDirs(t *testing.T) {
+	root := t.TempDir()
+	common := filepath.Join(root, "repo", ".git")
+	gitdir := filepath.Join(common, "worktrees", "wt")
+	wt := filepath.Join(root, "wt")
+	require.NoError(t, os.MkdirAll(gitdir, 0o755))
+	require.NoError(t, os.MkdirAll(wt, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: "+gitdir+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(gitdir, "commondir"), []byte("../..\n"), 0o644))
+
+	assert.Equal(t, []string{gitdir, common}, gitDirs(wt))
+
+	// A regular checkout keeps .git inside the work tree.
+	repo := filepath.Join(root, "repo")
+	assert.Empty(t, gitDirs(repo))
+}
+
+func TestWrapUnavailable(t *testing.T) {
+	required := &Sandbox{required: true, unavailErr: ErrUnavailable}
+	cmd := exec.Command("true")
+	require.ErrorIs(t, required.Wrap(cmd, Options{}), ErrUnavailable)
+
+	optional := &Sandbox{unavailErr: ErrUnavailable}
+	cmd = exec.Command("true")
+	path := cmd.Path
+	require.NoError(t, optional.Wrap(cmd, Options{}))
+	assert.Equal(t, path, cmd.Path)
+
+	var none *Sandbox
+	require.NoError(t, none.Wrap(cmd, Options{}))
+	assert.False(t, none.Active())
+}
+
+// TestBwrapIntegration runs a real command under bubblewrap when available.
+func TestBwrapIntegration(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("bubblewrap is Linux-only")
+	}
+	bwrap, err := exec.LookPath("bwrap")
+	if err != nil {
+		t.Skip("bwrap not installed")
+	}
+	if err := exec.Command(bwrap, "--unshare-all", "--ro-bind", "/", "/", "true").Run(); err != nil {
+		t.Skipf("bwrap cannot create namespaces here: %v", err)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"), []byte("hi"), 0o644))
+	s := &Sandbox{bwrap: bwrap}
+
+	run := func(writable bool, script string) error {
+		cmd := exec.Command("sh", "-c", script)
+		cmd.Dir = dir
+		cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "GITHUB_TOKEN=x"}
+		require.NoError(t, s.Wrap(cmd, Options{Writable: writable}))
+		return cmd.Run()
+	}
+	require.NoError(t, run(false, `cat file.txt && test -z "$GITHUB_TOKEN"`))
+	require.Error(t, run(false, "echo x > file.txt"), "read-only work tree")
+	require.NoError(t, run(true, "echo x > file.txt"))
+	require.NoError(t, run(false, "echo x > /tmp/scratch"), "scratch /tmp is writable")
+}
@@ -0,0 +1,66 @@
+package sandbox
+
+import "strings"
+
+// modelEnvKeys are credentials agents need to reach their model provider.
+// They pass through even though their names look like secrets.
+var modelEnvKeys = []string{
+	"ANTHROPIC_API_KEY",
+	"ANTHROPIC_AUTH_TOKEN",
+	"CLAUDE_CODE_OAUTH_TOKEN",
+	"OPENAI_API_KEY",
+	"CODEX_API_KEY",
+	"GEMINI_API_KEY",
+	"GOOGLE_API_KEY",
+	"CURSOR_API_KEY",
+	"FACTORY_API_KEY",
+	"OPENROUTER_API_KEY",
+	"COPILOT_GITHUB_TOKEN",
+}
+
+// secretEnvMarkers flag variable names that likely hold credentials.
+var secretEnvMarkers = []string{
+	"TOKEN", "SECRET", "PASSWORD", "PASSWD", "CREDENTIAL",
+	"API_KEY", "APIKEY", "ACCESS_KEY", "PRIVATE_KEY", "_AUTH",
+}
+
+// secretEnvPrefixes flag whole families of credential variables.
+var secretEnvPrefixes = []string{
+	"AWS_", "AZURE_", "GITHUB_", "GH_", "GITLAB_", "NPM_", "DOCKER_",
+	"KUBECONFIG", "SSH_AUTH_SOCK", "GPG_AGENT", "VAULT_", "ROBOREV_",
+}
+
+// FilterEnv returns env without variables that look like secrets. Model
+// provider keys and the names in pass are kept.
+func FilterEnv(env []string, pass []string) []string {
+	keep := make(map[string]bool, len(modelEnvKeys)+len(pass))
+	for _, k := range modelEnvKeys {
+		keep[k] = true
+	}
+	for _, k := range pass {
+		keep[strings.ToUpper(strings.TrimSpace(k))] = true
+	}
+	out := make([]string, 0, len(env))
+	for _, e := range env {
+		key, _, _ := strings.Cut(e, "=")
+		upper := strings.ToUpper(key)
+		if keep[upper] || !isSecretEnvKey(upper) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func isSecretEnvKey(upper string) bool {
+	for _, p := range secretEnvPrefixes {
+		if strings.HasPrefix(upper, p) {
+			return true
+		}
+	}
+	for _, m := range secretEnvMarkers {
+		if strings.Contains(upper, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterEnv(t *testing.T) {
+	env := []string{
+		"PATH=/usr/bin",
+		"HOME=/home/dev",
+		"ANTHROPIC_API_KEY=sk-ant",
+		"OPENAI_API_KEY=sk-openai",
+		"GITHUB_TOKEN=ghp",
+		"AWS_SECRET_ACCESS_KEY=aws",
+		"DATABASE_PASSWORD=pw",
+		"STRIPE_API_KEY=sk_live",
+		"SSH_AUTH_SOCK=/tmp/agent",
+		"ROBOREV_DATA_DIR=/home/dev/.roborev",
+		"MY_SERVICE_TOKEN=keep-me",
+		"GOPATH=/home/dev/go",
+	}
+	assert.Equal(t, []string{
+		"PATH=/usr/bin",
+		"HOME=/home/dev",
+		"ANTHROPIC_API_KEY=sk-ant",
+		"OPENAI_API_KEY=sk-openai",
+		"MY_SERVICE_TOKEN=keep-me",
+		"GOPATH=/home/dev/go",
+	}, FilterEnv(env, []string{"my_service_token"}))
+}
@@ -0,0 +1,86 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// RunHelper is the body of HelperCommand. It runs inside the sandbox's
+// network namespace, listens on loopback, forwards each connection to the
+// host's egress proxy socket, and runs argv with proxy variables pointing at
+// the listener. It returns argv's exit code.
+func RunHelper(proxySocket string, argv []string) (int, error) {
+	if len(argv) == 0 {
+		return 1, errors.New("no command given")
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 1, fmt.Errorf("listen on loopback: %w", err)
+	}
+	defer ln.Close()
+	go forwardToSocket(ln, proxySocket)
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), proxyEnv("http://"+ln.Addr().String())...)
+	if err := cmd.Start(); err != nil {
+		return 127, err
+	}
+
+	sigs := make(chan os.Signal, 4)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	go func() {
+		for sig := range sigs {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// proxyEnv points the common proxy variables at url. NO_PROXY is cleared
+// so loopback model servers are also reached through the proxy, which is
+// the only way out of the sandbox.
+func proxyEnv(url string) []string {
+	var env []string
+	for _, k := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY"} {
+		env = append(env, k+"="+url, strings.ToLower(k)+"="+url)
+	}
+	return append(env, "NO_PROXY=", "no_proxy=", "NODE_USE_ENV_PROXY=1")
+}
+
+// forwardToSocket relays every connection accepted on ln to the unix socket.
+func forwardToSocket(ln net.Listener, socket string) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			upstream, err := net.Dial("unix", socket)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			splice(conn, upstream)
+		}()
+	}
+}
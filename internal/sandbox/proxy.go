@@ -0,0 +1,196 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EgressProxy is an HTTP proxy on a unix socket that only connects to
+// allowed hosts. Sandboxed agents reach it through the helper, since their
+// network namespace has no route out.
+type EgressProxy struct {
+	allow    []string
+	dir      string
+	socket   string
+	ln       net.Listener
+	srv      *http.Server
+	dial     func(network, addr string) (net.Conn, error)
+	closeErr error
+	once     sync.Once
+}
+
+// NewEgressProxy starts a proxy allowing hosts, which are host or host:port
+// globs such as "api.anthropic.com" or "*.githubcopilot.com". A pattern
+// without a port allows ports 80 and 443.
+func NewEgressProxy(hosts []string) (*EgressProxy, error) {
+	// The socket path must fit in sun_path, so keep it short.
+	dir, err := os.MkdirTemp("", "roborev-sandbox-")
+	if err != nil {
+		return nil, fmt.Errorf("sandbox proxy: %w", err)
+	}
+	socket := filepath.Join(dir, "proxy.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("sandbox proxy: %w", err)
+	}
+	p := &EgressProxy{
+		allow:  hosts,
+		dir:    dir,
+		socket: socket,
+		ln:     ln,
+		dial:   (&net.Dialer{Timeout: 30 * time.Second}).Dial,
+	}
+	p.srv = &http.Server{Handler: p, ReadHeaderTimeout: 30 * time.Second}
+	go func() {
+		if err := p.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Sandbox proxy: %v", err)
+		}
+	}()
+	return p, nil
+}
+
+// Socket returns the proxy's unix socket path.
+func (p *EgressProxy) Socket() string {
+	return p.socket
+}
+
+// Close stops the proxy and removes its socket.
+func (p *EgressProxy) Close() error {
+	p.once.Do(func() {
+		p.closeErr = p.srv.Close()
+		_ = os.RemoveAll(p.dir)
+	})
+	return p.closeErr
+}
+
+// Allowed reports whether the proxy may connect to hostport.
+func (p *EgressProxy) Allowed(hostport string) bool {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return false
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, pattern := range p.allow {
+		patHost, patPort, err := net.SplitHostPort(pattern)
+		if err != nil {
+			patHost, patPort = pattern, ""
+		}
+		if patPort == "" && port != "443" && port != "80" {
+			continue
+		}
+		if patPort != "" && patPort != port {
+			continue
+		}
+		if ok, _ := path.Match(patHost, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *EgressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.serveConnect(w, r)
+		return
+	}
+	if r.URL.Host == "" {
+		http.Error(w, "sandbox proxy: absolute URL required", http.StatusBadRequest)
+		return
+	}
+	hostport := r.URL.Host
+	if r.URL.Port() == "" {
+		hostport = net.JoinHostPort(r.URL.Hostname(), "80")
+	}
+	if !p.Allowed(hostport) {
+		p.deny(w, hostport)
+		return
+	}
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	transport := &http.Transport{Dial: p.dial, Proxy: nil}
+	defer transport.CloseIdleConnections()
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, "sandbox proxy: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+func (p *EgressProxy) serveConnect(w http.ResponseWriter, r *http.Request) {
+	if !p.Allowed(r.Host) {
+		p.deny(w, r.Host)
+		return
+	}
+	upstream, err := p.dial("tcp", r.Host)
+	if err != nil {
+		http.Error(w, "sandbox proxy: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "sandbox proxy: hijacking unsupported", http.StatusInternalServerError)
+		return
+	}
+	client, buf, err := hj.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := io.WriteString(client, "HTTP/1.1 200 Connection established\r\n\r\n"); err != nil {
+		client.Close()
+		upstream.Close()
+		return
+	}
+	// Bytes the client sent after the CONNECT header are already buffered.
+	if n := buf.Reader.Buffered(); n > 0 {
+		pending, _ := buf.Reader.Peek(n)
+		if _, err := upstream.Write(pending); err != nil {
+			client.Close()
+			upstream.Close()
+			return
+		}
+	}
+	splice(client, upstream)
+}
+
+func (p *EgressProxy) deny(w http.ResponseWriter, hostport string) {
+	log.Printf("Sandbox proxy: blocked connection to %s", hostport)
+	http.Error(w, "sandbox proxy: "+hostport+" is not in sandbox.allow_hosts", http.StatusForbidden)
+}
+
+// splice copies between a and b until either side closes, then closes both.
+func splice(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	cp := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go cp(a, b)
+	go cp(b, a)
+	<-done
+	a.Close()
+	b.Close()
+	<-done
+}
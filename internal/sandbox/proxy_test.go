@@ -0,0 +1,146 @@
+package sandbox
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEgressProxyAllowed(t *testing.T) {
+	p := &EgressProxy{allow: []string{"api.anthropic.com", "*.githubcopilot.com", "localhost:11434"}}
+	cases := map[string]bool{
+		"api.anthropic.com:443":                true,
+		"API.Anthropic.com.:443":               true,
+		"api.anthropic.com:22":                 false,
+		"evil.com:443":                         false,
+		"api.individual.githubcopilot.com:443": true,
+		"githubcopilot.com:443":                false,
+		"localhost:11434":                      true,
+		"localhost:80":                         false,
+		"api.anthropic.com":                    false, // no port
+	}
+	for hostport, want := range cases {
+		assert.Equal(t, want, p.Allowed(hostport), hostport)
+	}
+}
+
+// newTestProxy starts a proxy whose dials all go to target, so allow rules
+// can name hosts that do not resolve in tests.
+func newTestProxy(t *testing.T, target string, allow ...string) *EgressProxy {
+	t.Helper()
+	p, err := NewEgressProxy(allow)
+	require.NoError(t, err)
+	t.Cleanup(func() { p.Close() })
+	p.dial = func(network, _ string) (net.Conn, error) {
+		return net.Dial(network, target)
+	}
+	return p
+}
+
+// unixClient returns an HTTP client that talks to the proxy socket.
+func unixClient(p *EgressProxy) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		Proxy: http.ProxyURL(&url.URL{Scheme: "http", Host: "proxy"}),
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", p.Socket())
+		},
+	}}
+}
+
+func TestEgressProxyHTTP(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "model says hi "+r.URL.Path)
+	}))
+	defer upstream.Close()
+	p := newTestProxy(t, upstream.Listener.Addr().String(), "api.example.com")
+	client := unixClient(p)
+
+	resp, err := client.Get("http://api.example.com/v1/messages")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "model says hi /v1/messages", string(body))
+
+	resp, err = client.Get("http://exfil.example.net/upload")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestEgressProxyConnect(t *testing.T) {
+	// A raw TCP echo server stands in for a TLS endpoint.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _, _ = io.Copy(c, c); c.Close() }()
+		}
+	}()
+	p := newTestProxy(t, ln.Addr().String(), "api.example.com")
+
+	connect := func(host string) (*bufio.Reader, net.Conn, int) {
+		conn, err := net.Dial("unix", p.Socket())
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		_, err = io.WriteString(conn, "CONNECT "+host+" HTTP/1.1\r\nHost: "+host+"\r\n\r\n")
+		require.NoError(t, err)
+		r := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(r, &http.Request{Method: http.MethodConnect})
+		require.NoError(t, err)
+		return r, conn, resp.StatusCode
+	}
+
+	r, conn, status := connect("api.example.com:443")
+	require.Equal(t, http.StatusOK, status)
+	_, err = io.WriteString(conn, "ping\n")
+	require.NoError(t, err)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", line)
+
+	_, _, status = connect("evil.example.net:443")
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestForwardToSocket(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer upstream.Close()
+	p := newTestProxy(t, upstream.Listener.Addr().String(), "api.example.com")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go forwardToSocket(ln, p.Socket())
+
+	proxyURL := "http://" + ln.Addr().String()
+	env := strings.Join(proxyEnv(proxyURL), " ")
+	assert.Contains(t, env, "HTTPS_PROXY="+proxyURL)
+	assert.Contains(t, env, "https_proxy="+proxyURL)
+	assert.Contains(t, env, "NO_PROXY= ")
+
+	u, err := url.Parse(proxyURL)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(u)}}
+	resp, err := client.Get("http://api.example.com/")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "ok", string(body))
+}
@@ -0,0 +1,229 @@
+// Package sandbox runs agent processes inside a bubblewrap sandbox on Linux.
+//
+// A sandboxed agent sees the host filesystem read-only, with the repository
+// under review bound read-only (read-write for fix and refine jobs), $HOME
+// behind a throwaway overlay, credential directories hidden, and a private
+// /tmp for scratch space. The agent gets its own network namespace; when
+// hosts are allowed, a helper inside the sandbox exposes an HTTP proxy on
+// loopback that forwards to an EgressProxy on the host, which only connects
+// to allowed hosts. Environment variables that look like secrets are
+// stripped, except for the model provider keys agents need.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.kenn.io/roborev/internal/config"
+)
+
+// HelperCommand is the hidden roborev subcommand that runs inside the
+// sandbox and bridges the agent's proxy traffic to the host.
+const HelperCommand = "sandbox-exec"
+
+// ErrUnavailable is returned by Wrap when the sandbox is required but
+// bubblewrap cannot be used on this host.
+var ErrUnavailable = errors.New("agent sandbox unavailable")
+
+// Options control how one command is sandboxed.
+type Options struct {
+	// Dir is the work tree exposed to the command. Defaults to cmd.Dir;
+	// when both are empty the command runs in the sandbox's /tmp with no
+	// work tree.
+	Dir string
+	// Writable binds Dir read-write, for agents that edit files.
+	Writable bool
+	// Share lists host files or directories outside Dir the command must
+	// read or write, such as prompt and output files under /tmp.
+	Share []string
+}
+
+// Sandbox wraps agent commands. A nil *Sandbox leaves commands unchanged.
+type Sandbox struct {
+	bwrap      string
+	helper     string
+	overlay    bool
+	home       string
+	hidePaths  []string
+	writable   []string
+	passEnv    []string
+	proxy      *EgressProxy
+	required   bool
+	unavailErr error
+	warnOnce   sync.Once
+}
+
+// New builds a sandbox from cfg. helper is the roborev executable that
+// provides HelperCommand. A host without bubblewrap yields a sandbox whose
+// Wrap fails when cfg requires the sandbox and is a no-op otherwise.
+func New(cfg config.SandboxConfig, helper string) (*Sandbox, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	home, _ := os.UserHomeDir()
+	s := &Sandbox{
+		helper:    helper,
+		home:      home,
+		hidePaths: append(cfg.ResolvedHidePaths(), config.DataDir()),
+		writable:  cfg.ResolvedWritablePaths(),
+		passEnv:   cfg.PassEnv,
+		required:  cfg.IsRequired(),
+	}
+	if runtime.GOOS != "linux" {
+		s.unavailErr = fmt.Errorf("%w: bubblewrap sandboxing is only supported on Linux", ErrUnavailable)
+		return s, nil
+	}
+	bwrap, err := exec.LookPath("bwrap")
+	if err != nil {
+		s.unavailErr = fmt.Errorf("%w: bwrap not found in PATH", ErrUnavailable)
+		return s, nil
+	}
+	s.bwrap = bwrap
+	if cfg.ResolvedHome() == config.SandboxHomeOverlay {
+		s.overlay = bwrapSupportsTmpOverlay(bwrap)
+	}
+	if hosts := cfg.ResolvedAllowHosts(); len(hosts) > 0 {
+		if helper == "" {
+			return nil, errors.New("sandbox: network access requires the roborev executable path")
+		}
+		proxy, err := NewEgressProxy(hosts)
+		if err != nil {
+			return nil, err
+		}
+		s.proxy = proxy
+	}
+	return s, nil
+}
+
+// Failed returns a sandbox that refuses to run commands, for when a
+// required sandbox could not be built.
+func Failed(err error) *Sandbox {
+	return &Sandbox{required: true, unavailErr: fmt.Errorf("%w: %w", ErrUnavailable, err)}
+}
+
+// Active reports whether Wrap sandboxes commands.
+func (s *Sandbox) Active() bool {
+	return s != nil && s.unavailErr == nil
+}
+
+// Close stops the egress proxy.
+func (s *Sandbox) Close() error {
+	if s == nil || s.proxy == nil {
+		return nil
+	}
+	return s.proxy.Close()
+}
+
+// Wrap rewrites cmd to run inside the sandbox. It must be called after
+// cmd.Dir and cmd.Env are set and before the command starts.
+func (s *Sandbox) Wrap(cmd *exec.Cmd, opts Options) error {
+	if s == nil || cmd.Err != nil {
+		return nil
+	}
+	if s.unavailErr != nil {
+		if s.required {
+			return s.unavailErr
+		}
+		s.warnOnce.Do(func() {
+			log.Printf("Sandbox: %v; running agents unsandboxed", s.unavailErr)
+		})
+		return nil
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = cmd.Dir
+	}
+	dir, err := absPath(dir)
+	if err != nil {
+		return err
+	}
+	chdir, err := absPath(cmd.Dir)
+	if err != nil {
+		return err
+	}
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	spec := bwrapSpec{
+		Home:      s.home,
+		Overlay:   s.overlay,
+		HidePaths: existingPaths(s.hidePaths),
+		Writable:  existingPaths(s.writable),
+		Dir:       dir,
+		Chdir:     chdir,
+		GitDirs:   gitDirs(dir),
+		DirRW:     opts.Writable,
+		Share:     opts.Share,
+		Command:   append([]string{cmd.Path}, cmd.Args[1:]...),
+	}
+	if s.proxy != nil {
+		spec.Helper = s.helper
+		spec.ProxySocket = s.proxy.Socket()
+	}
+	cmd.Path = s.bwrap
+	cmd.Args = append([]string{"bwrap"}, bwrapArgs(spec)...)
+	cmd.Env = FilterEnv(env, s.passEnv)
+	cmd.Env = append(cmd.Env, "TMPDIR=/tmp")
+	return nil
+}
+
+func absPath(p string) (string, error) {
+	if p == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", fmt.Errorf("sandbox: resolve %s: %w", p, err)
+	}
+	return abs, nil
+}
+
+// existingPaths drops paths that do not exist, which bwrap cannot mount.
+func existingPaths(paths []string) []pathEntry {
+	var out []pathEntry
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		out = append(out, pathEntry{Path: p, IsDir: info.IsDir()})
+	}
+	return out
+}
+
+// gitDirs returns the git directories outside dir that git needs when dir
+// is a linked worktree: the worktree's own gitdir and the common dir.
+func gitDirs(dir string) []string {
+	if dir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return nil // a .git directory is inside dir already
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return nil
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+	dirs := []string{filepath.Clean(gitdir)}
+	if common, err := os.ReadFile(filepath.Join(gitdir, "commondir")); err == nil {
+		c := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(c) {
+			c = filepath.Join(gitdir, c)
+		}
+		dirs = append(dirs, filepath.Clean(c))
+	}
+	return dirs
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Agent health statuses, from the latest canary probe.
+const (
+	AgentHealthOK          = "ok"          // the agent answered the canary prompt
+	AgentHealthUnavailable = "unavailable" // the agent is unknown or not installed
+	AgentHealthAuthFailed  = "auth_failed" // the agent is not logged in
+	AgentHealthLimited     = "limited"     // the agent hit a rate limit or quota
+	AgentHealthFailed      = "failed"      // the agent failed for another reason
+)
+
+// AgentHealth is the latest canary probe of one agent and model.
+type AgentHealth struct {
+	Agent           string     `json:"agent"`
+	Model           string     `json:"model,omitempty"`
+	Status          string     `json:"status"`
+	Auth            string     `json:"auth"`
+	LimitKind       string     `json:"limit_kind,omitempty"`
+	LimitResetAt    *time.Time `json:"limit_reset_at,omitempty"`
+	Command         string     `json:"command,omitempty"`
+	Version         string     `json:"version,omitempty"`
+	ReasoningLevels []string   `json:"reasoning_levels,omitempty"`
+	LatencyMs       int64      `json:"latency_ms"`
+	Error           string     `json:"error,omitempty"`
+	CheckedAt       time.Time  `json:"checked_at"`
+}
+
+// Healthy reports whether the agent answered its latest probe.
+func (h AgentHealth) Healthy() bool {
+	return h.Status == AgentHealthOK
+}
+
+// SaveAgentHealth records a probe result, replacing the previous one for the
+// same agent and model.
+func (db *DB) SaveAgentHealth(h AgentHealth) error {
+	var resetAt any
+	if h.LimitResetAt != nil {
+		resetAt = h.LimitResetAt.UTC().Format(time.RFC3339)
+	}
+	_, err := db.Exec(`
+		INSERT INTO agent_health (agent, model, status, auth, limit_kind, limit_reset_at,
+			command, version, reasoning_levels, latency_ms, error, checked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(agent, model) DO UPDATE SET
+			status = excluded.status, auth = excluded.auth,
+			limit_kind = excluded.limit_kind, limit_reset_at = excluded.limit_reset_at,
+			command = excluded.command, version = excluded.version,
+			reasoning_levels = excluded.reasoning_levels, latency_ms = excluded.latency_ms,
+			error = excluded.error, checked_at = excluded.checked_at`,
+		h.Agent, h.Model, h.Status, h.Auth, h.LimitKind, resetAt,
+		h.Command, h.Version, strings.Join(h.ReasoningLevels, ","), h.LatencyMs, h.Error,
+		h.CheckedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("save agent health: %w", err)
+	}
+	return nil
+}
+
+// ListAgentHealth returns the latest probe of every agent and model, ordered
+// by agent then model.
+func (db *DB) ListAgentHealth() ([]AgentHealth, error) {
+	rows, err := db.Query(`
+		SELECT agent, model, status, auth, limit_kind, limit_reset_at,
+			command, version, reasoning_levels, latency_ms, error, checked_at
+		FROM agent_health ORDER BY agent, model`)
+	if err != nil {
+		return nil, fmt.Errorf("list agent health: %w", err)
+	}
+	defer rows.Close()
+
+	var out []AgentHealth
+	for rows.Next() {
+		var h AgentHealth
+		var resetAt sql.NullString
+		var levels, checkedAt string
+		if err := rows.Scan(&h.Agent, &h.Model, &h.Status, &h.Auth, &h.LimitKind, &resetAt,
+			&h.Command, &h.Version, &levels, &h.LatencyMs, &h.Error, &checkedAt); err != nil {
+			return nil, fmt.Errorf("scan agent health: %w", err)
+		}
+		if resetAt.Valid {
+			if t, err := time.Parse(time.RFC3339, resetAt.String); err == nil {
+				h.LimitResetAt = &t
+			}
+		}
+		if levels != "" {
+			h.ReasoningLevels = strings.Split(levels, ",")
+		}
+		if t, err := time.Parse(time.RFC3339, checkedAt); err == nil {
+			h.CheckedAt = t
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
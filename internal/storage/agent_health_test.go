@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAgentHealth(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	checked := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	resetAt := checked.Add(2 * time.Hour)
+	require.NoError(t, db.SaveAgentHealth(AgentHealth{
+		Agent: "gemini", Status: AgentHealthLimited, Auth: "unknown", LimitKind: "quota",
+		LimitResetAt: &resetAt, Error: "quota exceeded", CheckedAt: checked,
+	}))
+	require.NoError(t, db.SaveAgentHealth(AgentHealth{
+		Agent: "codex", Model: "gpt-5", Status: AgentHealthOK, Auth: "ok",
+		Version: "codex-cli 0.50.0", ReasoningLevels: []string{"fast", "standard"},
+		LatencyMs: 800, CheckedAt: checked,
+	}))
+
+	health, err := db.ListAgentHealth()
+	require.NoError(t, err)
+	require.Len(t, health, 2)
+	assert.Equal(t, "codex", health[0].Agent)
+	assert.True(t, health[0].Healthy())
+	assert.Equal(t, []string{"fast", "standard"}, health[0].ReasoningLevels)
+	assert.Equal(t, int64(800), health[0].LatencyMs)
+	assert.True(t, checked.Equal(health[0].CheckedAt))
+	require.NotNil(t, health[1].LimitResetAt)
+	assert.True(t, resetAt.Equal(*health[1].LimitResetAt))
+
+	// A new probe replaces the previous one.
+	require.NoError(t, db.SaveAgentHealth(AgentHealth{
+		Agent: "gemini", Status: AgentHealthOK, Auth: "ok", CheckedAt: checked.Add(time.Hour),
+	}))
+	health, err = db.ListAgentHealth()
+	require.NoError(t, err)
+	require.Len(t, health, 2)
+	assert.True(t, health[1].Healthy())
+	assert.Nil(t, health[1].LimitResetAt)
+	assert.Empty(t, health[1].Error)
+}
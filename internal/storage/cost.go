@@ -20,6 +20,7 @@ type CostOptions struct {
 	RepoPaths   []string  // empty = all repos; multiple = OR over repos.root_path
 	Branch      string    // exact branch; ignored when BranchEmpty is true
 	BranchEmpty bool      // true = only jobs with empty/NULL branch
+	Author      string    // commit author name; empty = all authors
 	Since       time.Time // zero = all time; else enqueued_at >= Since
 }
 
@@ -81,6 +82,10 @@ func costAggregate(q querier, opts CostOptions) (CostAggregate, error) {
 		conditions = append(conditions, "j.branch = ?")
 		args = append(args, opts.Branch)
 	}
+	if opts.Author != "" {
+		conditions = append(conditions, authorFilter)
+		args = append(args, opts.Author)
+	}
 	if !opts.Since.IsZero() {
 		conditions = append(conditions, "datetime(j.enqueued_at) >= datetime(?)")
 		args = append(args, opts.Since.UTC().Format("2006-01-02 15:04:05"))
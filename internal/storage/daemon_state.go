@@ -35,3 +35,28 @@ func (db *DB) SetQueuePaused(paused bool) error {
 	}
 	return nil
 }
+
+// GetDaemonState returns the value stored under key, or "" when unset.
+func (db *DB) GetDaemonState(key string) (string, error) {
+	var value string
+	err := db.QueryRow(`SELECT value FROM daemon_state WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get daemon state %q: %w", key, err)
+	}
+	return value, nil
+}
+
+// SetDaemonState stores value under key, replacing any previous value.
+func (db *DB) SetDaemonState(key, value string) error {
+	_, err := db.Exec(`
+		INSERT INTO daemon_state (key, value, updated_at) VALUES (?, ?, datetime('now'))
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+	`, key, value)
+	if err != nil {
+		return fmt.Errorf("set daemon state %q: %w", key, err)
+	}
+	return nil
+}
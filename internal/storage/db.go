@@ -151,6 +151,119 @@ CREATE TABLE IF NOT EXISTS daemon_state (
   updated_at TEXT NOT NULL DEFAULT (datetime('now'))
 );
 
+-- webhook_deliveries is the local outbox for webhook hooks. Each row is one
+-- event bound for one URL, with its frozen JSON payload and retry state.
+-- status is 'pending' (due at next_attempt_at), 'delivered', or 'failed'
+-- (retries exhausted). Local-only: never synced to PostgreSQL.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL DEFAULT '',
+  event_type TEXT NOT NULL,
+  job_id INTEGER NOT NULL DEFAULT 0,
+  repo TEXT NOT NULL DEFAULT '',
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_status_code INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  next_attempt_at TEXT,
+  last_attempt_at TEXT,
+  delivered_at TEXT,
+  created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+
+-- notification_threads remembers the chat message that roots each Slack or
+-- Teams conversation, so later notifications for the same branch or PR reply
+-- in that thread. channel is the Slack channel ID or Teams "team/channel";
+-- thread_id is the Slack message ts or Teams message ID. Local-only.
+CREATE TABLE IF NOT EXISTS notification_threads (
+  provider TEXT NOT NULL,
+  channel TEXT NOT NULL,
+  thread_key TEXT NOT NULL,
+  thread_id TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  PRIMARY KEY (provider, channel, thread_key)
+);
+
+-- event_journal is the append-only log of daemon events behind the resumable
+-- event stream. id is the stream's event ID; payload is the event JSON.
+-- Rows older than the retention window are pruned. Local-only.
+CREATE TABLE IF NOT EXISTS event_journal (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  event_type TEXT NOT NULL,
+  repo TEXT NOT NULL DEFAULT '',
+  job_id INTEGER NOT NULL DEFAULT 0,
+  payload TEXT NOT NULL,
+  created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_event_journal_created ON event_journal(created_at);
+
+-- job_dependencies holds the prerequisites of jobs enqueued with depends_on.
+-- A dependent stays claim_blocked until every prerequisite succeeds and is
+-- canceled when one fails or is canceled. Local-only.
+CREATE TABLE IF NOT EXISTS job_dependencies (
+  job_id INTEGER NOT NULL,
+  depends_on_job_id INTEGER NOT NULL,
+  PRIMARY KEY (job_id, depends_on_job_id)
+);
+CREATE INDEX IF NOT EXISTS idx_job_dependencies_prereq ON job_dependencies(depends_on_job_id);
+
+-- workflows and workflow_steps group jobs submitted together as a DAG through
+-- /api/workflows, so the run can be tracked as a unit. Each step names the job
+-- it enqueued. Local-only.
+CREATE TABLE IF NOT EXISTS workflows (
+  uuid TEXT PRIMARY KEY,
+  name TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS workflow_steps (
+  workflow_uuid TEXT NOT NULL,
+  step TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  job_id INTEGER NOT NULL,
+  PRIMARY KEY (workflow_uuid, step)
+);
+
+-- job_attempts records every agent attempt of a job in order: the agent and
+-- model, whether it succeeded, failed, or was skipped because the agent's
+-- circuit was open, and the failure class. Local-only.
+CREATE TABLE IF NOT EXISTS job_attempts (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  job_id INTEGER NOT NULL,
+  attempt INTEGER NOT NULL,
+  agent TEXT NOT NULL,
+  model TEXT NOT NULL DEFAULT '',
+  outcome TEXT NOT NULL,
+  failure_class TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  started_at TEXT,
+  finished_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_job_attempts_job ON job_attempts(job_id, attempt);
+
+-- agent_health holds the latest canary probe of each configured agent and
+-- model (model is '' for the agent's default). status is 'ok',
+-- 'unavailable', 'auth_failed', 'limited', or 'failed'; reasoning_levels is
+-- comma-separated. Local-only.
+CREATE TABLE IF NOT EXISTS agent_health (
+  agent TEXT NOT NULL,
+  model TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL,
+  auth TEXT NOT NULL DEFAULT 'unknown',
+  limit_kind TEXT NOT NULL DEFAULT '',
+  limit_reset_at TEXT,
+  command TEXT NOT NULL DEFAULT '',
+  version TEXT NOT NULL DEFAULT '',
+  reasoning_levels TEXT NOT NULL DEFAULT '',
+  latency_ms INTEGER NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT '',
+  checked_at TEXT NOT NULL,
+  PRIMARY KEY (agent, model)
+);
+
 CREATE INDEX IF NOT EXISTS idx_review_jobs_status ON review_jobs(status);
 CREATE INDEX IF NOT EXISTS idx_review_jobs_repo ON review_jobs(repo_id);
 CREATE INDEX IF NOT EXISTS idx_review_jobs_git_ref ON review_jobs(git_ref);
@@ -981,6 +1094,92 @@ func (db *DB) migrate() error {
 		}
 	}
 
+	// Migration: add redactions column to review_jobs if missing. It counts
+	// secrets masked out of the job's prompt and diff before they reached
+	// the agent.
+	err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('review_jobs') WHERE name = 'redactions'`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("check redactions column: %w", err)
+	}
+	if count == 0 {
+		_, err = db.Exec(`ALTER TABLE review_jobs ADD COLUMN redactions INTEGER NOT NULL DEFAULT 0`)
+		if err != nil {
+			return fmt.Errorf("add redactions column: %w", err)
+		}
+	}
+
+	// Migration: add route column to review_jobs if missing. It names the
+	// [[review.routes]] entry that picked the job's review settings.
+	err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('review_jobs') WHERE name = 'route'`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("check route column: %w", err)
+	}
+	if count == 0 {
+		_, err = db.Exec(`ALTER TABLE review_jobs ADD COLUMN route TEXT`)
+		if err != nil {
+			return fmt.Errorf("add route column: %w", err)
+		}
+	}
+
+	// Migration: add verify_result column to review_jobs if missing. It holds
+	// the verify_command outcome of a fix job as JSON.
+	err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('review_jobs') WHERE name = 'verify_result'`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("check verify_result column: %w", err)
+	}
+	if count == 0 {
+		_, err = db.Exec(`ALTER TABLE review_jobs ADD COLUMN verify_result TEXT`)
+		if err != nil {
+			return fmt.Errorf("add verify_result column: %w", err)
+		}
+	}
+
+	// Migration: add incremental column to review_jobs if missing. It holds
+	// the prior review an incremental re-review builds on as JSON.
+	err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('review_jobs') WHERE name = 'incremental'`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("check incremental column: %w", err)
+	}
+	if count == 0 {
+		_, err = db.Exec(`ALTER TABLE review_jobs ADD COLUMN incremental TEXT`)
+		if err != nil {
+			return fmt.Errorf("add incremental column: %w", err)
+		}
+	}
+
+	// Migration: add resolution column to review_jobs if missing. It holds
+	// the reviews a resolution check job covers as JSON.
+	err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('review_jobs') WHERE name = 'resolution'`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("check resolution column: %w", err)
+	}
+	if count == 0 {
+		_, err = db.Exec(`ALTER TABLE review_jobs ADD COLUMN resolution TEXT`)
+		if err != nil {
+			return fmt.Errorf("add resolution column: %w", err)
+		}
+	}
+
+	// Migration: add review cache columns to review_jobs if missing.
+	// cache_key identifies the patch, review type, agent/model and guidelines
+	// a review ran with; cached_from holds the UUID of the job whose result a
+	// cache hit reused; no_cache opts a job out of the cache.
+	for _, col := range []struct{ name, ddl string }{
+		{"cache_key", `ALTER TABLE review_jobs ADD COLUMN cache_key TEXT`},
+		{"cached_from", `ALTER TABLE review_jobs ADD COLUMN cached_from TEXT`},
+		{"no_cache", `ALTER TABLE review_jobs ADD COLUMN no_cache INTEGER NOT NULL DEFAULT 0`},
+	} {
+		err = db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('review_jobs') WHERE name = ?`, col.name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("check %s column: %w", col.name, err)
+		}
+		if count == 0 {
+			if _, err = db.Exec(col.ddl); err != nil {
+				return fmt.Errorf("add %s column: %w", col.name, err)
+			}
+		}
+	}
+
 	// The panel composite index is created later, after
 	// migrateReviewJobsConstraintsForAutoDesign: that migration rebuilds
 	// review_jobs via DROP+RENAME on legacy DBs, which would drop an index
@@ -1021,6 +1220,11 @@ func (db *DB) migrate() error {
 		return fmt.Errorf("create idx_review_jobs_panel: %w", err)
 	}
 
+	// Review cache lookups, created after the rebuild for the same reason.
+	if _, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_review_jobs_cache_key ON review_jobs(cache_key) WHERE cache_key IS NOT NULL`); err != nil {
+		return fmt.Errorf("create idx_review_jobs_cache_key: %w", err)
+	}
+
 	// Partial index for the safety sweep: locate stuck synthesis rows (still
 	// claim_blocked) cheaply. claim_blocked is local-only, so this index is
 	// SQLite-only. Created here, after the legacy rebuild, for the same reason
@@ -408,6 +408,31 @@ func TestRetryJob(t *testing.T) {
 	assert.Equal(t, JobStatusRunning, updatedJob.Status)
 }
 
+func TestDeferJob(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	_, _, job := createJobChain(t, db, "/tmp/test-repo", "defer123")
+	claimJob(t, db, "worker-1")
+
+	deferred, err := db.DeferJob(job.ID, "worker-2", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, deferred, "another worker cannot defer the job")
+
+	deferred, err = db.DeferJob(job.ID, "worker-1", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, deferred)
+	updated, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusQueued, updated.Status)
+	count, _ := db.GetJobRetryCount(job.ID)
+	assert.Equal(t, 0, count, "deferring does not consume a retry")
+
+	claimed, err := db.ClaimJob("worker-1")
+	require.NoError(t, err)
+	assert.Nil(t, claimed, "a deferred job is not claimable before its time")
+}
+
 func TestRetryJobOnlyWorksForRunning(t *testing.T) {
 	db := openTestDB(t)
 	defer db.Close()
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Prerequisite outcomes. A prerequisite that ended in a satisfied status
+// lets its dependents run; one that failed, was canceled, or no longer
+// exists cancels them. Any other status keeps them waiting.
+const (
+	satisfiedPrereqStatuses = `'done','applied','rebased','skipped'`
+	brokenPrereqStatuses    = `'failed','canceled'`
+)
+
+// insertJobDependencies records jobID's prerequisites via exec, inside the
+// caller's insert transaction.
+func insertJobDependencies(ctx context.Context, exec execer, jobID int64, dependsOn []int64) error {
+	for _, dep := range dependsOn {
+		if _, err := exec.ExecContext(ctx, `
+			INSERT OR IGNORE INTO job_dependencies (job_id, depends_on_job_id) VALUES (?, ?)`,
+			jobID, dep); err != nil {
+			return fmt.Errorf("insert dependency %d -> %d: %w", jobID, dep, err)
+		}
+	}
+	return nil
+}
+
+// GetJobDependencies returns the prerequisite job IDs of each given job, in
+// ascending order. Jobs without prerequisites are absent from the map.
+func (db *DB) GetJobDependencies(jobIDs []int64) (map[int64][]int64, error) {
+	deps := make(map[int64][]int64)
+	if len(jobIDs) == 0 {
+		return deps, nil
+	}
+	placeholders := make([]string, len(jobIDs))
+	args := make([]any, len(jobIDs))
+	for i, id := range jobIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := db.Query(`
+		SELECT job_id, depends_on_job_id FROM job_dependencies
+		WHERE job_id IN (`+strings.Join(placeholders, ",")+`)
+		ORDER BY job_id, depends_on_job_id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query job dependencies: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jobID, dep int64
+		if err := rows.Scan(&jobID, &dep); err != nil {
+			return nil, fmt.Errorf("scan job dependency: %w", err)
+		}
+		deps[jobID] = append(deps[jobID], dep)
+	}
+	return deps, rows.Err()
+}
+
+// SettleJobDependencies moves queued dependents forward after their
+// prerequisites change state. Dependents with a failed, canceled, or missing
+// prerequisite are canceled, transitively, and their IDs returned; dependents
+// whose prerequisites all succeeded have claim_blocked cleared so a worker can
+// claim them.
+//
+// Like MaybeReleasePanelSynthesis, the state is derived from the prerequisite
+// rows, so the call is idempotent and safe to make on every terminal
+// transition; the panel sweep calls it as the backstop.
+func (db *DB) SettleJobDependencies() ([]int64, error) {
+	now := time.Now().Format(time.RFC3339)
+	var canceled []int64
+	// Each pass cancels one more level of a dependency chain.
+	for {
+		ids, err := db.cancelBrokenDependents(now)
+		if err != nil {
+			return canceled, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		canceled = append(canceled, ids...)
+	}
+
+	if _, err := db.Exec(`
+		UPDATE review_jobs
+		   SET claim_blocked = 0, updated_at = ?
+		 WHERE status = 'queued'
+		   AND claim_blocked = 1
+		   AND EXISTS (SELECT 1 FROM job_dependencies d WHERE d.job_id = review_jobs.id)
+		   AND NOT EXISTS (
+		       SELECT 1 FROM job_dependencies d
+		       LEFT JOIN review_jobs p ON p.id = d.depends_on_job_id
+		        WHERE d.job_id = review_jobs.id
+		          AND (p.id IS NULL OR p.status NOT IN (`+satisfiedPrereqStatuses+`))
+		   )
+	`, now); err != nil {
+		return canceled, fmt.Errorf("release dependent jobs: %w", err)
+	}
+	return canceled, nil
+}
+
+// cancelBrokenDependents cancels the queued jobs with a failed, canceled, or
+// missing prerequisite and returns their IDs. The error names the first such
+// prerequisite.
+func (db *DB) cancelBrokenDependents(now string) ([]int64, error) {
+	rows, err := db.Query(`
+		UPDATE review_jobs
+		   SET status = 'canceled', finished_at = ?, updated_at = ?,
+		       error = 'prerequisite job ' || (
+		           SELECT MIN(d.depends_on_job_id) FROM job_dependencies d
+		           LEFT JOIN review_jobs p ON p.id = d.depends_on_job_id
+		            WHERE d.job_id = review_jobs.id
+		              AND (p.id IS NULL OR p.status IN (`+brokenPrereqStatuses+`))
+		       ) || ' did not succeed'
+		 WHERE status = 'queued'
+		   AND EXISTS (
+		       SELECT 1 FROM job_dependencies d
+		       LEFT JOIN review_jobs p ON p.id = d.depends_on_job_id
+		        WHERE d.job_id = review_jobs.id
+		          AND (p.id IS NULL OR p.status IN (`+brokenPrereqStatuses+`))
+		   )
+		RETURNING id
+	`, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("cancel dependent jobs: %w", err)
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan canceled dependent: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettleJobDependenciesReleasesDependents(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/deps-repo")
+
+	analyze := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: repo.ID, Agent: "codex", Prompt: "analyze"})
+	other := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: repo.ID, Agent: "codex", Prompt: "other"})
+	fix := mustEnqueuePromptJob(t, db, EnqueueOpts{
+		RepoID: repo.ID, Agent: "codex", Prompt: "fix", DependsOn: []int64{analyze.ID, other.ID},
+	})
+	assert.True(t, fix.ClaimBlocked)
+
+	deps, err := db.GetJobDependencies([]int64{analyze.ID, fix.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]int64{fix.ID: {analyze.ID, other.ID}}, deps)
+
+	claimed := claimJob(t, db, "w1")
+	require.Equal(t, analyze.ID, claimed.ID)
+	require.NoError(t, db.CompleteJob(analyze.ID, "codex", "p", "ok"))
+	canceled, err := db.SettleJobDependencies()
+	require.NoError(t, err)
+	assert.Empty(t, canceled)
+
+	claimed = claimJob(t, db, "w1")
+	require.Equal(t, other.ID, claimed.ID)
+	next, err := db.ClaimJob("w1")
+	require.NoError(t, err)
+	assert.Nil(t, next, "a dependent waits for every prerequisite")
+
+	require.NoError(t, db.CompleteJob(other.ID, "codex", "p", "ok"))
+	_, err = db.SettleJobDependencies()
+	require.NoError(t, err)
+	claimed = claimJob(t, db, "w1")
+	assert.Equal(t, fix.ID, claimed.ID)
+}
+
+func TestSettleJobDependenciesCancelsChain(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/deps-chain-repo")
+
+	analyze := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: repo.ID, Agent: "codex", Prompt: "analyze"})
+	fix := mustEnqueuePromptJob(t, db, EnqueueOpts{
+		RepoID: repo.ID, Agent: "codex", Prompt: "fix", DependsOn: []int64{analyze.ID},
+	})
+	rereview := mustEnqueuePromptJob(t, db, EnqueueOpts{
+		RepoID: repo.ID, Agent: "codex", Prompt: "re-review", DependsOn: []int64{fix.ID},
+	})
+
+	claimJob(t, db, "w1")
+	updated, err := db.FailJob(analyze.ID, "w1", "agent error")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	canceled, err := db.SettleJobDependencies()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{fix.ID, rereview.ID}, canceled)
+
+	for id, prereq := range map[int64]int64{fix.ID: analyze.ID, rereview.ID: fix.ID} {
+		job, err := db.GetJobByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusCanceled, job.Status)
+		assert.Equal(t, fmt.Sprintf("prerequisite job %d did not succeed", prereq), job.Error)
+	}
+
+	canceled, err = db.SettleJobDependencies()
+	require.NoError(t, err)
+	assert.Empty(t, canceled, "settling is idempotent")
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// digestReviewLimit caps how many failed reviews one digest considers.
+const digestReviewLimit = 500
+
+// DigestReview is a failed review considered by an email digest.
+type DigestReview struct {
+	JobID      int64     `json:"job_id"`
+	RepoName   string    `json:"repo_name"`
+	RepoPath   string    `json:"repo_path"`
+	Branch     string    `json:"branch,omitempty"`
+	GitRef     string    `json:"git_ref"`
+	Subject    string    `json:"subject,omitempty"`
+	Author     string    `json:"author,omitempty"`
+	Agent      string    `json:"agent"`
+	Output     string    `json:"output"`
+	Closed     bool      `json:"closed"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
+
+// DigestReviewOptions scopes ListDigestReviews.
+type DigestReviewOptions struct {
+	RepoPath string    // empty = all repos
+	Author   string    // commit author name; empty = all authors
+	Since    time.Time // start of the digest period
+}
+
+// ListDigestReviews returns failed reviews that are still open, along with
+// failed reviews from the digest period that have since been closed, newest
+// first. Task and fix jobs are excluded since they carry no verdict.
+func (db *DB) ListDigestReviews(opts DigestReviewOptions) ([]DigestReview, error) {
+	query := `
+		SELECT j.id, r.name, r.root_path, COALESCE(j.branch, ''), j.git_ref,
+			COALESCE(c.subject, ''), COALESCE(c.author, ''), j.agent, rv.output,
+			rv.closed, j.enqueued_at
+		FROM review_jobs j
+		JOIN repos r ON r.id = j.repo_id
+		JOIN reviews rv ON rv.job_id = j.id
+		LEFT JOIN commits c ON c.id = j.commit_id
+		WHERE j.status IN ('done', 'applied', 'rebased')
+			AND rv.verdict_bool = 0
+			AND ` + verdictJobFilter + `
+			AND (rv.closed = 0 OR datetime(j.enqueued_at) >= datetime(?))`
+	args := []any{opts.Since.UTC().Format("2006-01-02 15:04:05")}
+	if opts.RepoPath != "" {
+		query += ` AND r.root_path = ?`
+		args = append(args, normalizeRepoPathBestEffort(opts.RepoPath))
+	}
+	if opts.Author != "" {
+		query += ` AND c.author = ?`
+		args = append(args, opts.Author)
+	}
+	query += ` ORDER BY j.id DESC LIMIT ?`
+	args = append(args, digestReviewLimit)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list digest reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var out []DigestReview
+	for rows.Next() {
+		var d DigestReview
+		var enqueuedAt string
+		if err := rows.Scan(&d.JobID, &d.RepoName, &d.RepoPath, &d.Branch, &d.GitRef,
+			&d.Subject, &d.Author, &d.Agent, &d.Output, &d.Closed, &enqueuedAt); err != nil {
+			return nil, fmt.Errorf("scan digest review: %w", err)
+		}
+		d.EnqueuedAt = parseSQLiteTime(enqueuedAt)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// ListActiveAuthors returns the distinct commit authors with jobs enqueued
+// since the given time, optionally within one repo, in name order.
+func (db *DB) ListActiveAuthors(since time.Time, repoPath string) ([]string, error) {
+	query := `
+		SELECT DISTINCT c.author
+		FROM review_jobs j
+		JOIN repos r ON r.id = j.repo_id
+		JOIN commits c ON c.id = j.commit_id
+		WHERE datetime(j.enqueued_at) >= datetime(?) AND c.author != ''`
+	args := []any{since.UTC().Format("2006-01-02 15:04:05")}
+	if repoPath != "" {
+		query += ` AND r.root_path = ?`
+		args = append(args, normalizeRepoPathBestEffort(repoPath))
+	}
+	query += ` ORDER BY c.author`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list active authors: %w", err)
+	}
+	defer rows.Close()
+	var authors []string
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			return nil, err
+		}
+		authors = append(authors, a)
+	}
+	return authors, rows.Err()
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestQueries(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	repo := createRepo(t, db, "/tmp/digest-repo")
+	other := createRepo(t, db, "/tmp/digest-other")
+
+	review := func(repoID int64, sha, author, output string) *ReviewJob {
+		t.Helper()
+		commit, err := db.GetOrCreateCommit(repoID, sha, author, "subject "+sha, time.Now())
+		require.NoError(t, err)
+		job, err := db.EnqueueJob(EnqueueOpts{RepoID: repoID, CommitID: commit.ID, GitRef: sha, Agent: "codex"})
+		require.NoError(t, err)
+		claimJob(t, db, "w1")
+		require.NoError(t, db.CompleteJob(job.ID, "codex", "p", output))
+		return job
+	}
+
+	aliceOpen := review(repo.ID, "a1", "Alice", "- High — a.go:1 bug")
+	aliceClosed := review(repo.ID, "a2", "Alice", "- Low — a.go:2 nit")
+	require.NoError(t, db.MarkReviewClosedByJobID(aliceClosed.ID, true))
+	review(repo.ID, "a3", "Alice", "No issues found.")
+	bobOpen := review(other.ID, "b1", "Bob", "- Medium — b.go:1 race")
+
+	since := time.Now().Add(-time.Hour)
+
+	all, err := db.ListDigestReviews(DigestReviewOptions{Since: since})
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(all))
+	for _, r := range all {
+		ids = append(ids, r.JobID)
+	}
+	assert.Equal(t, []int64{bobOpen.ID, aliceClosed.ID, aliceOpen.ID}, ids, "passing reviews are excluded")
+
+	alice, err := db.ListDigestReviews(DigestReviewOptions{Since: since, Author: "Alice", RepoPath: repo.RootPath})
+	require.NoError(t, err)
+	require.Len(t, alice, 2)
+	assert.Equal(t, "subject a2", alice[0].Subject)
+	assert.True(t, alice[0].Closed)
+
+	// Outside the period only still-open failures remain.
+	later, err := db.ListDigestReviews(DigestReviewOptions{Since: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, later, 2)
+
+	authors, err := db.ListActiveAuthors(since, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "Bob"}, authors)
+	authors, err = db.ListActiveAuthors(since, other.RootPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bob"}, authors)
+
+	summary, err := db.GetSummary(SummaryOptions{Since: since, Author: "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Overview.Total, "summary is scoped to the author's commits")
+	assert.Equal(t, 2, summary.Verdicts.Failed)
+}
+
+func TestDaemonStateKeyValue(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	v, err := db.GetDaemonState("digest:daily/repo")
+	require.NoError(t, err)
+	assert.Empty(t, v)
+
+	require.NoError(t, db.SetDaemonState("digest:daily/repo", "2026-10-18T09:00:00Z"))
+	require.NoError(t, db.SetDaemonState("digest:daily/repo", "2026-10-19T09:00:00Z"))
+	v, err = db.GetDaemonState("digest:daily/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-19T09:00:00Z", v)
+}
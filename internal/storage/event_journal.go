@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// JournalEvent is one row of the event journal.
+type JournalEvent struct {
+	ID        int64     `json:"id"`
+	EventType string    `json:"event_type"`
+	Repo      string    `json:"repo,omitempty"`
+	JobID     int64     `json:"job_id,omitempty"`
+	Payload   string    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JournalQuery selects journal events for replay. AfterID and Since combine:
+// an event must satisfy both.
+type JournalQuery struct {
+	AfterID int64     // only events with a greater ID
+	Since   time.Time // zero = no time bound; else created_at >= Since
+	Repo    string    // empty = all repos; else events for this repo path
+	Limit   int       // <= 0 = no limit
+}
+
+// AppendJournalEvent stores an event and returns its journal ID. IDs increase
+// monotonically and are never reused, even after pruning.
+func (db *DB) AppendJournalEvent(e JournalEvent) (int64, error) {
+	ids, err := db.AppendJournalEvents([]JournalEvent{e})
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// AppendJournalEvents stores events in one transaction and returns their
+// journal IDs in order. Either every event is stored or none is.
+func (db *DB) AppendJournalEvents(events []JournalEvent) ([]int64, error) {
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("append journal events: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		createdAt := e.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO event_journal (event_type, repo, job_id, payload, created_at)
+			VALUES (?, ?, ?, ?, ?)`,
+			e.EventType, e.Repo, e.JobID, e.Payload, createdAt.UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, fmt.Errorf("append journal event: %w", err)
+		}
+		if ids[i], err = res.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("append journal event: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("append journal events: %w", err)
+	}
+	return ids, nil
+}
+
+// ListJournalEvents returns journal events matching q in ID order.
+func (db *DB) ListJournalEvents(q JournalQuery) ([]JournalEvent, error) {
+	query := `
+		SELECT id, event_type, repo, job_id, payload, created_at
+		FROM event_journal
+		WHERE id > ?`
+	args := []any{q.AfterID}
+	if !q.Since.IsZero() {
+		query += ` AND created_at >= ?`
+		args = append(args, q.Since.UTC().Format(time.RFC3339))
+	}
+	if q.Repo != "" {
+		query += ` AND repo = ?`
+		args = append(args, q.Repo)
+	}
+	query += ` ORDER BY id`
+	if q.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, q.Limit)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list journal events: %w", err)
+	}
+	defer rows.Close()
+	var out []JournalEvent
+	for rows.Next() {
+		var e JournalEvent
+		var createdAt string
+		if err := rows.Scan(&e.ID, &e.EventType, &e.Repo, &e.JobID, &e.Payload, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan journal event: %w", err)
+		}
+		e.CreatedAt = parseSQLiteTime(createdAt)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// LatestJournalEventID returns the highest journal ID, or 0 when the journal
+// is empty.
+func (db *DB) LatestJournalEventID() (int64, error) {
+	var id int64
+	if err := db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM event_journal`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("latest journal event: %w", err)
+	}
+	return id, nil
+}
+
+// PruneJournalEvents deletes events created before cutoff.
+func (db *DB) PruneJournalEvents(cutoff time.Time) (int64, error) {
+	res, err := db.Exec(`DELETE FROM event_journal WHERE created_at < ?`,
+		cutoff.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, fmt.Errorf("prune journal events: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventJournal(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	old := time.Now().Add(-48 * time.Hour)
+	var ids []int64
+	for i, e := range []JournalEvent{
+		{EventType: "review.started", Repo: "/a", JobID: 1, Payload: `{"n":1}`, CreatedAt: old},
+		{EventType: "review.completed", Repo: "/a", JobID: 1, Payload: `{"n":2}`},
+		{EventType: "review.completed", Repo: "/b", JobID: 2, Payload: `{"n":3}`},
+	} {
+		id, err := db.AppendJournalEvent(e)
+		require.NoError(t, err, "append %d", i)
+		ids = append(ids, id)
+	}
+	assert.Less(t, ids[0], ids[1])
+	assert.Less(t, ids[1], ids[2])
+
+	latest, err := db.LatestJournalEventID()
+	require.NoError(t, err)
+	assert.Equal(t, ids[2], latest)
+
+	got, err := db.ListJournalEvents(JournalQuery{AfterID: ids[0]})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, `{"n":2}`, got[0].Payload)
+
+	got, err = db.ListJournalEvents(JournalQuery{Repo: "/a"})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = db.ListJournalEvents(JournalQuery{Since: time.Now().Add(-time.Hour), Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, ids[1], got[0].ID)
+
+	n, err := db.PruneJournalEvents(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	// IDs keep increasing after the newest row is pruned.
+	_, err = db.PruneJournalEvents(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	id, err := db.AppendJournalEvent(JournalEvent{EventType: "x", Payload: "{}"})
+	require.NoError(t, err)
+	assert.Greater(t, id, ids[2])
+}
+
+func TestAppendJournalEventsBatch(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	ids, err := db.AppendJournalEvents([]JournalEvent{
+		{EventType: "a", Payload: `{"n":1}`},
+		{EventType: "b", Payload: `{"n":2}`},
+		{EventType: "c", Payload: `{"n":3}`},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+	assert.Less(t, ids[0], ids[1])
+	assert.Less(t, ids[1], ids[2])
+
+	got, err := db.ListJournalEvents(JournalQuery{})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	for i, e := range got {
+		assert.Equal(t, ids[i], e.ID)
+	}
+}
@@ -69,6 +69,10 @@ type ExportReview struct {
 	Cost        ExportReviewCost `json:"cost"`
 	Content     *string          `json:"content"`
 	Subagents   []ExportSubagent `json:"subagents"`
+
+	// RepoPath is the local checkout, used to apply its redaction
+	// settings. It is not exported.
+	RepoPath string `json:"-"`
 }
 
 type ExportSubagent struct {
@@ -116,6 +120,7 @@ type exportReviewRow struct {
 	panelRole     sql.NullString
 	tokenUsage    sql.NullString
 	project       string
+	repoPath      string
 	repoIdentity  sql.NullString
 	commitSHA     sql.NullString
 	ciGitHubRepo  sql.NullString
@@ -198,6 +203,7 @@ func (db *DB) queryExportReviewRows(opts ExportReviewsOptions, cursor *exportCur
 		"j.status = 'done'",
 		"COALESCE(j.job_type, 'review') IN ('review','range','dirty','synthesis')",
 		"COALESCE(j.panel_role, '') != 'member'",
+		latestDoneSynthesisCond("j"),
 		"rv.verdict_bool IS NOT NULL",
 	)
 	if !opts.Since.IsZero() {
@@ -235,7 +241,7 @@ func (db *DB) queryExportReviewRows(opts ExportReviewsOptions, cursor *exportCur
 		       j.status, j.enqueued_at, j.started_at, j.finished_at, j.agent, j.model,
 		       j.git_ref, COALESCE(j.job_type, 'review'), j.branch, j.ci_base_branch,
 		       j.panel_run_uuid, j.panel_role, j.token_usage,
-		       rp.name, rp.identity, c.sha,
+		       rp.name, rp.root_path, rp.identity, c.sha,
 		       cp.github_repo, cp.pr_number, cp.head_sha
 		FROM reviews rv
 		JOIN review_jobs j ON j.id = rv.job_id
@@ -268,6 +274,7 @@ func scanExportReviewRow(rows *sql.Rows) (exportReviewRow, error) {
 		&row.panelRole,
 		&row.tokenUsage,
 		&row.project,
+		&row.repoPath,
 		&row.repoIdentity,
 		&row.commitSHA,
 		&row.ciGitHubRepo,
@@ -299,6 +306,7 @@ func (row exportReviewRow) toExportReview(profile ExportProfile) ExportReview {
 		Model:       stringPtrNonEmpty(nullStringValue(row.model)),
 		Cost:        parseExportCost(row.tokenUsage),
 		Subagents:   []ExportSubagent{},
+		RepoPath:    row.repoPath,
 	}
 	if review.PRNumber != nil && row.ciGitHubRepo.Valid && row.ciGitHubRepo.String != "" {
 		review.PRURL = stringPtrNonEmpty("https://github.com/" + capExportString(row.ciGitHubRepo.String) + "/pull/" + fmt.Sprint(*review.PRNumber))
@@ -643,6 +651,7 @@ func (db *DB) exportCursorReviewExists(cursor *exportCursor) (bool, error) {
 		  AND j.status = 'done'
 		  AND COALESCE(j.job_type, 'review') IN ('review','range','dirty','synthesis')
 		  AND COALESCE(j.panel_role, '') != 'member'
+		  AND `+latestDoneSynthesisCond("j")+`
 		  AND rv.verdict_bool IS NOT NULL
 	`, cursor.ReviewID, cursor.CompletedAt).Scan(&count)
 	if err != nil {
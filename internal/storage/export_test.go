@@ -470,6 +470,37 @@ func TestExportReviewsPanelSubagents(t *testing.T) {
 	assert.Nil(metadata.Reviews[0].Subagents[0].Content)
 }
 
+func TestExportReviewsResynthesizedRunExportsNewestDoneSynthesis(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	repo := createRepo(t, db, filepath.Join(t.TempDir(), "repo"))
+	panelRun := "panel-run-resynth"
+	seedPanelExportJob(t, db, repo.ID, panelRun, "member", "security", 0, "codex", "security", "2026-06-29 00:00:01", "- High — issue")
+	first := seedPanelExportJob(t, db, repo.ID, panelRun, "synthesis", "", 0, "codex", "", "2026-06-29 00:00:02", "- Medium — first")
+	second := seedPanelExportJob(t, db, repo.ID, panelRun, "synthesis", "", 0, "gemini", "", "2026-06-29 00:00:03", "- Medium — second")
+
+	page, err := db.ExportReviews(ExportReviewsOptions{Profile: ExportProfileContent, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Reviews, 1, "a re-synthesized run exports once")
+	assert.Equal(t, second.UUID, page.Reviews[0].ReviewID)
+	require.Len(t, page.Reviews[0].Subagents, 1)
+
+	// A newer synthesis that did not finish leaves the earlier review exported.
+	failed, err := db.EnqueueJob(EnqueueOpts{
+		RepoID: repo.ID, GitRef: "aaaa..dddd", Agent: "claude-code",
+		JobType: JobTypeSynthesis, PanelRunUUID: panelRun, PanelRole: PanelRoleSynthesis,
+	})
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE review_jobs SET status = 'failed' WHERE id = ?`, failed.ID)
+	require.NoError(t, err)
+	page, err = db.ExportReviews(ExportReviewsOptions{Profile: ExportProfileContent, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Reviews, 1)
+	assert.Equal(t, second.UUID, page.Reviews[0].ReviewID)
+	assert.NotEqual(t, first.UUID, page.Reviews[0].ReviewID)
+}
+
 func TestExportReviewsSynthesisWithoutMembersUsesEmptySubagentsArray(t *testing.T) {
 	db := openTestDB(t)
 	defer db.Close()
@@ -1,6 +1,9 @@
 package storage
 
-import "database/sql"
+import (
+	"database/sql"
+	"encoding/json"
+)
 
 type sqlScanner interface {
 	Scan(dest ...any) error
@@ -48,6 +51,14 @@ type reviewJobScanFields struct {
 	PanelMemberIndex  sql.NullInt64
 	PanelMemberConfig sql.NullString
 	ClaimBlocked      int
+	Redactions        int
+	Route             sql.NullString
+	VerifyResult      sql.NullString
+	Incremental       sql.NullString
+	Resolution        sql.NullString
+	CacheKey          sql.NullString
+	CachedFrom        sql.NullString
+	NoCache           int
 	SkipReason        sql.NullString
 	Source            sql.NullString
 }
@@ -125,6 +136,35 @@ func applyReviewJobScan(job *ReviewJob, fields reviewJobScanFields) {
 	if fields.TokenUsage.Valid {
 		job.TokenUsage = fields.TokenUsage.String
 	}
+	job.Redactions = fields.Redactions
+	if fields.Route.Valid {
+		job.Route = fields.Route.String
+	}
+	if fields.VerifyResult.Valid && fields.VerifyResult.String != "" {
+		var vr VerifyResult
+		if json.Unmarshal([]byte(fields.VerifyResult.String), &vr) == nil {
+			job.Verify = &vr
+		}
+	}
+	if fields.Incremental.Valid && fields.Incremental.String != "" {
+		var inc IncrementalReview
+		if json.Unmarshal([]byte(fields.Incremental.String), &inc) == nil {
+			job.Incremental = &inc
+		}
+	}
+	if fields.Resolution.Valid && fields.Resolution.String != "" {
+		var rc ResolutionCheck
+		if json.Unmarshal([]byte(fields.Resolution.String), &rc) == nil {
+			job.Resolution = &rc
+		}
+	}
+	if fields.CacheKey.Valid {
+		job.CacheKey = fields.CacheKey.String
+	}
+	if fields.CachedFrom.Valid {
+		job.CachedFrom = fields.CachedFrom.String
+	}
+	job.NoCache = fields.NoCache != 0
 	job.Agentic = fields.Agentic != 0
 	job.PromptPrebuilt = fields.PromptPrebuilt != 0
 	if fields.EnqueuedAt != "" {
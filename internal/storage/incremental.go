@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// PriorReview is a completed review that an incremental re-review may build
+// on or resolve.
+type PriorReview struct {
+	JobID  int64
+	GitRef string
+	Output string
+	Closed bool
+	Passed bool
+}
+
+// HeadSHA returns the commit the review covered: the end of a range, or the
+// reviewed commit itself.
+func (r PriorReview) HeadSHA() string {
+	if i := strings.LastIndex(r.GitRef, ".."); i >= 0 {
+		return r.GitRef[i+2:]
+	}
+	return r.GitRef
+}
+
+// defaultReviewTypeFilter restricts review_jobs (aliased "j") to default
+// reviews, matching config.IsDefaultReviewType.
+const defaultReviewTypeFilter = "COALESCE(j.review_type, '') IN ('', 'default', 'general', 'review')"
+
+// ListBranchReviews returns the completed default reviews of a branch, newest
+// first: commit and range reviews plus panel and chunked-review syntheses.
+// Panel members are excluded since their synthesis stands for the run.
+func (db *DB) ListBranchReviews(repoID int64, branch string, limit int) ([]PriorReview, error) {
+	rows, err := db.Query(`
+		SELECT j.id, j.git_ref, rv.output, rv.closed, rv.verdict_bool
+		FROM review_jobs j
+		JOIN reviews rv ON rv.job_id = j.id
+		WHERE j.repo_id = ? AND j.branch = ? AND j.status = 'done'
+			AND j.job_type IN ('review', 'range', 'synthesis')
+			AND COALESCE(j.panel_role, '') != 'member'
+			AND `+defaultReviewTypeFilter+`
+		ORDER BY j.finished_at DESC, j.id DESC
+		LIMIT ?`, repoID, branch, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list branch reviews: %w", err)
+	}
+	return scanPriorReviews(rows)
+}
+
+// ListCIPanelReviews returns the completed synthesis reviews of a PR's CI
+// panel runs, newest first.
+func (db *DB) ListCIPanelReviews(githubRepo string, prNumber, limit int) ([]PriorReview, error) {
+	rows, err := db.Query(`
+		SELECT j.id, j.git_ref, rv.output, rv.closed, rv.verdict_bool
+		FROM ci_pr_panels p
+		JOIN review_jobs j ON j.id = p.synthesis_job_id
+		JOIN reviews rv ON rv.job_id = j.id
+		WHERE p.github_repo = ? AND p.pr_number = ? AND j.status = 'done'
+		ORDER BY j.finished_at DESC, j.id DESC
+		LIMIT ?`, githubRepo, prNumber, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list CI panel reviews: %w", err)
+	}
+	return scanPriorReviews(rows)
+}
+
+func scanPriorReviews(rows *sql.Rows) ([]PriorReview, error) {
+	defer rows.Close()
+	var out []PriorReview
+	for rows.Next() {
+		var r PriorReview
+		var verdictBool sql.NullInt64
+		if err := rows.Scan(&r.JobID, &r.GitRef, &r.Output, &r.Closed, &verdictBool); err != nil {
+			return nil, fmt.Errorf("scan prior review: %w", err)
+		}
+		r.Passed = verdictFromBoolOrParse(verdictBool, r.Output) == verdictPass
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListBranchReviews(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/incremental-repo")
+
+	review := func(opts EnqueueOpts, output string) *ReviewJob {
+		t.Helper()
+		opts.RepoID = repo.ID
+		opts.Agent = "codex"
+		job, err := db.EnqueueJob(opts)
+		require.NoError(t, err)
+		claimJob(t, db, "w1")
+		require.NoError(t, db.CompleteJob(job.ID, "codex", "p", output))
+		return job
+	}
+
+	first := review(EnqueueOpts{GitRef: "base..aaa", Branch: "feature"}, "- High — a.go:1 bug")
+	review(EnqueueOpts{GitRef: "base..bbb", Branch: "other"}, "No issues found.")
+	review(EnqueueOpts{GitRef: "base..ccc", Branch: "feature", ReviewType: "security"}, "No issues found.")
+	second := review(EnqueueOpts{GitRef: "aaa..ddd", Branch: "feature"}, "No issues found.")
+
+	got, err := db.ListBranchReviews(repo.ID, "feature", 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "other branches and non-default review types are excluded")
+	assert.Equal(t, second.ID, got[0].JobID)
+	assert.Equal(t, "ddd", got[0].HeadSHA())
+	assert.True(t, got[0].Passed)
+	assert.Equal(t, first.ID, got[1].JobID)
+	assert.False(t, got[1].Passed)
+	assert.False(t, got[1].Closed)
+	assert.Equal(t, "- High — a.go:1 bug", got[1].Output)
+}
+
+func TestJobIncrementalRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/incremental-roundtrip")
+
+	inc := &IncrementalReview{BaseJobID: 7, BaseSHA: "aaa", OpenJobIDs: []int64{5, 7}}
+	job, err := db.EnqueueJob(EnqueueOpts{
+		RepoID: repo.ID, GitRef: "aaa..bbb", Branch: "feature", Agent: "codex", Incremental: inc,
+	})
+	require.NoError(t, err)
+
+	claimed := claimJob(t, db, "w1")
+	require.Equal(t, job.ID, claimed.ID)
+	assert.Equal(t, inc, claimed.Incremental)
+
+	inc.ResolvedJobIDs = []int64{5}
+	require.NoError(t, db.SaveJobIncremental(job.ID, *inc))
+	loaded, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, inc, loaded.Incremental)
+}
+
+func TestListCIPanelReviews(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/incremental-ci")
+
+	member := EnqueueOpts{RepoID: repo.ID, GitRef: "base..aaa", Agent: "codex", JobType: JobTypeRange}
+	synth := EnqueueOpts{RepoID: repo.ID, GitRef: "base..aaa", Agent: "codex"}
+	created, members, synthJob, err := db.CreateCIPanelRun("acme/api", 3, "aaa", []EnqueueOpts{member}, synth)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	got, err := db.ListCIPanelReviews("acme/api", 3, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got, "unfinished runs are not prior reviews")
+
+	claimJob(t, db, "w1")
+	require.NoError(t, db.CompleteJob(members[0].ID, "codex", "p", "- Medium — b.go:4 race"))
+	_, err = db.Exec(`UPDATE review_jobs SET claim_blocked = 0 WHERE id = ?`, synthJob.ID)
+	require.NoError(t, err)
+	claimJob(t, db, "w1")
+	require.NoError(t, db.CompleteJob(synthJob.ID, "codex", "p", "- Medium — b.go:4 race"))
+
+	got, err = db.ListCIPanelReviews("acme/api", 3, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1, "only the synthesis stands for the run")
+	assert.Equal(t, synthJob.ID, got[0].JobID)
+	assert.Equal(t, "aaa", got[0].HeadSHA())
+	assert.False(t, got[0].Passed)
+
+	other, err := db.ListCIPanelReviews("acme/api", 4, 10)
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
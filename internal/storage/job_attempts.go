@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Job attempt outcomes.
+const (
+	AttemptSucceeded = "succeeded" // the agent produced the job's result
+	AttemptFailed    = "failed"    // the agent ran and failed
+	AttemptSkipped   = "skipped"   // the agent's circuit was open, so it never ran
+)
+
+// Job attempt failure classes.
+const (
+	AttemptFailureQuota         = "quota"          // hard rate or quota limit
+	AttemptFailureSessionLimit  = "session_limit"  // session or usage cap
+	AttemptFailureOutage        = "outage"         // transient provider outage
+	AttemptFailureContextWindow = "context_window" // prompt exceeded the context window
+	AttemptFailureTimeout       = "timeout"        // the agent ran past the job timeout
+	AttemptFailureCircuitOpen   = "circuit_open"   // skipped while the agent's circuit was open
+	AttemptFailureError         = "error"          // any other failure
+)
+
+// JobAttempt is one agent attempt of a job. Attempts are numbered from 1 in
+// the order they finished, so a job's attempts read as its failover history,
+// such as codex failed on quota, then claude-code succeeded.
+type JobAttempt struct {
+	JobID        int64      `json:"job_id"`
+	Attempt      int        `json:"attempt"`
+	Agent        string     `json:"agent"`
+	Model        string     `json:"model,omitempty"`
+	Outcome      string     `json:"outcome"`
+	FailureClass string     `json:"failure_class,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	FinishedAt   time.Time  `json:"finished_at"`
+}
+
+// RecordJobAttempt appends an attempt to a job's history, numbering it after
+// the job's previous attempts.
+func (db *DB) RecordJobAttempt(a JobAttempt) error {
+	var startedAt any
+	if a.StartedAt != nil {
+		startedAt = a.StartedAt.UTC().Format(time.RFC3339)
+	}
+	if a.FinishedAt.IsZero() {
+		a.FinishedAt = time.Now()
+	}
+	_, err := db.Exec(`
+		INSERT INTO job_attempts (job_id, attempt, agent, model, outcome,
+			failure_class, error, started_at, finished_at)
+		SELECT ?, COALESCE(MAX(attempt), 0) + 1, ?, ?, ?, ?, ?, ?, ?
+		FROM job_attempts WHERE job_id = ?`,
+		a.JobID, a.Agent, a.Model, a.Outcome, a.FailureClass, a.Error,
+		startedAt, a.FinishedAt.UTC().Format(time.RFC3339), a.JobID)
+	if err != nil {
+		return fmt.Errorf("record job attempt: %w", err)
+	}
+	return nil
+}
+
+// ListJobAttempts returns a job's attempts in order.
+func (db *DB) ListJobAttempts(jobID int64) ([]JobAttempt, error) {
+	rows, err := db.Query(`
+		SELECT job_id, attempt, agent, model, outcome, failure_class, error,
+			started_at, finished_at
+		FROM job_attempts WHERE job_id = ? ORDER BY attempt`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list job attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var out []JobAttempt
+	for rows.Next() {
+		var a JobAttempt
+		var startedAt sql.NullString
+		var finishedAt string
+		if err := rows.Scan(&a.JobID, &a.Attempt, &a.Agent, &a.Model, &a.Outcome,
+			&a.FailureClass, &a.Error, &startedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("scan job attempt: %w", err)
+		}
+		if startedAt.Valid {
+			t := parseSQLiteTime(startedAt.String)
+			a.StartedAt = &t
+		}
+		a.FinishedAt = parseSQLiteTime(finishedAt)
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordJobAttempt(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	started := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Minute)
+	require.NoError(t, db.RecordJobAttempt(JobAttempt{
+		JobID: 7, Agent: "codex", Model: "gpt-5", Outcome: AttemptFailed,
+		FailureClass: AttemptFailureQuota, Error: "usage limit reached",
+		StartedAt: &started, FinishedAt: finished,
+	}))
+	require.NoError(t, db.RecordJobAttempt(JobAttempt{
+		JobID: 8, Agent: "gemini", Outcome: AttemptSucceeded, FinishedAt: finished,
+	}))
+	require.NoError(t, db.RecordJobAttempt(JobAttempt{
+		JobID: 7, Agent: "claude-code", Model: "sonnet", Outcome: AttemptSucceeded,
+		FinishedAt: finished.Add(time.Minute),
+	}))
+
+	attempts, err := db.ListJobAttempts(7)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, "codex", attempts[0].Agent)
+	assert.Equal(t, AttemptFailureQuota, attempts[0].FailureClass)
+	require.NotNil(t, attempts[0].StartedAt)
+	assert.True(t, started.Equal(*attempts[0].StartedAt))
+	assert.True(t, finished.Equal(attempts[0].FinishedAt))
+	assert.Equal(t, 2, attempts[1].Attempt)
+	assert.Equal(t, "claude-code", attempts[1].Agent)
+	assert.Equal(t, AttemptSucceeded, attempts[1].Outcome)
+	assert.Nil(t, attempts[1].StartedAt)
+
+	none, err := db.ListJobAttempts(99)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
@@ -96,8 +96,17 @@ type EnqueueOpts struct {
 	PanelName             string // Config panel name that produced the run
 	PanelMemberName       string // Subagent name for a member
 	PanelMemberIndex      int    // Stable order of a member within the run
-	PanelMemberConfigJSON string // Resolved member spec JSON (reproducibility)
+	PanelMemberConfigJSON string // Resolved member or synthesis spec JSON (reproducibility)
 	ClaimBlocked          bool   // Local-only gate: ClaimJob must not claim while set
+	Route                 string // [[review.routes]] entry that picked the settings
+	NoCache               bool   // Always run the agent, even when a cached review matches
+	// Incremental links an incremental re-review to the review it continues.
+	Incremental *IncrementalReview
+	// Resolution lists the open reviews a resolution check job covers.
+	Resolution *ResolutionCheck
+	// DependsOn lists prerequisite jobs. The job is stored claim_blocked
+	// until SettleJobDependencies sees every prerequisite succeed.
+	DependsOn []int64
 }
 
 // execer is satisfied by *DB (it embeds *sql.DB), *sql.Conn, and *sql.Tx.
@@ -108,11 +117,30 @@ type execer interface {
 }
 
 // EnqueueJob creates a new review job. The job type is inferred from opts.
+// A job with prerequisites is inserted together with its dependency rows;
+// the caller runs SettleJobDependencies to release it once they are done.
 func (db *DB) EnqueueJob(opts EnqueueOpts) (*ReviewJob, error) {
 	uid := GenerateUUID()
 	machineID, _ := db.GetMachineID()
 	now := time.Now()
-	return db.insertJobTx(context.Background(), db, opts, uid, machineID, now)
+	ctx := context.Background()
+	if len(opts.DependsOn) == 0 {
+		return db.insertJobTx(ctx, db, opts, uid, machineID, now)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	job, err := db.insertJobTx(ctx, tx, opts, uid, machineID, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return job, nil
 }
 
 // insertJobTx inserts one review_jobs row via exec and returns the built
@@ -180,16 +208,37 @@ func (db *DB) insertJobTx(ctx context.Context, exec execer, opts EnqueueOpts, ui
 	}
 
 	claimBlockedInt := 0
-	if opts.ClaimBlocked {
+	if opts.ClaimBlocked || len(opts.DependsOn) > 0 {
 		claimBlockedInt = 1
 	}
+	noCacheInt := 0
+	if opts.NoCache {
+		noCacheInt = 1
+	}
+
+	var incrementalJSON string
+	if opts.Incremental != nil {
+		data, err := json.Marshal(opts.Incremental)
+		if err != nil {
+			return nil, fmt.Errorf("marshal incremental review: %w", err)
+		}
+		incrementalJSON = string(data)
+	}
+	var resolutionJSON string
+	if opts.Resolution != nil {
+		data, err := json.Marshal(opts.Resolution)
+		if err != nil {
+			return nil, fmt.Errorf("marshal resolution check: %w", err)
+		}
+		resolutionJSON = string(data)
+	}
 
 	result, err := exec.ExecContext(ctx, `
 		INSERT INTO review_jobs (repo_id, commit_id, git_ref, branch, ci_base_branch, session_id, agent, model, provider, requested_model, requested_provider, reasoning,
 			status, job_type, review_type, patch_id, diff_content, dirty_files, prompt, agentic, prompt_prebuilt, output_prefix,
 			parent_job_id, uuid, source_machine_id, updated_at, worktree_path, min_severity, backup_agent, backup_model,
-			panel_run_uuid, panel_role, panel_name, panel_member_name, panel_member_index, panel_member_config_json, claim_blocked, source)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'queued', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			panel_run_uuid, panel_role, panel_name, panel_member_name, panel_member_index, panel_member_config_json, claim_blocked, source, route, incremental, resolution, no_cache)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'queued', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		opts.RepoID, commitIDParam, gitRef, nullString(opts.Branch), nullString(opts.CIBaseBranch), nullString(opts.SessionID),
 		opts.Agent, nullString(opts.Model), nullString(opts.Provider), nullString(opts.RequestedModel), nullString(opts.RequestedProvider), reasoning,
 		jobType, opts.ReviewType, nullString(opts.PatchID),
@@ -197,12 +246,15 @@ func (db *DB) insertJobTx(ctx context.Context, exec execer, opts EnqueueOpts, ui
 		nullString(opts.OutputPrefix), parentJobIDParam,
 		uid, machineID, nowStr, opts.WorktreePath, normalizeMinSeverityForWrite(opts.MinSeverity), opts.BackupAgent, opts.BackupModel,
 		nullString(opts.PanelRunUUID), nullString(opts.PanelRole), nullString(opts.PanelName),
-		nullString(opts.PanelMemberName), opts.PanelMemberIndex, nullString(opts.PanelMemberConfigJSON), claimBlockedInt, nullString(opts.Source))
+		nullString(opts.PanelMemberName), opts.PanelMemberIndex, nullString(opts.PanelMemberConfigJSON), claimBlockedInt, nullString(opts.Source), nullString(opts.Route), nullString(incrementalJSON), nullString(resolutionJSON), noCacheInt)
 	if err != nil {
 		return nil, err
 	}
 
 	id, _ := result.LastInsertId()
+	if err := insertJobDependencies(ctx, exec, id, opts.DependsOn); err != nil {
+		return nil, err
+	}
 	job := &ReviewJob{
 		ID:                    id,
 		RepoID:                opts.RepoID,
@@ -239,8 +291,12 @@ func (db *DB) insertJobTx(ctx context.Context, exec execer, opts EnqueueOpts, ui
 		PanelMemberName:       opts.PanelMemberName,
 		PanelMemberIndex:      opts.PanelMemberIndex,
 		PanelMemberConfigJSON: opts.PanelMemberConfigJSON,
-		ClaimBlocked:          opts.ClaimBlocked,
+		ClaimBlocked:          claimBlockedInt == 1,
 		Source:                opts.Source,
+		Route:                 opts.Route,
+		Incremental:           opts.Incremental,
+		Resolution:            opts.Resolution,
+		NoCache:               opts.NoCache,
 	}
 	if opts.ParentJobID > 0 {
 		job.ParentJobID = &opts.ParentJobID
@@ -375,7 +431,7 @@ func (db *DB) ClaimJob(workerID string) (*ReviewJob, error) {
 		SELECT j.id, j.repo_id, j.commit_id, j.git_ref, j.branch, j.ci_base_branch, j.session_id, j.agent, j.model, j.provider, j.requested_model, j.requested_provider, j.reasoning, j.status, j.enqueued_at,
 		       r.root_path, r.name, c.subject, j.diff_content, j.dirty_files, j.prompt, COALESCE(j.agentic, 0), COALESCE(j.prompt_prebuilt, 0), j.job_type, j.review_type,
 		       j.output_prefix, j.patch_id, j.parent_job_id, COALESCE(j.worktree_path, ''), j.command_line, COALESCE(j.min_severity, ''), COALESCE(j.backup_agent, ''), COALESCE(j.backup_model, ''),
-		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0), COALESCE(j.source, ''), j.retry_count, j.uuid
+		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0), COALESCE(j.source, ''), j.retry_count, j.uuid, j.incremental, j.resolution, j.cache_key, j.cached_from, COALESCE(j.no_cache, 0)
 		FROM review_jobs j
 		JOIN repos r ON r.id = j.repo_id
 		LEFT JOIN commits c ON c.id = j.commit_id
@@ -385,7 +441,7 @@ func (db *DB) ClaimJob(workerID string) (*ReviewJob, error) {
 	`, workerID).Scan(&job.ID, &job.RepoID, &fields.CommitID, &job.GitRef, &fields.Branch, &fields.CIBaseBranch, &fields.SessionID, &job.Agent, &fields.Model, &fields.Provider, &fields.RequestedModel, &fields.RequestedProvider, &job.Reasoning, &job.Status, &fields.EnqueuedAt,
 		&job.RepoPath, &job.RepoName, &fields.CommitSubject, &fields.DiffContent, &fields.DirtyFiles, &fields.Prompt, &fields.Agentic, &fields.PromptPrebuilt, &fields.JobType, &fields.ReviewType,
 		&fields.OutputPrefix, &fields.PatchID, &fields.ParentJobID, &fields.WorktreePath, &fields.CommandLine, &fields.MinSeverity, &fields.BackupAgent, &fields.BackupModel,
-		&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked, &fields.Source, &job.RetryCount, &fields.UUID)
+		&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked, &fields.Source, &job.RetryCount, &fields.UUID, &fields.Incremental, &fields.Resolution, &fields.CacheKey, &fields.CachedFrom, &fields.NoCache)
 	if err != nil {
 		return nil, err
 	}
@@ -402,6 +458,46 @@ func (db *DB) SaveJobPrompt(jobID int64, prompt string) error {
 	return err
 }
 
+// SaveJobRedactions records how many secrets were masked out of the job's
+// prompt and diff for its current run.
+func (db *DB) SaveJobRedactions(jobID int64, count int) error {
+	_, err := db.Exec(`UPDATE review_jobs SET redactions = ? WHERE id = ?`, count, jobID)
+	return err
+}
+
+// SaveJobVerifyResult records the verify_command outcome of a fix job's
+// current run.
+func (db *DB) SaveJobVerifyResult(jobID int64, result VerifyResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal verify result: %w", err)
+	}
+	_, err = db.Exec(`UPDATE review_jobs SET verify_result = ? WHERE id = ?`, string(data), jobID)
+	return err
+}
+
+// SaveJobIncremental updates the incremental re-review link of a job, e.g.
+// to record the prior reviews its run resolved.
+func (db *DB) SaveJobIncremental(jobID int64, inc IncrementalReview) error {
+	data, err := json.Marshal(inc)
+	if err != nil {
+		return fmt.Errorf("marshal incremental review: %w", err)
+	}
+	_, err = db.Exec(`UPDATE review_jobs SET incremental = ? WHERE id = ?`, string(data), jobID)
+	return err
+}
+
+// SaveJobResolution updates the resolution check of a job, e.g. to record
+// the reviews its run closed.
+func (db *DB) SaveJobResolution(jobID int64, rc ResolutionCheck) error {
+	data, err := json.Marshal(rc)
+	if err != nil {
+		return fmt.Errorf("marshal resolution check: %w", err)
+	}
+	_, err = db.Exec(`UPDATE review_jobs SET resolution = ? WHERE id = ?`, string(data), jobID)
+	return err
+}
+
 // MarkJobAgentInvoked records that an agent was actually invoked for this
 // attempt and stores the command line that was executed. The worker calls it
 // immediately before the agent runs — after all pre-agent gates (prompt size,
@@ -577,6 +673,17 @@ func (db *DB) CompleteFixJob(jobID int64, agent, prompt, output, patch string) e
 // Only updates if job is still in 'running' state (respects cancellation).
 // If the job has an output_prefix, it will be prepended to the output.
 func (db *DB) CompleteJob(jobID int64, agent, prompt, output string) error {
+	return db.completeJob(jobID, agent, prompt, output, "")
+}
+
+// CompleteJobFromCache marks a job as done with the output of the cached
+// review of job sourceUUID, recording the link in cached_from. Like
+// CompleteJob it is a no-op unless the job is still running.
+func (db *DB) CompleteJobFromCache(jobID int64, agent, prompt, output, sourceUUID string) error {
+	return db.completeJob(jobID, agent, prompt, output, sourceUUID)
+}
+
+func (db *DB) completeJob(jobID int64, agent, prompt, output, cachedFrom string) error {
 	// Get machine ID and generate UUIDs before starting transaction
 	// to avoid potential lock conflicts with GetMachineID's writes
 	now := time.Now().Format(time.RFC3339)
@@ -618,7 +725,7 @@ func (db *DB) CompleteJob(jobID int64, agent, prompt, output string) error {
 	}
 
 	// Update job status only if still running (not canceled)
-	result, err := conn.ExecContext(ctx, `UPDATE review_jobs SET status = 'done', finished_at = ?, updated_at = ? WHERE id = ? AND status = 'running'`, now, now, jobID)
+	result, err := conn.ExecContext(ctx, `UPDATE review_jobs SET status = 'done', finished_at = ?, updated_at = ?, cached_from = ? WHERE id = ? AND status = 'running'`, now, now, nullString(cachedFrom), jobID)
 	if err != nil {
 		return err
 	}
@@ -771,19 +878,34 @@ func (db *DB) ReenqueueJob(jobID int64, opts ReenqueueOpts) error {
 		}
 	}()
 
-	// Delete any existing review for this job (for done jobs being rerun)
-	_, err = conn.ExecContext(ctx, `DELETE FROM reviews WHERE job_id = ?`, jobID)
+	if err := reenqueueJobTx(ctx, conn, jobID, opts, time.Now().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, "COMMIT")
 	if err != nil {
 		return err
 	}
+	committed = true
+	return nil
+}
 
-	nowStr := time.Now().Format(time.RFC3339)
+// reenqueueJobTx deletes jobID's review and resets the job to queued with
+// the given execution settings. It is the shared body of ReenqueueJob and
+// the panel rerun paths; callers own the transaction. Returns
+// sql.ErrNoRows when the job is missing or not terminal.
+func reenqueueJobTx(ctx context.Context, exec execer, jobID int64, opts ReenqueueOpts, now string) error {
+	// Delete any existing review for this job (for done jobs being rerun)
+	if _, err := exec.ExecContext(ctx, `DELETE FROM reviews WHERE job_id = ?`, jobID); err != nil {
+		return err
+	}
 
 	// Reset job status and replace effective execution settings with the
 	// newly resolved values for this rerun. Clear prompt_prebuilt and prompt
 	// only for review jobs so they rebuild from current git/config state.
 	// Stored-prompt jobs (task, compact, fix, insights) keep their prompt
 	// since the worker needs it and cannot regenerate it from git.
+	// A rerun asks for a fresh review, so it bypasses the review cache.
 	//
 	// synced_at is cleared because this attempt's cost metadata is cleared: if
 	// the rerun completes unpriced in the same RFC3339 second as the prior
@@ -793,15 +915,16 @@ func (db *DB) ReenqueueJob(jobID int64, opts ReenqueueOpts) error {
 	// it on the symmetric cost-write path). The other attempt resets (RetryJob,
 	// FailoverJob, ResetStaleJobs, PromoteClassifyToDesignReview) clear it for
 	// the same reason.
-	result, err := conn.ExecContext(ctx, `
+	result, err := exec.ExecContext(ctx, `
 		UPDATE review_jobs
-		SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, retry_count = 0, patch = NULL, session_id = NULL, token_usage = NULL, command_line = NULL, agent_invoked = 0, synced_at = NULL, model = ?, provider = ?,
+		SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, retry_count = 0, patch = NULL, session_id = NULL, token_usage = NULL, command_line = NULL, verify_result = NULL, agent_invoked = 0, synced_at = NULL, model = ?, provider = ?,
 		    prompt_prebuilt = 0,
-		    prompt = CASE WHEN job_type IN ('task', 'compact', 'fix', 'insights') THEN prompt ELSE NULL END,
+		    prompt = CASE WHEN job_type IN ('task', 'compact', 'fix', 'insights', 'resolution') THEN prompt ELSE NULL END,
 		    skip_reason = NULL,
+		    cached_from = NULL, no_cache = 1,
 		    updated_at = ?
 		WHERE id = ? AND status IN ('done', 'failed', 'canceled', 'skipped')
-	`, nullString(opts.Model), nullString(opts.Provider), nowStr, jobID)
+	`, nullString(opts.Model), nullString(opts.Provider), now, jobID)
 	if err != nil {
 		return err
 	}
@@ -812,12 +935,6 @@ func (db *DB) ReenqueueJob(jobID int64, opts ReenqueueOpts) error {
 	if rows == 0 {
 		return sql.ErrNoRows
 	}
-
-	_, err = conn.ExecContext(ctx, "COMMIT")
-	if err != nil {
-		return err
-	}
-	committed = true
 	return nil
 }
 
@@ -840,13 +957,13 @@ func (db *DB) RetryJob(jobID int64, workerID string, maxRetries int, retryBackof
 	if workerID != "" {
 		result, err = db.Exec(`
 			UPDATE review_jobs
-			SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, retry_count = retry_count + 1, session_id = NULL, token_usage = NULL, command_line = NULL, agent_invoked = 0, synced_at = NULL, retry_not_before = ?
+			SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, retry_count = retry_count + 1, session_id = NULL, token_usage = NULL, command_line = NULL, verify_result = NULL, agent_invoked = 0, synced_at = NULL, retry_not_before = ?
 			WHERE id = ? AND retry_count < ? AND status = 'running' AND worker_id = ?
 		`, notBefore, jobID, maxRetries, workerID)
 	} else {
 		result, err = db.Exec(`
 			UPDATE review_jobs
-			SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, retry_count = retry_count + 1, session_id = NULL, token_usage = NULL, command_line = NULL, agent_invoked = 0, synced_at = NULL, retry_not_before = ?
+			SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, retry_count = retry_count + 1, session_id = NULL, token_usage = NULL, command_line = NULL, verify_result = NULL, agent_invoked = 0, synced_at = NULL, retry_not_before = ?
 			WHERE id = ? AND retry_count < ? AND status = 'running'
 		`, notBefore, jobID, maxRetries)
 	}
@@ -862,6 +979,21 @@ func (db *DB) RetryJob(jobID int64, workerID string, maxRetries int, retryBackof
 	return rows > 0, nil
 }
 
+// DeferJob requeues a running job owned by workerID without consuming a
+// retry. The job is not claimable again until notBefore.
+func (db *DB) DeferJob(jobID int64, workerID string, notBefore time.Time) (bool, error) {
+	result, err := db.Exec(`
+		UPDATE review_jobs
+		SET status = 'queued', worker_id = NULL, started_at = NULL, finished_at = NULL, error = NULL, session_id = NULL, token_usage = NULL, command_line = NULL, verify_result = NULL, agent_invoked = 0, synced_at = NULL, retry_not_before = ?
+		WHERE id = ? AND status = 'running' AND worker_id = ?
+	`, retryNotBeforeAt(notBefore), jobID, workerID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
 // FailoverJob atomically switches a running job to the given backup agent
 // and requeues it. When backupModel is non-empty the job's model is set
 // to that value; otherwise model is cleared (NULL) so the backup agent
@@ -885,6 +1017,7 @@ func (db *DB) FailoverJob(jobID int64, workerID, backupAgent, backupModel string
 		    session_id = NULL,
 		    token_usage = NULL,
 		    command_line = NULL,
+		    verify_result = NULL,
 		    agent_invoked = 0,
 		    synced_at = NULL,
 		    retry_not_before = NULL
@@ -923,6 +1056,7 @@ type listJobsOptions struct {
 	beforeCursor       *int64
 	panelRun           string
 	excludePanelRole   string
+	latestSynthesis    bool
 	omitPrompt         bool
 }
 
@@ -1012,6 +1146,34 @@ func WithExcludePanelRole(role string) ListJobsOption {
 	return func(o *listJobsOptions) { o.excludePanelRole = role }
 }
 
+// WithLatestSynthesisOnly excludes synthesis rows a later re-synthesis of the
+// same panel run superseded, so each run lists once, as its newest synthesis.
+func WithLatestSynthesisOnly() ListJobsOption {
+	return func(o *listJobsOptions) { o.latestSynthesis = true }
+}
+
+// latestSynthesisCond is true for every job except a synthesis row with a
+// newer synthesis in the same panel run. Re-synthesis keeps earlier
+// syntheses as history; the newest one is the run's parent.
+func latestSynthesisCond(alias string) string {
+	return synthesisNotSupersededCond(alias, "")
+}
+
+// latestDoneSynthesisCond is latestSynthesisCond counting only completed
+// syntheses as newer, so a failed re-synthesis leaves the earlier review in
+// place.
+func latestDoneSynthesisCond(alias string) string {
+	return synthesisNotSupersededCond(alias, "AND newer.status = 'done'")
+}
+
+func synthesisNotSupersededCond(alias, newerFilter string) string {
+	return fmt.Sprintf(`(COALESCE(%[1]s.panel_role, '') != 'synthesis' OR NOT EXISTS (
+		SELECT 1 FROM review_jobs newer
+		 WHERE newer.panel_run_uuid = %[1]s.panel_run_uuid
+		   AND newer.panel_role = 'synthesis'
+		   AND newer.id > %[1]s.id %[2]s))`, alias, newerFilter)
+}
+
 // escapeLike escapes SQL LIKE wildcards (% and _) in a literal string.
 // Uses '!' as the ESCAPE character to avoid conflicts with backslashes
 // in Windows paths stored in root_path.
@@ -1107,6 +1269,9 @@ func buildJobFilterClause(statusFilter, repoFilter string, o listJobsOptions) (s
 		conditions = append(conditions, "COALESCE(j.panel_role, '') != ?")
 		args = append(args, o.excludePanelRole)
 	}
+	if o.latestSynthesis {
+		conditions = append(conditions, latestSynthesisCond("j"))
+	}
 	if o.beforeCursor != nil {
 		conditions = append(conditions, "j.id < ?")
 		args = append(args, *o.beforeCursor)
@@ -1136,7 +1301,7 @@ func (db *DB) ListJobs(statusFilter string, repoFilter string, limit, offset int
 		       j.parent_job_id, j.provider, j.requested_model, j.requested_provider, j.token_usage, COALESCE(j.worktree_path, ''),
 		       j.command_line, j.dirty_files, COALESCE(j.min_severity, ''), COALESCE(j.backup_agent, ''), COALESCE(j.backup_model, ''),
 		       COALESCE(j.skip_reason, ''), COALESCE(j.source, ''),
-		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0)
+		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0), COALESCE(j.redactions, 0), COALESCE(j.route, ''), j.verify_result, j.incremental, j.resolution, j.cache_key, j.cached_from, COALESCE(j.no_cache, 0)
 		FROM review_jobs j
 		JOIN repos r ON r.id = j.repo_id
 		LEFT JOIN commits c ON c.id = j.commit_id
@@ -1177,7 +1342,7 @@ func (db *DB) ListJobs(statusFilter string, repoFilter string, limit, offset int
 			&fields.ParentJobID, &fields.Provider, &fields.RequestedModel, &fields.RequestedProvider, &fields.TokenUsage, &fields.WorktreePath,
 			&fields.CommandLine, &fields.DirtyFiles, &fields.MinSeverity, &fields.BackupAgent, &fields.BackupModel,
 			&fields.SkipReason, &fields.Source,
-			&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked)
+			&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked, &fields.Redactions, &fields.Route, &fields.VerifyResult, &fields.Incremental, &fields.Resolution, &fields.CacheKey, &fields.CachedFrom, &fields.NoCache)
 		if err != nil {
 			return nil, err
 		}
@@ -1229,7 +1394,7 @@ func (db *DB) GetJobByID(id int64) (*ReviewJob, error) {
 		       r.root_path, r.name, c.subject, j.model, j.provider, j.requested_model, j.requested_provider, j.job_type, j.review_type, j.patch_id, COALESCE(j.output_prefix, ''),
 		       j.parent_job_id, j.patch, j.token_usage, j.dirty_files, COALESCE(j.worktree_path, ''), j.command_line, COALESCE(j.min_severity, ''), COALESCE(j.backup_agent, ''), COALESCE(j.backup_model, ''),
 		       COALESCE(j.skip_reason, ''), COALESCE(j.source, ''),
-		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0)
+		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0), COALESCE(j.redactions, 0), COALESCE(j.route, ''), j.verify_result, j.incremental, j.resolution, j.cache_key, j.cached_from, COALESCE(j.no_cache, 0)
 		FROM review_jobs j
 		JOIN repos r ON r.id = j.repo_id
 		LEFT JOIN commits c ON c.id = j.commit_id
@@ -1239,7 +1404,7 @@ func (db *DB) GetJobByID(id int64) (*ReviewJob, error) {
 		&j.RepoPath, &j.RepoName, &fields.CommitSubject, &fields.Model, &fields.Provider, &fields.RequestedModel, &fields.RequestedProvider, &fields.JobType, &fields.ReviewType, &fields.PatchID, &fields.OutputPrefix,
 		&fields.ParentJobID, &fields.Patch, &fields.TokenUsage, &fields.DirtyFiles, &fields.WorktreePath, &fields.CommandLine, &fields.MinSeverity, &fields.BackupAgent, &fields.BackupModel,
 		&fields.SkipReason, &fields.Source,
-		&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked)
+		&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked, &fields.Redactions, &fields.Route, &fields.VerifyResult, &fields.Incremental, &fields.Resolution, &fields.CacheKey, &fields.CachedFrom, &fields.NoCache)
 	if err != nil {
 		return nil, err
 	}
@@ -1676,8 +1841,8 @@ func (db *DB) ListJobsByStatus(repoID int64, status JobStatus) ([]ReviewJob, err
 	return out, rows.Err()
 }
 
-// MaybeReleasePanelSynthesis clears claim_blocked on a panel run's synthesis
-// job once every member job is terminal. Terminal = done/failed/canceled/
+// MaybeReleasePanelSynthesis clears claim_blocked on a panel run's newest
+// synthesis job once every member job is terminal. Terminal = done/failed/canceled/
 // skipped/applied/rebased. Completion is derived from member rows, not from
 // maintained counters, so it cannot drift.
 //
@@ -1697,6 +1862,7 @@ func (db *DB) MaybeReleasePanelSynthesis(panelRunUUID string) error {
 		 WHERE panel_run_uuid = ?
 		   AND panel_role = 'synthesis'
 		   AND claim_blocked = 1
+		   AND `+latestSynthesisCond("review_jobs")+`
 		   AND NOT EXISTS (
 		       SELECT 1 FROM review_jobs m
 		        WHERE m.panel_run_uuid = review_jobs.panel_run_uuid
@@ -1710,8 +1876,8 @@ func (db *DB) MaybeReleasePanelSynthesis(panelRunUUID string) error {
 	return nil
 }
 
-// ListStuckPanelRuns returns the panel_run_uuid of every run whose synthesis
-// job is still claim_blocked even though all its member jobs are terminal.
+// ListStuckPanelRuns returns the panel_run_uuid of every run whose newest
+// synthesis job is still claim_blocked even though all its member jobs are terminal.
 // These are the runs the safety sweep must release. Reuses the same
 // member-terminal predicate as MaybeReleasePanelSynthesis.
 func (db *DB) ListStuckPanelRuns() ([]string, error) {
@@ -1719,6 +1885,7 @@ func (db *DB) ListStuckPanelRuns() ([]string, error) {
 		SELECT panel_run_uuid FROM review_jobs s
 		WHERE s.panel_role = 'synthesis'
 		  AND s.claim_blocked = 1
+		  AND ` + latestSynthesisCond("s") + `
 		  AND NOT EXISTS (
 		      SELECT 1 FROM review_jobs m
 		       WHERE m.panel_run_uuid = s.panel_run_uuid
@@ -1758,7 +1925,7 @@ func (db *DB) GetPanelMembers(panelRunUUID string) ([]ReviewJob, error) {
 		       j.parent_job_id, j.provider, j.requested_model, j.requested_provider, j.token_usage, COALESCE(j.worktree_path, ''),
 		       j.command_line, COALESCE(j.min_severity, ''), COALESCE(j.backup_agent, ''), COALESCE(j.backup_model, ''),
 		       COALESCE(j.skip_reason, ''), COALESCE(j.source, ''),
-		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0)
+		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0), COALESCE(j.redactions, 0), COALESCE(j.route, ''), j.verify_result, j.incremental, j.resolution, j.cache_key, j.cached_from, COALESCE(j.no_cache, 0)
 		FROM review_jobs j
 		JOIN repos r ON r.id = j.repo_id
 		LEFT JOIN commits c ON c.id = j.commit_id
@@ -1784,7 +1951,7 @@ func (db *DB) GetPanelMembers(panelRunUUID string) ([]ReviewJob, error) {
 			&fields.ParentJobID, &fields.Provider, &fields.RequestedModel, &fields.RequestedProvider, &fields.TokenUsage, &fields.WorktreePath,
 			&fields.CommandLine, &fields.MinSeverity, &fields.BackupAgent, &fields.BackupModel,
 			&fields.SkipReason, &fields.Source,
-			&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked)
+			&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked, &fields.Redactions, &fields.Route, &fields.VerifyResult, &fields.Incremental, &fields.Resolution, &fields.CacheKey, &fields.CachedFrom, &fields.NoCache)
 		if err != nil {
 			return nil, fmt.Errorf("scan panel member: %w", err)
 		}
@@ -1798,8 +1965,9 @@ func (db *DB) GetPanelMembers(panelRunUUID string) ([]ReviewJob, error) {
 }
 
 // GetSynthesisJob returns the synthesis (parent) job for a panel run, or
-// (nil, nil) when panelRunUUID is empty or no synthesis row exists. The job
-// carries the full joined/hydrated fields (verdict applied), mirroring
+// (nil, nil) when panelRunUUID is empty or no synthesis row exists. A
+// re-synthesized run has several synthesis rows; the newest is returned. The
+// job carries the full joined/hydrated fields (verdict applied), mirroring
 // GetPanelMembers.
 func (db *DB) GetSynthesisJob(panelRunUUID string) (*ReviewJob, error) {
 	if panelRunUUID == "" {
@@ -1817,12 +1985,13 @@ func (db *DB) GetSynthesisJob(panelRunUUID string) (*ReviewJob, error) {
 		       j.parent_job_id, j.provider, j.requested_model, j.requested_provider, j.token_usage, COALESCE(j.worktree_path, ''),
 		       j.command_line, COALESCE(j.min_severity, ''), COALESCE(j.backup_agent, ''), COALESCE(j.backup_model, ''),
 		       COALESCE(j.skip_reason, ''), COALESCE(j.source, ''),
-		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0)
+		       COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), j.panel_member_index, COALESCE(j.panel_member_config_json, ''), COALESCE(j.claim_blocked, 0), COALESCE(j.redactions, 0), COALESCE(j.route, ''), j.verify_result, j.incremental, j.resolution, j.cache_key, j.cached_from, COALESCE(j.no_cache, 0)
 		FROM review_jobs j
 		JOIN repos r ON r.id = j.repo_id
 		LEFT JOIN commits c ON c.id = j.commit_id
 		LEFT JOIN reviews rv ON rv.job_id = j.id
 		WHERE j.panel_run_uuid = ? AND j.panel_role = 'synthesis'
+		ORDER BY j.id DESC
 		LIMIT 1
 	`, panelRunUUID).Scan(&j.ID, &j.RepoID, &fields.CommitID, &j.GitRef, &fields.Branch, &fields.CIBaseBranch, &fields.SessionID, &j.Agent, &j.Reasoning, &j.Status, &fields.EnqueuedAt,
 		&fields.StartedAt, &fields.FinishedAt, &fields.WorkerID, &fields.Error, &fields.Prompt, &j.RetryCount,
@@ -1831,7 +2000,7 @@ func (db *DB) GetSynthesisJob(panelRunUUID string) (*ReviewJob, error) {
 		&fields.ParentJobID, &fields.Provider, &fields.RequestedModel, &fields.RequestedProvider, &fields.TokenUsage, &fields.WorktreePath,
 		&fields.CommandLine, &fields.MinSeverity, &fields.BackupAgent, &fields.BackupModel,
 		&fields.SkipReason, &fields.Source,
-		&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked)
+		&fields.PanelRunUUID, &fields.PanelRole, &fields.PanelName, &fields.PanelMemberName, &fields.PanelMemberIndex, &fields.PanelMemberConfig, &fields.ClaimBlocked, &fields.Redactions, &fields.Route, &fields.VerifyResult, &fields.Incremental, &fields.Resolution, &fields.CacheKey, &fields.CachedFrom, &fields.NoCache)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -1896,6 +2065,162 @@ type PanelSummary struct {
 	FirstStartedAt      *time.Time `json:"first_started_at,omitempty"`
 }
 
+// RerunPanelMember re-queues one terminal panel member in place and enqueues
+// a fresh, blocked synthesis for its run, so the run is re-synthesized over
+// the fresh member output once the member finishes. Earlier synthesis rows
+// and their reviews are kept as history, as with ResynthesizePanel. The
+// member and every synthesis of the run must be terminal; otherwise
+// sql.ErrNoRows is returned. The member keeps its frozen agent/model/provider
+// and its review is deleted, matching ReenqueueJob. synthesis describes the
+// new synthesis row; its run, role, job type and gate are enforced here. A
+// CI panel mapping is moved to the new synthesis so its result is posted.
+func (db *DB) RerunPanelMember(memberID int64, synthesis EnqueueOpts) (*ReviewJob, error) {
+	machineID, _ := db.GetMachineID()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if _, err := conn.ExecContext(ctx, "ROLLBACK"); err != nil {
+				log.Printf("jobs RerunPanelMember: rollback failed: %v", err)
+			}
+		}
+	}()
+
+	var runUUID string
+	var model, provider sql.NullString
+	err = conn.QueryRowContext(ctx, `
+		SELECT panel_run_uuid, model, provider FROM review_jobs
+		WHERE id = ? AND panel_role = 'member' AND COALESCE(panel_run_uuid, '') != ''
+	`, memberID).Scan(&runUUID, &model, &provider)
+	if err != nil {
+		return nil, err
+	}
+	var ready bool
+	err = conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+		           SELECT 1 FROM review_jobs
+		            WHERE panel_run_uuid = ? AND panel_role = 'synthesis'
+		       )
+		   AND NOT EXISTS (
+		           SELECT 1 FROM review_jobs
+		            WHERE panel_run_uuid = ? AND panel_role = 'synthesis'
+		              AND status NOT IN ('done','failed','canceled','skipped','applied','rebased')
+		       )
+	`, runUUID, runUUID).Scan(&ready)
+	if err != nil {
+		return nil, err
+	}
+	if !ready {
+		return nil, sql.ErrNoRows
+	}
+
+	now := time.Now()
+	if err := reenqueueJobTx(ctx, conn, memberID, ReenqueueOpts{
+		Model: model.String, Provider: provider.String,
+	}, now.Format(time.RFC3339)); err != nil {
+		return nil, err
+	}
+	// The member's terminal transition releases the new synthesis through
+	// MaybeReleasePanelSynthesis like a fresh run.
+	synthesis.PanelRunUUID = runUUID
+	synthesis.JobType = JobTypeSynthesis
+	synthesis.PanelRole = PanelRoleSynthesis
+	synthesis.ClaimBlocked = true
+	job, err := db.insertJobTx(ctx, conn, synthesis, GenerateUUID(), machineID, now)
+	if err != nil {
+		return nil, fmt.Errorf("insert panel synthesis: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx,
+		`UPDATE ci_pr_panels SET synthesis_job_id = ? WHERE panel_run_uuid = ?`, job.ID, runUUID,
+	); err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, err
+	}
+	committed = true
+	return job, nil
+}
+
+// ResynthesizePanel enqueues a fresh synthesis job for an existing panel run
+// without re-running its members, so the synthesis agent runs over the
+// stored member outputs. Earlier synthesis rows and their reviews are kept
+// as history for comparison; GetSynthesisJob returns the newest. The run
+// must have members, every member must be terminal, and no synthesis of the
+// run may still be queued or running; otherwise sql.ErrNoRows is returned.
+// opts.PanelRunUUID names the run; the role, job type and gate are enforced
+// here, and the new row is claimable immediately.
+func (db *DB) ResynthesizePanel(opts EnqueueOpts) (*ReviewJob, error) {
+	if opts.PanelRunUUID == "" {
+		return nil, sql.ErrNoRows
+	}
+	machineID, _ := db.GetMachineID()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if _, err := conn.ExecContext(ctx, "ROLLBACK"); err != nil {
+				log.Printf("jobs ResynthesizePanel: rollback failed: %v", err)
+			}
+		}
+	}()
+
+	var ready bool
+	err = conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+		           SELECT 1 FROM review_jobs
+		            WHERE panel_run_uuid = ? AND panel_role = 'member'
+		       )
+		   AND NOT EXISTS (
+		           SELECT 1 FROM review_jobs
+		            WHERE panel_run_uuid = ?
+		              AND panel_role IN ('member', 'synthesis')
+		              AND status NOT IN ('done','failed','canceled','skipped','applied','rebased')
+		       )
+	`, opts.PanelRunUUID, opts.PanelRunUUID).Scan(&ready)
+	if err != nil {
+		return nil, err
+	}
+	if !ready {
+		return nil, sql.ErrNoRows
+	}
+
+	opts.JobType = JobTypeSynthesis
+	opts.PanelRole = PanelRoleSynthesis
+	opts.ClaimBlocked = false
+	job, err := db.insertJobTx(ctx, conn, opts, GenerateUUID(), machineID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("insert panel synthesis: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, err
+	}
+	committed = true
+	return job, nil
+}
+
 // GetPanelSummaries computes the member breakdown for each given panel run in
 // one GROUP BY aggregate (no per-row N+1). Runs with no member rows are
 // absent from the returned map.
@@ -40,15 +40,16 @@ const (
 
 // JobType classifies what kind of work a review job represents.
 const (
-	JobTypeReview    = "review"    // Single commit review
-	JobTypeRange     = "range"     // Commit range review
-	JobTypeDirty     = "dirty"     // Uncommitted changes review
-	JobTypeTask      = "task"      // Run/analyze/design/custom prompt
-	JobTypeInsights  = "insights"  // Historical review insights analysis
-	JobTypeCompact   = "compact"   // Consolidated review verification
-	JobTypeFix       = "fix"       // Background fix using worktree
-	JobTypeClassify  = "classify"  // Routing classifier that decides whether to enqueue a design review
-	JobTypeSynthesis = "synthesis" // Panel synthesis job: produces the canonical review from member reviews
+	JobTypeReview     = "review"     // Single commit review
+	JobTypeRange      = "range"      // Commit range review
+	JobTypeDirty      = "dirty"      // Uncommitted changes review
+	JobTypeTask       = "task"       // Run/analyze/design/custom prompt
+	JobTypeInsights   = "insights"   // Historical review insights analysis
+	JobTypeCompact    = "compact"    // Consolidated review verification
+	JobTypeFix        = "fix"        // Background fix using worktree
+	JobTypeClassify   = "classify"   // Routing classifier that decides whether to enqueue a design review
+	JobTypeSynthesis  = "synthesis"  // Panel synthesis job: produces the canonical review from member reviews
+	JobTypeResolution = "resolution" // Resolution check: closes open reviews that later branch commits fixed
 )
 
 // Panel roles classify a review_jobs row within a panel run. These values
@@ -107,6 +108,8 @@ type ReviewJob struct {
 	BackupModel string `json:"backup_model,omitempty"`
 	// Panel relation (subagent review panels). Synced columns group member
 	// + synthesis jobs of one panel run; ClaimBlocked is local-only.
+	// PanelMemberConfigJSON holds the resolved member spec on member rows and
+	// the resolved synthesis spec on synthesis rows.
 	PanelRunUUID          string `json:"panel_run_uuid,omitempty"`
 	PanelRole             string `json:"panel_role,omitempty"` // "" (non-panel), "member", or "synthesis"
 	PanelName             string `json:"panel_name,omitempty"`
@@ -115,6 +118,20 @@ type ReviewJob struct {
 	PanelMemberConfigJSON string `json:"panel_member_config_json,omitempty"`
 	ClaimBlocked          bool   `json:"claim_blocked,omitempty"` // local-only scheduling gate
 	TokenUsage            string `json:"token_usage,omitempty"`   // JSON blob from agentsview (token consumption)
+	Redactions            int    `json:"redactions,omitempty"`    // Secrets masked from the prompt and diff
+	Route                 string `json:"route,omitempty"`         // [[review.routes]] entry that picked the settings
+	CacheKey              string `json:"cache_key,omitempty"`     // Review cache key: patch, review type, agent/model, guidelines
+	CachedFrom            string `json:"cached_from,omitempty"`   // UUID of the job whose result a cache hit reused
+	NoCache               bool   `json:"no_cache,omitempty"`      // Always run the agent, even on a cache hit
+	// Verify is the verify_command outcome of a fix job; nil when no
+	// verify command ran.
+	Verify *VerifyResult `json:"verify,omitempty"`
+	// Incremental records the prior review an incremental re-review builds
+	// on; nil for a full review.
+	Incremental *IncrementalReview `json:"incremental,omitempty"`
+	// Resolution lists the open reviews a resolution check job covers and
+	// the ones it closed; nil for other job types.
+	Resolution *ResolutionCheck `json:"resolution,omitempty"`
 	// Sync fields
 	UUID            string     `json:"uuid,omitempty"`              // Globally unique identifier for sync
 	SourceMachineID string     `json:"source_machine_id,omitempty"` // Machine that created this job
@@ -138,6 +155,48 @@ type ReviewJob struct {
 	ReusableSessionTarget string `json:"-"`
 }
 
+// VerifyResult is the outcome of running a repo's verify_command after a
+// fix agent's edits, including the agent's repair attempts.
+type VerifyResult struct {
+	Passed     bool   `json:"passed"`
+	Attempts   int    `json:"attempts,omitempty"` // Repair attempts made
+	DurationMs int64  `json:"duration_ms"`        // Total time spent in verify commands
+	Command    string `json:"command,omitempty"`  // Last failing command
+	Output     string `json:"output,omitempty"`   // Tail of the last failing command's output
+}
+
+// IncrementalReview links an incremental re-review to the branch or PR review
+// it continues from. The job's range starts at BaseSHA, the head that review
+// covered.
+type IncrementalReview struct {
+	BaseJobID      int64   `json:"base_job_id"`                // Last completed review of the branch or PR
+	BaseSHA        string  `json:"base_sha"`                   // Head the base review covered
+	OpenJobIDs     []int64 `json:"open_job_ids,omitempty"`     // Still-open failing reviews supplied as context
+	ResolvedJobIDs []int64 `json:"resolved_job_ids,omitempty"` // Open reviews this run reported resolved and closed
+}
+
+// ResolutionCheck is the work of a resolution check job: open reviews of a
+// branch whose referenced files changed in commits up to HeadSHA.
+type ResolutionCheck struct {
+	HeadSHA    string                `json:"head_sha"`           // Branch head the findings are checked against
+	Candidates []ResolutionCandidate `json:"candidates"`         // Open reviews to check
+	Resolved   []ResolvedReview      `json:"resolved,omitempty"` // Reviews the check closed
+}
+
+// ResolutionCandidate is an open review in a resolution check, with the
+// commits since it that touched the files its findings reference.
+type ResolutionCandidate struct {
+	JobID   int64    `json:"job_id"`
+	Commits []string `json:"commits"` // Newest first
+}
+
+// ResolvedReview is a review a resolution check closed and the commit
+// credited with the fix.
+type ResolvedReview struct {
+	JobID  int64  `json:"job_id"`
+	Commit string `json:"commit"`
+}
+
 // HookBranch returns the branch used for event/hook branch matching: the
 // local branch the job was enqueued from, or the PR base (target) branch for
 // CI jobs. CI jobs deliberately leave Branch empty so branch-scoped local
@@ -175,12 +234,13 @@ func (j ReviewJob) CommitIDValue() int64 {
 	return 0
 }
 
-// IsTaskJob returns true if this is a task job (run, analyze, custom label) rather than
-// a commit review or dirty review. Task jobs have pre-stored prompts and no verdicts.
-// Compact jobs are not considered task jobs since they produce P/F verdicts.
+// IsTaskJob returns true if this is a task job (run, analyze, custom label,
+// resolution check) rather than a commit review or dirty review. Task jobs have
+// pre-stored prompts and no verdicts. Compact jobs are not considered task jobs
+// since they produce P/F verdicts.
 func (j ReviewJob) IsTaskJob() bool {
 	if j.JobType != "" {
-		return j.JobType == JobTypeTask || j.JobType == JobTypeInsights
+		return j.JobType == JobTypeTask || j.JobType == JobTypeInsights || j.JobType == JobTypeResolution
 	}
 	// Fallback heuristic for jobs without job_type (e.g., from old sync data)
 	if j.CommitID != nil {
@@ -202,13 +262,14 @@ func (j ReviewJob) IsTaskJob() bool {
 }
 
 // UsesStoredPrompt returns true if this job type uses a pre-stored prompt
-// (task, insights, compact, or fix). These job types have prompts built at enqueue
-// time, not constructed by the worker from git data.
+// (task, insights, compact, fix, or resolution). These job types have prompts
+// built at enqueue time, not constructed by the worker from git data.
 func (j ReviewJob) UsesStoredPrompt() bool {
 	return j.JobType == JobTypeTask ||
 		j.JobType == JobTypeInsights ||
 		j.JobType == JobTypeCompact ||
-		j.JobType == JobTypeFix
+		j.JobType == JobTypeFix ||
+		j.JobType == JobTypeResolution
 }
 
 // IsReviewJob returns true if this is an actual code review
@@ -348,6 +409,10 @@ type DaemonStatus struct {
 	ConfigReloadCounter uint64 `json:"config_reload_counter,omitempty"` // Monotonic reload counter (for sub-second detection)
 
 	AutoDesign *AutoDesignStatus `json:"auto_design,omitempty"` // Auto design review counters; nil when disabled everywhere
+
+	// UnhealthyAgents lists agents whose latest probe failed, as
+	// "agent: status" (or "agent/model: status").
+	UnhealthyAgents []string `json:"unhealthy_agents,omitempty"`
 }
 
 // HealthStatus represents the overall daemon health
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetNotificationThread returns the chat message ID that roots the
+// conversation for threadKey in a provider's channel, or "" when no
+// notification has been posted for it yet.
+func (db *DB) GetNotificationThread(provider, channel, threadKey string) (string, error) {
+	var id string
+	err := db.QueryRow(`
+		SELECT thread_id FROM notification_threads
+		WHERE provider = ? AND channel = ? AND thread_key = ?`,
+		provider, channel, threadKey).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get notification thread: %w", err)
+	}
+	return id, nil
+}
+
+// SaveNotificationThread records the message that roots threadKey's
+// conversation, replacing any earlier root (for example one whose message was
+// deleted in the chat tool).
+func (db *DB) SaveNotificationThread(provider, channel, threadKey, threadID string) error {
+	_, err := db.Exec(`
+		INSERT INTO notification_threads (provider, channel, thread_key, thread_id, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(provider, channel, thread_key)
+		DO UPDATE SET thread_id = excluded.thread_id, created_at = excluded.created_at`,
+		provider, channel, threadKey, threadID, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("save notification thread: %w", err)
+	}
+	return nil
+}
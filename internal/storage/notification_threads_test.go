@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationThreads(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	id, err := db.GetNotificationThread("slack", "C1", "pr:acme/api#7")
+	require.NoError(t, err)
+	assert.Empty(t, id, "unknown thread")
+
+	require.NoError(t, db.SaveNotificationThread("slack", "C1", "pr:acme/api#7", "1700000000.000100"))
+	require.NoError(t, db.SaveNotificationThread("teams", "T/C", "pr:acme/api#7", "msg-1"))
+
+	id, err = db.GetNotificationThread("slack", "C1", "pr:acme/api#7")
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000.000100", id)
+
+	require.NoError(t, db.SaveNotificationThread("slack", "C1", "pr:acme/api#7", "1700000001.000200"))
+	id, err = db.GetNotificationThread("slack", "C1", "pr:acme/api#7")
+	require.NoError(t, err)
+	assert.Equal(t, "1700000001.000200", id, "a new root replaces the old one")
+
+	id, err = db.GetNotificationThread("teams", "T/C", "pr:acme/api#7")
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id, "threads are scoped by provider and channel")
+}
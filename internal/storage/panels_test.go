@@ -952,3 +952,210 @@ func TestGetReviewByCommitSHAPendingSynthesisHidesStaleReview(t *testing.T) {
 	require.ErrorIs(t, err, sql.ErrNoRows,
 		"a pending synthesis must hide the stale older standalone review")
 }
+
+// completePanelJob drives a queued panel job through running to done so it
+// has a stored review.
+func completePanelJob(t *testing.T, db *DB, jobID int64, output string) {
+	t.Helper()
+	_, err := db.Exec(`UPDATE review_jobs SET status='running', worker_id='w1', claim_blocked=0 WHERE id=?`, jobID)
+	require.NoError(t, err)
+	require.NoError(t, db.CompleteJob(jobID, "test", "prompt", output))
+}
+
+func TestRerunPanelMember(t *testing.T) {
+	assert := assert.New(t)
+	db := openTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	repo := createRepo(t, db, "/tmp/panel-rerun-member")
+	synth, members := enqueuePanelRun(t, db, repo.ID, "run-1", 2)
+	_, err := db.Exec(`UPDATE review_jobs SET model = 'opus' WHERE id = ?`, members[0].ID)
+	require.NoError(t, err)
+	synthOpts := EnqueueOpts{RepoID: repo.ID, GitRef: "base..head", Agent: "test", PanelName: "panel"}
+
+	// A member of an active run cannot be rerun.
+	_, err = db.RerunPanelMember(members[0].ID, synthOpts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	completePanelJob(t, db, members[0].ID, "member 0")
+	completePanelJob(t, db, members[1].ID, "member 1")
+	completePanelJob(t, db, synth.ID, "synthesis")
+
+	fresh, err := db.RerunPanelMember(members[0].ID, synthOpts)
+	require.NoError(t, err)
+	assert.NotEqual(synth.ID, fresh.ID, "the rerun gets a new synthesis row")
+	assert.Equal("run-1", fresh.PanelRunUUID)
+	assert.Equal(PanelRoleSynthesis, fresh.PanelRole)
+	assert.Equal(JobTypeSynthesis, fresh.JobType)
+	assert.True(claimBlockedOf(t, db, fresh.ID), "synthesis waits for the rerun member")
+
+	member, err := db.GetJobByID(members[0].ID)
+	require.NoError(t, err)
+	assert.Equal(JobStatusQueued, member.Status)
+	assert.Equal("opus", member.Model, "members keep their frozen model")
+	old, err := db.GetReviewByJobID(synth.ID)
+	require.NoError(t, err)
+	assert.Equal("synthesis", old.Output, "the previous synthesis is kept as history")
+	latest, err := db.GetSynthesisJob("run-1")
+	require.NoError(t, err)
+	assert.Equal(fresh.ID, latest.ID)
+
+	reviews, err := db.GetPanelMemberReviews("run-1")
+	require.NoError(t, err)
+	assert.Empty(reviews[0].Output, "rerun member's review is deleted")
+	assert.Equal("member 1", reviews[1].Output, "other members are untouched")
+
+	// The member finishing releases the new synthesis only.
+	completePanelJob(t, db, members[0].ID, "member 0 again")
+	require.NoError(t, db.MaybeReleasePanelSynthesis("run-1"))
+	assert.False(claimBlockedOf(t, db, fresh.ID))
+
+	// Synthesis jobs are not members, and a queued synthesis blocks reruns.
+	_, err = db.RerunPanelMember(synth.ID, synthOpts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = db.RerunPanelMember(members[1].ID, synthOpts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestResynthesizePanel(t *testing.T) {
+	assert := assert.New(t)
+	db := openTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	repo := createRepo(t, db, "/tmp/panel-resynth")
+	synth, members := enqueuePanelRun(t, db, repo.ID, "run-1", 2)
+	opts := EnqueueOpts{
+		RepoID:       repo.ID,
+		GitRef:       "base..head",
+		Agent:        "gemini",
+		Model:        "gemini-2.5-pro",
+		PanelRunUUID: "run-1",
+		PanelName:    "panel",
+	}
+
+	// Members still queued.
+	_, err := db.ResynthesizePanel(opts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	completePanelJob(t, db, members[0].ID, "member 0")
+	completePanelJob(t, db, members[1].ID, "member 1")
+
+	// Synthesis still queued (released but not run).
+	require.NoError(t, db.MaybeReleasePanelSynthesis("run-1"))
+	_, err = db.ResynthesizePanel(opts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	completePanelJob(t, db, synth.ID, "first synthesis")
+	fresh, err := db.ResynthesizePanel(opts)
+	require.NoError(t, err)
+	assert.NotEqual(synth.ID, fresh.ID)
+	assert.Equal(JobStatusQueued, fresh.Status)
+	assert.Equal(JobTypeSynthesis, fresh.JobType)
+	assert.Equal(PanelRoleSynthesis, fresh.PanelRole)
+	assert.Equal("gemini", fresh.Agent)
+	assert.Equal("gemini-2.5-pro", fresh.Model)
+	assert.False(claimBlockedOf(t, db, fresh.ID), "members are done, so it is claimable")
+
+	old, err := db.GetReviewByJobID(synth.ID)
+	require.NoError(t, err)
+	assert.Equal("first synthesis", old.Output, "the previous synthesis is kept as history")
+	latest, err := db.GetSynthesisJob("run-1")
+	require.NoError(t, err)
+	assert.Equal(fresh.ID, latest.ID, "the newest synthesis is the run's synthesis")
+
+	reviews, err := db.GetPanelMemberReviews("run-1")
+	require.NoError(t, err)
+	assert.Equal("member 0", reviews[0].Output, "members are not re-run")
+	assert.Equal("done", reviews[0].Status)
+
+	// Only one synthesis may be active at a time.
+	_, err = db.ResynthesizePanel(opts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	opts.PanelRunUUID = "no-such-run"
+	_, err = db.ResynthesizePanel(opts)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestResynthesizedRunUsesNewestSynthesis(t *testing.T) {
+	assert := assert.New(t)
+	db := openTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	repo := createRepo(t, db, "/tmp/panel-resynth-latest")
+	synth, members := enqueuePanelRun(t, db, repo.ID, "run-1", 1)
+	completePanelJob(t, db, members[0].ID, "member 0")
+	// The first synthesis was canceled while still blocked on its members.
+	_, err := db.Exec(`UPDATE review_jobs SET status = 'canceled', claim_blocked = 1 WHERE id = ?`, synth.ID)
+	require.NoError(t, err)
+	fresh, err := db.ResynthesizePanel(EnqueueOpts{
+		RepoID: repo.ID, GitRef: "base..head", Agent: "test", PanelRunUUID: "run-1",
+	})
+	require.NoError(t, err)
+
+	jobs, err := db.ListJobs("", "", 0, 0,
+		WithExcludePanelRole(PanelRoleMember), WithLatestSynthesisOnly())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1, "the run lists once, as its newest synthesis")
+	assert.Equal(fresh.ID, jobs[0].ID)
+	history, err := db.ListJobs("", "", 0, 0, WithPanelRun("run-1"))
+	require.NoError(t, err)
+	assert.Len(history, 3, "expanding the run keeps earlier syntheses")
+
+	stuck, err := db.ListStuckPanelRuns()
+	require.NoError(t, err)
+	assert.Empty(stuck, "a superseded synthesis left blocked does not make the run stuck")
+
+	// A member rerun re-blocks the newest synthesis; once the member is
+	// terminal again the run is stuck exactly once and releases that row.
+	_, err = db.Exec(`UPDATE review_jobs SET claim_blocked = 1 WHERE id = ?`, fresh.ID)
+	require.NoError(t, err)
+	stuck, err = db.ListStuckPanelRuns()
+	require.NoError(t, err)
+	assert.Equal([]string{"run-1"}, stuck)
+	require.NoError(t, db.MaybeReleasePanelSynthesis("run-1"))
+	assert.False(claimBlockedOf(t, db, fresh.ID))
+	assert.True(claimBlockedOf(t, db, synth.ID), "history rows are left alone")
+}
+
+func TestRerunPanelMemberAfterResynthesis(t *testing.T) {
+	db := openTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	repo := createRepo(t, db, "/tmp/panel-resynth-rerun")
+	synth, members := enqueuePanelRun(t, db, repo.ID, "run-1", 1)
+	completePanelJob(t, db, members[0].ID, "member 0")
+	completePanelJob(t, db, synth.ID, "first synthesis")
+	opts := EnqueueOpts{RepoID: repo.ID, GitRef: "base..head", Agent: "test", PanelRunUUID: "run-1"}
+	resynth, err := db.ResynthesizePanel(opts)
+	require.NoError(t, err)
+	completePanelJob(t, db, resynth.ID, "second synthesis")
+
+	fresh, err := db.RerunPanelMember(members[0].ID, opts)
+	require.NoError(t, err)
+	for id, want := range map[int64]string{synth.ID: "first synthesis", resynth.ID: "second synthesis"} {
+		review, err := db.GetReviewByJobID(id)
+		require.NoError(t, err)
+		assert.Equal(t, want, review.Output, "re-syntheses are kept as history")
+	}
+	history, err := db.ListJobs("", "", 0, 0, WithPanelRun("run-1"))
+	require.NoError(t, err)
+	assert.Len(t, history, 4)
+	latest, err := db.GetSynthesisJob("run-1")
+	require.NoError(t, err)
+	assert.Equal(t, fresh.ID, latest.ID)
+}
+
+func TestRerunPanelMemberMovesCIPanelMapping(t *testing.T) {
+	db := openTestDB(t)
+	t.Cleanup(func() { db.Close() })
+	repo := createRepo(t, db, "/tmp/panel-rerun-ci")
+	synth, members := enqueuePanelRun(t, db, repo.ID, "run-1", 1)
+	_, err := db.Exec(`INSERT INTO ci_pr_panels (github_repo, pr_number, head_sha, panel_run_uuid, synthesis_job_id, created_at)
+		VALUES ('o/r', 1, 'abc', 'run-1', ?, datetime('now'))`, synth.ID)
+	require.NoError(t, err)
+	completePanelJob(t, db, members[0].ID, "member 0")
+	completePanelJob(t, db, synth.ID, "synthesis")
+
+	fresh, err := db.RerunPanelMember(members[0].ID, EnqueueOpts{RepoID: repo.ID, GitRef: "base..head", Agent: "test"})
+	require.NoError(t, err)
+	panel, err := db.GetCIPanelBySynthesisJobID(fresh.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", panel.PanelRunUUID)
+}
@@ -644,6 +644,12 @@ func (db *DB) DeleteRepo(repoID int64, cascade bool) error {
 			return err
 		}
 
+		// 2b. Delete local-only rows keyed by job ID. They have no foreign
+		// key, and review_jobs IDs can be reused once the jobs are gone.
+		if err := deleteRepoJobRows(ctx, conn, repoID); err != nil {
+			return err
+		}
+
 		// 3. Delete jobs for this repo
 		_, err = conn.ExecContext(ctx, `DELETE FROM review_jobs WHERE repo_id = ?`, repoID)
 		if err != nil {
@@ -674,6 +680,34 @@ func (db *DB) DeleteRepo(repoID int64, cascade bool) error {
 	return nil
 }
 
+// deleteRepoJobRows deletes the dependency, workflow, and attempt rows of a
+// repo's jobs, in both directions for dependencies, and any workflow left
+// with no steps.
+func deleteRepoJobRows(ctx context.Context, exec execer, repoID int64) error {
+	if _, err := exec.ExecContext(ctx, `
+		DELETE FROM job_attempts WHERE job_id IN (SELECT id FROM review_jobs WHERE repo_id = ?)
+	`, repoID); err != nil {
+		return err
+	}
+	if _, err := exec.ExecContext(ctx, `
+		DELETE FROM job_dependencies
+		 WHERE job_id IN (SELECT id FROM review_jobs WHERE repo_id = ?)
+		    OR depends_on_job_id IN (SELECT id FROM review_jobs WHERE repo_id = ?)
+	`, repoID, repoID); err != nil {
+		return err
+	}
+	if _, err := exec.ExecContext(ctx, `
+		DELETE FROM workflow_steps WHERE job_id IN (SELECT id FROM review_jobs WHERE repo_id = ?)
+	`, repoID); err != nil {
+		return err
+	}
+	_, err := exec.ExecContext(ctx, `
+		DELETE FROM workflows
+		 WHERE NOT EXISTS (SELECT 1 FROM workflow_steps s WHERE s.workflow_uuid = workflows.uuid)
+	`)
+	return err
+}
+
 // MergeRepos moves all jobs and commits from sourceRepoID to targetRepoID, then deletes the source repo
 func (db *DB) MergeRepos(sourceRepoID, targetRepoID int64) (int64, error) {
 	if sourceRepoID == targetRepoID {
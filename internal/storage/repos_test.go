@@ -843,6 +843,42 @@ func TestDeleteRepoCascadeDeletesCommits(t *testing.T) {
 	assert.Equal(t, 0, afterCount)
 }
 
+func TestDeleteRepoCascadeDeletesJobDependencies(t *testing.T) {
+	db, repo := setupDBAndRepo(t, "delete-deps-test")
+	analyze := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: repo.ID, Agent: "codex", Prompt: "analyze"})
+	fix := mustEnqueuePromptJob(t, db, EnqueueOpts{
+		RepoID: repo.ID, Agent: "codex", Prompt: "fix", DependsOn: []int64{analyze.ID},
+	})
+	_, err := db.CreateWorkflow("wf", []WorkflowStep{{Name: "analyze", JobID: analyze.ID}, {Name: "fix", JobID: fix.ID}})
+	require.NoError(t, err)
+	require.NoError(t, db.RecordJobAttempt(JobAttempt{JobID: fix.ID, Agent: "codex", Outcome: AttemptFailed}))
+
+	require.NoError(t, db.DeleteRepo(repo.ID, true))
+	for _, table := range []string{"job_dependencies", "workflow_steps", "workflows", "job_attempts"} {
+		var n int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM `+table).Scan(&n))
+		assert.Zero(t, n, table)
+	}
+
+	// New jobs reuse the deleted IDs. The unrelated job in fix's old slot must
+	// not inherit fix's prerequisite and be canceled with the other job.
+	other := createRepo(t, db, "/tmp/delete-deps-other")
+	first := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: other.ID, Agent: "codex", Prompt: "one"})
+	second := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: other.ID, Agent: "codex", Prompt: "two"})
+	require.Equal(t, fix.ID, second.ID, "job IDs are reused after delete")
+	require.NoError(t, db.CancelJob(first.ID))
+
+	canceled, err := db.SettleJobDependencies()
+	require.NoError(t, err)
+	assert.Empty(t, canceled)
+	got, err := db.GetJobByID(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusQueued, got.Status)
+	attempts, err := db.ListJobAttempts(second.ID)
+	require.NoError(t, err)
+	assert.Empty(t, attempts, "a reused job ID starts with no attempt history")
+}
+
 func TestDeleteRepoCascadeDeletesLegacyCommitResponses(t *testing.T) {
 	db, repo := setupDBAndRepo(t, "delete-legacy-resp-test")
 	commit := createCommit(t, db, repo.ID, "legacy-resp-commit")
@@ -0,0 +1,18 @@
+package storage
+
+import "fmt"
+
+// HasPendingResolutionCheck reports whether a resolution check job for a
+// branch is still waiting to run. A pending check picks up the branch as it
+// was enqueued, so a newer one is not needed until it starts.
+func (db *DB) HasPendingResolutionCheck(repoID int64, branch string) (bool, error) {
+	var count int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM review_jobs
+		WHERE repo_id = ? AND branch = ? AND job_type = ? AND status = 'queued'`,
+		repoID, branch, JobTypeResolution).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("check pending resolution check: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolutionCheckJob(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/resolution-repo")
+
+	pending, err := db.HasPendingResolutionCheck(repo.ID, "feature")
+	require.NoError(t, err)
+	assert.False(t, pending)
+
+	rc := &ResolutionCheck{
+		HeadSHA:    "ccc",
+		Candidates: []ResolutionCandidate{{JobID: 4, Commits: []string{"ccc", "bbb"}}},
+	}
+	job, err := db.EnqueueJob(EnqueueOpts{
+		RepoID: repo.ID, GitRef: "resolution-check-ccc", Branch: "feature", Agent: "codex",
+		JobType: JobTypeResolution, Prompt: "check", Resolution: rc,
+	})
+	require.NoError(t, err)
+	assert.True(t, job.IsTaskJob())
+	assert.True(t, job.UsesStoredPrompt())
+
+	pending, err = db.HasPendingResolutionCheck(repo.ID, "feature")
+	require.NoError(t, err)
+	assert.True(t, pending)
+	pending, err = db.HasPendingResolutionCheck(repo.ID, "other")
+	require.NoError(t, err)
+	assert.False(t, pending)
+
+	claimed := claimJob(t, db, "w1")
+	require.Equal(t, job.ID, claimed.ID)
+	assert.Equal(t, rc, claimed.Resolution)
+	pending, err = db.HasPendingResolutionCheck(repo.ID, "feature")
+	require.NoError(t, err)
+	assert.False(t, pending, "a running check no longer covers new commits")
+
+	rc.Resolved = []ResolvedReview{{JobID: 4, Commit: "bbb"}}
+	require.NoError(t, db.SaveJobResolution(job.ID, *rc))
+	loaded, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rc, loaded.Resolution)
+}
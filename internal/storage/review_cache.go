@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CachedReview is a completed review whose result a new job with the same
+// cache key can reuse instead of running its agent.
+type CachedReview struct {
+	JobID   int64
+	JobUUID string
+	Agent   string
+	Output  string
+}
+
+// SetJobCacheKey records the review cache key of a job. The key syncs with
+// the job, so reviews completed on other machines are cache candidates too.
+func (db *DB) SetJobCacheKey(jobID int64, key string) error {
+	_, err := db.Exec(`UPDATE review_jobs SET cache_key = ? WHERE id = ?`, nullString(key), jobID)
+	return err
+}
+
+// FindCachedReview returns the newest completed review with the given cache
+// key that finished at or after since, or nil when there is none. Jobs
+// completed from the cache themselves are skipped so every hit links to the
+// review that actually ran, and excludeJobID keeps a job from matching itself.
+func (db *DB) FindCachedReview(key string, excludeJobID int64, since time.Time) (*CachedReview, error) {
+	if key == "" {
+		return nil, nil
+	}
+	var cr CachedReview
+	err := db.QueryRow(`
+		SELECT j.id, j.uuid, rv.agent, rv.output
+		FROM review_jobs j
+		JOIN reviews rv ON rv.job_id = j.id
+		WHERE j.cache_key = ? AND j.id != ?
+		  AND j.status = 'done' AND j.uuid IS NOT NULL
+		  AND COALESCE(j.cached_from, '') = ''
+		  AND rv.output != ''
+		  AND `+sqliteNormalizedTimestampExpr("j.finished_at")+` >= ?
+		ORDER BY `+sqliteNormalizedTimestampExpr("j.finished_at")+` DESC, j.id DESC
+		LIMIT 1`,
+		key, excludeJobID, since.UTC().Format("2006-01-02 15:04:05"),
+	).Scan(&cr.JobID, &cr.JobUUID, &cr.Agent, &cr.Output)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find cached review: %w", err)
+	}
+	return &cr, nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCachedReview(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/review-cache-repo")
+	since := time.Now().Add(-time.Hour)
+
+	complete := func(gitRef, output string) *ReviewJob {
+		t.Helper()
+		job, err := db.EnqueueJob(EnqueueOpts{RepoID: repo.ID, GitRef: gitRef, Agent: "codex", PatchID: "patch-1"})
+		require.NoError(t, err)
+		claimed := claimJob(t, db, "w1")
+		require.Equal(t, job.ID, claimed.ID)
+		require.NoError(t, db.SetJobCacheKey(job.ID, "key-1"))
+		require.NoError(t, db.CompleteJob(job.ID, "codex", "p", output))
+		return job
+	}
+
+	hit, err := db.FindCachedReview("key-1", 0, since)
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+
+	source := complete("aaa", "- High — a.go:1 bug")
+	hit, err = db.FindCachedReview("key-1", 0, since)
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, source.ID, hit.JobID)
+	assert.Equal(t, source.UUID, hit.JobUUID)
+	assert.Equal(t, "codex", hit.Agent)
+	assert.Equal(t, "- High — a.go:1 bug", hit.Output)
+
+	// A job never matches itself, and old reviews have expired.
+	hit, err = db.FindCachedReview("key-1", source.ID, since)
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+	hit, err = db.FindCachedReview("key-1", 0, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+
+	// A cache hit links to the source and is never itself a source.
+	job, err := db.EnqueueJob(EnqueueOpts{RepoID: repo.ID, GitRef: "bbb", Agent: "codex", PatchID: "patch-1"})
+	require.NoError(t, err)
+	claimJob(t, db, "w1")
+	require.NoError(t, db.SetJobCacheKey(job.ID, "key-1"))
+	require.NoError(t, db.CompleteJobFromCache(job.ID, "codex", "cached", "- High — a.go:1 bug", source.UUID))
+	cached, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusDone, cached.Status)
+	assert.Equal(t, source.UUID, cached.CachedFrom)
+	assert.Equal(t, "key-1", cached.CacheKey)
+	hit, err = db.FindCachedReview("key-1", source.ID, since)
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+
+	// A rerun asks for a fresh review.
+	require.NoError(t, db.ReenqueueJob(job.ID, ReenqueueOpts{}))
+	rerun, err := db.GetJobByID(job.ID)
+	require.NoError(t, err)
+	assert.Empty(t, rerun.CachedFrom)
+	assert.True(t, rerun.NoCache)
+}
+
+func TestFindCachedReviewMatchesPulledJob(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/review-cache-pull")
+
+	finished := time.Now().Add(-time.Minute)
+	require.NoError(t, db.UpsertPulledJob(PulledJob{
+		UUID:            "remote-job",
+		GitRef:          "abc123",
+		Agent:           "codex",
+		Reasoning:       "thorough",
+		JobType:         JobTypeReview,
+		PatchID:         "patch-1",
+		Status:          "done",
+		EnqueuedAt:      finished,
+		FinishedAt:      &finished,
+		UpdatedAt:       finished,
+		SourceMachineID: "remote-machine",
+		CacheKey:        "key-remote",
+	}, repo.ID, nil))
+	require.NoError(t, db.UpsertPulledReview(PulledReview{
+		UUID:               "remote-review",
+		JobUUID:            "remote-job",
+		Agent:              "codex",
+		Prompt:             "prompt",
+		Output:             "No issues found.",
+		UpdatedByMachineID: "remote-machine",
+		CreatedAt:          finished,
+		UpdatedAt:          finished,
+	}))
+
+	hit, err := db.FindCachedReview("key-remote", 0, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, "remote-job", hit.JobUUID)
+	assert.Equal(t, "No issues found.", hit.Output)
+}
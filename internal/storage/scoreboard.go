@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// AgentScore is one agent/model row of the agent quality scoreboard. The
+// scores are proxies built from what humans did with the reviews: a failing
+// review is accepted when a fix for it was applied or it was closed, and
+// dismissed when a human comment marks it as a false positive. Reviews with
+// neither signal are still open and do not count toward the rates.
+type AgentScore struct {
+	Agent string `json:"agent"`
+	Model string `json:"model,omitempty"`
+
+	Reviews       int `json:"reviews"`        // verdict-bearing reviews
+	FailedReviews int `json:"failed_reviews"` // reviews that reported findings
+	Findings      int `json:"findings"`       // findings across failing reviews
+
+	Accepted         int `json:"accepted"`          // failing reviews acted on
+	Dismissed        int `json:"dismissed"`         // failing reviews marked false positive
+	Open             int `json:"open"`              // failing reviews with no signal yet
+	AcceptedFindings int `json:"accepted_findings"` // findings in accepted reviews
+	FixesApplied     int `json:"fixes_applied"`     // accepted reviews with an applied fix
+
+	// Precision is Accepted/(Accepted+Dismissed); FalsePositiveRate is its
+	// complement. Both are zero until a failing review has been resolved.
+	Precision         float64 `json:"precision"`
+	FalsePositiveRate float64 `json:"false_positive_rate"`
+
+	// PanelFindings counts this agent's findings in panel member reviews;
+	// AgreedFindings are those another member of the same run also reported.
+	PanelFindings  int     `json:"panel_findings"`
+	AgreedFindings int     `json:"agreed_findings"`
+	AgreementRate  float64 `json:"agreement_rate"`
+
+	// CostUSD sums the reported cost of the scored reviews.
+	// CostPerAcceptedFinding is zero when no finding has been accepted.
+	CostUSD                float64 `json:"cost_usd"`
+	CostPerAcceptedFinding float64 `json:"cost_per_accepted_finding"`
+}
+
+// AgentScoreSample is one finished review with the human signals the
+// scoreboard is built from.
+type AgentScoreSample struct {
+	JobID        int64
+	Agent        string
+	Model        string
+	PanelRunUUID string // set for panel member reviews only, not chunked runs
+	Output       string
+	Passed       bool
+	Closed       bool
+	FixApplied   bool   // a fix job for this review reached applied or rebased
+	Comments     string // human comments, newline separated
+	CostUSD      float64
+}
+
+// runSynthesisIDs selects the synthesis jobs of j's panel run when j is a
+// panel member, and nothing otherwise.
+const runSynthesisIDs = `SELECT s.id FROM review_jobs s
+	WHERE COALESCE(j.panel_role, '') = 'member' AND COALESCE(j.panel_run_uuid, '') != ''
+	AND s.panel_run_uuid = j.panel_run_uuid AND s.panel_role = 'synthesis'`
+
+// chunkedRun matches when j belongs to a chunked review run. Chunk reviews
+// cover disjoint file groups, so they are not panel members for agreement.
+const chunkedRun = `EXISTS (SELECT 1 FROM review_jobs cs
+	WHERE cs.panel_run_uuid = j.panel_run_uuid AND cs.panel_role = 'synthesis'
+	AND json_valid(cs.panel_member_config_json)
+	AND json_extract(cs.panel_member_config_json, '$.strategy') = 'chunks')`
+
+// GetAgentScoreSamples loads the verdict-bearing reviews in the summary
+// window for scoring. Panel syntheses are excluded so every finding is
+// credited to the member agent that produced it. Humans act on a panel run
+// through its synthesis, so a member is also closed, fixed, or commented on
+// when any synthesis of its run is. Comments left by roborev itself
+// (responder "roborev-…") are not human signals and are skipped.
+func (db *DB) GetAgentScoreSamples(opts SummaryOptions) ([]AgentScoreSample, error) {
+	if opts.RepoPath != "" {
+		opts.RepoPath = normalizeRepoPathBestEffort(opts.RepoPath)
+	}
+	where, args := summaryWhere(opts)
+	query := `
+		SELECT
+			j.id, j.agent, COALESCE(j.model, ''),
+			CASE WHEN COALESCE(j.panel_role, '') = 'member' AND NOT ` + chunkedRun + `
+				THEN COALESCE(j.panel_run_uuid, '') ELSE '' END,
+			rv.output, rv.verdict_bool,
+			rv.closed OR EXISTS (SELECT 1 FROM reviews sr
+				WHERE sr.closed AND sr.job_id IN (` + runSynthesisIDs + `)),
+			EXISTS (SELECT 1 FROM review_jobs f
+				WHERE f.job_type = 'fix' AND f.status IN ('applied', 'rebased')
+				AND (f.parent_job_id = j.id OR f.parent_job_id IN (` + runSynthesisIDs + `))),
+			COALESCE((SELECT group_concat(c.response, char(10)) FROM responses c
+				WHERE (c.job_id = j.id OR c.job_id IN (` + runSynthesisIDs + `))
+				AND c.responder NOT LIKE 'roborev-%'), ''),
+			CASE WHEN ` + hasCost + `
+				THEN COALESCE(json_extract(j.token_usage, '$.cost_usd'), 0) ELSE 0 END
+		FROM review_jobs j
+		JOIN repos r ON r.id = j.repo_id
+		JOIN reviews rv ON rv.job_id = j.id
+		` + where + ` AND j.status IN ('done', 'applied', 'rebased')
+			AND rv.verdict_bool IS NOT NULL
+			AND COALESCE(j.panel_role, '') != 'synthesis'
+			AND ` + verdictJobFilter + `
+		ORDER BY j.id`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []AgentScoreSample
+	for rows.Next() {
+		var (
+			s       AgentScoreSample
+			verdict sql.NullInt64
+		)
+		if err := rows.Scan(&s.JobID, &s.Agent, &s.Model, &s.PanelRunUUID,
+			&s.Output, &verdict, &s.Closed, &s.FixApplied, &s.Comments, &s.CostUSD); err != nil {
+			return nil, err
+		}
+		s.Passed = verdict.Valid && verdict.Int64 == 1
+		s.Comments = strings.TrimSpace(s.Comments)
+		samples = append(samples, s)
+	}
+	return samples, rows.Err()
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAgentScoreSamples(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	repo := createRepo(t, db, "/tmp/test-repo")
+	commit := createCommit(t, db, repo.ID, "abc123")
+
+	complete := func(opts EnqueueOpts, agent, output string) *ReviewJob {
+		t.Helper()
+		opts.RepoID, opts.CommitID, opts.GitRef = repo.ID, commit.ID, "abc123"
+		job, err := db.EnqueueJob(opts)
+		require.NoError(t, err)
+		claimJob(t, db, "w1")
+		require.NoError(t, db.CompleteJob(job.ID, agent, "p", output))
+		return job
+	}
+
+	pass := complete(EnqueueOpts{Agent: "codex", Model: "gpt-5"}, "codex", "No issues found.")
+	fixed := complete(EnqueueOpts{Agent: "codex", Model: "gpt-5"}, "codex", "- High — a.go:1 bug")
+	require.NoError(t, db.BackfillJobTokenUsage(fixed.ID, "", `{"cost_usd":0.5,"has_cost":true}`))
+	fix := complete(EnqueueOpts{Agent: "codex", JobType: JobTypeFix, ParentJobID: fixed.ID}, "codex", "patched")
+	require.NoError(t, db.MarkJobApplied(fix.ID))
+
+	dismissed := complete(EnqueueOpts{Agent: "gemini"}, "gemini", "- Low — b.go:2 nit")
+	_, err := db.AddCommentToJob(dismissed.ID, "alice", "False positive, this is intended.")
+	require.NoError(t, err)
+	_, err = db.AddCommentToJob(dismissed.ID, "roborev-refine", "automated note")
+	require.NoError(t, err)
+	require.NoError(t, db.MarkReviewClosedByJobID(dismissed.ID, true))
+
+	member := complete(EnqueueOpts{
+		Agent: "gemini", PanelRunUUID: "run-1", PanelRole: PanelRoleMember, PanelName: "p",
+	}, "gemini", "- Medium — c.go:3 race")
+	complete(EnqueueOpts{
+		Agent: "claude-code", JobType: JobTypeSynthesis, PanelRunUUID: "run-1",
+		PanelRole: PanelRoleSynthesis, PanelName: "p",
+	}, "claude-code", "- Medium — c.go:3 race")
+
+	samples, err := db.GetAgentScoreSamples(SummaryOptions{Since: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+
+	byJob := make(map[int64]AgentScoreSample)
+	for _, s := range samples {
+		byJob[s.JobID] = s
+	}
+	require.Len(t, byJob, 4, "fix jobs and panel syntheses are not scored")
+
+	assert := assert.New(t)
+	assert.True(byJob[pass.ID].Passed)
+	assert.Equal("gpt-5", byJob[pass.ID].Model)
+
+	assert.False(byJob[fixed.ID].Passed)
+	assert.True(byJob[fixed.ID].FixApplied)
+	assert.InDelta(0.5, byJob[fixed.ID].CostUSD, 1e-9)
+
+	assert.True(byJob[dismissed.ID].Closed)
+	assert.False(byJob[dismissed.ID].FixApplied)
+	assert.Equal("False positive, this is intended.", byJob[dismissed.ID].Comments,
+		"roborev's own comments are not human signals")
+
+	assert.Equal("run-1", byJob[member.ID].PanelRunUUID)
+	assert.Empty(byJob[pass.ID].PanelRunUUID)
+	assert.False(byJob[member.ID].Closed, "no human has acted on the run yet")
+}
+
+func TestGetAgentScoreSamplesCreditsMembersWithSynthesisSignals(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	repo := createRepo(t, db, "/tmp/test-repo")
+	commit := createCommit(t, db, repo.ID, "abc123")
+	complete := func(opts EnqueueOpts, output string) *ReviewJob {
+		t.Helper()
+		opts.RepoID, opts.CommitID, opts.GitRef, opts.PanelName = repo.ID, commit.ID, "abc123", "p"
+		job, err := db.EnqueueJob(opts)
+		require.NoError(t, err)
+		claimJob(t, db, "w1")
+		require.NoError(t, db.CompleteJob(job.ID, opts.Agent, "p", output))
+		return job
+	}
+
+	// Run 1: the human closes the synthesis and comments on it.
+	closedMember := complete(EnqueueOpts{Agent: "gemini", PanelRunUUID: "run-1", PanelRole: PanelRoleMember},
+		"- Medium — c.go:3 race")
+	synth1 := complete(EnqueueOpts{Agent: "claude-code", JobType: JobTypeSynthesis, PanelRunUUID: "run-1",
+		PanelRole: PanelRoleSynthesis}, "- Medium — c.go:3 race")
+	require.NoError(t, db.MarkReviewClosedByJobID(synth1.ID, true))
+	_, err := db.AddCommentToJob(synth1.ID, "alice", "by design")
+	require.NoError(t, err)
+
+	// Run 2: a fix for the synthesis is applied.
+	fixedMember := complete(EnqueueOpts{Agent: "codex", PanelRunUUID: "run-2", PanelRole: PanelRoleMember},
+		"- High — a.go:1 bug")
+	synth2 := complete(EnqueueOpts{Agent: "claude-code", JobType: JobTypeSynthesis, PanelRunUUID: "run-2",
+		PanelRole: PanelRoleSynthesis}, "- High — a.go:1 bug")
+	fix := complete(EnqueueOpts{Agent: "codex", JobType: JobTypeFix, ParentJobID: synth2.ID}, "patched")
+	require.NoError(t, db.MarkJobApplied(fix.ID))
+
+	// A standalone review is not affected by other runs' signals.
+	solo := complete(EnqueueOpts{Agent: "codex"}, "- Low — d.go:1 nit")
+
+	samples, err := db.GetAgentScoreSamples(SummaryOptions{Since: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	byJob := make(map[int64]AgentScoreSample)
+	for _, s := range samples {
+		byJob[s.JobID] = s
+	}
+
+	assert := assert.New(t)
+	assert.True(byJob[closedMember.ID].Closed, "closing the synthesis closes its members")
+	assert.Equal("by design", byJob[closedMember.ID].Comments, "synthesis comments reach its members")
+	assert.False(byJob[closedMember.ID].FixApplied)
+	assert.True(byJob[fixedMember.ID].FixApplied, "a fix of the synthesis fixes its members")
+	assert.False(byJob[fixedMember.ID].Closed)
+	assert.Empty(byJob[fixedMember.ID].Comments)
+	assert.False(byJob[solo.ID].Closed)
+	assert.False(byJob[solo.ID].FixApplied)
+}
+
+func TestGetAgentScoreSamplesExcludesChunkedRunsFromPanels(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	repo := createRepo(t, db, "/tmp/test-repo")
+	commit := createCommit(t, db, repo.ID, "abc123")
+	complete := func(opts EnqueueOpts, output string) *ReviewJob {
+		t.Helper()
+		opts.RepoID, opts.CommitID, opts.GitRef = repo.ID, commit.ID, "abc123"
+		job, err := db.EnqueueJob(opts)
+		require.NoError(t, err)
+		claimJob(t, db, "w1")
+		require.NoError(t, db.CompleteJob(job.ID, opts.Agent, "p", output))
+		return job
+	}
+
+	chunk := complete(EnqueueOpts{Agent: "codex", PanelName: "chunks", PanelRunUUID: "chunk-run",
+		PanelRole: PanelRoleMember}, "- High — a.go:1 bug")
+	synth := complete(EnqueueOpts{Agent: "codex", JobType: JobTypeSynthesis, PanelName: "chunks",
+		PanelRunUUID: "chunk-run", PanelRole: PanelRoleSynthesis,
+		PanelMemberConfigJSON: `{"agent":"","model":"","reasoning":"","backup_agent":"","backup_model":"","strategy":"chunks"}`},
+		"- High — a.go:1 bug")
+	require.NoError(t, db.MarkReviewClosedByJobID(synth.ID, true))
+	member := complete(EnqueueOpts{Agent: "gemini", PanelName: "p", PanelRunUUID: "panel-run",
+		PanelRole: PanelRoleMember}, "- High — a.go:1 bug")
+
+	samples, err := db.GetAgentScoreSamples(SummaryOptions{Since: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	byJob := make(map[int64]AgentScoreSample)
+	for _, s := range samples {
+		byJob[s.JobID] = s
+	}
+
+	assert := assert.New(t)
+	assert.Empty(byJob[chunk.ID].PanelRunUUID, "chunk reviews are not panel members")
+	assert.True(byJob[chunk.ID].Closed, "chunk reviews still take their synthesis's signals")
+	assert.Equal("panel-run", byJob[member.ID].PanelRunUUID)
+}
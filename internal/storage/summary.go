@@ -26,6 +26,10 @@ type Summary struct {
 	Failures FailureStats   `json:"failures"`
 	Repos    []RepoSummary  `json:"repos,omitempty"`
 	Cost     CostAggregate  `json:"cost"`
+
+	// AgentScores is the agent quality scoreboard. It is only filled in
+	// when requested, because scoring parses every review in the window.
+	AgentScores []AgentScore `json:"agent_scores,omitempty"`
 }
 
 // OverviewStats contains job counts by status.
@@ -105,10 +109,15 @@ type FailureStats struct {
 // NOTE: assumes review_jobs is aliased as "j" in the enclosing query.
 const verdictJobFilter = "COALESCE(j.job_type, 'review') NOT IN ('task', 'fix')"
 
+// authorFilter restricts review_jobs (aliased "j") to commits by one author.
+// Range and dirty jobs have no commit row and never match.
+const authorFilter = "j.commit_id IN (SELECT id FROM commits WHERE author = ?)"
+
 // SummaryOptions configures the summary query.
 type SummaryOptions struct {
 	RepoPath string
 	Branch   string
+	Author   string // commit author name; empty = all authors
 	Since    time.Time
 	AllRepos bool
 }
@@ -132,24 +141,7 @@ func (db *DB) GetSummary(opts SummaryOptions) (*Summary, error) {
 		Branch:   opts.Branch,
 	}
 
-	sinceStr := opts.Since.UTC().Format("2006-01-02 15:04:05")
-
-	// Build shared WHERE clause.
-	// Use datetime() to normalize timestamps — synced rows may use RFC3339
-	// format (with 'T' separator) while local rows use space-separated format.
-	var conditions []string
-	var args []any
-	conditions = append(conditions, "datetime(j.enqueued_at) >= datetime(?)")
-	args = append(args, sinceStr)
-	if opts.RepoPath != "" {
-		conditions = append(conditions, "r.root_path = ?")
-		args = append(args, opts.RepoPath)
-	}
-	if opts.Branch != "" {
-		conditions = append(conditions, "j.branch = ?")
-		args = append(args, opts.Branch)
-	}
-	where := "WHERE " + strings.Join(conditions, " AND ")
+	where, args := summaryWhere(opts)
 
 	s.Overview, err = summaryOverview(tx, where, args)
 	if err != nil {
@@ -188,6 +180,7 @@ func (db *DB) GetSummary(opts SummaryOptions) (*Summary, error) {
 	s.Cost, err = costAggregate(tx, CostOptions{
 		RepoPaths: costRepos,
 		Branch:    opts.Branch,
+		Author:    opts.Author,
 		Since:     opts.Since,
 	})
 	if err != nil {
@@ -204,6 +197,28 @@ func (db *DB) GetSummary(opts SummaryOptions) (*Summary, error) {
 	return s, nil
 }
 
+// summaryWhere builds the WHERE clause shared by the summary queries. Use
+// datetime() to normalize timestamps — synced rows may use RFC3339 format
+// (with 'T' separator) while local rows use space-separated format.
+// Expects opts.RepoPath to be normalized already.
+func summaryWhere(opts SummaryOptions) (string, []any) {
+	conditions := []string{"datetime(j.enqueued_at) >= datetime(?)"}
+	args := []any{opts.Since.UTC().Format("2006-01-02 15:04:05")}
+	if opts.RepoPath != "" {
+		conditions = append(conditions, "r.root_path = ?")
+		args = append(args, opts.RepoPath)
+	}
+	if opts.Branch != "" {
+		conditions = append(conditions, "j.branch = ?")
+		args = append(args, opts.Branch)
+	}
+	if opts.Author != "" {
+		conditions = append(conditions, authorFilter)
+		args = append(args, opts.Author)
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
 func summaryOverview(q querier, where string, args []any) (OverviewStats, error) {
 	query := `
 		SELECT
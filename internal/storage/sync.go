@@ -408,6 +408,8 @@ type SyncableJob struct {
 	PanelMemberName       string
 	PanelMemberIndex      int
 	PanelMemberConfigJSON string
+	CacheKey              string
+	CachedFrom            string
 	SourceMachineID       string
 	UpdatedAt             time.Time
 	UpdatedAtRaw          string
@@ -427,6 +429,7 @@ func (db *DB) GetJobsToSync(machineID string, limit int) ([]SyncableJob, error)
 			COALESCE(j.prompt, ''), j.diff_content, j.dirty_files, COALESCE(j.error, ''), COALESCE(j.token_usage, ''),
 			COALESCE(j.worktree_path, ''), COALESCE(j.source, ''), COALESCE(j.min_severity, ''), COALESCE(j.backup_agent, ''), COALESCE(j.backup_model, ''),
 			COALESCE(j.panel_run_uuid, ''), COALESCE(j.panel_role, ''), COALESCE(j.panel_name, ''), COALESCE(j.panel_member_name, ''), COALESCE(j.panel_member_index, 0), COALESCE(j.panel_member_config_json, ''),
+			COALESCE(j.cache_key, ''), COALESCE(j.cached_from, ''),
 			j.source_machine_id, j.updated_at
 		FROM review_jobs j
 		JOIN repos r ON j.repo_id = r.id
@@ -459,6 +462,7 @@ func (db *DB) GetJobsToSync(machineID string, limit int) ([]SyncableJob, error)
 			&j.Prompt, &diffContent, &dirtyFiles, &j.Error, &j.TokenUsage,
 			&j.WorktreePath, &j.Source, &j.MinSeverity, &j.BackupAgent, &j.BackupModel,
 			&j.PanelRunUUID, &j.PanelRole, &j.PanelName, &j.PanelMemberName, &j.PanelMemberIndex, &j.PanelMemberConfigJSON,
+			&j.CacheKey, &j.CachedFrom,
 			&j.SourceMachineID, &updatedAt,
 		)
 		if err != nil {
@@ -792,8 +796,9 @@ func (db *DB) UpsertPulledJob(j PulledJob, repoID int64, commitID *int64) error
 			enqueued_at, started_at, finished_at, prompt, diff_content, dirty_files, error, token_usage,
 			worktree_path, source, min_severity, backup_agent, backup_model,
 			panel_run_uuid, panel_role, panel_name, panel_member_name, panel_member_index, panel_member_config_json,
+			cache_key, cached_from,
 			source_machine_id, updated_at, synced_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(uuid) DO UPDATE SET
 			status = excluded.status,
 			finished_at = excluded.finished_at,
@@ -820,6 +825,8 @@ func (db *DB) UpsertPulledJob(j PulledJob, repoID int64, commitID *int64) error
 			panel_member_name = excluded.panel_member_name,
 			panel_member_index = excluded.panel_member_index,
 			panel_member_config_json = excluded.panel_member_config_json,
+			cache_key = excluded.cache_key,
+			cached_from = excluded.cached_from,
 			updated_at = excluded.updated_at,
 			synced_at = ?
 			WHERE review_jobs.status NOT IN ('applied', 'rebased')
@@ -830,6 +837,7 @@ func (db *DB) UpsertPulledJob(j PulledJob, repoID int64, commitID *int64) error
 		nullStr(j.Prompt), j.DiffContent, nullStr(dirtyFilesJSON), nullStr(j.Error), nullStr(j.TokenUsage),
 		nullStr(j.WorktreePath), nullStr(j.Source), normalizeMinSeverityForWrite(j.MinSeverity), j.BackupAgent, j.BackupModel,
 		nullStr(j.PanelRunUUID), nullStr(j.PanelRole), nullStr(j.PanelName), nullStr(j.PanelMemberName), j.PanelMemberIndex, nullStr(j.PanelMemberConfigJSON),
+		nullStr(j.CacheKey), nullStr(j.CachedFrom),
 		j.SourceMachineID, j.UpdatedAt.Format(time.RFC3339), now, now)
 	return err
 }
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -273,3 +274,31 @@ func TestSyncWorker_FinalPushReturnsNilWhenNotConnected(t *testing.T) {
 	err = worker.FinalPush()
 	r.NoError(err, "FinalPush should return nil when not connected, got: %v", err)
 }
+
+func TestSyncWorker_HealthHandlerReportsTransitionsOnly(t *testing.T) {
+	worker := NewSyncWorker(nil, config.SyncConfig{})
+	type call struct {
+		phase string
+		err   error
+	}
+	var calls []call
+	worker.SetHealthHandler(func(phase string, err error) {
+		calls = append(calls, call{phase, err})
+	})
+
+	worker.noteHealth("sync", nil)
+	assert.Empty(t, calls, "healthy at start is not a transition")
+
+	connErr := errors.New("connection refused")
+	worker.noteHealth("connect", connErr)
+	worker.noteHealth("connect", connErr)
+	worker.noteHealth("sync", errors.New("push failed"))
+	require.Len(t, calls, 1, "a failure streak is reported once")
+	assert.Equal(t, "connect", calls[0].phase)
+	assert.Equal(t, connErr, calls[0].err)
+
+	worker.noteHealth("sync", nil)
+	worker.noteHealth("sync", nil)
+	require.Len(t, calls, 2)
+	assert.NoError(t, calls[1].err, "recovery is reported with a nil error")
+}
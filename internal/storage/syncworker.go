@@ -23,6 +23,10 @@ type SyncWorker struct {
 	connectMu       sync.Mutex // serializes connect operations
 	running         bool
 	skipInitialSync bool // when true, skip the immediate doSync on connect
+
+	healthMu sync.Mutex // protects onHealth and failing
+	onHealth func(phase string, err error)
+	failing  bool
 }
 
 // NewSyncWorker creates a new sync worker
@@ -47,6 +51,31 @@ func (w *SyncWorker) SetSkipInitialSync(skip bool) error {
 	return nil
 }
 
+// SetHealthHandler registers fn to be told when background sync starts
+// failing and when it recovers. fn is called with the failing phase
+// ("connect" or "sync") and the error on the first failure of a streak, and
+// with a nil error on the first success after it, so a flapping connection
+// does not report every retry.
+func (w *SyncWorker) SetHealthHandler(fn func(phase string, err error)) {
+	w.healthMu.Lock()
+	defer w.healthMu.Unlock()
+	w.onHealth = fn
+}
+
+// noteHealth records the outcome of a background connect or sync attempt and
+// notifies the health handler on a transition.
+func (w *SyncWorker) noteHealth(phase string, err error) {
+	w.healthMu.Lock()
+	failing := err != nil
+	changed := failing != w.failing
+	w.failing = failing
+	fn := w.onHealth
+	w.healthMu.Unlock()
+	if changed && fn != nil {
+		fn(phase, err)
+	}
+}
+
 // Start begins the sync worker in a background goroutine.
 // The worker can be stopped with Stop() and restarted with Start().
 func (w *SyncWorker) Start() error {
@@ -344,6 +373,7 @@ func (w *SyncWorker) run(stopCh, doneCh chan struct{}, interval, connectTimeout
 		newConn, err := w.connect(connectTimeout)
 		if err != nil {
 			log.Printf("Sync: connection failed: %v (retry in %v)", err, backoff)
+			w.noteHealth("connect", err)
 			select {
 			case <-stopCh:
 				return
@@ -481,9 +511,11 @@ func (w *SyncWorker) syncLoop(stopCh <-chan struct{}, interval time.Duration, do
 	// Do initial sync immediately only if we made the connection
 	// (if SyncNow connected, it will handle its own sync)
 	if doInitialSync {
-		if err := w.doSync(); err != nil {
+		err := w.doSync()
+		if err != nil {
 			log.Printf("Sync: error: %v", err)
 		}
+		w.noteHealth("sync", err)
 	}
 
 	for {
@@ -491,7 +523,9 @@ func (w *SyncWorker) syncLoop(stopCh <-chan struct{}, interval time.Duration, do
 		case <-stopCh:
 			return
 		case <-ticker.C:
-			if err := w.doSync(); err != nil {
+			err := w.doSync()
+			w.noteHealth("sync", err)
+			if err != nil {
 				log.Printf("Sync: error: %v", err)
 				// Check if connection is still alive - grab pool under lock
 				w.mu.Lock()
@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Webhook delivery status values.
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+	WebhookFailed    = "failed"
+)
+
+// WebhookDelivery is one outbox row: an event bound for one webhook URL with
+// its frozen payload and retry state. NextAttemptAt is set while the delivery
+// is pending and cleared once it is delivered or has failed for good. The URL
+// and secret stay local: handlers must redact URL before returning it.
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	URL            string     `json:"url"`
+	Secret         string     `json:"-"`
+	EventType      string     `json:"event_type"`
+	JobID          int64      `json:"job_id,omitempty"`
+	Repo           string     `json:"repo,omitempty"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+const webhookDeliveryColumns = `id, url, secret, event_type, job_id, repo, payload,
+	status, attempts, last_status_code, last_error, next_attempt_at,
+	last_attempt_at, delivered_at, created_at`
+
+func scanWebhookDelivery(row sqlScanner) (*WebhookDelivery, error) {
+	var d WebhookDelivery
+	var nextAttemptAt, lastAttemptAt, deliveredAt sql.NullString
+	var createdAt string
+	if err := row.Scan(
+		&d.ID, &d.URL, &d.Secret, &d.EventType, &d.JobID, &d.Repo, &d.Payload,
+		&d.Status, &d.Attempts, &d.LastStatusCode, &d.LastError, &nextAttemptAt,
+		&lastAttemptAt, &deliveredAt, &createdAt,
+	); err != nil {
+		return nil, err
+	}
+	d.CreatedAt = parseSQLiteTime(createdAt)
+	for _, f := range []struct {
+		src sql.NullString
+		dst **time.Time
+	}{
+		{nextAttemptAt, &d.NextAttemptAt},
+		{lastAttemptAt, &d.LastAttemptAt},
+		{deliveredAt, &d.DeliveredAt},
+	} {
+		if f.src.Valid {
+			t := parseSQLiteTime(f.src.String)
+			*f.dst = &t
+		}
+	}
+	return &d, nil
+}
+
+// webhookTime formats a timestamp for the webhook_deliveries table. Values are
+// always UTC so due-time comparisons can compare the strings directly.
+func webhookTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// EnqueueWebhookDelivery stores a new pending delivery due immediately and
+// returns its ID.
+func (db *DB) EnqueueWebhookDelivery(d WebhookDelivery) (int64, error) {
+	now := webhookTime(time.Now())
+	res, err := db.Exec(`
+		INSERT INTO webhook_deliveries
+			(url, secret, event_type, job_id, repo, payload, status, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, 'pending', ?, ?)`,
+		d.URL, d.Secret, d.EventType, d.JobID, d.Repo, d.Payload, now, now)
+	if err != nil {
+		return 0, fmt.Errorf("enqueue webhook delivery: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// ListDueWebhookDeliveries returns up to limit pending deliveries whose next
+// attempt is due at now, oldest first.
+func (db *DB) ListDueWebhookDeliveries(now time.Time, limit int) ([]WebhookDelivery, error) {
+	rows, err := db.Query(`
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?`, webhookTime(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list due webhook deliveries: %w", err)
+	}
+	return collectWebhookDeliveries(rows)
+}
+
+// NextWebhookDeliveryDue returns when the earliest pending delivery is due,
+// or nil when nothing is pending.
+func (db *DB) NextWebhookDeliveryDue() (*time.Time, error) {
+	var next sql.NullString
+	err := db.QueryRow(`
+		SELECT MIN(next_attempt_at) FROM webhook_deliveries WHERE status = 'pending'`,
+	).Scan(&next)
+	if err != nil {
+		return nil, fmt.Errorf("next webhook delivery: %w", err)
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+	t := parseSQLiteTime(next.String)
+	return &t, nil
+}
+
+// WebhookAttempt is the outcome of one delivery attempt. A nil NextAttemptAt
+// on a failed attempt means retries are exhausted.
+type WebhookAttempt struct {
+	Delivered     bool
+	StatusCode    int
+	Error         string
+	NextAttemptAt *time.Time
+}
+
+// RecordWebhookAttempt stores the outcome of a delivery attempt. A pending
+// delivery is retried at NextAttemptAt, marked delivered on success, or marked
+// failed when no further attempt is scheduled. Rows that are no longer
+// pending (pruned, or already finished) are left alone.
+func (db *DB) RecordWebhookAttempt(id int64, at time.Time, a WebhookAttempt) error {
+	status := WebhookPending
+	var next, delivered any
+	switch {
+	case a.Delivered:
+		status, delivered = WebhookDelivered, webhookTime(at)
+	case a.NextAttemptAt == nil:
+		status = WebhookFailed
+	default:
+		next = webhookTime(*a.NextAttemptAt)
+	}
+	_, err := db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = attempts + 1, last_status_code = ?, last_error = ?,
+			next_attempt_at = ?, last_attempt_at = ?, delivered_at = ?
+		WHERE id = ? AND status = 'pending'`,
+		status, a.StatusCode, a.Error, next, webhookTime(at), delivered, id)
+	if err != nil {
+		return fmt.Errorf("record webhook attempt: %w", err)
+	}
+	return nil
+}
+
+// DeferWebhookDeliveries pushes every pending delivery to url that is due
+// before until back to until, without spending an attempt. The outbox uses it
+// to hold a receiver's queue while that receiver is in backoff.
+func (db *DB) DeferWebhookDeliveries(url string, until time.Time) error {
+	_, err := db.Exec(`
+		UPDATE webhook_deliveries
+		SET next_attempt_at = ?
+		WHERE url = ? AND status = 'pending' AND next_attempt_at < ?`,
+		webhookTime(until), url, webhookTime(until))
+	if err != nil {
+		return fmt.Errorf("defer webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// WebhookDeliveryFilter scopes ListWebhookDeliveries. The zero value lists the
+// most recent deliveries of every status.
+type WebhookDeliveryFilter struct {
+	Status string
+	JobID  int64
+	Limit  int // 0 = 50
+}
+
+// ListWebhookDeliveries returns deliveries newest first.
+func (db *DB) ListWebhookDeliveries(f WebhookDeliveryFilter) ([]WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE 1=1`
+	var args []any
+	if f.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, f.Status)
+	}
+	if f.JobID != 0 {
+		query += ` AND job_id = ?`
+		args = append(args, f.JobID)
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return collectWebhookDeliveries(rows)
+}
+
+// GetWebhookDelivery returns one delivery by ID, or sql.ErrNoRows.
+func (db *DB) GetWebhookDelivery(id int64) (*WebhookDelivery, error) {
+	return scanWebhookDelivery(db.QueryRow(
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id))
+}
+
+// RedeliverWebhook requeues a delivery of any status to be sent immediately
+// with a fresh retry budget. Returns sql.ErrNoRows when the ID is unknown.
+func (db *DB) RedeliverWebhook(id int64) error {
+	res, err := db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = ?, delivered_at = NULL
+		WHERE id = ?`, webhookTime(time.Now()), id)
+	if err != nil {
+		return fmt.Errorf("redeliver webhook: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// PruneWebhookDeliveries deletes delivered and failed deliveries created
+// before cutoff and returns how many were removed. Pending rows are kept.
+func (db *DB) PruneWebhookDeliveries(cutoff time.Time) (int64, error) {
+	res, err := db.Exec(`
+		DELETE FROM webhook_deliveries
+		WHERE status != 'pending' AND created_at < ?`, webhookTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("prune webhook deliveries: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func collectWebhookDeliveries(rows *sql.Rows) ([]WebhookDelivery, error) {
+	defer rows.Close()
+	var out []WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
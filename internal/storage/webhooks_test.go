@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryLifecycle(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	id, err := db.EnqueueWebhookDelivery(WebhookDelivery{
+		URL: "https://hooks.example.com/abc", Secret: "s3cret",
+		EventType: "review.failed", JobID: 7, Repo: "/repo", Payload: `{"type":"review.failed"}`,
+	})
+	require.NoError(t, err)
+
+	due, err := db.ListDueWebhookDeliveries(time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "s3cret", due[0].Secret)
+	assert.Equal(t, WebhookPending, due[0].Status)
+	require.NotNil(t, due[0].NextAttemptAt)
+
+	// A failed attempt with a retry scheduled stays pending and is not due yet.
+	retryAt := time.Now().Add(time.Hour)
+	require.NoError(t, db.RecordWebhookAttempt(id, time.Now(), WebhookAttempt{
+		StatusCode: 500, Error: "status 500", NextAttemptAt: &retryAt,
+	}))
+	due, err = db.ListDueWebhookDeliveries(time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	next, err := db.NextWebhookDeliveryDue()
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.WithinDuration(t, retryAt, *next, time.Second)
+
+	// Exhausted retries mark the delivery failed.
+	require.NoError(t, db.RecordWebhookAttempt(id, time.Now(), WebhookAttempt{Error: "connection refused"}))
+	d, err := db.GetWebhookDelivery(id)
+	require.NoError(t, err)
+	assert.Equal(t, WebhookFailed, d.Status)
+	assert.Equal(t, 2, d.Attempts)
+	assert.Equal(t, "connection refused", d.LastError)
+	assert.Nil(t, d.NextAttemptAt)
+	next, err = db.NextWebhookDeliveryDue()
+	require.NoError(t, err)
+	assert.Nil(t, next)
+
+	// Later attempts on a finished delivery are ignored.
+	require.NoError(t, db.RecordWebhookAttempt(id, time.Now(), WebhookAttempt{Delivered: true}))
+	d, err = db.GetWebhookDelivery(id)
+	require.NoError(t, err)
+	assert.Equal(t, WebhookFailed, d.Status)
+
+	// Redelivery requeues with a fresh budget, then succeeds.
+	require.NoError(t, db.RedeliverWebhook(id))
+	d, err = db.GetWebhookDelivery(id)
+	require.NoError(t, err)
+	assert.Equal(t, WebhookPending, d.Status)
+	assert.Zero(t, d.Attempts)
+	require.NoError(t, db.RecordWebhookAttempt(id, time.Now(), WebhookAttempt{Delivered: true, StatusCode: 204}))
+	d, err = db.GetWebhookDelivery(id)
+	require.NoError(t, err)
+	assert.Equal(t, WebhookDelivered, d.Status)
+	assert.Equal(t, 204, d.LastStatusCode)
+	assert.NotNil(t, d.DeliveredAt)
+
+	assert.ErrorIs(t, db.RedeliverWebhook(id+100), sql.ErrNoRows)
+}
+
+func TestListAndPruneWebhookDeliveries(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	var ids []int64
+	for _, jobID := range []int64{1, 2, 2} {
+		id, err := db.EnqueueWebhookDelivery(WebhookDelivery{
+			URL: "https://example.com", EventType: "review.completed", JobID: jobID, Payload: "{}",
+		})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	require.NoError(t, db.RecordWebhookAttempt(ids[0], time.Now(), WebhookAttempt{Delivered: true}))
+
+	all, err := db.ListWebhookDeliveries(WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, ids[2], all[0].ID, "newest first")
+
+	byJob, err := db.ListWebhookDeliveries(WebhookDeliveryFilter{JobID: 2, Status: WebhookPending, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, byJob, 1)
+	assert.Equal(t, ids[2], byJob[0].ID)
+
+	n, err := db.PruneWebhookDeliveries(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n, "only finished deliveries are pruned")
+	all, err = db.ListWebhookDeliveries(WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Workflow statuses, derived from the statuses of a workflow's step jobs.
+const (
+	WorkflowStatusQueued  = "queued"  // no step has started
+	WorkflowStatusRunning = "running" // some step is queued or running
+	WorkflowStatusDone    = "done"    // every step succeeded
+	WorkflowStatusFailed  = "failed"  // every step finished and one did not succeed
+)
+
+// Workflow is a DAG of jobs submitted together through /api/workflows.
+type Workflow struct {
+	UUID      string         `json:"uuid"`
+	Name      string         `json:"name,omitempty"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	Steps     []WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep is one named job of a workflow. DependsOn names the steps it
+// waits for.
+type WorkflowStep struct {
+	Name      string     `json:"name"`
+	JobID     int64      `json:"job_id"`
+	DependsOn []string   `json:"depends_on,omitempty"`
+	Job       *ReviewJob `json:"job,omitempty"`
+}
+
+// CreateWorkflow records the steps of a workflow whose jobs were already
+// enqueued, in submission order, and returns its UUID.
+func (db *DB) CreateWorkflow(name string, steps []WorkflowStep) (string, error) {
+	uid := GenerateUUID()
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO workflows (uuid, name, created_at) VALUES (?, ?, ?)`,
+		uid, name, time.Now().Format(time.RFC3339)); err != nil {
+		return "", fmt.Errorf("insert workflow: %w", err)
+	}
+	for i, step := range steps {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO workflow_steps (workflow_uuid, step, position, job_id) VALUES (?, ?, ?, ?)`,
+			uid, step.Name, i, step.JobID); err != nil {
+			return "", fmt.Errorf("insert workflow step %q: %w", step.Name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return uid, nil
+}
+
+// GetWorkflow returns a workflow with each step's current job and its
+// derived status. Returns sql.ErrNoRows when the workflow does not exist.
+func (db *DB) GetWorkflow(uuid string) (*Workflow, error) {
+	var wf Workflow
+	var createdAt string
+	err := db.QueryRow(`SELECT uuid, name, created_at FROM workflows WHERE uuid = ?`, uuid).
+		Scan(&wf.UUID, &wf.Name, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+		wf.CreatedAt = t
+	}
+
+	rows, err := db.Query(`
+		SELECT step, job_id FROM workflow_steps WHERE workflow_uuid = ? ORDER BY position`, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("query workflow steps: %w", err)
+	}
+	var ids []int64
+	stepByJob := make(map[int64]string)
+	for rows.Next() {
+		var step WorkflowStep
+		if err := rows.Scan(&step.Name, &step.JobID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan workflow step: %w", err)
+		}
+		wf.Steps = append(wf.Steps, step)
+		ids = append(ids, step.JobID)
+		stepByJob[step.JobID] = step.Name
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	deps, err := db.GetJobDependencies(ids)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]JobStatus, len(wf.Steps))
+	for i := range wf.Steps {
+		step := &wf.Steps[i]
+		for _, dep := range deps[step.JobID] {
+			if name, ok := stepByJob[dep]; ok {
+				step.DependsOn = append(step.DependsOn, name)
+			}
+		}
+		job, err := db.GetJobByID(step.JobID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get workflow step %q job: %w", step.Name, err)
+		}
+		if job != nil {
+			step.Job = job
+			statuses[i] = job.Status
+		}
+	}
+	wf.Status = workflowStatus(statuses)
+	return &wf, nil
+}
+
+// ListWorkflows returns the most recent workflows, newest first. Steps carry
+// their job IDs but not the jobs.
+func (db *DB) ListWorkflows(limit int) ([]Workflow, error) {
+	rows, err := db.Query(`
+		SELECT w.uuid, w.name, w.created_at, s.step, s.job_id, COALESCE(j.status, '')
+		FROM (SELECT rowid AS seq, * FROM workflows ORDER BY created_at DESC, rowid DESC LIMIT ?) w
+		JOIN workflow_steps s ON s.workflow_uuid = w.uuid
+		LEFT JOIN review_jobs j ON j.id = s.job_id
+		ORDER BY w.created_at DESC, w.seq DESC, s.position`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Workflow
+	var statuses [][]JobStatus
+	for rows.Next() {
+		var uuid, name, createdAt, status string
+		var step WorkflowStep
+		if err := rows.Scan(&uuid, &name, &createdAt, &step.Name, &step.JobID, &status); err != nil {
+			return nil, fmt.Errorf("scan workflow: %w", err)
+		}
+		if len(out) == 0 || out[len(out)-1].UUID != uuid {
+			wf := Workflow{UUID: uuid, Name: name}
+			if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+				wf.CreatedAt = t
+			}
+			out = append(out, wf)
+			statuses = append(statuses, nil)
+		}
+		last := len(out) - 1
+		out[last].Steps = append(out[last].Steps, step)
+		statuses[last] = append(statuses[last], JobStatus(status))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range out {
+		out[i].Status = workflowStatus(statuses[i])
+	}
+	return out, nil
+}
+
+// workflowStatus derives a workflow's status from its step job statuses. An
+// empty status, for a job that no longer exists, counts as failed.
+func workflowStatus(statuses []JobStatus) string {
+	started, pending, broken := false, false, false
+	for _, status := range statuses {
+		switch status {
+		case JobStatusQueued:
+			pending = true
+		case JobStatusRunning:
+			pending, started = true, true
+		case JobStatusFailed, JobStatusCanceled, "":
+			broken, started = true, true
+		default:
+			started = true
+		}
+	}
+	switch {
+	case pending && !started:
+		return WorkflowStatusQueued
+	case pending:
+		return WorkflowStatusRunning
+	case broken:
+		return WorkflowStatusFailed
+	default:
+		return WorkflowStatusDone
+	}
+}
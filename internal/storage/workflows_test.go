@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowTracksStepJobs(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	repo := createRepo(t, db, "/tmp/workflow-repo")
+
+	analyze := mustEnqueuePromptJob(t, db, EnqueueOpts{RepoID: repo.ID, Agent: "codex", Prompt: "analyze"})
+	fix := mustEnqueuePromptJob(t, db, EnqueueOpts{
+		RepoID: repo.ID, Agent: "codex", Prompt: "fix", DependsOn: []int64{analyze.ID},
+	})
+	uuid, err := db.CreateWorkflow("analyze-fix", []WorkflowStep{
+		{Name: "analyze", JobID: analyze.ID},
+		{Name: "fix", JobID: fix.ID},
+	})
+	require.NoError(t, err)
+
+	wf, err := db.GetWorkflow(uuid)
+	require.NoError(t, err)
+	assert.Equal(t, "analyze-fix", wf.Name)
+	assert.Equal(t, WorkflowStatusQueued, wf.Status)
+	require.Len(t, wf.Steps, 2)
+	assert.Equal(t, "analyze", wf.Steps[0].Name)
+	assert.Empty(t, wf.Steps[0].DependsOn)
+	assert.Equal(t, []string{"analyze"}, wf.Steps[1].DependsOn)
+	require.NotNil(t, wf.Steps[1].Job)
+	assert.Equal(t, fix.ID, wf.Steps[1].Job.ID)
+
+	claimJob(t, db, "w1")
+	require.NoError(t, db.CompleteJob(analyze.ID, "codex", "p", "ok"))
+	wf, err = db.GetWorkflow(uuid)
+	require.NoError(t, err)
+	assert.Equal(t, WorkflowStatusRunning, wf.Status)
+
+	require.NoError(t, db.CancelJob(fix.ID))
+	listed, err := db.ListWorkflows(10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, uuid, listed[0].UUID)
+	assert.Equal(t, WorkflowStatusFailed, listed[0].Status)
+	assert.Len(t, listed[0].Steps, 2)
+}
+
+func TestWorkflowStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []JobStatus
+		want     string
+	}{
+		{"all queued", []JobStatus{JobStatusQueued, JobStatusQueued}, WorkflowStatusQueued},
+		{"step running", []JobStatus{JobStatusRunning, JobStatusQueued}, WorkflowStatusRunning},
+		{"done step with queued dependent", []JobStatus{JobStatusDone, JobStatusQueued}, WorkflowStatusRunning},
+		{"all succeeded", []JobStatus{JobStatusDone, JobStatusApplied}, WorkflowStatusDone},
+		{"canceled dependent", []JobStatus{JobStatusFailed, JobStatusCanceled}, WorkflowStatusFailed},
+		{"missing job", []JobStatus{JobStatusDone, ""}, WorkflowStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, workflowStatus(tt.statuses))
+		})
+	}
+}
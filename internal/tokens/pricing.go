@@ -0,0 +1,147 @@
+package tokens
+
+import (
+	"sort"
+	"strings"
+)
+
+// Price is a model's list price in USD per million tokens.
+type Price struct {
+	Input float64 `toml:"input" comment:"USD per million uncached input tokens."`
+	// CachedInput prices cache reads. Zero bills cached tokens at Input.
+	CachedInput float64 `toml:"cached_input" comment:"USD per million cached input tokens. 0 bills them at the input price."`
+	Output      float64 `toml:"output" comment:"USD per million output tokens."`
+}
+
+// Pricing maps model names to prices. Keys match a model by prefix after
+// lowercasing, so "claude-sonnet-4" covers dated releases such as
+// "claude-sonnet-4-5-20250929". A key may carry a provider prefix
+// ("openrouter/anthropic/claude-sonnet-4") to price one provider's route to
+// a model differently.
+type Pricing map[string]Price
+
+// defaultPricing is the built-in price list, grouped by provider. The longest
+// matching key wins, so specific releases are listed next to the family
+// price that covers the rest.
+var defaultPricing = Pricing{
+	// Anthropic
+	"claude-opus-4":      {Input: 5, CachedInput: 0.5, Output: 25},
+	"claude-opus-4-0":    {Input: 15, CachedInput: 1.5, Output: 75},
+	"claude-opus-4-1":    {Input: 15, CachedInput: 1.5, Output: 75},
+	"claude-opus-4-2025": {Input: 15, CachedInput: 1.5, Output: 75},
+	"claude-sonnet-4":    {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-haiku-4":     {Input: 1, CachedInput: 0.1, Output: 5},
+	"claude-3-7-sonnet":  {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-3-5-sonnet":  {Input: 3, CachedInput: 0.3, Output: 15},
+	"claude-3-5-haiku":   {Input: 0.8, CachedInput: 0.08, Output: 4},
+	"opus":               {Input: 5, CachedInput: 0.5, Output: 25},
+	"sonnet":             {Input: 3, CachedInput: 0.3, Output: 15},
+	"haiku":              {Input: 1, CachedInput: 0.1, Output: 5},
+
+	// OpenAI
+	"gpt-5":              {Input: 1.25, CachedInput: 0.125, Output: 10},
+	"gpt-5-mini":         {Input: 0.25, CachedInput: 0.025, Output: 2},
+	"gpt-5-nano":         {Input: 0.05, CachedInput: 0.005, Output: 0.4},
+	"gpt-5-pro":          {Input: 15, Output: 120},
+	"gpt-5.1-codex-mini": {Input: 0.25, CachedInput: 0.025, Output: 2},
+	"gpt-5.2":            {Input: 1.75, CachedInput: 0.175, Output: 14},
+	"gpt-4.1":            {Input: 2, CachedInput: 0.5, Output: 8},
+	"gpt-4.1-mini":       {Input: 0.4, CachedInput: 0.1, Output: 1.6},
+	"o3":                 {Input: 2, CachedInput: 0.5, Output: 8},
+	"o3-mini":            {Input: 1.1, CachedInput: 0.55, Output: 4.4},
+	"o4-mini":            {Input: 1.1, CachedInput: 0.275, Output: 4.4},
+
+	// Google
+	"gemini-2.5-pro":        {Input: 1.25, CachedInput: 0.125, Output: 10},
+	"gemini-2.5-flash":      {Input: 0.3, CachedInput: 0.03, Output: 2.5},
+	"gemini-2.5-flash-lite": {Input: 0.1, CachedInput: 0.01, Output: 0.4},
+	"gemini-3-pro":          {Input: 2, CachedInput: 0.2, Output: 12},
+}
+
+// DefaultPricing returns a copy of the built-in price list.
+func DefaultPricing() Pricing {
+	p := make(Pricing, len(defaultPricing))
+	for k, v := range defaultPricing {
+		p[k] = v
+	}
+	return p
+}
+
+// WithOverrides returns the built-in price list with overrides layered on
+// top. Override keys replace built-in keys of the same name.
+func WithOverrides(overrides Pricing) Pricing {
+	p := DefaultPricing()
+	for k, v := range overrides {
+		p[strings.ToLower(strings.TrimSpace(k))] = v
+	}
+	return p
+}
+
+// Lookup finds the price for a model. The full name is tried first, then
+// the name with each leading "provider/" segment removed, so
+// "openrouter/anthropic/claude-sonnet-4.5" falls back to
+// "anthropic/claude-sonnet-4.5" and then "claude-sonnet-4.5".
+func (p Pricing) Lookup(model string) (Price, bool) {
+	name := strings.ToLower(strings.TrimSpace(model))
+	if name == "" || len(p) == 0 {
+		return Price{}, false
+	}
+	prices := make(map[string]Price, len(p))
+	keys := make([]string, 0, len(p))
+	for k, v := range p {
+		k = strings.ToLower(k)
+		prices[k] = v
+		keys = append(keys, k)
+	}
+	// Longest key first so the most specific prefix wins.
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	for {
+		for _, k := range keys {
+			if strings.HasPrefix(name, k) {
+				return prices[k], true
+			}
+		}
+		_, rest, ok := strings.Cut(name, "/")
+		if !ok {
+			return Price{}, false
+		}
+		name = rest
+	}
+}
+
+// Cost computes the USD cost of usage at this price. Cached input tokens are
+// part of InputTokens and billed at CachedInput.
+func (pr Price) Cost(u Usage) float64 {
+	cached := min(u.CachedInputTokens, u.InputTokens)
+	cachedPrice := pr.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = pr.Input
+	}
+	return (float64(u.InputTokens-cached)*pr.Input +
+		float64(cached)*cachedPrice +
+		float64(u.OutputTokens)*pr.Output) / 1_000_000
+}
+
+// EstimateCost fills in the cost of usage that has token counts but no cost,
+// pricing it as model. It reports whether a cost was added. Usage that
+// already has a cost, or whose model has no price, is left unchanged.
+func (p Pricing) EstimateCost(u *Usage, model string) bool {
+	if u == nil || u.HasCost {
+		return false
+	}
+	if u.InputTokens == 0 && u.OutputTokens == 0 {
+		return false
+	}
+	price, ok := p.Lookup(model)
+	if !ok {
+		return false
+	}
+	u.CostUSD = price.Cost(*u)
+	u.HasCost = true
+	return true
+}
@@ -0,0 +1,63 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingLookup(t *testing.T) {
+	p := WithOverrides(Pricing{
+		"GPT-5":                                {Input: 2, Output: 20},
+		"openrouter/anthropic/claude-sonnet-4": {Input: 4, Output: 16},
+	})
+	tests := []struct {
+		model string
+		want  Price
+		found bool
+	}{
+		{model: "gpt-5.1-codex", want: Price{Input: 2, Output: 20}, found: true},
+		{model: "gpt-5-mini", want: defaultPricing["gpt-5-mini"], found: true},
+		{model: "claude-opus-4-1-20250805", want: defaultPricing["claude-opus-4-1"], found: true},
+		{model: "claude-opus-4-5", want: defaultPricing["claude-opus-4"], found: true},
+		{model: "anthropic/claude-sonnet-4-5", want: defaultPricing["claude-sonnet-4"], found: true},
+		{model: "openrouter/anthropic/claude-sonnet-4.5", want: Price{Input: 4, Output: 16}, found: true},
+		{model: "llama-3"},
+		{model: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, ok := p.Lookup(tt.model)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPricingEstimateCost(t *testing.T) {
+	p := Pricing{"gpt-5": {Input: 1.25, CachedInput: 0.125, Output: 10}}
+
+	t.Run("prices uncached, cached and output tokens", func(t *testing.T) {
+		u := Usage{InputTokens: 1_000_000, CachedInputTokens: 800_000, OutputTokens: 100_000}
+		assert.True(t, p.EstimateCost(&u, "gpt-5-codex"))
+		assert.True(t, u.HasCost)
+		assert.InDelta(t, 0.25+0.1+1.0, u.CostUSD, 1e-9)
+	})
+
+	t.Run("keeps a reported cost", func(t *testing.T) {
+		u := Usage{InputTokens: 1000, CostUSD: 0.5, HasCost: true}
+		assert.False(t, p.EstimateCost(&u, "gpt-5"))
+		assert.InDelta(t, 0.5, u.CostUSD, 1e-9)
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		u := Usage{InputTokens: 1000}
+		assert.False(t, p.EstimateCost(&u, "mystery"))
+		assert.False(t, u.HasCost)
+	})
+
+	t.Run("cached price defaults to input price", func(t *testing.T) {
+		u := Usage{InputTokens: 1_000_000, CachedInputTokens: 1_000_000}
+		assert.InDelta(t, 3.0, Price{Input: 3}.Cost(u), 1e-9)
+	})
+}
@@ -0,0 +1,172 @@
+package tokens
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// Usage sources recorded for usage parsed from a job log.
+const (
+	// SourceTurnCompleted is Codex's per-turn turn.completed event.
+	SourceTurnCompleted = "job_log_turn_completed"
+	// SourceResult is the final result event of Claude Code and Gemini
+	// stream-json output.
+	SourceResult = "job_log_result"
+	// SourceMessageEnd is the sum of Pi's per-message message_end events.
+	SourceMessageEnd = "job_log_message_end"
+)
+
+// streamEvent is the union of the usage-bearing fields of the agent
+// stream formats roborev records in job logs.
+type streamEvent struct {
+	Type      string `json:"type"`
+	ThreadID  string `json:"thread_id"`
+	SessionID string `json:"session_id"`
+	Model     string `json:"model"`
+	// Codex turn.completed and Claude result usage.
+	Usage *struct {
+		InputTokens              int64 `json:"input_tokens"`
+		CachedInputTokens        int64 `json:"cached_input_tokens"`
+		CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+		OutputTokens             int64 `json:"output_tokens"`
+	} `json:"usage"`
+	TotalCostUSD *float64 `json:"total_cost_usd"`
+	// Gemini result stats.
+	Stats *struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+		Cached       int64 `json:"cached"`
+	} `json:"stats"`
+	// Pi message_end.
+	Message struct {
+		Role  string `json:"role"`
+		Model string `json:"model"`
+		Usage *struct {
+			Input      int64 `json:"input"`
+			Output     int64 `json:"output"`
+			CacheRead  int64 `json:"cacheRead"`
+			CacheWrite int64 `json:"cacheWrite"`
+			Cost       struct {
+				Total float64 `json:"total"`
+			} `json:"cost"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ParseUsageFile extracts token usage from a raw job log. A missing log
+// yields nil usage.
+func ParseUsageFile(path string) (*Usage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return ParseUsageJSONL(f)
+}
+
+// ParseUsageJSONL extracts token usage from an agent event stream. It
+// understands Codex turn.completed events, the Claude Code and Gemini
+// stream-json result event, and Pi message_end events. The final Codex
+// turn or result event wins; Pi messages are summed. Input token counts
+// include cached input, matching Codex. Non-JSON lines and unrelated events
+// are ignored.
+func ParseUsageJSONL(r io.Reader) (*Usage, error) {
+	reader := bufio.NewReader(r)
+	var offset int64
+	var threadID, model string
+	var usage *Usage
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			trimmed := bytes.TrimSpace(line)
+			var ev streamEvent
+			if len(trimmed) > 0 && json.Unmarshal(trimmed, &ev) == nil {
+				if ev.ThreadID != "" {
+					threadID = ev.ThreadID
+				} else if ev.SessionID != "" {
+					threadID = ev.SessionID
+				}
+				if ev.Model != "" {
+					model = ev.Model
+				}
+				if u := usageFromEvent(ev, usage); u != nil && u.HasUsageData() {
+					u.ThreadID = threadID
+					u.EventOffset = offset
+					usage = u
+				}
+			}
+			offset += int64(len(line))
+		}
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			if usage != nil && usage.Model == "" {
+				usage.Model = model
+			}
+			return usage, nil
+		}
+		return nil, err
+	}
+}
+
+// usageFromEvent converts one stream event to usage, or returns nil when
+// the event carries none. prev is the usage parsed so far, which Pi
+// message_end events add to.
+func usageFromEvent(ev streamEvent, prev *Usage) *Usage {
+	switch {
+	case ev.Type == "turn.completed" && ev.Usage != nil:
+		return &Usage{
+			InputTokens:       ev.Usage.InputTokens,
+			CachedInputTokens: ev.Usage.CachedInputTokens,
+			OutputTokens:      ev.Usage.OutputTokens,
+			UsageSource:       SourceTurnCompleted,
+		}
+	case ev.Type == "result" && ev.Usage != nil:
+		u := &Usage{
+			InputTokens: ev.Usage.InputTokens + ev.Usage.CacheReadInputTokens +
+				ev.Usage.CacheCreationInputTokens,
+			CachedInputTokens: ev.Usage.CacheReadInputTokens,
+			OutputTokens:      ev.Usage.OutputTokens,
+			UsageSource:       SourceResult,
+		}
+		if ev.TotalCostUSD != nil {
+			u.CostUSD = *ev.TotalCostUSD
+			u.HasCost = true
+		}
+		return u
+	case ev.Type == "result" && ev.Stats != nil:
+		return &Usage{
+			InputTokens:       ev.Stats.InputTokens,
+			CachedInputTokens: ev.Stats.Cached,
+			OutputTokens:      ev.Stats.OutputTokens,
+			UsageSource:       SourceResult,
+		}
+	case ev.Type == "message_end" && ev.Message.Role == "assistant" && ev.Message.Usage != nil:
+		mu := ev.Message.Usage
+		u := &Usage{UsageSource: SourceMessageEnd}
+		if prev != nil && prev.UsageSource == SourceMessageEnd {
+			*u = *prev
+		}
+		u.InputTokens += mu.Input + mu.CacheRead + mu.CacheWrite
+		u.CachedInputTokens += mu.CacheRead
+		u.OutputTokens += mu.Output
+		if mu.Cost.Total > 0 {
+			u.CostUSD += mu.Cost.Total
+			u.HasCost = true
+		}
+		if ev.Message.Model != "" {
+			u.Model = ev.Message.Model
+		}
+		return u
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUsageJSONLAgentFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		log  []string
+		want Usage
+	}{
+		{
+			name: "claude result",
+			log: []string{
+				`{"type":"system","subtype":"init","session_id":"sess-1","model":"claude-sonnet-4-5-20250929"}`,
+				`{"type":"assistant","message":{"role":"assistant","usage":{"input_tokens":5}}}`,
+				`{"type":"result","subtype":"success","session_id":"sess-1","total_cost_usd":0.31,` +
+					`"usage":{"input_tokens":1200,"cache_creation_input_tokens":800,` +
+					`"cache_read_input_tokens":40000,"output_tokens":2100}}`,
+			},
+			want: Usage{
+				InputTokens: 42000, CachedInputTokens: 40000, OutputTokens: 2100,
+				CostUSD: 0.31, HasCost: true, UsageSource: SourceResult,
+				ThreadID: "sess-1", Model: "claude-sonnet-4-5-20250929",
+			},
+		},
+		{
+			name: "gemini result stats",
+			log: []string{
+				`{"type":"init","session_id":"gem-1","model":"gemini-2.5-pro"}`,
+				`{"type":"message","role":"assistant","content":"done"}`,
+				`{"type":"result","status":"success","stats":{"total_tokens":13000,` +
+					`"input_tokens":12000,"output_tokens":1000,"cached":3000}}`,
+			},
+			want: Usage{
+				InputTokens: 12000, CachedInputTokens: 3000, OutputTokens: 1000,
+				UsageSource: SourceResult, ThreadID: "gem-1", Model: "gemini-2.5-pro",
+			},
+		},
+		{
+			name: "pi message_end events are summed",
+			log: []string{
+				`{"type":"message_end","message":{"role":"user","usage":{"input":999}}}`,
+				`{"type":"message_end","message":{"role":"assistant","model":"gpt-5",` +
+					`"usage":{"input":100,"output":50,"cacheRead":1000,"cacheWrite":0,"cost":{"total":0.01}}}}`,
+				`{"type":"message_end","message":{"role":"assistant","model":"gpt-5",` +
+					`"usage":{"input":200,"output":70,"cacheRead":2000,"cacheWrite":10,"cost":{"total":0.02}}}}`,
+			},
+			want: Usage{
+				InputTokens: 3310, CachedInputTokens: 3000, OutputTokens: 120,
+				CostUSD: 0.03, HasCost: true, UsageSource: SourceMessageEnd, Model: "gpt-5",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := ParseUsageJSONL(strings.NewReader(strings.Join(tt.log, "\n") + "\n"))
+			require.NoError(t, err)
+			require.NotNil(t, usage)
+			assert.Positive(t, usage.EventOffset)
+			usage.EventOffset = 0
+			assert.InDelta(t, tt.want.CostUSD, usage.CostUSD, 1e-9)
+			usage.CostUSD = tt.want.CostUSD
+			assert.Equal(t, tt.want, *usage)
+		})
+	}
+}
+
+func TestParseUsageFileMissing(t *testing.T) {
+	usage, err := ParseUsageFile(t.TempDir() + "/missing.log")
+	require.NoError(t, err)
+	assert.Nil(t, usage)
+}
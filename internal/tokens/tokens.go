@@ -1,8 +1,6 @@
 package tokens
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,7 +8,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -31,6 +28,8 @@ type Usage struct {
 	UsageSource       string  `json:"usage_source,omitempty"`
 	ThreadID          string  `json:"thread_id,omitempty"`
 	EventOffset       int64   `json:"event_offset,omitempty"`
+	// Model is the model the agent stream reported, when it names one.
+	Model string `json:"model,omitempty"`
 }
 
 // FetchConfig configures session usage lookup. When Endpoint is set,
@@ -407,77 +406,6 @@ func (u Usage) HasUsageData() bool {
 		u.HasCost
 }
 
-// ParseCodexUsageFile extracts the final Codex turn.completed usage event
-// from a raw JSONL job log.
-func ParseCodexUsageFile(path string) (*Usage, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	defer f.Close()
-	return ParseCodexUsageJSONL(f)
-}
-
-// ParseCodexUsageJSONL extracts the final Codex turn.completed usage event
-// from an event stream. Non-JSON lines and unrelated JSON events are ignored.
-func ParseCodexUsageJSONL(r io.Reader) (*Usage, error) {
-	type codexUsageEvent struct {
-		Type     string `json:"type"`
-		ThreadID string `json:"thread_id,omitempty"`
-		Usage    struct {
-			InputTokens       int64 `json:"input_tokens"`
-			CachedInputTokens int64 `json:"cached_input_tokens"`
-			OutputTokens      int64 `json:"output_tokens"`
-		} `json:"usage,omitempty"`
-	}
-
-	reader := bufio.NewReader(r)
-	var offset int64
-	var threadID string
-	var usage *Usage
-	for {
-		line, err := reader.ReadBytes('\n')
-		if len(line) > 0 {
-			trimmed := bytes.TrimSpace(line)
-			if len(trimmed) > 0 {
-				var ev codexUsageEvent
-				if json.Unmarshal(trimmed, &ev) == nil {
-					if ev.ThreadID != "" {
-						threadID = ev.ThreadID
-					}
-					if ev.Type == "turn.completed" {
-						u := Usage{
-							InputTokens:       ev.Usage.InputTokens,
-							CachedInputTokens: ev.Usage.CachedInputTokens,
-							OutputTokens:      ev.Usage.OutputTokens,
-							UsageSource:       "job_log_turn_completed",
-							ThreadID:          threadID,
-							EventOffset:       offset,
-						}
-						if ev.ThreadID != "" {
-							u.ThreadID = ev.ThreadID
-						}
-						if u.HasUsageData() {
-							usage = &u
-						}
-					}
-				}
-			}
-			offset += int64(len(line))
-		}
-		if err == nil {
-			continue
-		}
-		if errors.Is(err, io.EOF) {
-			return usage, nil
-		}
-		return nil, err
-	}
-}
-
 // ToJSON serializes token usage to JSON for database storage.
 func ToJSON(u *Usage) string {
 	if u == nil {
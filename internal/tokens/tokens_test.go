@@ -144,7 +144,7 @@ func TestParseJSON(t *testing.T) {
 	})
 }
 
-func TestParseCodexUsageJSONL(t *testing.T) {
+func TestParseUsageJSONL(t *testing.T) {
 	log := strings.Join([]string{
 		`{"type":"thread.started","thread_id":"thread-123"}`,
 		`{"type":"turn.started"}`,
@@ -152,7 +152,7 @@ func TestParseCodexUsageJSONL(t *testing.T) {
 		`{"type":"turn.completed","usage":{"input_tokens":79150,"cached_input_tokens":2560,"output_tokens":3389}}`,
 	}, "\n") + "\n"
 
-	usage, err := ParseCodexUsageJSONL(strings.NewReader(log))
+	usage, err := ParseUsageJSONL(strings.NewReader(log))
 	require.NoError(t, err)
 	require.NotNil(t, usage)
 	assert.Equal(t, int64(79150), usage.InputTokens)
@@ -164,7 +164,7 @@ func TestParseCodexUsageJSONL(t *testing.T) {
 }
 
 func TestParseCodexUsageJSONLIgnoresMissingUsage(t *testing.T) {
-	usage, err := ParseCodexUsageJSONL(strings.NewReader(
+	usage, err := ParseUsageJSONL(strings.NewReader(
 		"plain text\n" +
 			`{"type":"turn.completed","usage":{}}` + "\n",
 	))
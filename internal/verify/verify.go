@@ -0,0 +1,158 @@
+// Package verify runs a repo's verify_command after a fix agent edits it
+// and drives the repair loop that feeds failures back to the agent.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+	"unicode/utf8"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/procutil"
+)
+
+// maxFailureOutput caps how much of a failing command's output is kept. The
+// tail is kept because build and test tools print the summary last.
+const maxFailureOutput = 16 * 1024
+
+// waitDelay bounds how long a command may hold its output pipes open after
+// it exits or is killed, e.g. through a background child process.
+const waitDelay = 5 * time.Second
+
+// Result is one run of the verify commands.
+type Result struct {
+	Passed   bool
+	Command  string // first failing command; empty when passed
+	Output   string // tail of the failing command's output
+	Duration time.Duration
+}
+
+// Outcome is the result of a verification gate, including repairs.
+type Outcome struct {
+	Passed   bool
+	Attempts int           // repair attempts made
+	Duration time.Duration // total time spent in verify commands
+	Failure  Result        // last failing run; zero when passed
+}
+
+// RepairFunc asks the agent to fix the failure described by prompt.
+type RepairFunc func(ctx context.Context, prompt string) error
+
+// Run runs commands in dir in order and stops at the first failure. Each
+// command gets its own timeout.
+func Run(ctx context.Context, dir string, commands []string, timeout time.Duration) Result {
+	start := time.Now()
+	for _, command := range commands {
+		output, err := runCommand(ctx, dir, command, timeout)
+		if err != nil {
+			return Result{
+				Command:  command,
+				Output:   tail(fmt.Sprintf("%s\n%v", bytes.TrimRight(output, "\n"), err), maxFailureOutput),
+				Duration: time.Since(start),
+			}
+		}
+	}
+	return Result{Passed: true, Duration: time.Since(start)}
+}
+
+// Check runs the verify commands in dir and, while they fail, calls repair
+// with the failing output up to settings.Attempts times. Progress is written
+// to out. A repair error ends the gate and is returned with the outcome so
+// far.
+func Check(
+	ctx context.Context, dir string, settings config.VerifySettings,
+	repair RepairFunc, out io.Writer,
+) (Outcome, error) {
+	if out == nil {
+		out = io.Discard
+	}
+	var outcome Outcome
+	for {
+		fmt.Fprintf(out, "\nRunning verify command...\n")
+		res := Run(ctx, dir, settings.Commands, settings.Timeout)
+		outcome.Duration += res.Duration
+		if res.Passed {
+			fmt.Fprintf(out, "Verification passed (%s)\n", res.Duration.Round(time.Millisecond))
+			outcome.Passed = true
+			outcome.Failure = Result{}
+			return outcome, nil
+		}
+		outcome.Failure = res
+		fmt.Fprintf(out, "Verification failed: %s\n%s\n", res.Command, res.Output)
+		if outcome.Attempts >= settings.Attempts || ctx.Err() != nil {
+			return outcome, nil
+		}
+		outcome.Attempts++
+		fmt.Fprintf(out, "\nRunning agent to repair (attempt %d/%d)...\n\n", outcome.Attempts, settings.Attempts)
+		if err := repair(ctx, RepairPrompt(res)); err != nil {
+			return outcome, fmt.Errorf("repair agent: %w", err)
+		}
+	}
+}
+
+// RepairPrompt asks the agent to fix a failed verification.
+func RepairPrompt(res Result) string {
+	return fmt.Sprintf(
+		"Your changes failed verification. The command `%s` exited with the "+
+			"following output. Fix the problem without reverting the intended "+
+			"change, then make sure the command passes.\n\n```\n%s\n```\n",
+		res.Command, res.Output)
+}
+
+// Summary describes an outcome in one line for logs and comments.
+func (o Outcome) Summary() string {
+	took := o.Duration.Round(time.Second)
+	repairs := ""
+	if o.Attempts > 0 {
+		repairs = fmt.Sprintf(" after %d repair attempt(s)", o.Attempts)
+	}
+	if o.Passed {
+		return fmt.Sprintf("verification passed%s in %s", repairs, took)
+	}
+	return fmt.Sprintf("verification failed%s in %s: %s", repairs, took, o.Failure.Command)
+}
+
+func runCommand(ctx context.Context, dir, command string, timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", command)
+		procutil.HideConsole(cmd)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.WaitDelay = waitDelay
+	// verify_command comes from the repository under test, so it gets no
+	// more access than the agents do.
+	if err := agent.SandboxCommand(cmd, dir); err != nil {
+		return nil, err
+	}
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("timed out after %s", timeout)
+	}
+	return output, err
+}
+
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	start := len(s) - n
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return "...\n" + s[start:]
+}
@@ -0,0 +1,126 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.kenn.io/roborev/internal/agent"
+	"go.kenn.io/roborev/internal/config"
+	"go.kenn.io/roborev/internal/sandbox"
+)
+
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("verify commands in these tests use sh")
+	}
+}
+
+func TestRun(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+
+	t.Run("all commands pass", func(t *testing.T) {
+		res := Run(context.Background(), dir, []string{"true", "echo ok"}, time.Minute)
+		assert.True(t, res.Passed)
+		assert.Empty(t, res.Command)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		res := Run(context.Background(), dir, []string{"true", "echo broken build; exit 3", "touch never"}, time.Minute)
+		assert.False(t, res.Passed)
+		assert.Equal(t, "echo broken build; exit 3", res.Command)
+		assert.Contains(t, res.Output, "broken build")
+		assert.Contains(t, res.Output, "exit status 3")
+		assert.NoFileExists(t, filepath.Join(dir, "never"))
+	})
+
+	t.Run("timeout fails the command", func(t *testing.T) {
+		res := Run(context.Background(), dir, []string{"sleep 10"}, 50*time.Millisecond)
+		assert.False(t, res.Passed)
+		assert.Contains(t, res.Output, "timed out")
+	})
+
+	t.Run("keeps the tail of long output", func(t *testing.T) {
+		res := Run(context.Background(), dir, []string{"yes line | head -n 10000; echo SUMMARY; exit 1"}, time.Minute)
+		assert.LessOrEqual(t, len(res.Output), maxFailureOutput+len("...\n"))
+		assert.True(t, strings.HasPrefix(res.Output, "...\n"))
+		assert.Contains(t, res.Output, "SUMMARY")
+	})
+}
+
+func TestRunUsesAgentSandbox(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	agent.SetSandbox(sandbox.Failed(errors.New("bwrap missing")))
+	t.Cleanup(func() { agent.SetSandbox(nil) })
+
+	res := Run(context.Background(), dir, []string{"touch ran"}, time.Minute)
+	assert.False(t, res.Passed)
+	assert.Contains(t, res.Output, "agent sandbox unavailable")
+	assert.NoFileExists(t, filepath.Join(dir, "ran"), "a required sandbox that cannot start must not run the command")
+}
+
+func TestCheck(t *testing.T) {
+	skipOnWindows(t)
+	settings := config.VerifySettings{Commands: []string{"test -f ok"}, Attempts: 2, Timeout: time.Minute}
+
+	t.Run("repair fixes the failure", func(t *testing.T) {
+		dir := t.TempDir()
+		var prompts []string
+		outcome, err := Check(context.Background(), dir, settings, func(_ context.Context, prompt string) error {
+			prompts = append(prompts, prompt)
+			return os.WriteFile(filepath.Join(dir, "ok"), nil, 0o644)
+		}, nil)
+		require.NoError(t, err)
+		assert.True(t, outcome.Passed)
+		assert.Equal(t, 1, outcome.Attempts)
+		assert.Empty(t, outcome.Failure.Command)
+		require.Len(t, prompts, 1)
+		assert.Contains(t, prompts[0], "`test -f ok`")
+	})
+
+	t.Run("gives up after the configured attempts", func(t *testing.T) {
+		calls := 0
+		outcome, err := Check(context.Background(), t.TempDir(), settings, func(context.Context, string) error {
+			calls++
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		assert.False(t, outcome.Passed)
+		assert.Equal(t, 2, outcome.Attempts)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "test -f ok", outcome.Failure.Command)
+		assert.Contains(t, outcome.Summary(), "verification failed after 2 repair attempt(s)")
+	})
+
+	t.Run("zero attempts only verifies", func(t *testing.T) {
+		noRepair := settings
+		noRepair.Attempts = 0
+		outcome, err := Check(context.Background(), t.TempDir(), noRepair, func(context.Context, string) error {
+			t.Fatal("repair must not run")
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		assert.False(t, outcome.Passed)
+		assert.Zero(t, outcome.Attempts)
+	})
+
+	t.Run("repair error ends the gate", func(t *testing.T) {
+		outcome, err := Check(context.Background(), t.TempDir(), settings, func(context.Context, string) error {
+			return errors.New("agent crashed")
+		}, nil)
+		require.ErrorContains(t, err, "agent crashed")
+		assert.False(t, outcome.Passed)
+		assert.Equal(t, 1, outcome.Attempts)
+	})
+}